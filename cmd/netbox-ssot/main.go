@@ -4,7 +4,10 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,9 +17,33 @@ import (
 	"github.com/bl4ko/netbox-ssot/internal/parser"
 	"github.com/bl4ko/netbox-ssot/internal/source"
 	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
 )
 
-var configPath = flag.String("config", "config.yaml", "Path to the configuration file")
+var configPath = flag.String(
+	"config",
+	"config.yaml",
+	"Path to the configuration file, or to the directory with configuration files",
+)
+
+var interval = flag.Duration(
+	"interval",
+	0,
+	"Run continuously and start a sync every interval (e.g. 1h). "+
+		"Changes of configuration files are applied before the next run",
+)
+
+var onlySources = flag.String(
+	"only",
+	"",
+	"Comma separated names of sources to sync. Other sources are skipped",
+)
+
+var skipSources = flag.String(
+	"skip",
+	"",
+	"Comma separated names of sources, that are not synced",
+)
 
 // Build variables provided with ldflags.
 var (
@@ -26,102 +53,242 @@ var (
 )
 
 func main() {
+	// Subcommands (validate, schema, transform) exit after they are done
+	runSubcommand(os.Args[1:])
+
 	// Print build information
 	fmt.Printf("Running version %s built on %s (commit %s)\n\n", version, date, commit)
 
-	startTime := time.Now()
-
 	// Parse configuration
-	fmt.Printf("Netbox-SSOT has started at %s\n", startTime.Format(time.RFC3339))
 	flag.Parse()
 	config, err := parser.ParseConfig(*configPath)
 	if err != nil {
 		fmt.Println("Parser:", err)
 		os.Exit(1)
 	}
+	loggers, err := newLoggers(config.Logger)
+	if err != nil {
+		fmt.Println("Logger:", err)
+		os.Exit(1)
+	}
 
-	// Create our main context
-	mainCtx := context.Background()
-	mainCtx = context.WithValue(mainCtx, constants.CtxSourceKey, "main")
+	scheduler, err := newSourceScheduler(config, *interval, *onlySources, *skipSources)
+	if err != nil {
+		fmt.Println("Sources:", err)
+		os.Exit(1)
+	}
+
+	if *interval <= 0 {
+		if !runScheduledSources(config, loggers, scheduler) {
+			os.Exit(1)
+		}
+		return
+	}
 
-	// Initialize Logger
-	ssotLogger, err := logger.New(config.Logger.Dest, config.Logger.Level)
+	// Long-running mode. Changes of configuration files are applied between runs,
+	// so each run uses a single consistent configuration.
+	reloader, err := newConfigReloader(*configPath)
 	if err != nil {
-		fmt.Println("Logger:", err)
+		fmt.Println("Parser:", err)
 		os.Exit(1)
 	}
+	for {
+		runScheduledSources(config, loggers, scheduler)
+		nextRun := scheduler.nextRun(config, time.Now())
+		loggers.ssot.Infof(mainContext(), "Next run starts at %s", nextRun.Format(time.RFC3339))
+		time.Sleep(time.Until(nextRun))
+		config, loggers = reloader.reload(config, loggers)
+	}
+}
+
+// runScheduledSources syncs sources, that are due by the scheduler. It returns false, if the run failed.
+func runScheduledSources(config *parser.Config, loggers loggers, scheduler *sourceScheduler) bool {
+	runStart := time.Now()
+	dueSources, skipped := scheduler.dueSources(config, runStart)
+	for _, reason := range skipped {
+		loggers.ssot.Infof(mainContext(), "Skipping sync, %s", reason)
+	}
+	if len(dueSources) == 0 {
+		loggers.ssot.Info(mainContext(), "No sources are due, skipping run")
+		return true
+	}
+	successfulRun, failedSources := runSync(config, loggers, dueSources)
+	scheduler.markRun(dueSources, failedSources, runStart)
+	return successfulRun
+}
+
+// loggers are loggers of a run, created from the logger configuration.
+type loggers struct {
+	ssot      *logger.Logger
+	inventory *logger.Logger
+}
+
+// newLoggers creates loggers from the logger configuration.
+func newLoggers(loggerConfig *parser.LoggerConfig) (loggers, error) {
+	ssotLogger, err := logger.New(loggerConfig.Dest, loggerConfig.Level)
+	if err != nil {
+		return loggers{}, err
+	}
+	inventoryLogger, err := logger.New(loggerConfig.Dest, loggerConfig.Level)
+	if err != nil {
+		return loggers{}, fmt.Errorf("inventoryLogger: %s", err)
+	}
+	return loggers{ssot: ssotLogger, inventory: inventoryLogger}, nil
+}
+
+// mainContext returns context of logs, that don't belong to any source.
+func mainContext() context.Context {
+	return context.WithValue(context.Background(), constants.CtxSourceKey, "main")
+}
+
+// runSync syncs the named sources of the configuration and removes orphans. Objects of
+// other sources are not handled as orphans. It returns false, if the run failed, and
+// names of sources, that failed. If the run failed before or after syncing sources,
+// all sources are failed.
+//
+//nolint:gocyclo
+func runSync(config *parser.Config, loggers loggers, sourceNames []string) (bool, map[string]bool) {
+	startTime := time.Now()
+	fmt.Printf("Netbox-SSOT has started at %s\n", startTime.Format(time.RFC3339))
+
+	// Lookup tables are shared by relations of all sources
+	utils.SetRelationLookups(config.RelationLookups)
+
+	// Create our main context
+	mainCtx := mainContext()
+
+	ssotLogger := loggers.ssot
 	ssotLogger.Debug(mainCtx, "Parsed Logger config: ", config.Logger)
 	ssotLogger.Debug(mainCtx, "Parsed Netbox config: ", config.Netbox)
 	ssotLogger.Debug(mainCtx, "Parsed Source config: ", config.Sources)
 
-	inventoryLogger, err := logger.New(config.Logger.Dest, config.Logger.Level)
-	if err != nil {
-		ssotLogger.Errorf(mainCtx, "inventoryLogger: %s", err)
-		os.Exit(1)
-	}
 	inventoryCtx := context.WithValue(context.Background(), constants.CtxSourceKey, "inventory")
-	netboxInventory := inventory.NewNetboxInventory(inventoryCtx, inventoryLogger, config.Netbox)
+	netboxInventory := inventory.NewNetboxInventory(
+		inventoryCtx, loggers.inventory, config.Netbox, config.Defaults,
+	)
 	ssotLogger.Debug(mainCtx, "Netbox inventory: ", netboxInventory)
 
 	ssotLogger.Info(mainCtx, "Starting initializing netbox inventory")
-	err = netboxInventory.Init()
+	// allFailed returns all sources as failed
+	allFailed := func() map[string]bool {
+		failed := make(map[string]bool, len(sourceNames))
+		for _, sourceName := range sourceNames {
+			failed[sourceName] = true
+		}
+		return failed
+	}
+
+	err := netboxInventory.Init()
 	if err != nil {
 		ssotLogger.Error(mainCtx, err)
-		os.Exit(1)
+		return false, allFailed()
 	}
 	ssotLogger.Debug(mainCtx, "Netbox inventory initialized: ", netboxInventory)
 
 	// Variable to store if the run was successful. If it wasn't we don't remove orphans.
 	successfullRun := true
-	// Variable to store failed sourcesFalse
+	// Variable to store failed sources, written by sources synced in parallel
 	encounteredErrors := map[string]bool{}
+	var encounteredErrorsLock sync.Mutex
+	failSource := func(sourceName string) {
+		encounteredErrorsLock.Lock()
+		defer encounteredErrorsLock.Unlock()
+		successfullRun = false
+		encounteredErrors[sourceName] = true
+	}
 
-	// Go through all sources and sync data
-	var wg sync.WaitGroup
-	for i := range config.Sources {
-		sourceConfig := &config.Sources[i]
-		ssotLogger.Info(mainCtx, "Processing source ", sourceConfig.Name, "...")
-		sourceCtx := context.WithValue(mainCtx, constants.CtxSourceKey, sourceConfig.Name)
-		source, err := source.NewSource(sourceCtx, sourceConfig, ssotLogger, netboxInventory)
-		if err != nil {
-			ssotLogger.Error(sourceCtx, err)
-			os.Exit(1)
-		}
-		ssotLogger.Infof(sourceCtx, "Successfully created source %s", constants.CheckMark)
-		ssotLogger.Debugf(sourceCtx, "Source content: %s", source)
-		wg.Add(1)
-		// Run each source in parallel
-		go func(sourceCtx context.Context, source common.Source) {
-			defer wg.Done()
-			sourceName, ok := sourceCtx.Value(constants.CtxSourceKey).(string)
-			if !ok {
-				ssotLogger.Errorf(sourceCtx, "source ctx value is not set")
-				return
-			}
-			// Source initialization
-			ssotLogger.Info(sourceCtx, "Initializing source")
-			err = source.Init()
+	// Sources are synced in groups by priority, sources of a group in parallel
+	for _, sourceGroup := range sourcesByPriority(config, sourceNames) {
+		var wg sync.WaitGroup
+		for _, sourceConfig := range sourceGroup {
+			ssotLogger.Info(mainCtx, "Processing source ", sourceConfig.Name, "...")
+			sourceCtx := context.WithValue(mainCtx, constants.CtxSourceKey, sourceConfig.Name)
+			source, err := source.NewSource(sourceCtx, sourceConfig, ssotLogger, netboxInventory)
 			if err != nil {
 				ssotLogger.Error(sourceCtx, err)
-				successfullRun = false
-				encounteredErrors[sourceName] = true
-				return
+				return false, allFailed()
 			}
-			ssotLogger.Infof(sourceCtx, "Successfully initialized source %s", constants.CheckMark)
+			ssotLogger.Infof(sourceCtx, "Successfully created source %s", constants.CheckMark)
+			ssotLogger.Debugf(sourceCtx, "Source content: %s", source)
+			wg.Add(1)
+			// Run each source in parallel
+			go func(sourceCtx context.Context, source common.Source) {
+				defer wg.Done()
+				sourceName, ok := sourceCtx.Value(constants.CtxSourceKey).(string)
+				if !ok {
+					ssotLogger.Errorf(sourceCtx, "source ctx value is not set")
+					return
+				}
+				// Source initialization
+				ssotLogger.Info(sourceCtx, "Initializing source")
+				err = source.Init()
+				if err != nil {
+					ssotLogger.Error(sourceCtx, err)
+					failSource(sourceName)
+					return
+				}
+				ssotLogger.Infof(sourceCtx, "Successfully initialized source %s", constants.CheckMark)
 
-			// Source synchronization
-			ssotLogger.Info(sourceCtx, "Syncing source...")
-			err = source.Sync(netboxInventory)
-			if err != nil {
-				successfullRun = false
-				ssotLogger.Error(sourceCtx, err)
-				encounteredErrors[sourceName] = true
-				return
-			}
-			ssotLogger.Infof(sourceCtx, "Source synced successfully %s", constants.CheckMark)
-		}(sourceCtx, source)
+				// Default objects renamed in defaults of the source are migrated before they are used
+				err = netboxInventory.RenameDefaultObjects(sourceCtx, &sourceConfig.Defaults)
+				if err != nil {
+					ssotLogger.Error(sourceCtx, err)
+					failSource(sourceName)
+					return
+				}
+
+				// Source synchronization
+				ssotLogger.Info(sourceCtx, "Syncing source...")
+				err = source.Sync(netboxInventory)
+				if err != nil {
+					ssotLogger.Error(sourceCtx, err)
+					failSource(sourceName)
+					return
+				}
+				ssotLogger.Infof(sourceCtx, "Source synced successfully %s", constants.CheckMark)
+				filteredCounts := source.GetFilteredCounts()
+				for _, objectType := range slices.Sorted(maps.Keys(filteredCounts)) {
+					ssotLogger.Infof(sourceCtx, "Filtered out %d objects of type %s", filteredCounts[objectType], objectType)
+				}
+
+				// Raw objects configured for the source
+				if len(sourceConfig.RawObjects) > 0 {
+					ssotLogger.Info(sourceCtx, "Syncing raw objects...")
+					err = common.SyncRawObjects(sourceCtx, netboxInventory, sourceConfig, source.GetSourceTags())
+					if err != nil {
+						ssotLogger.Error(sourceCtx, err)
+						failSource(sourceName)
+						return
+					}
+					ssotLogger.Infof(sourceCtx, "Raw objects synced successfully %s", constants.CheckMark)
+				}
+			}(sourceCtx, source)
+		}
+		wg.Wait()
+	}
+
+	// Objects of sources, that were not synced in this run, are not orphans
+	notSynced := []string{}
+	for _, sourceConfig := range config.Sources {
+		if !slices.Contains(sourceNames, sourceConfig.Name) {
+			notSynced = append(notSynced, sourceConfig.Name)
+		}
+	}
+	if len(notSynced) > 0 {
+		ssotLogger.Infof(mainCtx, "Keeping objects of sources, that were not synced: %s", strings.Join(notSynced, ", "))
+		netboxInventory.OrphanManager.RemoveItemsOfSources(notSynced)
+	}
+
+	if config.Netbox.CreateParentPrefixes {
+		ssotLogger.Info(mainCtx, "Creating parent prefixes...")
+		err = netboxInventory.AddParentPrefixes(mainCtx)
+		if err != nil {
+			ssotLogger.Error(mainCtx, err)
+			successfullRun = false
+		} else {
+			ssotLogger.Infof(mainCtx, "%s Successfully created parent prefixes", constants.CheckMark)
+		}
 	}
-	wg.Wait()
 
 	// Orphan manager cleanup on successful run and if enabled
 	if successfullRun {
@@ -129,7 +296,7 @@ func main() {
 		err = netboxInventory.DeleteOrphans(config.Netbox.RemoveOrphans)
 		if err != nil {
 			ssotLogger.Error(mainCtx, err)
-			os.Exit(1)
+			return false, allFailed()
 		}
 		ssotLogger.Infof(mainCtx, "%s Successfully removed orphans", constants.CheckMark)
 	} else {
@@ -147,10 +314,10 @@ func main() {
 			minutes,
 			seconds,
 		)
-	} else {
-		for source := range encounteredErrors {
-			ssotLogger.Infof(mainCtx, "%s syncing of source %s failed", constants.WarningSign, source)
-		}
-		os.Exit(1)
+		return true, encounteredErrors
+	}
+	for source := range encounteredErrors {
+		ssotLogger.Infof(mainCtx, "%s syncing of source %s failed", constants.WarningSign, source)
 	}
+	return false, encounteredErrors
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+)
+
+// configReloader reloads configuration in long-running mode, when configuration
+// files are changed.
+type configReloader struct {
+	configPath string
+	// fingerprint is the fingerprint of the last parsed configuration files.
+	fingerprint string
+}
+
+// newConfigReloader creates a configReloader for the already parsed configuration files.
+func newConfigReloader(configPath string) (*configReloader, error) {
+	fingerprint, err := parser.ConfigFingerprint(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return &configReloader{configPath: configPath, fingerprint: fingerprint}, nil
+}
+
+// reload returns the new configuration and its loggers, if configuration files were
+// changed since the last reload. Invalid configuration is rejected and the current
+// configuration stays in effect.
+func (cr *configReloader) reload(config *parser.Config, currentLoggers loggers) (*parser.Config, loggers) {
+	ctx := mainContext()
+	fingerprint, err := parser.ConfigFingerprint(cr.configPath)
+	if err != nil {
+		currentLoggers.ssot.Errorf(ctx, "Reading configuration files: %s. Keeping current configuration", err)
+		return config, currentLoggers
+	}
+	if fingerprint == cr.fingerprint {
+		return config, currentLoggers
+	}
+	// Each change is parsed only once, so invalid configuration is reported only once
+	cr.fingerprint = fingerprint
+
+	currentLoggers.ssot.Info(ctx, "Configuration files changed, reloading configuration...")
+	newConfig, err := parser.ParseConfig(cr.configPath)
+	if err != nil {
+		currentLoggers.ssot.Errorf(ctx, "Rejected invalid configuration: %s. Keeping current configuration", err)
+		return config, currentLoggers
+	}
+	diff := parser.DiffConfigs(config, newConfig)
+	if len(diff) == 0 {
+		currentLoggers.ssot.Info(ctx, "Configuration has no changes")
+		return config, currentLoggers
+	}
+
+	reloadedLoggers := currentLoggers
+	if !reflect.DeepEqual(config.Logger, newConfig.Logger) {
+		reloadedLoggers, err = newLoggers(newConfig.Logger)
+		if err != nil {
+			currentLoggers.ssot.Errorf(ctx, "Rejected logger configuration: %s. Keeping current configuration", err)
+			return config, currentLoggers
+		}
+	}
+	for _, change := range diff {
+		reloadedLoggers.ssot.Infof(ctx, "Configuration changed: %s", change)
+	}
+	reloadedLoggers.ssot.Infof(ctx, "Configuration reloaded, changes are applied from the next run")
+	return newConfig, reloadedLoggers
+}
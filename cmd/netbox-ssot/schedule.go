@@ -0,0 +1,206 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// sourceState is the scheduling state of a source.
+type sourceState struct {
+	// added is the time, when the source was first scheduled.
+	added time.Time
+	// lastRun is start time of the last successful run of the source. It is zero, if the source
+	// hasn't run yet.
+	lastRun time.Time
+	// lastFailure is start time of the last run of the source, if it failed. It is zero,
+	// if the last run of the source was successful.
+	lastFailure time.Time
+}
+
+// sourceScheduler decides, which sources are synced in a run. Sources must be enabled,
+// selected with -only and -skip flags and inside their maintenance windows. In long-running
+// mode sources with schedule are synced by their schedule, other sources every interval.
+type sourceScheduler struct {
+	// interval is the interval of long-running mode. It is 0 for a single run,
+	// where schedules are ignored.
+	interval time.Duration
+	only     []string
+	skip     []string
+	states   map[string]*sourceState
+}
+
+// newSourceScheduler creates a scheduler with sources selected by comma separated lists
+// of source names only and skip. Names must be names of sources of the configuration.
+func newSourceScheduler(
+	config *parser.Config,
+	interval time.Duration,
+	only string,
+	skip string,
+) (*sourceScheduler, error) {
+	scheduler := &sourceScheduler{
+		interval: interval,
+		only:     splitSourceNames(only),
+		skip:     splitSourceNames(skip),
+		states:   map[string]*sourceState{},
+	}
+	for _, name := range slices.Concat(scheduler.only, scheduler.skip) {
+		if !slices.ContainsFunc(config.Sources, func(sourceConfig parser.SourceConfig) bool {
+			return sourceConfig.Name == name
+		}) {
+			return nil, fmt.Errorf("source %s doesn't exist", name)
+		}
+	}
+	return scheduler, nil
+}
+
+// splitSourceNames splits comma separated list of source names.
+func splitSourceNames(names string) []string {
+	sourceNames := []string{}
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			sourceNames = append(sourceNames, name)
+		}
+	}
+	return sourceNames
+}
+
+// selected returns true, if the source is enabled and selected with -only and -skip flags.
+func (s *sourceScheduler) selected(sourceConfig *parser.SourceConfig) bool {
+	if !sourceConfig.Enabled || slices.Contains(s.skip, sourceConfig.Name) {
+		return false
+	}
+	return len(s.only) == 0 || slices.Contains(s.only, sourceConfig.Name)
+}
+
+// nextSourceRun returns time of the next run of the source, without considering maintenance
+// windows. Zero time is returned, if the source's schedule never matches. Failed sources are
+// retried after interval, or at the next match of their schedule, if it is sooner.
+func (s *sourceScheduler) nextSourceRun(sourceConfig *parser.SourceConfig, now time.Time) time.Time {
+	if s.interval <= 0 {
+		return now
+	}
+	state, ok := s.states[sourceConfig.Name]
+	if !ok {
+		state = &sourceState{added: now}
+		s.states[sourceConfig.Name] = state
+	}
+	if sourceConfig.Schedule != "" {
+		// Schedule is validated by the parser
+		schedule, err := utils.ParseSchedule(sourceConfig.Schedule)
+		if err != nil {
+			return time.Time{}
+		}
+		if !state.lastFailure.IsZero() {
+			retry := state.lastFailure.Add(s.interval)
+			if next := schedule.Next(state.lastFailure); !next.IsZero() && next.Before(retry) {
+				return next
+			}
+			return retry
+		}
+		if state.lastRun.IsZero() {
+			return schedule.Next(state.added)
+		}
+		return schedule.Next(state.lastRun)
+	}
+	// Sources without schedule are synced right after they are added
+	switch {
+	case !state.lastFailure.IsZero():
+		return state.lastFailure.Add(s.interval)
+	case state.lastRun.IsZero():
+		return state.added
+	}
+	return state.lastRun.Add(s.interval)
+}
+
+// dueSources returns names of sources, that are synced in the run at now, and the reasons,
+// why other sources are not synced.
+func (s *sourceScheduler) dueSources(config *parser.Config, now time.Time) ([]string, []string) {
+	due := []string{}
+	skipped := []string{}
+	for i := range config.Sources {
+		sourceConfig := &config.Sources[i]
+		switch nextRun := s.nextSourceRun(sourceConfig, now); {
+		case !sourceConfig.Enabled:
+			skipped = append(skipped, fmt.Sprintf("source %s is disabled", sourceConfig.Name))
+		case !s.selected(sourceConfig):
+			skipped = append(skipped, fmt.Sprintf("source %s is not selected", sourceConfig.Name))
+		case nextRun.IsZero() || nextRun.After(now):
+			// Source is not scheduled in this run
+		case !utils.InMaintenanceWindows(sourceConfig.MaintenanceWindows, now):
+			skipped = append(skipped, fmt.Sprintf("source %s is outside of maintenance windows", sourceConfig.Name))
+		default:
+			due = append(due, sourceConfig.Name)
+		}
+	}
+	return due, skipped
+}
+
+// markRun records the run of the sources, that started at start. Sources in failed
+// are not marked as run, so they are retried.
+func (s *sourceScheduler) markRun(sourceNames []string, failed map[string]bool, start time.Time) {
+	for _, name := range sourceNames {
+		state, ok := s.states[name]
+		if !ok {
+			continue
+		}
+		if failed[name] {
+			state.lastFailure = start
+			continue
+		}
+		state.lastRun = start
+		state.lastFailure = time.Time{}
+	}
+}
+
+// nextRun returns time of the next run in long-running mode. Runs are at most interval
+// apart, so configuration changes are applied, even if no sources are due.
+func (s *sourceScheduler) nextRun(config *parser.Config, now time.Time) time.Time {
+	nextRun := now.Add(s.interval)
+	for i := range config.Sources {
+		sourceConfig := &config.Sources[i]
+		if !s.selected(sourceConfig) {
+			continue
+		}
+		sourceRun := s.nextSourceRun(sourceConfig, now)
+		if sourceRun.IsZero() {
+			continue
+		}
+		// Postponed sources are synced, when their next maintenance window starts
+		if sourceRun.Before(now) {
+			sourceRun = now
+		}
+		sourceRun = utils.NextMaintenanceWindow(sourceConfig.MaintenanceWindows, sourceRun)
+		if !sourceRun.IsZero() && sourceRun.Before(nextRun) {
+			nextRun = sourceRun
+		}
+	}
+	return nextRun
+}
+
+// sourcesByPriority returns configurations of the named sources, grouped by priority
+// from the highest to the lowest.
+func sourcesByPriority(config *parser.Config, sourceNames []string) [][]*parser.SourceConfig {
+	sources := []*parser.SourceConfig{}
+	for i := range config.Sources {
+		if slices.Contains(sourceNames, config.Sources[i].Name) {
+			sources = append(sources, &config.Sources[i])
+		}
+	}
+	slices.SortStableFunc(sources, func(a, b *parser.SourceConfig) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+	groups := [][]*parser.SourceConfig{}
+	for i, sourceConfig := range sources {
+		if i == 0 || sources[i-1].Priority != sourceConfig.Priority {
+			groups = append(groups, []*parser.SourceConfig{})
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], sourceConfig)
+	}
+	return groups
+}
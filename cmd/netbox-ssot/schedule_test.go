@@ -0,0 +1,233 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// Monday
+var scheduleStart = time.Date(2024, time.June, 3, 10, 7, 0, 0, time.UTC)
+
+func TestSourceSchedulerCron(t *testing.T) {
+	config := &parser.Config{
+		Sources: []parser.SourceConfig{
+			{Name: "hourly", Enabled: true, Schedule: "0 * * * *"},
+			{Name: "always", Enabled: true},
+		},
+	}
+	scheduler, err := newSourceScheduler(config, 30*time.Minute, "", "")
+	if err != nil {
+		t.Fatalf("newSourceScheduler() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		{"Before the next match", scheduleStart, []string{"always"}},
+		{"Not yet matched", scheduleStart.Add(20 * time.Minute), []string{}},
+		{"At the next match", time.Date(2024, time.June, 3, 11, 0, 0, 0, time.UTC), []string{"hourly", "always"}},
+		{"After the run", time.Date(2024, time.June, 3, 11, 20, 0, 0, time.UTC), []string{}},
+		{"At the following match", time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC), []string{"hourly", "always"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, _ := scheduler.dueSources(config, tt.now)
+			if !reflect.DeepEqual(due, tt.want) {
+				t.Errorf("dueSources() = %v, want %v", due, tt.want)
+			}
+			scheduler.markRun(due, nil, tt.now)
+		})
+	}
+
+	// Next run is the next match of the schedule, or interval after the last run
+	now := time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC)
+	if got, want := scheduler.nextRun(config, now), now.Add(30*time.Minute); !got.Equal(want) {
+		t.Errorf("nextRun() = %v, want %v", got, want)
+	}
+}
+
+func TestSourceSchedulerRetryFailed(t *testing.T) {
+	config := &parser.Config{
+		Sources: []parser.SourceConfig{
+			{Name: "daily", Enabled: true, Schedule: "0 11 * * *"},
+			{Name: "always", Enabled: true},
+			{Name: "other", Enabled: true},
+		},
+	}
+	scheduler, err := newSourceScheduler(config, 30*time.Minute, "", "")
+	if err != nil {
+		t.Fatalf("newSourceScheduler() error = %v", err)
+	}
+	tests := []struct {
+		name   string
+		now    time.Time
+		failed map[string]bool
+		want   []string
+	}{
+		{"Before the match", scheduleStart, nil, []string{"always", "other"}},
+		{
+			"At the match",
+			time.Date(2024, time.June, 3, 11, 0, 0, 0, time.UTC),
+			map[string]bool{"daily": true, "always": true},
+			[]string{"daily", "always", "other"},
+		},
+		{"Before the retry", time.Date(2024, time.June, 3, 11, 20, 0, 0, time.UTC), nil, []string{}},
+		{
+			"Failed sources are retried after interval",
+			time.Date(2024, time.June, 3, 11, 30, 0, 0, time.UTC),
+			nil,
+			[]string{"daily", "always", "other"},
+		},
+		{"After the retry", time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC), nil, []string{"always", "other"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, _ := scheduler.dueSources(config, tt.now)
+			if !reflect.DeepEqual(due, tt.want) {
+				t.Errorf("dueSources() = %v, want %v", due, tt.want)
+			}
+			scheduler.markRun(due, tt.failed, tt.now)
+		})
+	}
+}
+
+func TestSourceSchedulerMaintenanceWindow(t *testing.T) {
+	config := &parser.Config{
+		Sources: []parser.SourceConfig{
+			{
+				Name:               "nightly",
+				Enabled:            true,
+				MaintenanceWindows: []utils.MaintenanceWindow{{Start: "18:00", End: "07:00"}},
+			},
+		},
+	}
+	scheduler, err := newSourceScheduler(config, 24*time.Hour, "", "")
+	if err != nil {
+		t.Fatalf("newSourceScheduler() error = %v", err)
+	}
+
+	due, skipped := scheduler.dueSources(config, scheduleStart)
+	if len(due) != 0 {
+		t.Errorf("dueSources() = %v, want no sources outside of maintenance window", due)
+	}
+	wantSkipped := []string{"source nightly is outside of maintenance windows"}
+	if !reflect.DeepEqual(skipped, wantSkipped) {
+		t.Errorf("dueSources() skipped = %v, want %v", skipped, wantSkipped)
+	}
+
+	// Postponed source is synced at the start of the window
+	windowStart := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+	if got := scheduler.nextRun(config, scheduleStart); !got.Equal(windowStart) {
+		t.Errorf("nextRun() = %v, want %v", got, windowStart)
+	}
+	if due, _ := scheduler.dueSources(config, windowStart); !reflect.DeepEqual(due, []string{"nightly"}) {
+		t.Errorf("dueSources() = %v, want [nightly]", due)
+	}
+}
+
+func TestSourceSchedulerSelection(t *testing.T) {
+	config := &parser.Config{
+		Sources: []parser.SourceConfig{
+			{Name: "vcenter", Enabled: true},
+			{Name: "ovirt", Enabled: true},
+			{Name: "dnac", Enabled: true},
+			{Name: "disabled", Enabled: false},
+		},
+	}
+	tests := []struct {
+		name        string
+		only        string
+		skip        string
+		wantErr     bool
+		wantDue     []string
+		wantSkipped []string
+	}{
+		{
+			name:        "All enabled sources",
+			wantDue:     []string{"vcenter", "ovirt", "dnac"},
+			wantSkipped: []string{"source disabled is disabled"},
+		},
+		{
+			name:    "Only",
+			only:    "vcenter, dnac",
+			wantDue: []string{"vcenter", "dnac"},
+			wantSkipped: []string{
+				"source ovirt is not selected",
+				"source disabled is disabled",
+			},
+		},
+		{
+			name:    "Skip",
+			skip:    "ovirt",
+			wantDue: []string{"vcenter", "dnac"},
+			wantSkipped: []string{
+				"source ovirt is not selected",
+				"source disabled is disabled",
+			},
+		},
+		{
+			name:    "Disabled source is not synced, even if selected",
+			only:    "disabled",
+			wantDue: []string{},
+			wantSkipped: []string{
+				"source vcenter is not selected",
+				"source ovirt is not selected",
+				"source dnac is not selected",
+				"source disabled is disabled",
+			},
+		},
+		{
+			name:    "Unknown source",
+			skip:    "proxmox",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler, err := newSourceScheduler(config, 0, tt.only, tt.skip)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newSourceScheduler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			due, skipped := scheduler.dueSources(config, scheduleStart)
+			if !reflect.DeepEqual(due, tt.wantDue) {
+				t.Errorf("dueSources() = %v, want %v", due, tt.wantDue)
+			}
+			if !reflect.DeepEqual(skipped, tt.wantSkipped) {
+				t.Errorf("dueSources() skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestSourcesByPriority(t *testing.T) {
+	config := &parser.Config{
+		Sources: []parser.SourceConfig{
+			{Name: "low", Priority: -1},
+			{Name: "default1"},
+			{Name: "high", Priority: 10},
+			{Name: "default2"},
+			{Name: "notSynced", Priority: 20},
+		},
+	}
+	groups := sourcesByPriority(config, []string{"low", "default1", "high", "default2"})
+	got := [][]string{}
+	for _, group := range groups {
+		names := []string{}
+		for _, sourceConfig := range group {
+			names = append(names, sourceConfig.Name)
+		}
+		got = append(got, names)
+	}
+	want := [][]string{{"high"}, {"default1", "default2"}, {"low"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sourcesByPriority() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// runTransform applies transforms of the source to sample objects and prints
+// transformed objects and emitted raw objects, so transforms can be tested
+// without running the sync.
+func runTransform(args []string) int {
+	transformFlags := flag.NewFlagSet("transform", flag.ExitOnError)
+	transformConfigPath := transformFlags.String(
+		"config",
+		"config.yaml",
+		"Path to the configuration file, or to the directory with configuration files",
+	)
+	sourceName := transformFlags.String("source", "", "Name of the source, whose transforms are applied")
+	objectType := transformFlags.String(
+		"type",
+		"device",
+		fmt.Sprintf("Type of sample objects (%s)", strings.Join(utils.TransformObjectTypes, ", ")),
+	)
+	objectsPath := transformFlags.String(
+		"objects",
+		"-",
+		"Path to yaml or json file with a sample object or a list of sample objects, - for stdin",
+	)
+	_ = transformFlags.Parse(args)
+
+	config, err := parser.ParseConfig(*transformConfigPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Parser:", err)
+		return 1
+	}
+	sourceIndex := slices.IndexFunc(config.Sources, func(sourceConfig parser.SourceConfig) bool {
+		return sourceConfig.Name == *sourceName
+	})
+	if sourceIndex < 0 {
+		fmt.Fprintf(os.Stderr, "Transform: source %q doesn't exist\n", *sourceName)
+		return 1
+	}
+	if !slices.Contains(utils.TransformObjectTypes, *objectType) {
+		fmt.Fprintf(
+			os.Stderr,
+			"Transform: type %s is not supported. Supported types: %s\n",
+			*objectType,
+			strings.Join(utils.TransformObjectTypes, ", "),
+		)
+		return 1
+	}
+	samples, err := readTransformSamples(*objectsPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Transform:", err)
+		return 1
+	}
+
+	// Transforms can use lookup tables of relations
+	utils.SetRelationLookups(config.RelationLookups)
+	transforms := config.Sources[sourceIndex].Transforms[*objectType]
+	for i, sample := range samples {
+		object := utils.NewTransformObject(map[string]any{"source": *sourceName})
+		maps.Copy(object, sample)
+		dropped, emitted, err := utils.ApplyTransforms(transforms, object)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Transform: object %d: %s\n", i+1, err)
+			return 1
+		}
+		if dropped {
+			fmt.Printf("# object %d: dropped\n", i+1)
+			continue
+		}
+		fmt.Printf("# object %d\n", i+1)
+		if err := printTransformed(object); err != nil {
+			fmt.Fprintf(os.Stderr, "Transform: object %d: %s\n", i+1, err)
+			return 1
+		}
+		if len(emitted) > 0 {
+			fmt.Printf("# object %d: emitted raw objects\n", i+1)
+			if err := printTransformed(emitted); err != nil {
+				fmt.Fprintf(os.Stderr, "Transform: object %d: %s\n", i+1, err)
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// printTransformed prints the value as a yaml document.
+func printTransformed(value any) error {
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// readTransformSamples reads a sample object or a list of sample objects from yaml
+// or json file. Fields of sample objects must be fields, that transforms can use.
+func readTransformSamples(path string) ([]map[string]any, error) {
+	var content []byte
+	var err error
+	if path == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var samples []map[string]any
+	if err := yaml.Unmarshal(content, &samples); err != nil {
+		var sample map[string]any
+		if err := yaml.Unmarshal(content, &sample); err != nil {
+			return nil, fmt.Errorf("%s: sample objects must be an object or a list of objects", path)
+		}
+		samples = []map[string]any{sample}
+	}
+	fields := utils.NewTransformObject(nil)
+	for i, sample := range samples {
+		for _, field := range slices.Sorted(maps.Keys(sample)) {
+			if _, ok := fields[field]; !ok {
+				return nil, fmt.Errorf(
+					"%s: object %d: unknown field %s. Fields: %s",
+					path, i+1, field, strings.Join(slices.Sorted(maps.Keys(fields)), ", "),
+				)
+			}
+		}
+	}
+	return samples, nil
+}
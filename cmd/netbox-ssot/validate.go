@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+)
+
+// runSubcommand runs the subcommand given as the first argument, and returns false
+// if there is no subcommand.
+func runSubcommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "validate":
+		os.Exit(runValidate(args[1:]))
+	case "schema":
+		os.Exit(runSchema())
+	case "transform":
+		os.Exit(runTransform(args[1:]))
+	}
+	return false
+}
+
+// runValidate validates the configuration and prints all found problems.
+// Returns exit code 1, if any of the problems is an error.
+func runValidate(args []string) int {
+	validateFlags := flag.NewFlagSet("validate", flag.ExitOnError)
+	validateConfigPath := validateFlags.String(
+		"config",
+		"config.yaml",
+		"Path to the configuration file, or to the directory with configuration files",
+	)
+	_ = validateFlags.Parse(args)
+
+	problems := parser.ValidateConfig(*validateConfigPath)
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if parser.HasErrors(problems) {
+		return 1
+	}
+	if len(problems) == 0 {
+		fmt.Printf("%s: configuration is valid\n", *validateConfigPath)
+	}
+	return 0
+}
+
+// runSchema prints JSON Schema of the configuration.
+func runSchema() int {
+	schema, err := parser.JSONSchema()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Schema:", err)
+		return 1
+	}
+	fmt.Print(string(schema))
+	return 0
+}
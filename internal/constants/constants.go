@@ -28,6 +28,12 @@ const DefaultVlanGroupName = "DefaultVlanGroup"
 const DefaultVlanGroupDescription = "Default netbox-ssot VlanGroup for all vlans that are not part of " +
 	"any other vlanGroup. This group is required for netbox-ssot vlan index to work."
 
+// SitePlaceholder is replaced with the site name in names of default site vlan groups.
+const SitePlaceholder = "{site}"
+
+// DefaultSiteVlanGroupName is the name of default vlan group for vlans of a site.
+const DefaultSiteVlanGroupName = SitePlaceholder + DefaultVlanGroupName
+
 const DefaultArpTagName = "arp-entry"
 const DefaultArpTagColor = ColorRed
 
@@ -39,6 +45,7 @@ const (
 	DefaultManufacturerDescription string = "Generic Manufacturer created by netbox-ssot"
 	DefaultModel                   string = "Generic Model"
 	DefaultSite                    string = "DefaultSite"
+	DefaultSiteDescription         string = "Default netbox-ssot site used for all hosts, that have no site matched."
 	DefaultDeviceTypeDescription   string = "Generic Device Type created by netbox-ssot"
 )
 
@@ -122,8 +129,50 @@ const (
 	IPv6            = 6
 	MaxIPv4MaskBits = 32
 	MaxIPv6MaskBits = 128
+	MaxPort         = 65535
+
+	DefaultParentPrefixLengthIPv4 = 16
+	DefaultParentPrefixLengthIPv6 = 48
+)
+
+// Ranges of autonomous system numbers.
+const (
+	MaxASN16        = 65535
+	MaxASN32        = 4294967295
+	MinPrivateASN16 = 64512
+	MaxPrivateASN16 = 65534
+	MinPrivateASN32 = 4200000000
+	MaxPrivateASN32 = 4294967294
+)
+
+// RIRs used for ASNs discovered by netbox-ssot.
+const (
+	DefaultRIRName        = "DefaultRIR"
+	DefaultRIRDescription = "Default netbox-ssot RIR for all ASNs, whose RIR is unknown"
+	PrivateRIRName        = "RFC 6996"
+	PrivateRIRDescription = "Private use ASNs, as defined in RFC 6996"
 )
 
+// Keys of the local context data, collected from the sources.
+const (
+	LocalContextKeyDNSServers       = "dns_servers"
+	LocalContextKeyDNSSearchDomains = "dns_search_domains"
+	LocalContextKeyNTPServers       = "ntp_servers"
+	LocalContextKeySyslogServers    = "syslog_servers"
+	// LocalContextKeyAdvancedSettings holds vSphere host advanced settings.
+	// Each setting has to be whitelisted separately as advanced_settings.<Setting.Key>.
+	LocalContextKeyAdvancedSettings = "advanced_settings"
+)
+
+// DefaultLocalContextKeys are keys, that are written to local context data,
+// when no keys are whitelisted.
+var DefaultLocalContextKeys = []string{
+	LocalContextKeyDNSServers,
+	LocalContextKeyDNSSearchDomains,
+	LocalContextKeyNTPServers,
+	LocalContextKeySyslogServers,
+}
+
 const (
 	HTTPSDefaultPort = 443
 )
@@ -220,6 +269,8 @@ const (
 	ContentTypeDcimDeviceType           ContentType = "dcim.devicetype"
 	ContentTypeDcimInterface            ContentType = "dcim.interface"
 	ContentTypeDcimLocation             ContentType = "dcim.location"
+	ContentTypeDcimRack                 ContentType = "dcim.rack"
+	ContentTypeDcimRackRole             ContentType = "dcim.rackrole"
 	ContentTypeDcimManufacturer         ContentType = "dcim.manufacturer"
 	ContentTypeDcimPlatform             ContentType = "dcim.platform"
 	ContentTypeDcimRegion               ContentType = "dcim.region"
@@ -227,16 +278,35 @@ const (
 	ContentTypeDcimSiteGroup            ContentType = "dcim.sitegroup"
 	ContentTypeDcimVirtualDeviceContext ContentType = "dcim.virtualdevicecontext"
 	ContentTypeDcimMACAddress           ContentType = "dcim.macaddress"
+	ContentTypeDcimModuleType           ContentType = "dcim.moduletype"
+	ContentTypeDcimModuleBay            ContentType = "dcim.modulebay"
+	ContentTypeDcimModule               ContentType = "dcim.module"
+	ContentTypeDcimInventoryItem        ContentType = "dcim.inventoryitem"
+	ContentTypeDcimPowerPanel           ContentType = "dcim.powerpanel"
+	ContentTypeDcimPowerFeed            ContentType = "dcim.powerfeed"
+	ContentTypeDcimPowerPort            ContentType = "dcim.powerport"
+	ContentTypeDcimConsolePort          ContentType = "dcim.consoleport"
 
 	// Extras object types.
-	ContentTypeExtrasCustomField ContentType = "extras.customfield"
-	ContentTypeExtrasTag         ContentType = "extras.tag"
+	ContentTypeExtrasCustomField  ContentType = "extras.customfield"
+	ContentTypeExtrasTag          ContentType = "extras.tag"
+	ContentTypeExtrasJournalEntry ContentType = "extras.journalentry"
 
 	// IPAM object types.
-	ContentTypeIpamIPAddress ContentType = "ipam.ipaddress"
-	ContentTypeIpamVlanGroup ContentType = "ipam.vlangroup"
-	ContentTypeIpamVlan      ContentType = "ipam.vlan"
-	ContentTypeIpamPrefix    ContentType = "ipam.prefix"
+	ContentTypeIpamIPAddress   ContentType = "ipam.ipaddress"
+	ContentTypeIpamVlanGroup   ContentType = "ipam.vlangroup"
+	ContentTypeIpamVlan        ContentType = "ipam.vlan"
+	ContentTypeIpamPrefix      ContentType = "ipam.prefix"
+	ContentTypeIpamVRF         ContentType = "ipam.vrf"
+	ContentTypeIpamRouteTarget ContentType = "ipam.routetarget"
+	ContentTypeIpamIPRange     ContentType = "ipam.iprange"
+
+	ContentTypeIpamFHRPGroup           ContentType = "ipam.fhrpgroup"
+	ContentTypeIpamFHRPGroupAssignment ContentType = "ipam.fhrpgroupassignment"
+	ContentTypeIpamService             ContentType = "ipam.service"
+	ContentTypeIpamRIR                 ContentType = "ipam.rir"
+	ContentTypeIpamASN                 ContentType = "ipam.asn"
+	ContentTypeIpamAggregate           ContentType = "ipam.aggregate"
 
 	// Tenancy object types.
 	ContentTypeTenancyTenantGroup       ContentType = "tenancy.tenantgroup"
@@ -253,6 +323,13 @@ const (
 	ContentTypeVirtualizationVirtualMachine ContentType = "virtualization.virtualmachine"
 	ContentTypeVirtualizationVMInterface    ContentType = "virtualization.vminterface"
 
+	// VPN object types.
+	ContentTypeVpnL2VPN             ContentType = "vpn.l2vpn"
+	ContentTypeVpnL2VPNTermination  ContentType = "vpn.l2vpntermination"
+	ContentTypeVpnTunnel            ContentType = "vpn.tunnel"
+	ContentTypeVpnTunnelGroup       ContentType = "vpn.tunnelgroup"
+	ContentTypeVpnTunnelTermination ContentType = "vpn.tunneltermination"
+
 	// Wireless object type.
 	ContentTypeWirelessLink     ContentType = "wireless.wirelesslink"
 	ContentTypeWirelessLAN      ContentType = "wireless.wirelesslan"
@@ -273,10 +350,20 @@ const (
 	ContactAssignmentsAPIPath APIPath = "/api/tenancy/contact-assignments/"
 
 	// IPAM paths.
-	PrefixesAPIPath    APIPath = "/api/ipam/prefixes/"
-	VlanGroupsAPIPath  APIPath = "/api/ipam/vlan-groups/"
-	VlansAPIPath       APIPath = "/api/ipam/vlans/"
-	IPAddressesAPIPath APIPath = "/api/ipam/ip-addresses/"
+	PrefixesAPIPath     APIPath = "/api/ipam/prefixes/"
+	VlanGroupsAPIPath   APIPath = "/api/ipam/vlan-groups/"
+	VlansAPIPath        APIPath = "/api/ipam/vlans/"
+	IPAddressesAPIPath  APIPath = "/api/ipam/ip-addresses/"
+	VRFsAPIPath         APIPath = "/api/ipam/vrfs/"
+	RouteTargetsAPIPath APIPath = "/api/ipam/route-targets/"
+	IPRangesAPIPath     APIPath = "/api/ipam/ip-ranges/"
+
+	FHRPGroupsAPIPath           APIPath = "/api/ipam/fhrp-groups/"
+	FHRPGroupAssignmentsAPIPath APIPath = "/api/ipam/fhrp-group-assignments/"
+	ServicesAPIPath             APIPath = "/api/ipam/services/"
+	RIRsAPIPath                 APIPath = "/api/ipam/rirs/"
+	ASNsAPIPath                 APIPath = "/api/ipam/asns/"
+	AggregatesAPIPath           APIPath = "/api/ipam/aggregates/"
 
 	// Virtualization paths.
 	ClusterTypesAPIPath    APIPath = "/api/virtualization/cluster-types/"
@@ -295,17 +382,35 @@ const (
 	SiteGroupsAPIPath            APIPath = "/api/dcim/site-groups/"
 	RegionsAPIPath               APIPath = "/api/dcim/regions/"
 	LocationsAPIPath             APIPath = "/api/dcim/locations/"
+	RacksAPIPath                 APIPath = "/api/dcim/racks/"
+	RackRolesAPIPath             APIPath = "/api/dcim/rack-roles/"
+	PowerPanelsAPIPath           APIPath = "/api/dcim/power-panels/"
+	PowerFeedsAPIPath            APIPath = "/api/dcim/power-feeds/"
+	PowerPortsAPIPath            APIPath = "/api/dcim/power-ports/"
+	ConsolePortsAPIPath          APIPath = "/api/dcim/console-ports/"
 	ManufacturersAPIPath         APIPath = "/api/dcim/manufacturers/"
 	PlatformsAPIPath             APIPath = "/api/dcim/platforms/"
 	VirtualDeviceContextsAPIPath APIPath = "/api/dcim/virtual-device-contexts/"
+	ModuleTypesAPIPath           APIPath = "/api/dcim/module-types/"
+	ModuleBaysAPIPath            APIPath = "/api/dcim/module-bays/"
+	ModulesAPIPath               APIPath = "/api/dcim/modules/"
+	InventoryItemsAPIPath        APIPath = "/api/dcim/inventory-items/"
 
 	// Wireless paths.
 	WirelessLANsAPIPath      APIPath = "/api/wireless/wireless-lans/"
 	WirelessLANGroupsAPIPath APIPath = "/api/wireless/wireless-lan-groups/"
 
+	// VPN paths.
+	L2VPNsAPIPath             APIPath = "/api/vpn/l2vpns/"
+	L2VPNTerminationsAPIPath  APIPath = "/api/vpn/l2vpn-terminations/"
+	TunnelsAPIPath            APIPath = "/api/vpn/tunnels/"
+	TunnelGroupsAPIPath       APIPath = "/api/vpn/tunnel-groups/"
+	TunnelTerminationsAPIPath APIPath = "/api/vpn/tunnel-terminations/"
+
 	// Extras paths.
-	CustomFieldsAPIPath APIPath = "/api/extras/custom-fields/"
-	TagsAPIPath         APIPath = "/api/extras/tags/"
+	CustomFieldsAPIPath   APIPath = "/api/extras/custom-fields/"
+	TagsAPIPath           APIPath = "/api/extras/tags/"
+	JournalEntriesAPIPath APIPath = "/api/extras/journal-entries/"
 )
 
 var Arch2Bit = map[string]string{
@@ -336,4 +441,7 @@ const (
 	MaxDeviceNameLength   = 64
 	MaxSerialNumberLength = 50
 	MaxAssetTagLength     = 50
+
+	// Limitations for inventory items.
+	MaxInventoryItemNameLength = 64
 )
@@ -132,6 +132,220 @@ func (nbi *NetboxInventory) AddSite(
 	return nbi.sitesIndexByName[newSite.Name], nil
 }
 
+// AddLocation adds a Location to the local netbox inventory.
+// If the Location already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddLocation(
+	ctx context.Context,
+	newLocation *objects.Location,
+) (*objects.Location, error) {
+	newLocation.NetboxObject.AddTag(nbi.SsotTag)
+	if newLocation.Site == nil {
+		return nil, fmt.Errorf("Location %s has no site", newLocation.Name)
+	}
+	nbi.locationsLock.Lock()
+	defer nbi.locationsLock.Unlock()
+	if nbi.locationsIndex[newLocation.Site.ID] == nil {
+		nbi.locationsIndex[newLocation.Site.ID] = make(map[string]*objects.Location)
+	}
+	if _, ok := nbi.locationsIndex[newLocation.Site.ID][newLocation.Name]; ok {
+		oldLocation := nbi.locationsIndex[newLocation.Site.ID][newLocation.Name]
+		diffMap, err := utils.JSONDiffMapExceptID(newLocation, oldLocation, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "Location %s already exists in Netbox but is out of date. Patching it...", newLocation.Name)
+			patchedLocation, err := service.Patch[objects.Location](ctx, nbi.NetboxAPI, oldLocation.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.locationsIndex[newLocation.Site.ID][newLocation.Name] = patchedLocation
+		} else {
+			nbi.Logger.Debugf(ctx, "Location %s already exists in Netbox and is up to date...", newLocation.Name)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "Location %s does not exist in Netbox. Creating it...", newLocation.Name)
+		createdLocation, err := service.Create(ctx, nbi.NetboxAPI, newLocation)
+		if err != nil {
+			return nil, err
+		}
+		nbi.locationsIndex[newLocation.Site.ID][newLocation.Name] = createdLocation
+	}
+	return nbi.locationsIndex[newLocation.Site.ID][newLocation.Name], nil
+}
+
+// AddRackRole adds a RackRole to the local netbox inventory.
+// If the RackRole already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddRackRole(
+	ctx context.Context,
+	newRackRole *objects.RackRole,
+) (*objects.RackRole, error) {
+	newRackRole.NetboxObject.AddTag(nbi.SsotTag)
+	nbi.rackRolesLock.Lock()
+	defer nbi.rackRolesLock.Unlock()
+	if _, ok := nbi.rackRolesIndexByName[newRackRole.Name]; ok {
+		oldRackRole := nbi.rackRolesIndexByName[newRackRole.Name]
+		diffMap, err := utils.JSONDiffMapExceptID(newRackRole, oldRackRole, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "RackRole %s already exists in Netbox but is out of date. Patching it...", newRackRole.Name)
+			patchedRackRole, err := service.Patch[objects.RackRole](ctx, nbi.NetboxAPI, oldRackRole.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.rackRolesIndexByName[newRackRole.Name] = patchedRackRole
+		} else {
+			nbi.Logger.Debugf(ctx, "RackRole %s already exists in Netbox and is up to date...", newRackRole.Name)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "RackRole %s does not exist in Netbox. Creating it...", newRackRole.Name)
+		createdRackRole, err := service.Create(ctx, nbi.NetboxAPI, newRackRole)
+		if err != nil {
+			return nil, err
+		}
+		nbi.rackRolesIndexByName[newRackRole.Name] = createdRackRole
+	}
+	return nbi.rackRolesIndexByName[newRackRole.Name], nil
+}
+
+// AddRack adds a Rack to the local netbox inventory.
+// If the Rack already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddRack(
+	ctx context.Context,
+	newRack *objects.Rack,
+) (*objects.Rack, error) {
+	newRack.NetboxObject.AddTag(nbi.SsotTag)
+	if newRack.Site == nil {
+		return nil, fmt.Errorf("Rack %s has no site", newRack.Name)
+	}
+	nbi.racksLock.Lock()
+	defer nbi.racksLock.Unlock()
+	if nbi.racksIndex[newRack.Site.ID] == nil {
+		nbi.racksIndex[newRack.Site.ID] = make(map[string]*objects.Rack)
+	}
+	if _, ok := nbi.racksIndex[newRack.Site.ID][newRack.Name]; ok {
+		oldRack := nbi.racksIndex[newRack.Site.ID][newRack.Name]
+		diffMap, err := utils.JSONDiffMapExceptID(newRack, oldRack, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "Rack %s already exists in Netbox but is out of date. Patching it...", newRack.Name)
+			patchedRack, err := service.Patch[objects.Rack](ctx, nbi.NetboxAPI, oldRack.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.racksIndex[newRack.Site.ID][newRack.Name] = patchedRack
+		} else {
+			nbi.Logger.Debugf(ctx, "Rack %s already exists in Netbox and is up to date...", newRack.Name)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "Rack %s does not exist in Netbox. Creating it...", newRack.Name)
+		createdRack, err := service.Create(ctx, nbi.NetboxAPI, newRack)
+		if err != nil {
+			return nil, err
+		}
+		nbi.racksIndex[newRack.Site.ID][newRack.Name] = createdRack
+	}
+	return nbi.racksIndex[newRack.Site.ID][newRack.Name], nil
+}
+
+// AddPowerPanel adds a power panel to the local netbox inventory.
+// If the power panel already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddPowerPanel(
+	ctx context.Context,
+	newPowerPanel *objects.PowerPanel,
+) (*objects.PowerPanel, error) {
+	newPowerPanel.NetboxObject.AddTag(nbi.SsotTag)
+	if newPowerPanel.Site == nil {
+		return nil, fmt.Errorf("PowerPanel %s has no site", newPowerPanel.Name)
+	}
+	siteID := newPowerPanel.Site.ID
+	nbi.powerPanelsLock.Lock()
+	defer nbi.powerPanelsLock.Unlock()
+	if nbi.powerPanelsIndex[siteID] == nil {
+		nbi.powerPanelsIndex[siteID] = make(map[string]*objects.PowerPanel)
+	}
+	if _, ok := nbi.powerPanelsIndex[siteID][newPowerPanel.Name]; ok {
+		oldPowerPanel := nbi.powerPanelsIndex[siteID][newPowerPanel.Name]
+		diffMap, err := utils.JSONDiffMapExceptID(newPowerPanel, oldPowerPanel, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(
+				ctx,
+				"PowerPanel %s already exists in Netbox but is out of date. Patching it...",
+				newPowerPanel.Name,
+			)
+			patchedPowerPanel, err := service.Patch[objects.PowerPanel](ctx, nbi.NetboxAPI, oldPowerPanel.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.powerPanelsIndex[siteID][newPowerPanel.Name] = patchedPowerPanel
+		} else {
+			nbi.Logger.Debugf(ctx, "PowerPanel %s already exists in Netbox and is up to date...", newPowerPanel.Name)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "PowerPanel %s does not exist in Netbox. Creating it...", newPowerPanel.Name)
+		createdPowerPanel, err := service.Create(ctx, nbi.NetboxAPI, newPowerPanel)
+		if err != nil {
+			return nil, err
+		}
+		nbi.powerPanelsIndex[siteID][newPowerPanel.Name] = createdPowerPanel
+	}
+	return nbi.powerPanelsIndex[siteID][newPowerPanel.Name], nil
+}
+
+// AddPowerFeed adds a power feed to the local netbox inventory.
+// If the power feed already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddPowerFeed(
+	ctx context.Context,
+	newPowerFeed *objects.PowerFeed,
+) (*objects.PowerFeed, error) {
+	newPowerFeed.NetboxObject.AddTag(nbi.SsotTag)
+	if newPowerFeed.PowerPanel == nil {
+		return nil, fmt.Errorf("PowerFeed %s has no power panel", newPowerFeed.Name)
+	}
+	panelID := newPowerFeed.PowerPanel.ID
+	nbi.powerFeedsLock.Lock()
+	defer nbi.powerFeedsLock.Unlock()
+	if nbi.powerFeedsIndex[panelID] == nil {
+		nbi.powerFeedsIndex[panelID] = make(map[string]*objects.PowerFeed)
+	}
+	if _, ok := nbi.powerFeedsIndex[panelID][newPowerFeed.Name]; ok {
+		oldPowerFeed := nbi.powerFeedsIndex[panelID][newPowerFeed.Name]
+		diffMap, err := utils.JSONDiffMapExceptID(newPowerFeed, oldPowerFeed, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(
+				ctx,
+				"PowerFeed %s already exists in Netbox but is out of date. Patching it...",
+				newPowerFeed.Name,
+			)
+			patchedPowerFeed, err := service.Patch[objects.PowerFeed](ctx, nbi.NetboxAPI, oldPowerFeed.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.powerFeedsIndex[panelID][newPowerFeed.Name] = patchedPowerFeed
+		} else {
+			nbi.Logger.Debugf(ctx, "PowerFeed %s already exists in Netbox and is up to date...", newPowerFeed.Name)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "PowerFeed %s does not exist in Netbox. Creating it...", newPowerFeed.Name)
+		createdPowerFeed, err := service.Create(ctx, nbi.NetboxAPI, newPowerFeed)
+		if err != nil {
+			return nil, err
+		}
+		nbi.powerFeedsIndex[panelID][newPowerFeed.Name] = createdPowerFeed
+	}
+	return nbi.powerFeedsIndex[panelID][newPowerFeed.Name], nil
+}
+
 // AddSiteGroup adds a SiteGroup to the local netbox inventory.
 func (nbi *NetboxInventory) AddSiteGroup(
 	ctx context.Context,
@@ -966,6 +1180,348 @@ func (nbi *NetboxInventory) AddVirtualDeviceContext(
 	return nbi.virtualDeviceContextsIndex[newVDC.Name][newVDC.Device.ID], nil
 }
 
+// AddModuleType adds a new module type to the Netbox inventory.
+// It takes a context and a newModuleType object as input and
+// returns the created or updated module type object and an error, if any.
+// If the module type already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the module type does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddModuleType(
+	ctx context.Context,
+	newModuleType *objects.ModuleType,
+) (*objects.ModuleType, error) {
+	newModuleType.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newModuleType.NetboxObject)
+	newModuleType.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.moduleTypesLock.Lock()
+	defer nbi.moduleTypesLock.Unlock()
+	if newModuleType.Manufacturer == nil {
+		return nil, fmt.Errorf("module type %s is not assigned to a manufacturer, but it should be", newModuleType)
+	}
+	manufacturerID := newModuleType.Manufacturer.ID
+	if _, ok := nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID][newModuleType.Model]; ok {
+		oldModuleType := nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID][newModuleType.Model]
+		nbi.OrphanManager.RemoveItem(oldModuleType)
+		diffMap, err := utils.JSONDiffMapExceptID(
+			newModuleType,
+			oldModuleType,
+			false,
+			nbi.SourcePriority,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"Module type ",
+				newModuleType.Model,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedModuleType, err := service.Patch[objects.ModuleType](
+				ctx,
+				nbi.NetboxAPI,
+				oldModuleType.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID][newModuleType.Model] = patchedModuleType
+		} else {
+			nbi.Logger.Debug(ctx, "Module type ", newModuleType.Model, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Module type ", newModuleType.Model, " does not exist in Netbox. Creating it...")
+		newModuleType, err := service.Create(ctx, nbi.NetboxAPI, newModuleType)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID] == nil {
+			nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID] = make(map[string]*objects.ModuleType)
+		}
+		nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID][newModuleType.Model] = newModuleType
+	}
+	return nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID][newModuleType.Model], nil
+}
+
+// AddModuleBay adds a new module bay to the Netbox inventory.
+// It takes a context and a newModuleBay object as input and
+// returns the created or updated module bay object and an error, if any.
+// If the module bay already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the module bay does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddModuleBay(
+	ctx context.Context,
+	newModuleBay *objects.ModuleBay,
+) (*objects.ModuleBay, error) {
+	newModuleBay.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newModuleBay.NetboxObject)
+	newModuleBay.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.moduleBaysLock.Lock()
+	defer nbi.moduleBaysLock.Unlock()
+	if newModuleBay.Device == nil {
+		return nil, fmt.Errorf("ModuleBay %s is not assigned to a device, but it should be", newModuleBay)
+	}
+	if _, ok := nbi.moduleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID][newModuleBay.Name]; ok {
+		oldModuleBay := nbi.moduleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID][newModuleBay.Name]
+		nbi.OrphanManager.RemoveItem(oldModuleBay)
+		diffMap, err := utils.JSONDiffMapExceptID(newModuleBay, oldModuleBay, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"Module bay ",
+				newModuleBay.Name,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedModuleBay, err := service.Patch[objects.ModuleBay](
+				ctx,
+				nbi.NetboxAPI,
+				oldModuleBay.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.moduleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID][newModuleBay.Name] = patchedModuleBay
+		} else {
+			nbi.Logger.Debug(ctx, "Module bay ", newModuleBay.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Module bay ", newModuleBay.Name, " does not exist in Netbox. Creating it...")
+		createdModuleBay, err := service.Create(ctx, nbi.NetboxAPI, newModuleBay)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.moduleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID] == nil {
+			nbi.moduleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID] = make(map[string]*objects.ModuleBay)
+		}
+		nbi.moduleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID][newModuleBay.Name] = createdModuleBay
+	}
+	return nbi.moduleBaysIndexByDeviceIDAndName[newModuleBay.Device.ID][newModuleBay.Name], nil
+}
+
+// AddModule adds a new module to the Netbox inventory.
+// It takes a context and a newModule object as input and
+// returns the created or updated module object and an error, if any.
+// If the module already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the module does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddModule(
+	ctx context.Context,
+	newModule *objects.Module,
+) (*objects.Module, error) {
+	newModule.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newModule.NetboxObject)
+	newModule.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	if len(newModule.SerialNumber) > constants.MaxSerialNumberLength {
+		newModule.SerialNumber = newModule.SerialNumber[:constants.MaxSerialNumberLength]
+	}
+	nbi.modulesLock.Lock()
+	defer nbi.modulesLock.Unlock()
+	if newModule.ModuleBay == nil {
+		return nil, fmt.Errorf("Module %s is not assigned to a module bay, but it should be", newModule)
+	}
+	if _, ok := nbi.modulesIndexByModuleBayID[newModule.ModuleBay.ID]; ok {
+		oldModule := nbi.modulesIndexByModuleBayID[newModule.ModuleBay.ID]
+		nbi.OrphanManager.RemoveItem(oldModule)
+		diffMap, err := utils.JSONDiffMapExceptID(newModule, oldModule, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"Module ",
+				newModule,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedModule, err := service.Patch[objects.Module](
+				ctx,
+				nbi.NetboxAPI,
+				oldModule.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.modulesIndexByModuleBayID[newModule.ModuleBay.ID] = patchedModule
+		} else {
+			nbi.Logger.Debug(ctx, "Module ", newModule, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Module ", newModule, " does not exist in Netbox. Creating it...")
+		createdModule, err := service.Create(ctx, nbi.NetboxAPI, newModule)
+		if err != nil {
+			return nil, err
+		}
+		nbi.modulesIndexByModuleBayID[newModule.ModuleBay.ID] = createdModule
+	}
+	return nbi.modulesIndexByModuleBayID[newModule.ModuleBay.ID], nil
+}
+
+// AddInventoryItem adds a new inventory item to the Netbox inventory.
+// It takes a context and a newInventoryItem object as input and
+// returns the created or updated inventory item object and an error, if any.
+// If the inventory item already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the inventory item does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddInventoryItem(
+	ctx context.Context,
+	newInventoryItem *objects.InventoryItem,
+) (*objects.InventoryItem, error) {
+	newInventoryItem.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newInventoryItem.NetboxObject)
+	newInventoryItem.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.applyInventoryItemFieldLengthLimitations(newInventoryItem)
+	nbi.inventoryItemsLock.Lock()
+	defer nbi.inventoryItemsLock.Unlock()
+	if newInventoryItem.Device == nil {
+		return nil, fmt.Errorf(
+			"InventoryItem %s is not assigned to a device, but it should be",
+			newInventoryItem,
+		)
+	}
+	deviceID := newInventoryItem.Device.ID
+	if _, ok := nbi.inventoryItemsIndexByDeviceIDAndName[deviceID][newInventoryItem.Name]; ok {
+		oldInventoryItem := nbi.inventoryItemsIndexByDeviceIDAndName[deviceID][newInventoryItem.Name]
+		nbi.OrphanManager.RemoveItem(oldInventoryItem)
+		diffMap, err := utils.JSONDiffMapExceptID(
+			newInventoryItem,
+			oldInventoryItem,
+			false,
+			nbi.SourcePriority,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"Inventory item ",
+				newInventoryItem.Name,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedInventoryItem, err := service.Patch[objects.InventoryItem](
+				ctx,
+				nbi.NetboxAPI,
+				oldInventoryItem.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.inventoryItemsIndexByDeviceIDAndName[deviceID][newInventoryItem.Name] = patchedInventoryItem
+		} else {
+			nbi.Logger.Debug(ctx, "Inventory item ", newInventoryItem.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Inventory item ", newInventoryItem.Name, " does not exist in Netbox. Creating it...")
+		createdInventoryItem, err := service.Create(ctx, nbi.NetboxAPI, newInventoryItem)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.inventoryItemsIndexByDeviceIDAndName[deviceID] == nil {
+			nbi.inventoryItemsIndexByDeviceIDAndName[deviceID] = make(map[string]*objects.InventoryItem)
+		}
+		nbi.inventoryItemsIndexByDeviceIDAndName[deviceID][newInventoryItem.Name] = createdInventoryItem
+	}
+	return nbi.inventoryItemsIndexByDeviceIDAndName[deviceID][newInventoryItem.Name], nil
+}
+
+// AddPowerPort adds a new power port to the Netbox inventory.
+// If the power port already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the power port does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddPowerPort(
+	ctx context.Context,
+	newPowerPort *objects.PowerPort,
+) (*objects.PowerPort, error) {
+	newPowerPort.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newPowerPort.NetboxObject)
+	newPowerPort.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	if newPowerPort.Device == nil {
+		return nil, fmt.Errorf("PowerPort %s is not assigned to a device, but it should be", newPowerPort)
+	}
+	deviceID := newPowerPort.Device.ID
+	nbi.powerPortsLock.Lock()
+	defer nbi.powerPortsLock.Unlock()
+	if _, ok := nbi.powerPortsIndexByDeviceIDAndName[deviceID][newPowerPort.Name]; ok {
+		oldPowerPort := nbi.powerPortsIndexByDeviceIDAndName[deviceID][newPowerPort.Name]
+		nbi.OrphanManager.RemoveItem(oldPowerPort)
+		diffMap, err := utils.JSONDiffMapExceptID(newPowerPort, oldPowerPort, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "%s already exists in Netbox but is out of date. Patching it...", newPowerPort)
+			patchedPowerPort, err := service.Patch[objects.PowerPort](ctx, nbi.NetboxAPI, oldPowerPort.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.powerPortsIndexByDeviceIDAndName[deviceID][newPowerPort.Name] = patchedPowerPort
+		} else {
+			nbi.Logger.Debugf(ctx, "%s already exists in Netbox and is up to date...", newPowerPort)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "%s does not exist in Netbox. Creating it...", newPowerPort)
+		createdPowerPort, err := service.Create(ctx, nbi.NetboxAPI, newPowerPort)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.powerPortsIndexByDeviceIDAndName[deviceID] == nil {
+			nbi.powerPortsIndexByDeviceIDAndName[deviceID] = make(map[string]*objects.PowerPort)
+		}
+		nbi.powerPortsIndexByDeviceIDAndName[deviceID][newPowerPort.Name] = createdPowerPort
+	}
+	return nbi.powerPortsIndexByDeviceIDAndName[deviceID][newPowerPort.Name], nil
+}
+
+// AddConsolePort adds a new console port to the Netbox inventory.
+// If the console port already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the console port does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddConsolePort(
+	ctx context.Context,
+	newConsolePort *objects.ConsolePort,
+) (*objects.ConsolePort, error) {
+	newConsolePort.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newConsolePort.NetboxObject)
+	newConsolePort.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	if newConsolePort.Device == nil {
+		return nil, fmt.Errorf("ConsolePort %s is not assigned to a device, but it should be", newConsolePort)
+	}
+	deviceID := newConsolePort.Device.ID
+	nbi.consolePortsLock.Lock()
+	defer nbi.consolePortsLock.Unlock()
+	if _, ok := nbi.consolePortsIndexByDeviceIDAndName[deviceID][newConsolePort.Name]; ok {
+		oldConsolePort := nbi.consolePortsIndexByDeviceIDAndName[deviceID][newConsolePort.Name]
+		nbi.OrphanManager.RemoveItem(oldConsolePort)
+		diffMap, err := utils.JSONDiffMapExceptID(newConsolePort, oldConsolePort, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "%s already exists in Netbox but is out of date. Patching it...", newConsolePort)
+			patchedConsolePort, err := service.Patch[objects.ConsolePort](ctx, nbi.NetboxAPI, oldConsolePort.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.consolePortsIndexByDeviceIDAndName[deviceID][newConsolePort.Name] = patchedConsolePort
+		} else {
+			nbi.Logger.Debugf(ctx, "%s already exists in Netbox and is up to date...", newConsolePort)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "%s does not exist in Netbox. Creating it...", newConsolePort)
+		createdConsolePort, err := service.Create(ctx, nbi.NetboxAPI, newConsolePort)
+		if err != nil {
+			return nil, err
+		}
+		if nbi.consolePortsIndexByDeviceIDAndName[deviceID] == nil {
+			nbi.consolePortsIndexByDeviceIDAndName[deviceID] = make(map[string]*objects.ConsolePort)
+		}
+		nbi.consolePortsIndexByDeviceIDAndName[deviceID][newConsolePort.Name] = createdConsolePort
+	}
+	return nbi.consolePortsIndexByDeviceIDAndName[deviceID][newConsolePort.Name], nil
+}
+
 // AddVlanGroup adds a new vlan group to the Netbox inventory.
 // It takes a context and a newVlanGroup object as input and
 // returns the created or updated vlan group object and an error, if any.
@@ -1277,13 +1833,14 @@ func (nbi *NetboxInventory) AddIPAddress(
 	if err != nil {
 		return nil, fmt.Errorf("get index values for ip address %+v: %s", newIPAddress, err)
 	}
+	vrfID := getVRFIndexValue(newIPAddress.VRF)
 	// Ensure index is not nil.
-	nbi.verifyIPAddressIndexExists(objType, objName, ifaceName)
+	nbi.verifyIPAddressIndexExists(vrfID, objType, objName, ifaceName)
 
 	nbi.ipAddressesLock.Lock()
 	defer nbi.ipAddressesLock.Unlock()
-	if _, ok := nbi.ipAddressesIndex[objType][objName][ifaceName][newIPAddress.Address]; ok {
-		oldIPAddress := nbi.ipAddressesIndex[objType][objName][ifaceName][newIPAddress.Address]
+	if _, ok := nbi.ipAddressesIndex[vrfID][objType][objName][ifaceName][newIPAddress.Address]; ok {
+		oldIPAddress := nbi.ipAddressesIndex[vrfID][objType][objName][ifaceName][newIPAddress.Address]
 		nbi.OrphanManager.RemoveItem(oldIPAddress)
 
 		diffMap, err := utils.JSONDiffMapExceptID(
@@ -1310,7 +1867,7 @@ func (nbi *NetboxInventory) AddIPAddress(
 			if err != nil {
 				return nil, err
 			}
-			nbi.ipAddressesIndex[objType][objName][ifaceName][newIPAddress.Address] = patchedIPAddress
+			nbi.ipAddressesIndex[vrfID][objType][objName][ifaceName][newIPAddress.Address] = patchedIPAddress
 			return patchedIPAddress, nil
 		}
 		nbi.Logger.Debugf(
@@ -1328,10 +1885,10 @@ func (nbi *NetboxInventory) AddIPAddress(
 		if err != nil {
 			return nil, err
 		}
-		nbi.ipAddressesIndex[objType][objName][ifaceName][newIPAddress.Address] = newIPAddress
+		nbi.ipAddressesIndex[vrfID][objType][objName][ifaceName][newIPAddress.Address] = newIPAddress
 		return newIPAddress, nil
 	}
-	return nbi.ipAddressesIndex[objType][objName][ifaceName][newIPAddress.Address], nil
+	return nbi.ipAddressesIndex[vrfID][objType][objName][ifaceName][newIPAddress.Address], nil
 }
 
 // AddMACAddress adds a new MAC address to the Netbox inventory.
@@ -1409,7 +1966,705 @@ func (nbi *NetboxInventory) AddMACAddress(
 	return nbi.macAddressesIndex[objType][objName][ifaceName][newMACAddress.MAC], nil
 }
 
-// AddPrefix adds a new prefix to the Netbox inventory.
+// AddRouteTarget adds a new route target to the Netbox inventory.
+// It takes a context and a newRouteTarget object as input and
+// returns the created or updated route target object and an error, if any.
+// If the route target already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the route target does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddRouteTarget(
+	ctx context.Context,
+	newRouteTarget *objects.RouteTarget,
+) (*objects.RouteTarget, error) {
+	newRouteTarget.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newRouteTarget.NetboxObject)
+	newRouteTarget.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.routeTargetsLock.Lock()
+	defer nbi.routeTargetsLock.Unlock()
+	if _, ok := nbi.routeTargetsIndexByName[newRouteTarget.Name]; ok {
+		oldRouteTarget := nbi.routeTargetsIndexByName[newRouteTarget.Name]
+		nbi.OrphanManager.RemoveItem(oldRouteTarget)
+		diffMap, err := utils.JSONDiffMapExceptID(
+			newRouteTarget,
+			oldRouteTarget,
+			false,
+			nbi.SourcePriority,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"Route target ",
+				newRouteTarget.Name,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedRouteTarget, err := service.Patch[objects.RouteTarget](
+				ctx,
+				nbi.NetboxAPI,
+				oldRouteTarget.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.routeTargetsIndexByName[newRouteTarget.Name] = patchedRouteTarget
+		} else {
+			nbi.Logger.Debug(ctx, "Route target ", newRouteTarget.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Route target ", newRouteTarget.Name, " does not exist in Netbox. Creating it...")
+		newRouteTarget, err := service.Create(ctx, nbi.NetboxAPI, newRouteTarget)
+		if err != nil {
+			return nil, err
+		}
+		nbi.routeTargetsIndexByName[newRouteTarget.Name] = newRouteTarget
+	}
+	return nbi.routeTargetsIndexByName[newRouteTarget.Name], nil
+}
+
+// AddVRF adds a new VRF to the Netbox inventory.
+// It takes a context and a newVRF object as input and
+// returns the created or updated VRF object and an error, if any.
+// If the VRF already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the VRF does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddVRF(
+	ctx context.Context,
+	newVRF *objects.VRF,
+) (*objects.VRF, error) {
+	newVRF.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newVRF.NetboxObject)
+	newVRF.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.vrfsLock.Lock()
+	defer nbi.vrfsLock.Unlock()
+	if _, ok := nbi.vrfsIndexByName[newVRF.Name]; ok {
+		oldVRF := nbi.vrfsIndexByName[newVRF.Name]
+		nbi.OrphanManager.RemoveItem(oldVRF)
+		diffMap, err := utils.JSONDiffMapExceptID(newVRF, oldVRF, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"VRF ",
+				newVRF.Name,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedVRF, err := service.Patch[objects.VRF](
+				ctx,
+				nbi.NetboxAPI,
+				oldVRF.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.vrfsIndexByName[newVRF.Name] = patchedVRF
+		} else {
+			nbi.Logger.Debug(ctx, "VRF ", newVRF.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "VRF ", newVRF.Name, " does not exist in Netbox. Creating it...")
+		newVRF, err := service.Create(ctx, nbi.NetboxAPI, newVRF)
+		if err != nil {
+			return nil, err
+		}
+		nbi.vrfsIndexByName[newVRF.Name] = newVRF
+	}
+	return nbi.vrfsIndexByName[newVRF.Name], nil
+}
+
+// AddService adds a new service to the Netbox inventory.
+// It takes a context and a newService object as input and
+// returns the created or updated service object and an error, if any.
+// If the service already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the service does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddService(
+	ctx context.Context,
+	newService *objects.Service,
+) (*objects.Service, error) {
+	newService.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newService.NetboxObject)
+	newService.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+
+	parentType, parentID, protocol, err := getIndexValuesForService(newService)
+	if err != nil {
+		return nil, err
+	}
+	nbi.verifyServiceIndexExists(parentType, parentID, protocol)
+
+	nbi.servicesLock.Lock()
+	defer nbi.servicesLock.Unlock()
+	if _, ok := nbi.servicesIndex[parentType][parentID][protocol][newService.Name]; ok {
+		oldService := nbi.servicesIndex[parentType][parentID][protocol][newService.Name]
+		nbi.OrphanManager.RemoveItem(oldService)
+		diffMap, err := utils.JSONDiffMapExceptID(newService, oldService, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"Service ",
+				newService.Name,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedService, err := service.Patch[objects.Service](
+				ctx,
+				nbi.NetboxAPI,
+				oldService.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.servicesIndex[parentType][parentID][protocol][newService.Name] = patchedService
+		} else {
+			nbi.Logger.Debug(ctx, "Service ", newService.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "Service ", newService.Name, " does not exist in Netbox. Creating it...")
+		newService, err := service.Create(ctx, nbi.NetboxAPI, newService)
+		if err != nil {
+			return nil, err
+		}
+		nbi.servicesIndex[parentType][parentID][protocol][newService.Name] = newService
+	}
+	return nbi.servicesIndex[parentType][parentID][protocol][newService.Name], nil
+}
+
+// AddRIR adds a RIR to the local netbox inventory.
+func (nbi *NetboxInventory) AddRIR(ctx context.Context, newRIR *objects.RIR) (*objects.RIR, error) {
+	newRIR.NetboxObject.AddTag(nbi.SsotTag)
+	nbi.rirsLock.Lock()
+	defer nbi.rirsLock.Unlock()
+	if _, ok := nbi.rirsIndexByName[newRIR.Name]; ok {
+		oldRIR := nbi.rirsIndexByName[newRIR.Name]
+		diffMap, err := utils.JSONDiffMapExceptID(newRIR, oldRIR, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "RIR %s already exists in Netbox but is out of date. Patching it...", newRIR.Name)
+			patchedRIR, err := service.Patch[objects.RIR](ctx, nbi.NetboxAPI, oldRIR.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.rirsIndexByName[newRIR.Name] = patchedRIR
+		} else {
+			nbi.Logger.Debugf(ctx, "RIR %s already exists in Netbox and is up to date...", newRIR.Name)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "RIR %s does not exist in Netbox. Creating it...", newRIR.Name)
+		createdRIR, err := service.Create(ctx, nbi.NetboxAPI, newRIR)
+		if err != nil {
+			return nil, err
+		}
+		nbi.rirsIndexByName[newRIR.Name] = createdRIR
+	}
+	return nbi.rirsIndexByName[newRIR.Name], nil
+}
+
+// AddASN adds an ASN to the local netbox inventory.
+// If the ASN already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddASN(ctx context.Context, newASN *objects.ASN) (*objects.ASN, error) {
+	newASN.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newASN.NetboxObject)
+	newASN.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.asnsLock.Lock()
+	defer nbi.asnsLock.Unlock()
+	if _, ok := nbi.asnsIndexByASN[newASN.ASN]; ok {
+		oldASN := nbi.asnsIndexByASN[newASN.ASN]
+		nbi.OrphanManager.RemoveItem(oldASN)
+		diffMap, err := utils.JSONDiffMapExceptID(newASN, oldASN, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "ASN %d already exists in Netbox but is out of date. Patching it...", newASN.ASN)
+			patchedASN, err := service.Patch[objects.ASN](ctx, nbi.NetboxAPI, oldASN.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.asnsIndexByASN[newASN.ASN] = patchedASN
+		} else {
+			nbi.Logger.Debugf(ctx, "ASN %d already exists in Netbox and is up to date...", newASN.ASN)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "ASN %d does not exist in Netbox. Creating it...", newASN.ASN)
+		createdASN, err := service.Create(ctx, nbi.NetboxAPI, newASN)
+		if err != nil {
+			return nil, err
+		}
+		nbi.asnsIndexByASN[newASN.ASN] = createdASN
+	}
+	return nbi.asnsIndexByASN[newASN.ASN], nil
+}
+
+// AddAggregate adds an aggregate to the local netbox inventory.
+// If the aggregate already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddAggregate(
+	ctx context.Context,
+	newAggregate *objects.Aggregate,
+) (*objects.Aggregate, error) {
+	newAggregate.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newAggregate.NetboxObject)
+	newAggregate.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.aggregatesLock.Lock()
+	defer nbi.aggregatesLock.Unlock()
+	if _, ok := nbi.aggregatesIndexByPrefix[newAggregate.Prefix]; ok {
+		oldAggregate := nbi.aggregatesIndexByPrefix[newAggregate.Prefix]
+		nbi.OrphanManager.RemoveItem(oldAggregate)
+		diffMap, err := utils.JSONDiffMapExceptID(newAggregate, oldAggregate, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(
+				ctx,
+				"Aggregate %s already exists in Netbox but is out of date. Patching it...",
+				newAggregate.Prefix,
+			)
+			patchedAggregate, err := service.Patch[objects.Aggregate](
+				ctx,
+				nbi.NetboxAPI,
+				oldAggregate.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.aggregatesIndexByPrefix[newAggregate.Prefix] = patchedAggregate
+		} else {
+			nbi.Logger.Debugf(ctx, "Aggregate %s already exists in Netbox and is up to date...", newAggregate.Prefix)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "Aggregate %s does not exist in Netbox. Creating it...", newAggregate.Prefix)
+		createdAggregate, err := service.Create(ctx, nbi.NetboxAPI, newAggregate)
+		if err != nil {
+			return nil, err
+		}
+		nbi.aggregatesIndexByPrefix[newAggregate.Prefix] = createdAggregate
+	}
+	return nbi.aggregatesIndexByPrefix[newAggregate.Prefix], nil
+}
+
+// AddL2VPN adds a L2VPN to the local netbox inventory.
+// If the L2VPN already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddL2VPN(ctx context.Context, newL2VPN *objects.L2VPN) (*objects.L2VPN, error) {
+	newL2VPN.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newL2VPN.NetboxObject)
+	newL2VPN.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.l2vpnsLock.Lock()
+	defer nbi.l2vpnsLock.Unlock()
+	if _, ok := nbi.l2vpnsIndexByName[newL2VPN.Name]; ok {
+		oldL2VPN := nbi.l2vpnsIndexByName[newL2VPN.Name]
+		nbi.OrphanManager.RemoveItem(oldL2VPN)
+		diffMap, err := utils.JSONDiffMapExceptID(newL2VPN, oldL2VPN, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "L2VPN %s already exists in Netbox but is out of date. Patching it...", newL2VPN.Name)
+			patchedL2VPN, err := service.Patch[objects.L2VPN](ctx, nbi.NetboxAPI, oldL2VPN.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.l2vpnsIndexByName[newL2VPN.Name] = patchedL2VPN
+		} else {
+			nbi.Logger.Debugf(ctx, "L2VPN %s already exists in Netbox and is up to date...", newL2VPN.Name)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "L2VPN %s does not exist in Netbox. Creating it...", newL2VPN.Name)
+		createdL2VPN, err := service.Create(ctx, nbi.NetboxAPI, newL2VPN)
+		if err != nil {
+			return nil, err
+		}
+		nbi.l2vpnsIndexByName[newL2VPN.Name] = createdL2VPN
+	}
+	return nbi.l2vpnsIndexByName[newL2VPN.Name], nil
+}
+
+// AddL2VPNTermination adds a L2VPN termination to the local netbox inventory.
+// Terminations are identified by the object they are assigned to, because each object
+// can be terminated to only one L2VPN.
+func (nbi *NetboxInventory) AddL2VPNTermination(
+	ctx context.Context,
+	newTermination *objects.L2VPNTermination,
+) (*objects.L2VPNTermination, error) {
+	if newTermination.L2VPN == nil {
+		return nil, fmt.Errorf("l2vpn termination %s has no l2vpn", newTermination)
+	}
+	newTermination.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newTermination.NetboxObject)
+	newTermination.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+
+	objectType := newTermination.AssignedObjectType
+	objectID := newTermination.AssignedObjectID
+	nbi.l2vpnTerminationsLock.Lock()
+	defer nbi.l2vpnTerminationsLock.Unlock()
+	if nbi.l2vpnTerminationsIndex[objectType] == nil {
+		nbi.l2vpnTerminationsIndex[objectType] = make(map[int]*objects.L2VPNTermination)
+	}
+	if _, ok := nbi.l2vpnTerminationsIndex[objectType][objectID]; ok {
+		oldTermination := nbi.l2vpnTerminationsIndex[objectType][objectID]
+		nbi.OrphanManager.RemoveItem(oldTermination)
+		diffMap, err := utils.JSONDiffMapExceptID(newTermination, oldTermination, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(
+				ctx,
+				"L2VPN termination %s already exists in Netbox but is out of date. Patching it...",
+				newTermination,
+			)
+			patchedTermination, err := service.Patch[objects.L2VPNTermination](
+				ctx,
+				nbi.NetboxAPI,
+				oldTermination.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.l2vpnTerminationsIndex[objectType][objectID] = patchedTermination
+		} else {
+			nbi.Logger.Debugf(ctx, "L2VPN termination %s already exists in Netbox and is up to date...", newTermination)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "L2VPN termination %s does not exist in Netbox. Creating it...", newTermination)
+		createdTermination, err := service.Create(ctx, nbi.NetboxAPI, newTermination)
+		if err != nil {
+			return nil, err
+		}
+		nbi.l2vpnTerminationsIndex[objectType][objectID] = createdTermination
+	}
+	return nbi.l2vpnTerminationsIndex[objectType][objectID], nil
+}
+
+// AddTunnelGroup adds a tunnel group to the local netbox inventory.
+// If the tunnel group already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddTunnelGroup(
+	ctx context.Context,
+	newTunnelGroup *objects.TunnelGroup,
+) (*objects.TunnelGroup, error) {
+	newTunnelGroup.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newTunnelGroup.NetboxObject)
+	newTunnelGroup.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.tunnelGroupsLock.Lock()
+	defer nbi.tunnelGroupsLock.Unlock()
+	if _, ok := nbi.tunnelGroupsIndexByName[newTunnelGroup.Name]; ok {
+		oldTunnelGroup := nbi.tunnelGroupsIndexByName[newTunnelGroup.Name]
+		nbi.OrphanManager.RemoveItem(oldTunnelGroup)
+		diffMap, err := utils.JSONDiffMapExceptID(newTunnelGroup, oldTunnelGroup, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(
+				ctx,
+				"Tunnel group %s already exists in Netbox but is out of date. Patching it...",
+				newTunnelGroup.Name,
+			)
+			patchedTunnelGroup, err := service.Patch[objects.TunnelGroup](
+				ctx,
+				nbi.NetboxAPI,
+				oldTunnelGroup.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.tunnelGroupsIndexByName[newTunnelGroup.Name] = patchedTunnelGroup
+		} else {
+			nbi.Logger.Debugf(ctx, "Tunnel group %s already exists in Netbox and is up to date...", newTunnelGroup.Name)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "Tunnel group %s does not exist in Netbox. Creating it...", newTunnelGroup.Name)
+		createdTunnelGroup, err := service.Create(ctx, nbi.NetboxAPI, newTunnelGroup)
+		if err != nil {
+			return nil, err
+		}
+		nbi.tunnelGroupsIndexByName[newTunnelGroup.Name] = createdTunnelGroup
+	}
+	return nbi.tunnelGroupsIndexByName[newTunnelGroup.Name], nil
+}
+
+// AddTunnel adds a tunnel to the local netbox inventory.
+// If the tunnel already exists in Netbox, it checks if it is up to date and patches it if necessary.
+func (nbi *NetboxInventory) AddTunnel(ctx context.Context, newTunnel *objects.Tunnel) (*objects.Tunnel, error) {
+	newTunnel.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newTunnel.NetboxObject)
+	newTunnel.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.tunnelsLock.Lock()
+	defer nbi.tunnelsLock.Unlock()
+	if _, ok := nbi.tunnelsIndexByName[newTunnel.Name]; ok {
+		oldTunnel := nbi.tunnelsIndexByName[newTunnel.Name]
+		nbi.OrphanManager.RemoveItem(oldTunnel)
+		diffMap, err := utils.JSONDiffMapExceptID(newTunnel, oldTunnel, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "Tunnel %s already exists in Netbox but is out of date. Patching it...", newTunnel.Name)
+			patchedTunnel, err := service.Patch[objects.Tunnel](ctx, nbi.NetboxAPI, oldTunnel.ID, diffMap)
+			if err != nil {
+				return nil, err
+			}
+			nbi.tunnelsIndexByName[newTunnel.Name] = patchedTunnel
+		} else {
+			nbi.Logger.Debugf(ctx, "Tunnel %s already exists in Netbox and is up to date...", newTunnel.Name)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "Tunnel %s does not exist in Netbox. Creating it...", newTunnel.Name)
+		createdTunnel, err := service.Create(ctx, nbi.NetboxAPI, newTunnel)
+		if err != nil {
+			return nil, err
+		}
+		nbi.tunnelsIndexByName[newTunnel.Name] = createdTunnel
+	}
+	return nbi.tunnelsIndexByName[newTunnel.Name], nil
+}
+
+// AddTunnelTermination adds a tunnel termination to the local netbox inventory.
+// Terminations are identified by the interface they are assigned to, because each interface
+// can be terminated to only one tunnel.
+func (nbi *NetboxInventory) AddTunnelTermination(
+	ctx context.Context,
+	newTermination *objects.TunnelTermination,
+) (*objects.TunnelTermination, error) {
+	if newTermination.Tunnel == nil {
+		return nil, fmt.Errorf("tunnel termination %s has no tunnel", newTermination)
+	}
+	newTermination.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newTermination.NetboxObject)
+	newTermination.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+
+	terminationType := newTermination.TerminationType
+	terminationID := newTermination.TerminationID
+	nbi.tunnelTerminationsLock.Lock()
+	defer nbi.tunnelTerminationsLock.Unlock()
+	if nbi.tunnelTerminationsIndex[terminationType] == nil {
+		nbi.tunnelTerminationsIndex[terminationType] = make(map[int]*objects.TunnelTermination)
+	}
+	if _, ok := nbi.tunnelTerminationsIndex[terminationType][terminationID]; ok {
+		oldTermination := nbi.tunnelTerminationsIndex[terminationType][terminationID]
+		nbi.OrphanManager.RemoveItem(oldTermination)
+		diffMap, err := utils.JSONDiffMapExceptID(newTermination, oldTermination, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(
+				ctx,
+				"Tunnel termination %s already exists in Netbox but is out of date. Patching it...",
+				newTermination,
+			)
+			patchedTermination, err := service.Patch[objects.TunnelTermination](
+				ctx,
+				nbi.NetboxAPI,
+				oldTermination.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.tunnelTerminationsIndex[terminationType][terminationID] = patchedTermination
+		} else {
+			nbi.Logger.Debugf(ctx, "Tunnel termination %s already exists in Netbox and is up to date...", newTermination)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "Tunnel termination %s does not exist in Netbox. Creating it...", newTermination)
+		createdTermination, err := service.Create(ctx, nbi.NetboxAPI, newTermination)
+		if err != nil {
+			return nil, err
+		}
+		nbi.tunnelTerminationsIndex[terminationType][terminationID] = createdTermination
+	}
+	return nbi.tunnelTerminationsIndex[terminationType][terminationID], nil
+}
+
+// AddJournalEntry adds a journal entry to the local netbox inventory.
+// Journal entries are identified by the object they are assigned to and their comments,
+// so a change of comments results in a new journal entry, while the old one is orphaned.
+func (nbi *NetboxInventory) AddJournalEntry(
+	ctx context.Context,
+	newJournalEntry *objects.JournalEntry,
+) (*objects.JournalEntry, error) {
+	newJournalEntry.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newJournalEntry.NetboxObject)
+	newJournalEntry.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+
+	objectType, objectID := newJournalEntry.AssignedObjectType, newJournalEntry.AssignedObjectID
+	nbi.verifyJournalEntryIndexExists(objectType, objectID)
+
+	nbi.journalEntriesLock.Lock()
+	defer nbi.journalEntriesLock.Unlock()
+	if _, ok := nbi.journalEntriesIndex[objectType][objectID][newJournalEntry.Comments]; ok {
+		oldJournalEntry := nbi.journalEntriesIndex[objectType][objectID][newJournalEntry.Comments]
+		nbi.OrphanManager.RemoveItem(oldJournalEntry)
+		diffMap, err := utils.JSONDiffMapExceptID(
+			newJournalEntry,
+			oldJournalEntry,
+			false,
+			nbi.SourcePriority,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(
+				ctx,
+				"%s already exists in Netbox but is out of date. Patching it...",
+				newJournalEntry,
+			)
+			patchedJournalEntry, err := service.Patch[objects.JournalEntry](
+				ctx,
+				nbi.NetboxAPI,
+				oldJournalEntry.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.journalEntriesIndex[objectType][objectID][newJournalEntry.Comments] = patchedJournalEntry
+		} else {
+			nbi.Logger.Debugf(ctx, "%s already exists in Netbox and is up to date...", newJournalEntry)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "%s does not exist in Netbox. Creating it...", newJournalEntry)
+		createdJournalEntry, err := service.Create(ctx, nbi.NetboxAPI, newJournalEntry)
+		if err != nil {
+			return nil, err
+		}
+		nbi.journalEntriesIndex[objectType][objectID][newJournalEntry.Comments] = createdJournalEntry
+	}
+	return nbi.journalEntriesIndex[objectType][objectID][newJournalEntry.Comments], nil
+}
+
+// AddFHRPGroup adds a new FHRP group to the Netbox inventory.
+// It takes a context and a newFHRPGroup object as input and
+// returns the created or updated FHRP group object and an error, if any.
+// If the FHRP group already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the FHRP group does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddFHRPGroup(
+	ctx context.Context,
+	newFHRPGroup *objects.FHRPGroup,
+) (*objects.FHRPGroup, error) {
+	newFHRPGroup.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newFHRPGroup.NetboxObject)
+	newFHRPGroup.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.fhrpGroupsLock.Lock()
+	defer nbi.fhrpGroupsLock.Unlock()
+	if _, ok := nbi.fhrpGroupsIndexByName[newFHRPGroup.Name]; ok {
+		oldFHRPGroup := nbi.fhrpGroupsIndexByName[newFHRPGroup.Name]
+		nbi.OrphanManager.RemoveItem(oldFHRPGroup)
+		diffMap, err := utils.JSONDiffMapExceptID(newFHRPGroup, oldFHRPGroup, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"FHRP group ",
+				newFHRPGroup.Name,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedFHRPGroup, err := service.Patch[objects.FHRPGroup](
+				ctx,
+				nbi.NetboxAPI,
+				oldFHRPGroup.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.fhrpGroupsIndexByName[newFHRPGroup.Name] = patchedFHRPGroup
+			nbi.fhrpGroupsIndexByID[patchedFHRPGroup.ID] = patchedFHRPGroup
+		} else {
+			nbi.Logger.Debug(ctx, "FHRP group ", newFHRPGroup.Name, " already exists in Netbox and is up to date...")
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "FHRP group ", newFHRPGroup.Name, " does not exist in Netbox. Creating it...")
+		newFHRPGroup, err := service.Create(ctx, nbi.NetboxAPI, newFHRPGroup)
+		if err != nil {
+			return nil, err
+		}
+		nbi.fhrpGroupsIndexByName[newFHRPGroup.Name] = newFHRPGroup
+		nbi.fhrpGroupsIndexByID[newFHRPGroup.ID] = newFHRPGroup
+	}
+	return nbi.fhrpGroupsIndexByName[newFHRPGroup.Name], nil
+}
+
+// AddFHRPGroupAssignment adds a new FHRP group assignment to the Netbox inventory.
+// It takes a context and a newAssignment object as input and
+// returns the created or updated FHRP group assignment object and an error, if any.
+// If the assignment already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the assignment does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddFHRPGroupAssignment(
+	ctx context.Context,
+	newAssignment *objects.FHRPGroupAssignment,
+) (*objects.FHRPGroupAssignment, error) {
+	if newAssignment.Group == nil {
+		return nil, fmt.Errorf("fhrp group assignment %s has no group", newAssignment)
+	}
+	newAssignment.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newAssignment.NetboxObject)
+	newAssignment.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+
+	groupID := newAssignment.Group.ID
+	ifaceType := newAssignment.InterfaceType
+	ifaceID := newAssignment.InterfaceID
+	nbi.verifyFHRPGroupAssignmentIndexExists(groupID, ifaceType)
+
+	nbi.fhrpGroupAssignmentsLock.Lock()
+	defer nbi.fhrpGroupAssignmentsLock.Unlock()
+	if _, ok := nbi.fhrpGroupAssignmentsIndex[groupID][ifaceType][ifaceID]; ok {
+		oldAssignment := nbi.fhrpGroupAssignmentsIndex[groupID][ifaceType][ifaceID]
+		nbi.OrphanManager.RemoveItem(oldAssignment)
+		diffMap, err := utils.JSONDiffMapExceptID(newAssignment, oldAssignment, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"FHRP group assignment ",
+				newAssignment,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedAssignment, err := service.Patch[objects.FHRPGroupAssignment](
+				ctx,
+				nbi.NetboxAPI,
+				oldAssignment.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.fhrpGroupAssignmentsIndex[groupID][ifaceType][ifaceID] = patchedAssignment
+		} else {
+			nbi.Logger.Debug(
+				ctx,
+				"FHRP group assignment ",
+				newAssignment,
+				" already exists in Netbox and is up to date...",
+			)
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "FHRP group assignment ", newAssignment, " does not exist in Netbox. Creating it...")
+		newAssignment, err := service.Create(ctx, nbi.NetboxAPI, newAssignment)
+		if err != nil {
+			return nil, err
+		}
+		nbi.fhrpGroupAssignmentsIndex[groupID][ifaceType][ifaceID] = newAssignment
+	}
+	return nbi.fhrpGroupAssignmentsIndex[groupID][ifaceType][ifaceID], nil
+}
+
+// AddPrefix adds a new prefix to the Netbox inventory.
 // It takes a context and a newPrefix object as input and
 // returns the created or updated prefix object and an error, if any.
 // If the prefix already exists in Netbox, it checks if it is up to date and patches it if necessary.
@@ -1428,8 +2683,12 @@ func (nbi *NetboxInventory) AddPrefix(
 	//nolint:forcetypeassert
 	newPrefix.NetboxObject.CustomFields[constants.CustomFieldSourceName] = ctx.Value(constants.CtxSourceKey).(string)
 	defer nbi.prefixesLock.Unlock()
-	if _, ok := nbi.prefixesIndexByPrefix[newPrefix.Prefix]; ok {
-		oldPrefix := nbi.prefixesIndexByPrefix[newPrefix.Prefix]
+	vrfID := getVRFIndexValue(newPrefix.VRF)
+	if nbi.prefixesIndexByVRFIDAndPrefix[vrfID] == nil {
+		nbi.prefixesIndexByVRFIDAndPrefix[vrfID] = make(map[string]*objects.Prefix)
+	}
+	if _, ok := nbi.prefixesIndexByVRFIDAndPrefix[vrfID][newPrefix.Prefix]; ok {
+		oldPrefix := nbi.prefixesIndexByVRFIDAndPrefix[vrfID][newPrefix.Prefix]
 		nbi.OrphanManager.RemoveItem(oldPrefix)
 		diffMap, err := utils.JSONDiffMapExceptID(newPrefix, oldPrefix, false, nbi.SourcePriority)
 		if err != nil {
@@ -1451,7 +2710,7 @@ func (nbi *NetboxInventory) AddPrefix(
 			if err != nil {
 				return nil, err
 			}
-			nbi.prefixesIndexByPrefix[newPrefix.Prefix] = patchedPrefix
+			nbi.prefixesIndexByVRFIDAndPrefix[vrfID][newPrefix.Prefix] = patchedPrefix
 		} else {
 			nbi.Logger.Debug(ctx, "IP address ", newPrefix.Prefix, " already exists in Netbox and is up to date...")
 		}
@@ -1461,10 +2720,72 @@ func (nbi *NetboxInventory) AddPrefix(
 		if err != nil {
 			return nil, err
 		}
-		nbi.prefixesIndexByPrefix[newPrefix.Prefix] = newPrefix
+		nbi.prefixesIndexByVRFIDAndPrefix[vrfID][newPrefix.Prefix] = newPrefix
 		return newPrefix, nil
 	}
-	return nbi.prefixesIndexByPrefix[newPrefix.Prefix], nil
+	return nbi.prefixesIndexByVRFIDAndPrefix[vrfID][newPrefix.Prefix], nil
+}
+
+// AddIPRange adds a new IP range to the Netbox inventory.
+// It takes a context and a newIPRange object as input and
+// returns the created or updated IP range object and an error, if any.
+// If the IP range already exists in Netbox, it checks if it is up to date and patches it if necessary.
+// If the IP range does not exist, it creates a new one.
+func (nbi *NetboxInventory) AddIPRange(
+	ctx context.Context,
+	newIPRange *objects.IPRange,
+) (*objects.IPRange, error) {
+	newIPRange.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newIPRange.NetboxObject)
+	newIPRange.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	nbi.ipRangesLock.Lock()
+	defer nbi.ipRangesLock.Unlock()
+	vrfID := getVRFIndexValue(newIPRange.VRF)
+	if nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID] == nil {
+		nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID] = make(map[string]*objects.IPRange)
+	}
+	if _, ok := nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID][newIPRange.StartAddress]; ok {
+		oldIPRange := nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID][newIPRange.StartAddress]
+		nbi.OrphanManager.RemoveItem(oldIPRange)
+		diffMap, err := utils.JSONDiffMapExceptID(newIPRange, oldIPRange, false, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debug(
+				ctx,
+				"IP range ",
+				newIPRange.StartAddress,
+				" already exists in Netbox but is out of date. Patching it...",
+			)
+			patchedIPRange, err := service.Patch[objects.IPRange](
+				ctx,
+				nbi.NetboxAPI,
+				oldIPRange.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID][newIPRange.StartAddress] = patchedIPRange
+		} else {
+			nbi.Logger.Debug(
+				ctx,
+				"IP range ",
+				newIPRange.StartAddress,
+				" already exists in Netbox and is up to date...",
+			)
+		}
+	} else {
+		nbi.Logger.Debug(ctx, "IP range ", newIPRange.StartAddress, " does not exist in Netbox. Creating it...")
+		newIPRange, err := service.Create(ctx, nbi.NetboxAPI, newIPRange)
+		if err != nil {
+			return nil, err
+		}
+		nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID][newIPRange.StartAddress] = newIPRange
+		return newIPRange, nil
+	}
+	return nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID][newIPRange.StartAddress], nil
 }
 
 // AddWirelessLAN adds a new wireless LAN to the Netbox inventory.
@@ -1586,6 +2907,69 @@ func (nbi *NetboxInventory) AddWirelessLANGroup(
 	return nbi.wirelessLANGroupsIndexByName[newWirelessLANGroup.Name], nil
 }
 
+// AddRawObject adds a new raw object to the Netbox inventory.
+// Path of the object must be one of the configured raw object types, and the object
+// is identified by the natural key of its type. If the object already exists in Netbox,
+// it is patched with attributes that differ, otherwise a new one is created.
+// Fields of the object should be in their json form (see objects.NewRawObject).
+func (nbi *NetboxInventory) AddRawObject(
+	ctx context.Context,
+	newRawObject *objects.RawObject,
+) (*objects.RawObject, error) {
+	rawObjectType, ok := nbi.rawObjectTypes[newRawObject.Path]
+	if !ok {
+		return nil, fmt.Errorf("%s: path %s is not one of configured raw object types", newRawObject, newRawObject.Path)
+	}
+	newRawObject.ObjectType = constants.ContentType(rawObjectType.ObjectType)
+	newRawObject.NetboxObject.AddTag(nbi.SsotTag)
+	addSourceNameCustomField(ctx, &newRawObject.NetboxObject)
+	newRawObject.SetCustomField(constants.CustomFieldOrphanLastSeenName, nil)
+	key := utils.RawObjectKey(newRawObject.Fields, rawObjectType.NaturalKey)
+	path := newRawObject.Path
+	nbi.rawObjectsLock.Lock()
+	defer nbi.rawObjectsLock.Unlock()
+	if oldRawObject, ok := nbi.rawObjectsIndex[path][key]; ok {
+		nbi.OrphanManager.RemoveItem(oldRawObject)
+		diffMap, err := utils.RawObjectDiffMap(newRawObject, oldRawObject, nbi.SourcePriority)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffMap) > 0 {
+			nbi.Logger.Debugf(ctx, "%s already exists in Netbox but is out of date. Patching it...", newRawObject)
+			patchedRawObject, err := service.PatchOnPath[objects.RawObject](
+				ctx,
+				nbi.NetboxAPI,
+				path,
+				oldRawObject.ID,
+				diffMap,
+			)
+			if err != nil {
+				return nil, err
+			}
+			patchedRawObject.Path = path
+			patchedRawObject.ObjectType = newRawObject.ObjectType
+			nbi.rawObjectsIndex[path][key] = patchedRawObject
+		} else {
+			nbi.Logger.Debugf(ctx, "%s already exists in Netbox and is up to date...", newRawObject)
+		}
+	} else {
+		nbi.Logger.Debugf(ctx, "%s does not exist in Netbox. Creating it...", newRawObject)
+		createdRawObject, err := service.CreateOnPath[objects.RawObject](
+			ctx,
+			nbi.NetboxAPI,
+			path,
+			utils.RawObjectToNetboxJSONMap(newRawObject),
+		)
+		if err != nil {
+			return nil, err
+		}
+		createdRawObject.Path = path
+		createdRawObject.ObjectType = newRawObject.ObjectType
+		nbi.rawObjectsIndex[path][key] = createdRawObject
+	}
+	return nbi.rawObjectsIndex[path][key], nil
+}
+
 // Helper function that adds source name to custom field of the netbox object.
 func addSourceNameCustomField(ctx context.Context, netboxObject *objects.NetboxObject) {
 	if netboxObject.CustomFields == nil {
@@ -1625,3 +3009,107 @@ func (nbi *NetboxInventory) applyDeviceFieldLengthLimitations(device *objects.De
 		device.AssetTag = device.AssetTag[:constants.MaxAssetTagLength]
 	}
 }
+
+// applyInventoryItemFieldLengthLimitations applies field length limitations
+// to the inventory item object.
+func (nbi *NetboxInventory) applyInventoryItemFieldLengthLimitations(item *objects.InventoryItem) {
+	if len(item.Name) > constants.MaxInventoryItemNameLength {
+		nbi.Logger.Warningf(
+			nbi.Ctx,
+			"Inventory item name %s is too long, truncating to %d characters",
+			item.Name,
+			constants.MaxInventoryItemNameLength,
+		)
+		item.Name = item.Name[:constants.MaxInventoryItemNameLength]
+	}
+	if len(item.SerialNumber) > constants.MaxSerialNumberLength {
+		nbi.Logger.Warningf(
+			nbi.Ctx,
+			"Inventory item serial %s is too long, truncating to %d characters",
+			item.SerialNumber,
+			constants.MaxSerialNumberLength,
+		)
+		item.SerialNumber = item.SerialNumber[:constants.MaxSerialNumberLength]
+	}
+}
+
+// AddParentPrefixes adds missing container prefixes above the ip addresses and prefixes
+// synced by netbox-ssot in this run, for each of the configured parent prefix lengths.
+// Orphaned ip addresses and prefixes are ignored, so they don't keep their containers alive.
+//
+// Existing prefixes, that were not created as containers by netbox-ssot, are left untouched.
+func (nbi *NetboxInventory) AddParentPrefixes(ctx context.Context) error {
+	// Collect addresses of all synced ip addresses and prefixes by vrf id
+	addresses := make(map[int][]string)
+	vrfs := make(map[int]*objects.VRF)
+	nbi.ipAddressesLock.Lock()
+	for vrfID, ifaceTypeIndex := range nbi.ipAddressesIndex {
+		for _, ifaceNameIndex := range ifaceTypeIndex {
+			for _, ifaceParentIndex := range ifaceNameIndex {
+				for _, ipIndex := range ifaceParentIndex {
+					for _, ipAddress := range ipIndex {
+						if ipAddress.HasTagByName(constants.SsotTagName) && !nbi.OrphanManager.IsOrphan(ipAddress) {
+							addresses[vrfID] = append(addresses[vrfID], ipAddress.Address)
+							vrfs[vrfID] = ipAddress.VRF
+						}
+					}
+				}
+			}
+		}
+	}
+	nbi.ipAddressesLock.Unlock()
+
+	existingPrefixes := make(map[int]map[string]*objects.Prefix)
+	nbi.prefixesLock.Lock()
+	for vrfID, prefixIndex := range nbi.prefixesIndexByVRFIDAndPrefix {
+		existingPrefixes[vrfID] = make(map[string]*objects.Prefix, len(prefixIndex))
+		for prefixAddress, prefix := range prefixIndex {
+			existingPrefixes[vrfID][prefixAddress] = prefix
+			isContainer := prefix.Status != nil && *prefix.Status == objects.PrefixStatusContainer
+			if prefix.HasTagByName(constants.SsotTagName) && !isContainer && !nbi.OrphanManager.IsOrphan(prefix) {
+				addresses[vrfID] = append(addresses[vrfID], prefix.Prefix)
+				vrfs[vrfID] = prefix.VRF
+			}
+		}
+	}
+	nbi.prefixesLock.Unlock()
+
+	for vrfID, vrfAddresses := range addresses {
+		parentPrefixes := make(map[string]bool)
+		for _, address := range vrfAddresses {
+			prefixLengths := nbi.NetboxConfig.ParentPrefixLengthsIPv4
+			if utils.GetIPVersion(address) == constants.IPv6 {
+				prefixLengths = nbi.NetboxConfig.ParentPrefixLengthsIPv6
+			}
+			addressParents, err := utils.GetParentPrefixes(address, prefixLengths)
+			if err != nil {
+				nbi.Logger.Debugf(ctx, "get parent prefixes of %s: %s", address, err)
+				continue
+			}
+			for _, parentPrefix := range addressParents {
+				parentPrefixes[parentPrefix] = true
+			}
+		}
+		for parentPrefix := range parentPrefixes {
+			if existingPrefix, ok := existingPrefixes[vrfID][parentPrefix]; ok {
+				if !existingPrefix.HasTagByName(constants.SsotTagName) ||
+					existingPrefix.Status == nil ||
+					*existingPrefix.Status != objects.PrefixStatusContainer {
+					continue
+				}
+			}
+			_, err := nbi.AddPrefix(ctx, &objects.Prefix{
+				NetboxObject: objects.NetboxObject{
+					Description: "Container prefix derived from synced ip addresses",
+				},
+				Prefix: parentPrefix,
+				Status: &objects.PrefixStatusContainer,
+				VRF:    vrfs[vrfID],
+			})
+			if err != nil {
+				return fmt.Errorf("add parent prefix %s: %s", parentPrefix, err)
+			}
+		}
+	}
+	return nil
+}
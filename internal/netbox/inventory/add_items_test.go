@@ -939,3 +939,99 @@ func TestNetboxInventory_applyDeviceFieldLengthLimitations(t *testing.T) {
 		})
 	}
 }
+
+func TestNetboxInventory_AddModuleTypeIndexedByManufacturer(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.CtxSourceKey, "test")
+	ssotTag := &objects.Tag{ID: 1, Name: constants.SsotTagName}
+	newExistingModuleType := func(id int, manufacturer *objects.Manufacturer) *objects.ModuleType {
+		return &objects.ModuleType{
+			NetboxObject: objects.NetboxObject{
+				ID:   id,
+				Tags: []*objects.Tag{ssotTag},
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceName:         "test",
+					constants.CustomFieldOrphanLastSeenName: nil,
+				},
+			},
+			Manufacturer: manufacturer,
+			Model:        "PWR-C1-350WAC",
+		}
+	}
+	cisco := &objects.Manufacturer{NetboxObject: objects.NetboxObject{ID: 1}, Name: "Cisco"}
+	other := &objects.Manufacturer{NetboxObject: objects.NetboxObject{ID: 2}, Name: "Other"}
+	nbi := &NetboxInventory{
+		Logger:        MockInventory.Logger,
+		SsotTag:       ssotTag,
+		OrphanManager: &OrphanManager{Items: map[constants.APIPath]map[int]objects.OrphanItem{}},
+		moduleTypesIndexByManufacturerIDAndModel: map[int]map[string]*objects.ModuleType{
+			cisco.ID: {"PWR-C1-350WAC": newExistingModuleType(10, cisco)},
+			other.ID: {"PWR-C1-350WAC": newExistingModuleType(20, other)},
+		},
+	}
+
+	got, err := nbi.AddModuleType(ctx, &objects.ModuleType{Manufacturer: other, Model: "PWR-C1-350WAC"})
+	if err != nil {
+		t.Fatalf("NetboxInventory.AddModuleType() error = %v", err)
+	}
+	if got.ID != 20 {
+		t.Errorf("NetboxInventory.AddModuleType() = %v, want module type of manufacturer %s", got, other.Name)
+	}
+
+	_, err = nbi.AddModuleType(ctx, &objects.ModuleType{Model: "PWR-C1-350WAC"})
+	if err == nil {
+		t.Errorf("NetboxInventory.AddModuleType() error = nil, want error for module type without manufacturer")
+	}
+}
+
+func TestNetboxInventory_AddServiceIndexedByProtocol(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.CtxSourceKey, "test")
+	ssotTag := &objects.Tag{ID: 1, Name: constants.SsotTagName}
+	device := &objects.Device{NetboxObject: objects.NetboxObject{ID: 1}, Name: "dns1"}
+	newExistingService := func(id int, protocol *objects.ServiceProtocol) *objects.Service {
+		return &objects.Service{
+			NetboxObject: objects.NetboxObject{
+				ID:   id,
+				Tags: []*objects.Tag{ssotTag},
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceName:         "test",
+					constants.CustomFieldOrphanLastSeenName: nil,
+				},
+			},
+			Device:   device,
+			Name:     "dns",
+			Protocol: protocol,
+			Ports:    []int{53},
+		}
+	}
+	nbi := &NetboxInventory{
+		Logger:        MockInventory.Logger,
+		SsotTag:       ssotTag,
+		OrphanManager: &OrphanManager{Items: map[constants.APIPath]map[int]objects.OrphanItem{}},
+		servicesIndex: map[constants.ContentType]map[int]map[string]map[string]*objects.Service{
+			constants.ContentTypeDcimDevice: {
+				device.ID: {
+					objects.ServiceProtocolTCP.Value: {"dns": newExistingService(10, &objects.ServiceProtocolTCP)},
+					objects.ServiceProtocolUDP.Value: {"dns": newExistingService(20, &objects.ServiceProtocolUDP)},
+				},
+			},
+		},
+	}
+
+	got, err := nbi.AddService(ctx, &objects.Service{
+		Device:   device,
+		Name:     "dns",
+		Protocol: &objects.ServiceProtocolUDP,
+		Ports:    []int{53},
+	})
+	if err != nil {
+		t.Fatalf("NetboxInventory.AddService() error = %v", err)
+	}
+	if got.ID != 20 {
+		t.Errorf("NetboxInventory.AddService() = %v, want udp service", got)
+	}
+
+	_, err = nbi.AddService(ctx, &objects.Service{Device: device, Name: "dns", Ports: []int{53}})
+	if err == nil {
+		t.Errorf("NetboxInventory.AddService() error = nil, want error for service without protocol")
+	}
+}
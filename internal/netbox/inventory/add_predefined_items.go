@@ -5,19 +5,20 @@ import (
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
-	"github.com/bl4ko/netbox-ssot/internal/utils"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
 )
 
 func (nbi *NetboxInventory) AddContainerDeviceRole(
 	ctx context.Context,
+	roles parser.DefaultRoles,
 ) (*objects.DeviceRole, error) {
 	newRole, err := nbi.AddDeviceRole(ctx, &objects.DeviceRole{
 		NetboxObject: objects.NetboxObject{
-			Description: constants.DeviceRoleContainerDescription,
+			Description: roles.Container.Description,
 		},
-		Name:   constants.DeviceRoleContainer,
-		Slug:   utils.Slugify(constants.DeviceRoleContainer),
-		Color:  constants.DeviceRoleContainerColor,
+		Name:   roles.Container.Name,
+		Slug:   roles.Container.Slug,
+		Color:  constants.Color(roles.Container.Color),
 		VMRole: true,
 	})
 
@@ -29,14 +30,15 @@ func (nbi *NetboxInventory) AddContainerDeviceRole(
 
 func (nbi *NetboxInventory) AddFirewallDeviceRole(
 	ctx context.Context,
+	roles parser.DefaultRoles,
 ) (*objects.DeviceRole, error) {
 	newRole, err := nbi.AddDeviceRole(ctx, &objects.DeviceRole{
 		NetboxObject: objects.NetboxObject{
-			Description: constants.DeviceRoleFirewallDescription,
+			Description: roles.Firewall.Description,
 		},
-		Name:   constants.DeviceRoleFirewall,
-		Slug:   utils.Slugify(constants.DeviceRoleFirewall),
-		Color:  constants.DeviceRoleFirewallColor,
+		Name:   roles.Firewall.Name,
+		Slug:   roles.Firewall.Slug,
+		Color:  constants.Color(roles.Firewall.Color),
 		VMRole: false,
 	})
 
@@ -46,14 +48,17 @@ func (nbi *NetboxInventory) AddFirewallDeviceRole(
 	return newRole, nil
 }
 
-func (nbi *NetboxInventory) AddSwitchDeviceRole(ctx context.Context) (*objects.DeviceRole, error) {
+func (nbi *NetboxInventory) AddSwitchDeviceRole(
+	ctx context.Context,
+	roles parser.DefaultRoles,
+) (*objects.DeviceRole, error) {
 	newRole, err := nbi.AddDeviceRole(ctx, &objects.DeviceRole{
 		NetboxObject: objects.NetboxObject{
-			Description: constants.DeviceRoleSwitchDescription,
+			Description: roles.Switch.Description,
 		},
-		Name:   constants.DeviceRoleSwitch,
-		Slug:   utils.Slugify(constants.DeviceRoleSwitch),
-		Color:  constants.DeviceRoleSwitchColor,
+		Name:   roles.Switch.Name,
+		Slug:   roles.Switch.Slug,
+		Color:  constants.Color(roles.Switch.Color),
 		VMRole: false,
 	})
 
@@ -63,14 +68,17 @@ func (nbi *NetboxInventory) AddSwitchDeviceRole(ctx context.Context) (*objects.D
 	return newRole, nil
 }
 
-func (nbi *NetboxInventory) AddServerDeviceRole(ctx context.Context) (*objects.DeviceRole, error) {
+func (nbi *NetboxInventory) AddServerDeviceRole(
+	ctx context.Context,
+	roles parser.DefaultRoles,
+) (*objects.DeviceRole, error) {
 	newRole, err := nbi.AddDeviceRole(ctx, &objects.DeviceRole{
 		NetboxObject: objects.NetboxObject{
-			Description: constants.DeviceRoleServerDescription,
+			Description: roles.Server.Description,
 		},
-		Name:   constants.DeviceRoleServer,
-		Slug:   utils.Slugify(constants.DeviceRoleServer),
-		Color:  constants.DeviceRoleServerColor,
+		Name:   roles.Server.Name,
+		Slug:   roles.Server.Slug,
+		Color:  constants.Color(roles.Server.Color),
 		VMRole: false,
 	})
 
@@ -80,14 +88,17 @@ func (nbi *NetboxInventory) AddServerDeviceRole(ctx context.Context) (*objects.D
 	return newRole, nil
 }
 
-func (nbi *NetboxInventory) AddVMDeviceRole(ctx context.Context) (*objects.DeviceRole, error) {
+func (nbi *NetboxInventory) AddVMDeviceRole(
+	ctx context.Context,
+	roles parser.DefaultRoles,
+) (*objects.DeviceRole, error) {
 	newRole, err := nbi.AddDeviceRole(ctx, &objects.DeviceRole{
 		NetboxObject: objects.NetboxObject{
-			Description: constants.DeviceRoleVMDescription,
+			Description: roles.VM.Description,
 		},
-		Name:   constants.DeviceRoleVM,
-		Slug:   utils.Slugify(constants.DeviceRoleVM),
-		Color:  constants.DeviceRoleVMColor,
+		Name:   roles.VM.Name,
+		Slug:   roles.VM.Slug,
+		Color:  constants.Color(roles.VM.Color),
 		VMRole: false,
 	})
 
@@ -99,14 +110,15 @@ func (nbi *NetboxInventory) AddVMDeviceRole(ctx context.Context) (*objects.Devic
 
 func (nbi *NetboxInventory) AddVMTemplateDeviceRole(
 	ctx context.Context,
+	roles parser.DefaultRoles,
 ) (*objects.DeviceRole, error) {
 	newRole, err := nbi.AddDeviceRole(ctx, &objects.DeviceRole{
 		NetboxObject: objects.NetboxObject{
-			Description: constants.DeviceRoleVMTemplateDescription,
+			Description: roles.VMTemplate.Description,
 		},
-		Name:   constants.DeviceRoleVMTemplate,
-		Slug:   utils.Slugify(constants.DeviceRoleVMTemplate),
-		Color:  constants.DeviceRoleVMTemplateColor,
+		Name:   roles.VMTemplate.Name,
+		Slug:   roles.VMTemplate.Slug,
+		Color:  constants.Color(roles.VMTemplate.Color),
 		VMRole: false,
 	})
 
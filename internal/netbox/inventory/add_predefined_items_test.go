@@ -6,11 +6,13 @@ import (
 	"testing"
 
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
 )
 
 func TestNetboxInventory_AddContainerDeviceRole(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		ctx   context.Context
+		roles parser.DefaultRoles
 	}
 	tests := []struct {
 		name    string
@@ -23,7 +25,7 @@ func TestNetboxInventory_AddContainerDeviceRole(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.nbi.AddContainerDeviceRole(tt.args.ctx)
+			got, err := tt.nbi.AddContainerDeviceRole(tt.args.ctx, tt.args.roles)
 			if (err != nil) != tt.wantErr {
 				t.Errorf(
 					"NetboxInventory.AddContainerDeviceRole() error = %v, wantErr %v",
@@ -41,7 +43,8 @@ func TestNetboxInventory_AddContainerDeviceRole(t *testing.T) {
 
 func TestNetboxInventory_AddFirewallDeviceRole(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		ctx   context.Context
+		roles parser.DefaultRoles
 	}
 	tests := []struct {
 		name    string
@@ -54,7 +57,7 @@ func TestNetboxInventory_AddFirewallDeviceRole(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.nbi.AddFirewallDeviceRole(tt.args.ctx)
+			got, err := tt.nbi.AddFirewallDeviceRole(tt.args.ctx, tt.args.roles)
 			if (err != nil) != tt.wantErr {
 				t.Errorf(
 					"NetboxInventory.AddFirewallDeviceRole() error = %v, wantErr %v",
@@ -72,7 +75,8 @@ func TestNetboxInventory_AddFirewallDeviceRole(t *testing.T) {
 
 func TestNetboxInventory_AddSwitchDeviceRole(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		ctx   context.Context
+		roles parser.DefaultRoles
 	}
 	tests := []struct {
 		name    string
@@ -85,7 +89,7 @@ func TestNetboxInventory_AddSwitchDeviceRole(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.nbi.AddSwitchDeviceRole(tt.args.ctx)
+			got, err := tt.nbi.AddSwitchDeviceRole(tt.args.ctx, tt.args.roles)
 			if (err != nil) != tt.wantErr {
 				t.Errorf(
 					"NetboxInventory.AddSwitchDeviceRole() error = %v, wantErr %v",
@@ -103,7 +107,8 @@ func TestNetboxInventory_AddSwitchDeviceRole(t *testing.T) {
 
 func TestNetboxInventory_AddServerDeviceRole(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		ctx   context.Context
+		roles parser.DefaultRoles
 	}
 	tests := []struct {
 		name    string
@@ -116,7 +121,7 @@ func TestNetboxInventory_AddServerDeviceRole(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.nbi.AddServerDeviceRole(tt.args.ctx)
+			got, err := tt.nbi.AddServerDeviceRole(tt.args.ctx, tt.args.roles)
 			if (err != nil) != tt.wantErr {
 				t.Errorf(
 					"NetboxInventory.AddServerDeviceRole() error = %v, wantErr %v",
@@ -134,7 +139,8 @@ func TestNetboxInventory_AddServerDeviceRole(t *testing.T) {
 
 func TestNetboxInventory_AddVMDeviceRole(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		ctx   context.Context
+		roles parser.DefaultRoles
 	}
 	tests := []struct {
 		name    string
@@ -147,7 +153,7 @@ func TestNetboxInventory_AddVMDeviceRole(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.nbi.AddVMDeviceRole(tt.args.ctx)
+			got, err := tt.nbi.AddVMDeviceRole(tt.args.ctx, tt.args.roles)
 			if (err != nil) != tt.wantErr {
 				t.Errorf(
 					"NetboxInventory.AddVMDeviceRole() error = %v, wantErr %v",
@@ -165,7 +171,8 @@ func TestNetboxInventory_AddVMDeviceRole(t *testing.T) {
 
 func TestNetboxInventory_AddVMTemplateDeviceRole(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		ctx   context.Context
+		roles parser.DefaultRoles
 	}
 	tests := []struct {
 		name    string
@@ -178,7 +185,7 @@ func TestNetboxInventory_AddVMTemplateDeviceRole(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.nbi.AddVMTemplateDeviceRole(tt.args.ctx)
+			got, err := tt.nbi.AddVMTemplateDeviceRole(tt.args.ctx, tt.args.roles)
 			if (err != nil) != tt.wantErr {
 				t.Errorf(
 					"NetboxInventory.AddVMTemplateDeviceRole() error = %v, wantErr %v",
@@ -0,0 +1,193 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/service"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// RenameDefaultObjects renames existing default objects, that have one of previous names
+// of the defaults. Renamed objects are reused, instead of creating new default objects.
+// Orphan tag is renamed when the inventory is initialized.
+func (nbi *NetboxInventory) RenameDefaultObjects(ctx context.Context, defaults *parser.DefaultsConfig) error {
+	renameFunctions := []func(context.Context, *parser.DefaultsConfig) error{
+		nbi.renameDefaultSite,
+		nbi.renameDefaultVlanGroups,
+		nbi.renameDefaultManufacturer,
+		nbi.renameDefaultDeviceType,
+		nbi.renameDefaultDeviceRoles,
+	}
+	for _, renameFunction := range renameFunctions {
+		if err := renameFunction(ctx, defaults); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameDefaultObject renames the object with one of the previous names to name, if object
+// with the name doesn't exist yet. Renamed object is patched with body and indexed by the name.
+func renameDefaultObject[T any, P interface {
+	*T
+	GetID() int
+}](
+	ctx context.Context,
+	nbi *NetboxInventory,
+	lock *sync.Mutex,
+	index map[string]P,
+	objectType string,
+	previousNames []string,
+	name string,
+	body map[string]interface{},
+) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if _, ok := index[name]; ok {
+		return nil
+	}
+	for _, previousName := range previousNames {
+		object, ok := index[previousName]
+		if !ok {
+			continue
+		}
+		nbi.Logger.Infof(ctx, "Renaming %s %s to %s", objectType, previousName, name)
+		renamedObject, err := service.Patch[T](ctx, nbi.NetboxAPI, object.GetID(), body)
+		if err != nil {
+			return fmt.Errorf("rename %s %s to %s: %s", objectType, previousName, name, err)
+		}
+		delete(index, previousName)
+		index[name] = renamedObject
+		return nil
+	}
+	return nil
+}
+
+// renameBody returns body for patching name and slug of the default object.
+func renameBody(name string, slug string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "slug": slug}
+}
+
+func (nbi *NetboxInventory) renameOrphanTag(ctx context.Context, defaults *parser.DefaultsConfig) error {
+	orphanTag := defaults.OrphanTag
+	return renameDefaultObject(
+		ctx, nbi, &nbi.tagsLock, nbi.tagsIndexByName, "tag",
+		orphanTag.PreviousNames, orphanTag.Name, renameBody(orphanTag.Name, orphanTag.Slug),
+	)
+}
+
+func (nbi *NetboxInventory) renameDefaultSite(ctx context.Context, defaults *parser.DefaultsConfig) error {
+	site := defaults.Site
+	return renameDefaultObject(
+		ctx, nbi, &nbi.sitesLock, nbi.sitesIndexByName, "site",
+		site.PreviousNames, site.Name, renameBody(site.Name, site.Slug),
+	)
+}
+
+// renameDefaultVlanGroups renames default vlan group and default vlan groups of all sites.
+func (nbi *NetboxInventory) renameDefaultVlanGroups(ctx context.Context, defaults *parser.DefaultsConfig) error {
+	vlanGroup := defaults.VlanGroup
+	err := renameDefaultObject(
+		ctx, nbi, &nbi.vlanGroupsLock, nbi.vlanGroupsIndexByName, "vlan group",
+		vlanGroup.PreviousNames, vlanGroup.Name, renameBody(vlanGroup.Name, vlanGroup.Slug),
+	)
+	if err != nil {
+		return err
+	}
+	if len(defaults.SiteVlanGroup.PreviousNames) == 0 {
+		return nil
+	}
+	nbi.sitesLock.Lock()
+	siteNames := make([]string, 0, len(nbi.sitesIndexByName))
+	for siteName := range nbi.sitesIndexByName {
+		siteNames = append(siteNames, siteName)
+	}
+	nbi.sitesLock.Unlock()
+	for _, siteName := range siteNames {
+		name, slug := defaultSiteVlanGroupNameAndSlug(defaults, siteName)
+		previousNames := make([]string, 0, len(defaults.SiteVlanGroup.PreviousNames))
+		for _, previousName := range defaults.SiteVlanGroup.PreviousNames {
+			previousNames = append(previousNames, strings.ReplaceAll(previousName, constants.SitePlaceholder, siteName))
+		}
+		err := renameDefaultObject(
+			ctx, nbi, &nbi.vlanGroupsLock, nbi.vlanGroupsIndexByName, "vlan group",
+			previousNames, name, renameBody(name, slug),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultSiteVlanGroupNameAndSlug returns name and slug of default vlan group of the site.
+func defaultSiteVlanGroupNameAndSlug(defaults *parser.DefaultsConfig, siteName string) (string, string) {
+	name := strings.ReplaceAll(defaults.SiteVlanGroup.Name, constants.SitePlaceholder, siteName)
+	if defaults.SiteVlanGroup.Slug == "" {
+		return name, utils.Slugify(name)
+	}
+	return name, strings.ReplaceAll(defaults.SiteVlanGroup.Slug, constants.SitePlaceholder, utils.Slugify(siteName))
+}
+
+func (nbi *NetboxInventory) renameDefaultManufacturer(ctx context.Context, defaults *parser.DefaultsConfig) error {
+	manufacturer := defaults.Manufacturer
+	return renameDefaultObject(
+		ctx, nbi, &nbi.manufacturersLock, nbi.manufacturersIndexByName, "manufacturer",
+		manufacturer.PreviousNames, manufacturer.Name, renameBody(manufacturer.Name, manufacturer.Slug),
+	)
+}
+
+// renameDefaultDeviceType renames model of the default device type. Slug is updated,
+// when the device type is added again.
+func (nbi *NetboxInventory) renameDefaultDeviceType(ctx context.Context, defaults *parser.DefaultsConfig) error {
+	deviceType := defaults.DeviceType
+	return renameDefaultObject(
+		ctx, nbi, &nbi.deviceTypesLock, nbi.deviceTypesIndexByModel, "device type",
+		deviceType.PreviousNames, deviceType.Name, map[string]interface{}{"model": deviceType.Name},
+	)
+}
+
+func (nbi *NetboxInventory) renameDefaultDeviceRoles(ctx context.Context, defaults *parser.DefaultsConfig) error {
+	roles := []parser.DefaultObject{
+		defaults.Roles.Server,
+		defaults.Roles.Switch,
+		defaults.Roles.Firewall,
+		defaults.Roles.VM,
+		defaults.Roles.VMTemplate,
+		defaults.Roles.Container,
+	}
+	for _, role := range roles {
+		err := renameDefaultObject(
+			ctx, nbi, &nbi.deviceRolesLock, nbi.deviceRolesIndexByName, "device role",
+			role.PreviousNames, role.Name, renameBody(role.Name, role.Slug),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultsOrBuiltin returns defaults of the inventory. Built-in defaults are used for
+// inventories created without defaults.
+func (nbi *NetboxInventory) defaultsOrBuiltin() *parser.DefaultsConfig {
+	if nbi.Defaults != nil {
+		return nbi.Defaults
+	}
+	return parser.BuiltinDefaults()
+}
+
+// defaultVlanGroupNameAndSlug returns name and slug of default vlan group for vlans
+// of the site. If site is nil, default vlan group for vlans without site is returned.
+func defaultVlanGroupNameAndSlug(defaults *parser.DefaultsConfig, vlanSite *objects.Site) (string, string) {
+	if vlanSite != nil {
+		return defaultSiteVlanGroupNameAndSlug(defaults, vlanSite.Name)
+	}
+	return defaults.VlanGroup.Name, defaults.VlanGroup.Slug
+}
@@ -0,0 +1,59 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+)
+
+func TestDefaultVlanGroupNameAndSlug(t *testing.T) {
+	defaults := parser.BuiltinDefaults()
+	customDefaults := parser.BuiltinDefaults()
+	customDefaults.SiteVlanGroup.Name = "{site} vlans"
+	customDefaults.SiteVlanGroup.Slug = "vlans-{site}"
+	tests := []struct {
+		name     string
+		defaults *parser.DefaultsConfig
+		site     *objects.Site
+		wantName string
+		wantSlug string
+	}{
+		{"Without site", defaults, nil, "DefaultVlanGroup", "defaultvlangroup"},
+		{"Site", defaults, &objects.Site{Name: "Berlin"}, "BerlinDefaultVlanGroup", "berlindefaultvlangroup"},
+		{"Custom site vlan group", customDefaults, &objects.Site{Name: "New York"}, "New York vlans", "vlans-new-york"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, slug := defaultVlanGroupNameAndSlug(tt.defaults, tt.site)
+			if name != tt.wantName || slug != tt.wantSlug {
+				t.Errorf("defaultVlanGroupNameAndSlug() = %s, %s, want %s, %s", name, slug, tt.wantName, tt.wantSlug)
+			}
+		})
+	}
+}
+
+func TestRenameDefaultObjectWithoutPreviousObject(t *testing.T) {
+	nbi := &NetboxInventory{
+		sitesIndexByName: map[string]*objects.Site{
+			"Unassigned": {NetboxObject: objects.NetboxObject{ID: 1}, Name: "Unassigned"},
+			"Berlin":     {NetboxObject: objects.NetboxObject{ID: 2}, Name: "Berlin"},
+		},
+	}
+	defaults := parser.BuiltinDefaults()
+
+	// Objects are not renamed, if object with the new name already exists
+	defaults.Site = parser.DefaultObject{Name: "Unassigned", PreviousNames: []string{"Berlin"}}
+	if err := nbi.renameDefaultSite(context.Background(), defaults); err != nil {
+		t.Fatalf("renameDefaultSite() error = %v", err)
+	}
+	// Objects are not renamed, if none of previous names exist
+	defaults.Site = parser.DefaultObject{Name: "Default", PreviousNames: []string{"DefaultSite"}}
+	if err := nbi.renameDefaultSite(context.Background(), defaults); err != nil {
+		t.Fatalf("renameDefaultSite() error = %v", err)
+	}
+	if len(nbi.sitesIndexByName) != 2 || nbi.sitesIndexByName["Berlin"].ID != 2 {
+		t.Errorf("sitesIndexByName = %v, want unchanged index", nbi.sitesIndexByName)
+	}
+}
@@ -85,12 +85,29 @@ func (nbi *NetboxInventory) softDelete(orphanItem objects.OrphanItem) error {
 		switch orphanItem.(type) {
 		case *objects.VlanGroup:
 			_, err = service.Patch[objects.VlanGroup](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.Aggregate:
+			_, err = service.Patch[objects.Aggregate](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.Prefix:
 			_, err = service.Patch[objects.Prefix](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.Vlan:
 			_, err = service.Patch[objects.Vlan](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.IPAddress:
 			_, err = service.Patch[objects.IPAddress](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.IPRange:
+			_, err = service.Patch[objects.IPRange](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.JournalEntry:
+			_, err = service.Patch[objects.JournalEntry](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.Service:
+			_, err = service.Patch[objects.Service](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.FHRPGroup:
+			_, err = service.Patch[objects.FHRPGroup](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.FHRPGroupAssignment:
+			_, err = service.Patch[objects.FHRPGroupAssignment](
+				nbi.OrphanManager.Ctx,
+				nbi.NetboxAPI,
+				orphanItem.GetID(),
+				diffMap,
+			)
 		case *objects.VirtualDeviceContext:
 			_, err = service.Patch[objects.VirtualDeviceContext](
 				nbi.OrphanManager.Ctx,
@@ -98,10 +115,20 @@ func (nbi *NetboxInventory) softDelete(orphanItem objects.OrphanItem) error {
 				orphanItem.GetID(),
 				diffMap,
 			)
+		case *objects.InventoryItem:
+			_, err = service.Patch[objects.InventoryItem](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.Module:
+			_, err = service.Patch[objects.Module](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.ModuleBay:
+			_, err = service.Patch[objects.ModuleBay](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.Interface:
 			_, err = service.Patch[objects.Interface](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.VMInterface:
 			_, err = service.Patch[objects.VMInterface](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.VRF:
+			_, err = service.Patch[objects.VRF](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.RouteTarget:
+			_, err = service.Patch[objects.RouteTarget](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.VM:
 			_, err = service.Patch[objects.VM](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.Device:
@@ -110,6 +137,8 @@ func (nbi *NetboxInventory) softDelete(orphanItem objects.OrphanItem) error {
 			_, err = service.Patch[objects.Platform](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.DeviceType:
 			_, err = service.Patch[objects.DeviceType](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.ModuleType:
+			_, err = service.Patch[objects.ModuleType](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.Manufacturer:
 			_, err = service.Patch[objects.Manufacturer](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.DeviceRole:
@@ -128,8 +157,32 @@ func (nbi *NetboxInventory) softDelete(orphanItem objects.OrphanItem) error {
 			_, err = service.Patch[objects.WirelessLAN](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.WirelessLANGroup:
 			_, err = service.Patch[objects.WirelessLANGroup](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.ASN:
+			_, err = service.Patch[objects.ASN](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.L2VPN:
+			_, err = service.Patch[objects.L2VPN](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.L2VPNTermination:
+			_, err = service.Patch[objects.L2VPNTermination](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.Tunnel:
+			_, err = service.Patch[objects.Tunnel](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.TunnelGroup:
+			_, err = service.Patch[objects.TunnelGroup](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.TunnelTermination:
+			_, err = service.Patch[objects.TunnelTermination](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.PowerPort:
+			_, err = service.Patch[objects.PowerPort](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.ConsolePort:
+			_, err = service.Patch[objects.ConsolePort](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
 		case *objects.MACAddress:
 			_, err = service.Patch[objects.MACAddress](nbi.OrphanManager.Ctx, nbi.NetboxAPI, orphanItem.GetID(), diffMap)
+		case *objects.RawObject:
+			_, err = service.PatchOnPath[objects.RawObject](
+				nbi.OrphanManager.Ctx,
+				nbi.NetboxAPI,
+				orphanItem.GetAPIPath(),
+				orphanItem.GetID(),
+				diffMap,
+			)
 		default:
 			return fmt.Errorf("unsupported type for orphan item%T", orphanItem)
 		}
@@ -1,6 +1,8 @@
 package inventory
 
 import (
+	"strings"
+
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
 )
@@ -104,6 +106,42 @@ func (nbi *NetboxInventory) GetSiteByID(siteID int) *objects.Site {
 	return nil
 }
 
+// GetLocation returns the Location for the given siteID and locationName.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetLocation(siteID int, locationName string) (*objects.Location, bool) {
+	nbi.locationsLock.Lock()
+	defer nbi.locationsLock.Unlock()
+	location, locationExists := nbi.locationsIndex[siteID][locationName]
+	if !locationExists {
+		return nil, false
+	}
+	return location, true
+}
+
+// GetRackRole returns the RackRole for the given rackRoleName.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetRackRole(rackRoleName string) (*objects.RackRole, bool) {
+	nbi.rackRolesLock.Lock()
+	defer nbi.rackRolesLock.Unlock()
+	rackRole, rackRoleExists := nbi.rackRolesIndexByName[rackRoleName]
+	if !rackRoleExists {
+		return nil, false
+	}
+	return rackRole, true
+}
+
+// GetRack returns the Rack for the given siteID and rackName.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetRack(siteID int, rackName string) (*objects.Rack, bool) {
+	nbi.racksLock.Lock()
+	defer nbi.racksLock.Unlock()
+	rack, rackExists := nbi.racksIndex[siteID][rackName]
+	if !rackExists {
+		return nil, false
+	}
+	return rack, true
+}
+
 // GetVlanGroup returns the VlanGroup for the given vlanGroupName.
 // It returns nil if the VlanGroup is not found.
 // This function is thread-safe.
@@ -117,6 +155,70 @@ func (nbi *NetboxInventory) GetVlanGroup(vlanGroupName string) (*objects.VlanGro
 	return vlanGroup, true
 }
 
+// GetVRF returns the VRF for the given vrfName.
+// It returns nil if the VRF is not found.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetVRF(vrfName string) (*objects.VRF, bool) {
+	nbi.vrfsLock.Lock()
+	defer nbi.vrfsLock.Unlock()
+	vrf, vrfExists := nbi.vrfsIndexByName[vrfName]
+	if !vrfExists {
+		return nil, false
+	}
+	return vrf, true
+}
+
+// GetFHRPGroup returns the FHRPGroup for the given fhrpGroupName.
+// It returns nil if the FHRPGroup is not found.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetFHRPGroup(fhrpGroupName string) (*objects.FHRPGroup, bool) {
+	nbi.fhrpGroupsLock.Lock()
+	defer nbi.fhrpGroupsLock.Unlock()
+	fhrpGroup, fhrpGroupExists := nbi.fhrpGroupsIndexByName[fhrpGroupName]
+	if !fhrpGroupExists {
+		return nil, false
+	}
+	return fhrpGroup, true
+}
+
+// GetRIR returns the RIR for the given rirName.
+// It returns nil if the RIR is not found.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetRIR(rirName string) (*objects.RIR, bool) {
+	nbi.rirsLock.Lock()
+	defer nbi.rirsLock.Unlock()
+	rir, rirExists := nbi.rirsIndexByName[rirName]
+	if !rirExists {
+		return nil, false
+	}
+	return rir, true
+}
+
+// GetASN returns the ASN for the given autonomous system number.
+// It returns nil if the ASN is not found.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetASN(asn int64) (*objects.ASN, bool) {
+	nbi.asnsLock.Lock()
+	defer nbi.asnsLock.Unlock()
+	nbASN, asnExists := nbi.asnsIndexByASN[asn]
+	if !asnExists {
+		return nil, false
+	}
+	return nbASN, true
+}
+
+// GetL2VPN returns the L2VPN for the given l2vpnName.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetL2VPN(l2vpnName string) (*objects.L2VPN, bool) {
+	nbi.l2vpnsLock.Lock()
+	defer nbi.l2vpnsLock.Unlock()
+	l2vpn, l2vpnExists := nbi.l2vpnsIndexByName[l2vpnName]
+	if !l2vpnExists {
+		return nil, false
+	}
+	return l2vpn, true
+}
+
 // GetClusterGroup returns the ClusterGroup for the given clusterGroupName.
 // It returns nil if the ClusterGroup is not found.
 // This function is thread-safe.
@@ -211,6 +313,40 @@ func (nbi *NetboxInventory) GetInterface(
 	return iface, true
 }
 
+// GetModuleBay returns the ModuleBay for the given moduleBayName and deviceID.
+// It returns nil if the ModuleBay is not found.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetModuleBay(
+	moduleBayName string,
+	deviceID int,
+) (*objects.ModuleBay, bool) {
+	nbi.moduleBaysLock.Lock()
+	defer nbi.moduleBaysLock.Unlock()
+
+	moduleBay, moduleBayExists := nbi.moduleBaysIndexByDeviceIDAndName[deviceID][moduleBayName]
+	if !moduleBayExists {
+		return nil, false
+	}
+	return moduleBay, true
+}
+
+// GetInventoryItem returns the InventoryItem for the given inventoryItemName and deviceID.
+// It returns nil if the InventoryItem is not found.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetInventoryItem(
+	inventoryItemName string,
+	deviceID int,
+) (*objects.InventoryItem, bool) {
+	nbi.inventoryItemsLock.Lock()
+	defer nbi.inventoryItemsLock.Unlock()
+
+	inventoryItem, inventoryItemExists := nbi.inventoryItemsIndexByDeviceIDAndName[deviceID][inventoryItemName]
+	if !inventoryItemExists {
+		return nil, false
+	}
+	return inventoryItem, true
+}
+
 // GetContactAssignment returns the ContactAssignment for the given contentType, objectID, contactID and roleID.
 // It returns nil if the ContactAssignment is not found.
 // This function is thread-safe.
@@ -264,3 +400,37 @@ func (nbi *NetboxInventory) GetVMByID(vmID int) *objects.VM {
 	defer nbi.vmsLock.Unlock()
 	return nbi.vmsIndexByID[vmID]
 }
+
+// GetFHRPGroupByID returns the FHRPGroup for the given fhrpGroupID.
+// It returns nil if the FHRPGroup is not found.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetFHRPGroupByID(fhrpGroupID int) *objects.FHRPGroup {
+	nbi.fhrpGroupsLock.Lock()
+	defer nbi.fhrpGroupsLock.Unlock()
+	return nbi.fhrpGroupsIndexByID[fhrpGroupID]
+}
+
+// GetIPAddressByIP returns the first IPAddress with the given ip (without mask) in the given vrf
+// (nil for the global table), that is assigned to an interface of a device or a virtual machine.
+// It returns nil if the IPAddress is not found.
+// This function is thread-safe.
+func (nbi *NetboxInventory) GetIPAddressByIP(vrf *objects.VRF, ip string) *objects.IPAddress {
+	nbi.ipAddressesLock.Lock()
+	defer nbi.ipAddressesLock.Unlock()
+	for ifaceType, ifaceNameIndex := range nbi.ipAddressesIndex[getVRFIndexValue(vrf)] {
+		if ifaceType != constants.ContentTypeDcimDevice &&
+			ifaceType != constants.ContentTypeVirtualizationVirtualMachine {
+			continue
+		}
+		for _, parentNameIndex := range ifaceNameIndex {
+			for _, addressIndex := range parentNameIndex {
+				for address, ipAddress := range addressIndex {
+					if addressIP, _, _ := strings.Cut(address, "/"); addressIP == ip {
+						return ipAddress
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -421,3 +421,35 @@ func TestNetboxInventory_GetContactAssignment(t *testing.T) {
 		})
 	}
 }
+
+func TestNetboxInventory_GetIPAddressByIP(t *testing.T) {
+	vrf := &objects.VRF{NetboxObject: objects.NetboxObject{ID: 5}, Name: "fw1:trust"}
+	globalIP := &objects.IPAddress{NetboxObject: objects.NetboxObject{ID: 1}, Address: "10.0.0.1/24"}
+	vrfIP := &objects.IPAddress{NetboxObject: objects.NetboxObject{ID: 2}, Address: "10.0.0.1/24", VRF: vrf}
+	nbi := &NetboxInventory{
+		ipAddressesIndex: map[int]map[constants.ContentType]map[string]map[string]map[string]*objects.IPAddress{
+			0: {constants.ContentTypeDcimDevice: {"eth0": {"server": {globalIP.Address: globalIP}}}},
+			vrf.ID: {
+				constants.ContentTypeDcimDevice: {"port1": {"fw1": {vrfIP.Address: vrfIP}}},
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		vrf  *objects.VRF
+		ip   string
+		want *objects.IPAddress
+	}{
+		{"Global table", nil, "10.0.0.1", globalIP},
+		{"Vrf", vrf, "10.0.0.1", vrfIP},
+		{"Missing ip", vrf, "10.0.0.2", nil},
+		{"Missing vrf", &objects.VRF{NetboxObject: objects.NetboxObject{ID: 6}}, "10.0.0.1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nbi.GetIPAddressByIP(tt.vrf, tt.ip); got != tt.want {
+				t.Errorf("NetboxInventory.GetIPAddressByIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
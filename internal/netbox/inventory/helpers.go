@@ -6,33 +6,39 @@ import (
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
-	"github.com/bl4ko/netbox-ssot/internal/utils"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
 )
 
 // Inits default VlanGroup, which is required to group all Vlans that are not part of other
-// vlangroups into it. Each vlan is indexed by their (vlanGroup, vid).
+// vlangroups into it. Each vlan is indexed by their (vlanGroup, vid). Name of the vlan group
+// is taken from defaults. If defaults are nil, defaults of the inventory are used.
 func (nbi *NetboxInventory) CreateDefaultVlanGroupForVlan(
 	ctx context.Context,
 	vlanSite *objects.Site,
+	defaults *parser.DefaultsConfig,
 ) (*objects.VlanGroup, error) {
+	if defaults == nil {
+		defaults = nbi.defaultsOrBuiltin()
+	}
+	description := defaults.VlanGroup.Description
+	if vlanSite != nil {
+		description = defaults.SiteVlanGroup.Description
+	}
 	defaultVlanGroup := &objects.VlanGroup{
 		NetboxObject: objects.NetboxObject{
 			Tags:        []*objects.Tag{nbi.SsotTag},
-			Description: constants.DefaultVlanGroupDescription,
+			Description: description,
 			CustomFields: map[string]interface{}{
 				constants.CustomFieldSourceName: nbi.SsotTag.Name,
 			},
 		},
 		VidRanges: []objects.VidRange{{constants.DefaultVID, constants.MaxVID}}}
 
+	defaultVlanGroup.Name, defaultVlanGroup.Slug = defaultVlanGroupNameAndSlug(defaults, vlanSite)
 	if vlanSite != nil {
-		defaultVlanGroup.Name = fmt.Sprintf("%sDefaultVlanGroup", vlanSite.Name)
 		defaultVlanGroup.ScopeType = constants.ContentTypeDcimSite
 		defaultVlanGroup.ScopeID = vlanSite.ID
-	} else {
-		defaultVlanGroup.Name = constants.DefaultVlanGroupName
 	}
-	defaultVlanGroup.Slug = utils.Slugify(defaultVlanGroup.Name)
 
 	nbVlanGroup, err := nbi.AddVlanGroup(ctx, defaultVlanGroup)
 
@@ -77,6 +83,16 @@ func (nbi *NetboxInventory) getIndexValuesForIPAddress(
 			if ipIface.VM != nil {
 				ipIfaceParentName = ipIface.VM.Name
 			}
+		case constants.ContentTypeIpamFHRPGroup:
+			ipIfaceType = constants.ContentTypeIpamFHRPGroup
+			fhrpGroup := nbi.GetFHRPGroupByID(ipAddr.AssignedObjectID)
+			if fhrpGroup == nil {
+				return "", "", "", fmt.Errorf(
+					"assigned object not found for ip address %+v",
+					ipAddr,
+				)
+			}
+			ipIfaceName = fhrpGroup.Name
 		default:
 			return "", "", "", fmt.Errorf(
 				"unsupported assigned object type for ip address %+v: %s",
@@ -132,27 +148,43 @@ func (nbi *NetboxInventory) getIndexValuesForMACAddress(
 	return macIfaceType, macIfaceName, macIfaceParentName, nil
 }
 
+// getVRFIndexValue returns the id of the given vrf, that is used as
+// a key in the vrf aware indexes. Nil vrf represents the global table (0).
+func getVRFIndexValue(vrf *objects.VRF) int {
+	if vrf == nil {
+		return 0
+	}
+	return vrf.ID
+}
+
 func (nbi *NetboxInventory) verifyIPAddressIndexExists(
+	vrfID int,
 	ifaceType constants.ContentType,
 	ifaceName string,
 	ifaceParentName string,
 ) {
 	nbi.ipAddressesLock.Lock()
 	defer nbi.ipAddressesLock.Unlock()
-	if nbi.ipAddressesIndex[ifaceType] == nil {
-		nbi.ipAddressesIndex[ifaceType] = make(
+	if nbi.ipAddressesIndex[vrfID] == nil {
+		nbi.ipAddressesIndex[vrfID] = make(
+			map[constants.ContentType]map[string]map[string]map[string]*objects.IPAddress,
+		)
+	}
+
+	if nbi.ipAddressesIndex[vrfID][ifaceType] == nil {
+		nbi.ipAddressesIndex[vrfID][ifaceType] = make(
 			map[string]map[string]map[string]*objects.IPAddress,
 		)
 	}
 
-	if nbi.ipAddressesIndex[ifaceType][ifaceName] == nil {
-		nbi.ipAddressesIndex[ifaceType][ifaceName] = make(
+	if nbi.ipAddressesIndex[vrfID][ifaceType][ifaceName] == nil {
+		nbi.ipAddressesIndex[vrfID][ifaceType][ifaceName] = make(
 			map[string]map[string]*objects.IPAddress,
 		)
 	}
 
-	if nbi.ipAddressesIndex[ifaceType][ifaceName][ifaceParentName] == nil {
-		nbi.ipAddressesIndex[ifaceType][ifaceName][ifaceParentName] = make(
+	if nbi.ipAddressesIndex[vrfID][ifaceType][ifaceName][ifaceParentName] == nil {
+		nbi.ipAddressesIndex[vrfID][ifaceType][ifaceName][ifaceParentName] = make(
 			map[string]*objects.IPAddress,
 		)
 	}
@@ -183,3 +215,73 @@ func (nbi *NetboxInventory) verifyMACAddressIndexExists(
 		)
 	}
 }
+
+func (nbi *NetboxInventory) verifyFHRPGroupAssignmentIndexExists(
+	groupID int,
+	ifaceType constants.ContentType,
+) {
+	nbi.fhrpGroupAssignmentsLock.Lock()
+	defer nbi.fhrpGroupAssignmentsLock.Unlock()
+	if nbi.fhrpGroupAssignmentsIndex[groupID] == nil {
+		nbi.fhrpGroupAssignmentsIndex[groupID] = make(
+			map[constants.ContentType]map[int]*objects.FHRPGroupAssignment,
+		)
+	}
+
+	if nbi.fhrpGroupAssignmentsIndex[groupID][ifaceType] == nil {
+		nbi.fhrpGroupAssignmentsIndex[groupID][ifaceType] = make(
+			map[int]*objects.FHRPGroupAssignment,
+		)
+	}
+}
+
+// getIndexValuesForService returns parent type and parent id of the service,
+// which are used for indexing services.
+func getIndexValuesForService(service *objects.Service) (constants.ContentType, int, string, error) {
+	if service.Protocol == nil {
+		return "", 0, "", fmt.Errorf("service %+v has no protocol", service)
+	}
+	switch {
+	case service.Device != nil:
+		return constants.ContentTypeDcimDevice, service.Device.ID, service.Protocol.Value, nil
+	case service.VM != nil:
+		return constants.ContentTypeVirtualizationVirtualMachine, service.VM.ID, service.Protocol.Value, nil
+	default:
+		return "", 0, "", fmt.Errorf("service %+v has no parent device or virtual machine", service)
+	}
+}
+
+func (nbi *NetboxInventory) verifyServiceIndexExists(
+	parentType constants.ContentType,
+	parentID int,
+	protocol string,
+) {
+	nbi.servicesLock.Lock()
+	defer nbi.servicesLock.Unlock()
+	if nbi.servicesIndex[parentType] == nil {
+		nbi.servicesIndex[parentType] = make(map[int]map[string]map[string]*objects.Service)
+	}
+
+	if nbi.servicesIndex[parentType][parentID] == nil {
+		nbi.servicesIndex[parentType][parentID] = make(map[string]map[string]*objects.Service)
+	}
+
+	if nbi.servicesIndex[parentType][parentID][protocol] == nil {
+		nbi.servicesIndex[parentType][parentID][protocol] = make(map[string]*objects.Service)
+	}
+}
+
+func (nbi *NetboxInventory) verifyJournalEntryIndexExists(
+	objectType constants.ContentType,
+	objectID int,
+) {
+	nbi.journalEntriesLock.Lock()
+	defer nbi.journalEntriesLock.Unlock()
+	if nbi.journalEntriesIndex[objectType] == nil {
+		nbi.journalEntriesIndex[objectType] = make(map[int]map[string]*objects.JournalEntry)
+	}
+
+	if nbi.journalEntriesIndex[objectType][objectID] == nil {
+		nbi.journalEntriesIndex[objectType][objectID] = make(map[string]*objects.JournalEntry)
+	}
+}
@@ -3,10 +3,12 @@ package inventory
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/service"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
 	"github.com/bl4ko/netbox-ssot/internal/utils"
 )
 
@@ -45,13 +47,17 @@ func (nbi *NetboxInventory) initTags(ctx context.Context) error {
 	nbi.SsotTag = ssotTag
 
 	// Create default tag for orphaned objects
+	defaults := nbi.defaultsOrBuiltin()
+	if err := nbi.renameOrphanTag(ctx, defaults); err != nil {
+		return err
+	}
 	orphanTag, err := nbi.AddTag(
 		ctx,
 		&objects.Tag{
-			Name:        constants.OrphanTagName,
-			Slug:        constants.OrphanTagName,
-			Description: constants.OrphanTagDescription,
-			Color:       constants.OrphanTagColor,
+			Name:        defaults.OrphanTag.Name,
+			Slug:        defaults.OrphanTag.Slug,
+			Description: defaults.OrphanTag.Description,
+			Color:       constants.Color(defaults.OrphanTag.Color),
 		},
 	)
 	if err != nil {
@@ -227,6 +233,125 @@ func (nbi *NetboxInventory) initSites(ctx context.Context) error {
 	return nil
 }
 
+// Collects all locations from Netbox API and stores them in the NetBoxInventory.
+func (nbi *NetboxInventory) initLocations(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.Location{}),
+	)
+	nbLocations, err := service.GetAll[objects.Location](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.locationsIndex = make(map[int]map[string]*objects.Location)
+	for i := range nbLocations {
+		location := &nbLocations[i]
+		if location.Site == nil {
+			continue
+		}
+		if nbi.locationsIndex[location.Site.ID] == nil {
+			nbi.locationsIndex[location.Site.ID] = make(map[string]*objects.Location)
+		}
+		nbi.locationsIndex[location.Site.ID][location.Name] = location
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected locations from Netbox: ", nbi.locationsIndex)
+	return nil
+}
+
+// Collects all rack roles from Netbox API and stores them in the NetBoxInventory.
+func (nbi *NetboxInventory) initRackRoles(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.RackRole{}),
+	)
+	nbRackRoles, err := service.GetAll[objects.RackRole](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.rackRolesIndexByName = make(map[string]*objects.RackRole)
+	for i := range nbRackRoles {
+		rackRole := &nbRackRoles[i]
+		nbi.rackRolesIndexByName[rackRole.Name] = rackRole
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected rack roles from Netbox: ", nbi.rackRolesIndexByName)
+	return nil
+}
+
+// Collects all racks from Netbox API and stores them in the NetBoxInventory.
+func (nbi *NetboxInventory) initRacks(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.Rack{}),
+	)
+	nbRacks, err := service.GetAll[objects.Rack](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.racksIndex = make(map[int]map[string]*objects.Rack)
+	for i := range nbRacks {
+		rack := &nbRacks[i]
+		if rack.Site == nil {
+			continue
+		}
+		if nbi.racksIndex[rack.Site.ID] == nil {
+			nbi.racksIndex[rack.Site.ID] = make(map[string]*objects.Rack)
+		}
+		nbi.racksIndex[rack.Site.ID][rack.Name] = rack
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected racks from Netbox: ", nbi.racksIndex)
+	return nil
+}
+
+// Collects all power panels from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initPowerPanels(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.PowerPanel{}),
+	)
+	nbPowerPanels, err := service.GetAll[objects.PowerPanel](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.powerPanelsIndex = make(map[int]map[string]*objects.PowerPanel)
+	for i := range nbPowerPanels {
+		powerPanel := &nbPowerPanels[i]
+		if powerPanel.Site == nil {
+			continue
+		}
+		if nbi.powerPanelsIndex[powerPanel.Site.ID] == nil {
+			nbi.powerPanelsIndex[powerPanel.Site.ID] = make(map[string]*objects.PowerPanel)
+		}
+		nbi.powerPanelsIndex[powerPanel.Site.ID][powerPanel.Name] = powerPanel
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected power panels from Netbox: ", nbi.powerPanelsIndex)
+	return nil
+}
+
+// Collects all power feeds from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initPowerFeeds(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.PowerFeed{}),
+	)
+	nbPowerFeeds, err := service.GetAll[objects.PowerFeed](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.powerFeedsIndex = make(map[int]map[string]*objects.PowerFeed)
+	for i := range nbPowerFeeds {
+		powerFeed := &nbPowerFeeds[i]
+		if powerFeed.PowerPanel == nil {
+			continue
+		}
+		if nbi.powerFeedsIndex[powerFeed.PowerPanel.ID] == nil {
+			nbi.powerFeedsIndex[powerFeed.PowerPanel.ID] = make(map[string]*objects.PowerFeed)
+		}
+		nbi.powerFeedsIndex[powerFeed.PowerPanel.ID][powerFeed.Name] = powerFeed
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected power feeds from Netbox: ", nbi.powerFeedsIndex)
+	return nil
+}
+
 // Collects all sites from Netbox API and store them in the NetBoxInventory.
 func (nbi *NetboxInventory) initSiteGroups(ctx context.Context) error {
 	extraArgs := fmt.Sprintf(
@@ -254,16 +379,20 @@ func (nbi *NetboxInventory) initSiteGroups(ctx context.Context) error {
 // initDefaultSite inits default site, which is used for hosts that have no corresponding site.
 // This is because site is required for adding new hosts.
 func (nbi *NetboxInventory) initDefaultSite(ctx context.Context) error {
+	defaults := nbi.defaultsOrBuiltin()
+	if err := nbi.renameDefaultSite(ctx, defaults); err != nil {
+		return err
+	}
 	_, err := nbi.AddSite(ctx, &objects.Site{
 		NetboxObject: objects.NetboxObject{
 			Tags:        []*objects.Tag{nbi.SsotTag},
-			Description: "Default netbox-ssot site used for all hosts, that have no site matched.",
+			Description: defaults.Site.Description,
 			CustomFields: map[string]interface{}{
 				constants.CustomFieldSourceName: nbi.SsotTag.Name,
 			},
 		},
-		Name: constants.DefaultSite,
-		Slug: utils.Slugify(constants.DefaultSite),
+		Name: defaults.Site.Name,
+		Slug: defaults.Site.Slug,
 	})
 	if err != nil {
 		return fmt.Errorf("init default site: %s", err)
@@ -294,7 +423,7 @@ func (nbi *NetboxInventory) initManufacturers(ctx context.Context) error {
 		"Successfully collected manufacturers from Netbox: ",
 		nbi.manufacturersIndexByName,
 	)
-	return nil
+	return nbi.renameDefaultManufacturer(ctx, nbi.defaultsOrBuiltin())
 }
 
 // Collects all platforms from Netbox API and store them in the NetBoxInventory.
@@ -394,6 +523,202 @@ func (nbi *NetboxInventory) initVirtualDeviceContexts(ctx context.Context) error
 	return nil
 }
 
+// Collects all module types from Netbox API and stores them in the
+// NetBoxInventory.
+func (nbi *NetboxInventory) initModuleTypes(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.ModuleType{}),
+	)
+	nbModuleTypes, err := service.GetAll[objects.ModuleType](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initialize internal index of module types by manufacturer id and model
+	nbi.moduleTypesIndexByManufacturerIDAndModel = make(map[int]map[string]*objects.ModuleType)
+	for i := range nbModuleTypes {
+		moduleType := &nbModuleTypes[i]
+		if moduleType.Manufacturer == nil {
+			nbi.Logger.Debugf(ctx, "Skipping module type %s without manufacturer", moduleType.Model)
+			continue
+		}
+		manufacturerID := moduleType.Manufacturer.ID
+		if nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID] == nil {
+			nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID] = make(map[string]*objects.ModuleType)
+		}
+		nbi.moduleTypesIndexByManufacturerIDAndModel[manufacturerID][moduleType.Model] = moduleType
+		nbi.OrphanManager.AddItem(moduleType)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected module types from Netbox: ",
+		nbi.moduleTypesIndexByManufacturerIDAndModel,
+	)
+	return nil
+}
+
+// Collects all module bays from Netbox API and stores them in the
+// NetBoxInventory.
+func (nbi *NetboxInventory) initModuleBays(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.ModuleBay{}),
+	)
+	nbModuleBays, err := service.GetAll[objects.ModuleBay](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initialize internal index of module bays by device id and name
+	nbi.moduleBaysIndexByDeviceIDAndName = make(map[int]map[string]*objects.ModuleBay)
+	for i := range nbModuleBays {
+		moduleBay := &nbModuleBays[i]
+		if nbi.moduleBaysIndexByDeviceIDAndName[moduleBay.Device.ID] == nil {
+			nbi.moduleBaysIndexByDeviceIDAndName[moduleBay.Device.ID] = make(
+				map[string]*objects.ModuleBay,
+			)
+		}
+		nbi.moduleBaysIndexByDeviceIDAndName[moduleBay.Device.ID][moduleBay.Name] = moduleBay
+		nbi.OrphanManager.AddItem(moduleBay)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected module bays from Netbox: ",
+		nbi.moduleBaysIndexByDeviceIDAndName,
+	)
+	return nil
+}
+
+// Collects all modules from Netbox API and stores them in the
+// NetBoxInventory.
+func (nbi *NetboxInventory) initModules(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.Module{}),
+	)
+	nbModules, err := service.GetAll[objects.Module](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initialize internal index of modules by module bay id
+	nbi.modulesIndexByModuleBayID = make(map[int]*objects.Module)
+	for i := range nbModules {
+		module := &nbModules[i]
+		nbi.modulesIndexByModuleBayID[module.ModuleBay.ID] = module
+		nbi.OrphanManager.AddItem(module)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected modules from Netbox: ",
+		nbi.modulesIndexByModuleBayID,
+	)
+	return nil
+}
+
+// Collects all inventory items from Netbox API and stores them in the
+// NetBoxInventory.
+func (nbi *NetboxInventory) initInventoryItems(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.InventoryItem{}),
+	)
+	nbInventoryItems, err := service.GetAll[objects.InventoryItem](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initialize internal index of inventory items by device id and name
+	nbi.inventoryItemsIndexByDeviceIDAndName = make(map[int]map[string]*objects.InventoryItem)
+	for i := range nbInventoryItems {
+		inventoryItem := &nbInventoryItems[i]
+		if nbi.inventoryItemsIndexByDeviceIDAndName[inventoryItem.Device.ID] == nil {
+			nbi.inventoryItemsIndexByDeviceIDAndName[inventoryItem.Device.ID] = make(
+				map[string]*objects.InventoryItem,
+			)
+		}
+		nbi.inventoryItemsIndexByDeviceIDAndName[inventoryItem.Device.ID][inventoryItem.Name] = inventoryItem
+		nbi.OrphanManager.AddItem(inventoryItem)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected inventory items from Netbox: ",
+		nbi.inventoryItemsIndexByDeviceIDAndName,
+	)
+	return nil
+}
+
+// Collects all power ports from Netbox API and stores them in the
+// NetBoxInventory.
+func (nbi *NetboxInventory) initPowerPorts(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.PowerPort{}),
+	)
+	nbPowerPorts, err := service.GetAll[objects.PowerPort](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initialize internal index of power ports by device id and name
+	nbi.powerPortsIndexByDeviceIDAndName = make(map[int]map[string]*objects.PowerPort)
+	for i := range nbPowerPorts {
+		powerPort := &nbPowerPorts[i]
+		if nbi.powerPortsIndexByDeviceIDAndName[powerPort.Device.ID] == nil {
+			nbi.powerPortsIndexByDeviceIDAndName[powerPort.Device.ID] = make(
+				map[string]*objects.PowerPort,
+			)
+		}
+		nbi.powerPortsIndexByDeviceIDAndName[powerPort.Device.ID][powerPort.Name] = powerPort
+		nbi.OrphanManager.AddItem(powerPort)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected power ports from Netbox: ",
+		nbi.powerPortsIndexByDeviceIDAndName,
+	)
+	return nil
+}
+
+// Collects all console ports from Netbox API and stores them in the
+// NetBoxInventory.
+func (nbi *NetboxInventory) initConsolePorts(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.ConsolePort{}),
+	)
+	nbConsolePorts, err := service.GetAll[objects.ConsolePort](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initialize internal index of console ports by device id and name
+	nbi.consolePortsIndexByDeviceIDAndName = make(map[int]map[string]*objects.ConsolePort)
+	for i := range nbConsolePorts {
+		consolePort := &nbConsolePorts[i]
+		if nbi.consolePortsIndexByDeviceIDAndName[consolePort.Device.ID] == nil {
+			nbi.consolePortsIndexByDeviceIDAndName[consolePort.Device.ID] = make(
+				map[string]*objects.ConsolePort,
+			)
+		}
+		nbi.consolePortsIndexByDeviceIDAndName[consolePort.Device.ID][consolePort.Name] = consolePort
+		nbi.OrphanManager.AddItem(consolePort)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected console ports from Netbox: ",
+		nbi.consolePortsIndexByDeviceIDAndName,
+	)
+	return nil
+}
+
 // Collects all deviceRoles from Netbox API and store them in the
 // NetBoxInventory.
 func (nbi *NetboxInventory) initDeviceRoles(ctx context.Context) error {
@@ -419,7 +744,7 @@ func (nbi *NetboxInventory) initDeviceRoles(ctx context.Context) error {
 		"Successfully collected device roles from Netbox: ",
 		nbi.deviceRolesIndexByName,
 	)
-	return nil
+	return nbi.renameDefaultDeviceRoles(ctx, nbi.defaultsOrBuiltin())
 }
 
 func (nbi *NetboxInventory) initCustomFields(ctx context.Context) error {
@@ -462,7 +787,7 @@ func (nbi *NetboxInventory) initSsotCustomFields(ctx context.Context) error {
 		DisplayWeight:         objects.DisplayWeightDefault,
 		Description:           constants.CustomFieldSourceDescription,
 		SearchWeight:          objects.SearchWeightDefault,
-		ObjectTypes: []constants.ContentType{
+		ObjectTypes: append([]constants.ContentType{
 			constants.ContentTypeDcimDevice,
 			constants.ContentTypeDcimDeviceRole,
 			constants.ContentTypeDcimDeviceType,
@@ -473,10 +798,30 @@ func (nbi *NetboxInventory) initSsotCustomFields(ctx context.Context) error {
 			constants.ContentTypeDcimRegion,
 			constants.ContentTypeDcimSite,
 			constants.ContentTypeDcimVirtualDeviceContext,
+			constants.ContentTypeDcimModuleType,
+			constants.ContentTypeDcimModuleBay,
+			constants.ContentTypeDcimModule,
+			constants.ContentTypeDcimInventoryItem,
+			constants.ContentTypeDcimPowerPort,
+			constants.ContentTypeDcimConsolePort,
 			constants.ContentTypeIpamIPAddress,
 			constants.ContentTypeIpamVlanGroup,
 			constants.ContentTypeIpamVlan,
 			constants.ContentTypeIpamPrefix,
+			constants.ContentTypeIpamVRF,
+			constants.ContentTypeIpamRouteTarget,
+			constants.ContentTypeIpamIPRange,
+			constants.ContentTypeIpamFHRPGroup,
+			constants.ContentTypeIpamFHRPGroupAssignment,
+			constants.ContentTypeIpamService,
+			constants.ContentTypeIpamASN,
+			constants.ContentTypeIpamAggregate,
+			constants.ContentTypeExtrasJournalEntry,
+			constants.ContentTypeVpnL2VPN,
+			constants.ContentTypeVpnL2VPNTermination,
+			constants.ContentTypeVpnTunnel,
+			constants.ContentTypeVpnTunnelGroup,
+			constants.ContentTypeVpnTunnelTermination,
 			constants.ContentTypeTenancyTenantGroup,
 			constants.ContentTypeTenancyTenant,
 			constants.ContentTypeTenancyContact,
@@ -490,7 +835,7 @@ func (nbi *NetboxInventory) initSsotCustomFields(ctx context.Context) error {
 			constants.ContentTypeVirtualizationVMInterface,
 			constants.ContentTypeWirelessLAN,
 			constants.ContentTypeWirelessLANGroup,
-		},
+		}, nbi.rawObjectContentTypes()...),
 	})
 	if err != nil {
 		return fmt.Errorf("add source custom field %s", err)
@@ -508,7 +853,7 @@ func (nbi *NetboxInventory) initSsotCustomFields(ctx context.Context) error {
 		DisplayWeight:         objects.DisplayWeightDefault,
 		Description:           constants.CustomFieldOrphanLastSeenDescription,
 		SearchWeight:          objects.SearchWeightDefault,
-		ObjectTypes: []constants.ContentType{
+		ObjectTypes: append([]constants.ContentType{
 			constants.ContentTypeDcimDevice,
 			constants.ContentTypeDcimDeviceRole,
 			constants.ContentTypeDcimDeviceType,
@@ -519,10 +864,30 @@ func (nbi *NetboxInventory) initSsotCustomFields(ctx context.Context) error {
 			constants.ContentTypeDcimRegion,
 			constants.ContentTypeDcimSite,
 			constants.ContentTypeDcimVirtualDeviceContext,
+			constants.ContentTypeDcimModuleType,
+			constants.ContentTypeDcimModuleBay,
+			constants.ContentTypeDcimModule,
+			constants.ContentTypeDcimInventoryItem,
+			constants.ContentTypeDcimPowerPort,
+			constants.ContentTypeDcimConsolePort,
 			constants.ContentTypeIpamIPAddress,
 			constants.ContentTypeIpamVlanGroup,
 			constants.ContentTypeIpamVlan,
 			constants.ContentTypeIpamPrefix,
+			constants.ContentTypeIpamVRF,
+			constants.ContentTypeIpamRouteTarget,
+			constants.ContentTypeIpamIPRange,
+			constants.ContentTypeIpamFHRPGroup,
+			constants.ContentTypeIpamFHRPGroupAssignment,
+			constants.ContentTypeIpamService,
+			constants.ContentTypeIpamASN,
+			constants.ContentTypeIpamAggregate,
+			constants.ContentTypeExtrasJournalEntry,
+			constants.ContentTypeVpnL2VPN,
+			constants.ContentTypeVpnL2VPNTermination,
+			constants.ContentTypeVpnTunnel,
+			constants.ContentTypeVpnTunnelGroup,
+			constants.ContentTypeVpnTunnelTermination,
 			constants.ContentTypeTenancyTenantGroup,
 			constants.ContentTypeTenancyTenant,
 			constants.ContentTypeTenancyContact,
@@ -537,7 +902,7 @@ func (nbi *NetboxInventory) initSsotCustomFields(ctx context.Context) error {
 			constants.ContentTypeWirelessLAN,
 			constants.ContentTypeWirelessLANGroup,
 			constants.ContentTypeDcimMACAddress,
-		},
+		}, nbi.rawObjectContentTypes()...),
 	})
 	if err != nil {
 		return fmt.Errorf("add last seen custom field: %s", err)
@@ -565,10 +930,30 @@ func (nbi *NetboxInventory) initSsotCustomFields(ctx context.Context) error {
 			constants.ContentTypeDcimRegion,
 			constants.ContentTypeDcimSite,
 			constants.ContentTypeDcimVirtualDeviceContext,
+			constants.ContentTypeDcimModuleType,
+			constants.ContentTypeDcimModuleBay,
+			constants.ContentTypeDcimModule,
+			constants.ContentTypeDcimInventoryItem,
+			constants.ContentTypeDcimPowerPort,
+			constants.ContentTypeDcimConsolePort,
 			constants.ContentTypeIpamIPAddress,
 			constants.ContentTypeIpamVlanGroup,
 			constants.ContentTypeIpamVlan,
 			constants.ContentTypeIpamPrefix,
+			constants.ContentTypeIpamVRF,
+			constants.ContentTypeIpamRouteTarget,
+			constants.ContentTypeIpamIPRange,
+			constants.ContentTypeIpamFHRPGroup,
+			constants.ContentTypeIpamFHRPGroupAssignment,
+			constants.ContentTypeIpamService,
+			constants.ContentTypeIpamASN,
+			constants.ContentTypeIpamAggregate,
+			constants.ContentTypeExtrasJournalEntry,
+			constants.ContentTypeVpnL2VPN,
+			constants.ContentTypeVpnL2VPNTermination,
+			constants.ContentTypeVpnTunnel,
+			constants.ContentTypeVpnTunnelGroup,
+			constants.ContentTypeVpnTunnelTermination,
 			constants.ContentTypeTenancyTenantGroup,
 			constants.ContentTypeTenancyTenant,
 			constants.ContentTypeTenancyContact,
@@ -756,7 +1141,7 @@ func (nbi *NetboxInventory) initDeviceTypes(ctx context.Context) error {
 		"Successfully collected device types from Netbox: ",
 		nbi.deviceTypesIndexByModel,
 	)
-	return nil
+	return nbi.renameDefaultDeviceType(ctx, nbi.defaultsOrBuiltin())
 }
 
 // Collects all interfaces from Netbox API and stores them to local inventory.
@@ -830,6 +1215,11 @@ func (nbi *NetboxInventory) initVlans(ctx context.Context) error {
 		return err
 	}
 
+	// Default vlan groups are renamed before vlans without group are added to them
+	if err := nbi.renameDefaultVlanGroups(ctx, nbi.defaultsOrBuiltin()); err != nil {
+		return err
+	}
+
 	// Initialize internal index of vlans by VlanGroupId and Vid
 	nbi.vlansIndexByVlanGroupIDAndVID = make(map[int]map[int]*objects.Vlan)
 
@@ -839,7 +1229,7 @@ func (nbi *NetboxInventory) initVlans(ctx context.Context) error {
 			// Update all existing vlans with default vlanGroup. This only happens
 			// when there are predefined vlans in netbox. This is required because
 			// vlans are indexed by vlan group.
-			defaultVlanGroup, err := nbi.CreateDefaultVlanGroupForVlan(nbi.Ctx, vlan.Site)
+			defaultVlanGroup, err := nbi.CreateDefaultVlanGroupForVlan(nbi.Ctx, vlan.Site, nil)
 			if err != nil {
 				return fmt.Errorf("create default vlan group for vlan: %s", err)
 			}
@@ -929,6 +1319,255 @@ func (nbi *NetboxInventory) initVMInterfaces(ctx context.Context) error {
 	return nil
 }
 
+// Collects all route targets from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initRouteTargets(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.RouteTarget{}),
+	)
+	nbRouteTargets, err := service.GetAll[objects.RouteTarget](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initializes internal index of route targets by name
+	nbi.routeTargetsIndexByName = make(map[string]*objects.RouteTarget)
+	for i := range nbRouteTargets {
+		routeTarget := &nbRouteTargets[i]
+		nbi.routeTargetsIndexByName[routeTarget.Name] = routeTarget
+		nbi.OrphanManager.AddItem(routeTarget)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected route targets from Netbox: ",
+		nbi.routeTargetsIndexByName,
+	)
+	return nil
+}
+
+// Collects all VRFs from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initVRFs(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.VRF{}),
+	)
+	nbVRFs, err := service.GetAll[objects.VRF](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initializes internal index of VRFs by name
+	nbi.vrfsIndexByName = make(map[string]*objects.VRF)
+	for i := range nbVRFs {
+		vrf := &nbVRFs[i]
+		nbi.vrfsIndexByName[vrf.Name] = vrf
+		nbi.OrphanManager.AddItem(vrf)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected VRFs from Netbox: ",
+		nbi.vrfsIndexByName,
+	)
+	return nil
+}
+
+// Collects all services from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initServices(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.Service{}),
+	)
+	nbServices, err := service.GetAll[objects.Service](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initializes internal index of services by parent type, parent id, protocol and name
+	nbi.servicesIndex = make(map[constants.ContentType]map[int]map[string]map[string]*objects.Service)
+	for i := range nbServices {
+		nbService := &nbServices[i]
+		parentType, parentID, protocol, err := getIndexValuesForService(nbService)
+		if err != nil {
+			return fmt.Errorf("get index values for service: %s", err)
+		}
+		nbi.verifyServiceIndexExists(parentType, parentID, protocol)
+		nbi.servicesIndex[parentType][parentID][protocol][nbService.Name] = nbService
+		nbi.OrphanManager.AddItem(nbService)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected services from Netbox: ",
+		nbi.servicesIndex,
+	)
+	return nil
+}
+
+// Collects all RIRs from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initRIRs(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.RIR{}),
+	)
+	nbRIRs, err := service.GetAll[objects.RIR](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.rirsIndexByName = make(map[string]*objects.RIR)
+	for i := range nbRIRs {
+		rir := &nbRIRs[i]
+		nbi.rirsIndexByName[rir.Name] = rir
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected RIRs from Netbox: ", nbi.rirsIndexByName)
+	return nil
+}
+
+// Collects all ASNs from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initASNs(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.ASN{}),
+	)
+	nbASNs, err := service.GetAll[objects.ASN](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.asnsIndexByASN = make(map[int64]*objects.ASN)
+	for i := range nbASNs {
+		asn := &nbASNs[i]
+		nbi.asnsIndexByASN[asn.ASN] = asn
+		nbi.OrphanManager.AddItem(asn)
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected ASNs from Netbox: ", nbi.asnsIndexByASN)
+	return nil
+}
+
+// Collects all aggregates from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initAggregates(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.Aggregate{}),
+	)
+	nbAggregates, err := service.GetAll[objects.Aggregate](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.aggregatesIndexByPrefix = make(map[string]*objects.Aggregate)
+	for i := range nbAggregates {
+		aggregate := &nbAggregates[i]
+		nbi.aggregatesIndexByPrefix[aggregate.Prefix] = aggregate
+		nbi.OrphanManager.AddItem(aggregate)
+	}
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected aggregates from Netbox: ",
+		nbi.aggregatesIndexByPrefix,
+	)
+	return nil
+}
+
+// initConfiguredAggregates adds aggregates and their RIRs,
+// configured in the netbox config, to the netbox inventory.
+func (nbi *NetboxInventory) initConfiguredAggregates(ctx context.Context) error {
+	privateRIRs := make(map[string]bool, len(nbi.NetboxConfig.RIRs))
+	for _, rirConfig := range nbi.NetboxConfig.RIRs {
+		privateRIRs[rirConfig.Name] = rirConfig.Private
+		_, err := nbi.AddRIR(ctx, &objects.RIR{
+			Name:      rirConfig.Name,
+			Slug:      utils.Slugify(rirConfig.Name),
+			IsPrivate: rirConfig.Private,
+		})
+		if err != nil {
+			return fmt.Errorf("add rir %s: %s", rirConfig.Name, err)
+		}
+	}
+	for _, aggregateConfig := range nbi.NetboxConfig.Aggregates {
+		nbRIR, err := nbi.AddRIR(ctx, &objects.RIR{
+			Name:      aggregateConfig.RIR,
+			Slug:      utils.Slugify(aggregateConfig.RIR),
+			IsPrivate: privateRIRs[aggregateConfig.RIR],
+		})
+		if err != nil {
+			return fmt.Errorf("add rir %s: %s", aggregateConfig.RIR, err)
+		}
+		_, err = nbi.AddAggregate(ctx, &objects.Aggregate{
+			NetboxObject: objects.NetboxObject{
+				Description: aggregateConfig.Description,
+			},
+			Prefix: aggregateConfig.Prefix,
+			RIR:    nbRIR,
+		})
+		if err != nil {
+			return fmt.Errorf("add aggregate %s: %s", aggregateConfig.Prefix, err)
+		}
+	}
+	return nil
+}
+
+// Collects all FHRP groups from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initFHRPGroups(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.FHRPGroup{}),
+	)
+	nbFHRPGroups, err := service.GetAll[objects.FHRPGroup](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initializes internal indexes of FHRP groups by name and id
+	nbi.fhrpGroupsIndexByName = make(map[string]*objects.FHRPGroup)
+	nbi.fhrpGroupsIndexByID = make(map[int]*objects.FHRPGroup)
+	for i := range nbFHRPGroups {
+		fhrpGroup := &nbFHRPGroups[i]
+		nbi.fhrpGroupsIndexByName[fhrpGroup.Name] = fhrpGroup
+		nbi.fhrpGroupsIndexByID[fhrpGroup.ID] = fhrpGroup
+		nbi.OrphanManager.AddItem(fhrpGroup)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected FHRP groups from Netbox: ",
+		nbi.fhrpGroupsIndexByName,
+	)
+	return nil
+}
+
+// Collects all FHRP group assignments from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initFHRPGroupAssignments(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.FHRPGroupAssignment{}),
+	)
+	nbAssignments, err := service.GetAll[objects.FHRPGroupAssignment](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initializes internal index of FHRP group assignments
+	nbi.fhrpGroupAssignmentsIndex = make(
+		map[int]map[constants.ContentType]map[int]*objects.FHRPGroupAssignment,
+	)
+	for i := range nbAssignments {
+		assignment := &nbAssignments[i]
+		if assignment.Group == nil {
+			continue
+		}
+		nbi.verifyFHRPGroupAssignmentIndexExists(assignment.Group.ID, assignment.InterfaceType)
+		nbi.fhrpGroupAssignmentsIndex[assignment.Group.ID][assignment.InterfaceType][assignment.InterfaceID] = assignment
+		nbi.OrphanManager.AddItem(assignment)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected FHRP group assignments from Netbox: ",
+		nbi.fhrpGroupAssignmentsIndex,
+	)
+	return nil
+}
+
 // Collects all IP addresses from Netbox API and stores them to local inventory.
 func (nbi *NetboxInventory) initIPAddresses(ctx context.Context) error {
 	extraArgs := fmt.Sprintf(
@@ -942,7 +1581,7 @@ func (nbi *NetboxInventory) initIPAddresses(ctx context.Context) error {
 
 	// Initializes internal index
 	nbi.ipAddressesIndex = make(
-		map[constants.ContentType]map[string]map[string]map[string]*objects.IPAddress,
+		map[int]map[constants.ContentType]map[string]map[string]map[string]*objects.IPAddress,
 	)
 	for i := range ipAddresses {
 		ipAddr := &ipAddresses[i]
@@ -950,8 +1589,9 @@ func (nbi *NetboxInventory) initIPAddresses(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("get index values for ip address: %s", err)
 		}
-		nbi.verifyIPAddressIndexExists(ifaceType, ifaceName, ifaceParentName)
-		nbi.ipAddressesIndex[ifaceType][ifaceName][ifaceParentName][ipAddr.Address] = ipAddr
+		vrfID := getVRFIndexValue(ipAddr.VRF)
+		nbi.verifyIPAddressIndexExists(vrfID, ifaceType, ifaceName, ifaceParentName)
+		nbi.ipAddressesIndex[vrfID][ifaceType][ifaceName][ifaceParentName][ipAddr.Address] = ipAddr
 		nbi.OrphanManager.AddItem(ipAddr)
 	}
 
@@ -1008,19 +1648,54 @@ func (nbi *NetboxInventory) initPrefixes(ctx context.Context) error {
 		return err
 	}
 
-	// Initializes internal index of prefixes by prefix
-	nbi.prefixesIndexByPrefix = make(map[string]*objects.Prefix)
+	// Initializes internal index of prefixes by vrf id and prefix
+	nbi.prefixesIndexByVRFIDAndPrefix = make(map[int]map[string]*objects.Prefix)
 
 	for i := range prefixes {
 		prefix := &prefixes[i]
-		nbi.prefixesIndexByPrefix[prefix.Prefix] = prefix
+		vrfID := getVRFIndexValue(prefix.VRF)
+		if nbi.prefixesIndexByVRFIDAndPrefix[vrfID] == nil {
+			nbi.prefixesIndexByVRFIDAndPrefix[vrfID] = make(map[string]*objects.Prefix)
+		}
+		nbi.prefixesIndexByVRFIDAndPrefix[vrfID][prefix.Prefix] = prefix
 		nbi.OrphanManager.AddItem(prefix)
 	}
 
 	nbi.Logger.Debug(
 		ctx,
 		"Successfully collected prefixes from Netbox: ",
-		nbi.prefixesIndexByPrefix,
+		nbi.prefixesIndexByVRFIDAndPrefix,
+	)
+	return nil
+}
+
+// Collects all IP ranges from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initIPRanges(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.IPRange{}),
+	)
+	ipRanges, err := service.GetAll[objects.IPRange](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+
+	// Initializes internal index of ip ranges by vrf id and start address
+	nbi.ipRangesIndexByVRFIDAndStartAddress = make(map[int]map[string]*objects.IPRange)
+	for i := range ipRanges {
+		ipRange := &ipRanges[i]
+		vrfID := getVRFIndexValue(ipRange.VRF)
+		if nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID] == nil {
+			nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID] = make(map[string]*objects.IPRange)
+		}
+		nbi.ipRangesIndexByVRFIDAndStartAddress[vrfID][ipRange.StartAddress] = ipRange
+		nbi.OrphanManager.AddItem(ipRange)
+	}
+
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected IP ranges from Netbox: ",
+		nbi.ipRangesIndexByVRFIDAndStartAddress,
 	)
 	return nil
 }
@@ -1082,3 +1757,191 @@ func (nbi *NetboxInventory) initWirelessLANGroups(ctx context.Context) error {
 	)
 	return nil
 }
+
+// Collects all journal entries created by netbox-ssot from Netbox API
+// and stores them to local inventory.
+func (nbi *NetboxInventory) initJournalEntries(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&tag=%s&fields=%s",
+		constants.SsotTagName,
+		utils.ExtractJSONTagsFromStructIntoString(objects.JournalEntry{}),
+	)
+	nbJournalEntries, err := service.GetAll[objects.JournalEntry](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.journalEntriesIndex = make(
+		map[constants.ContentType]map[int]map[string]*objects.JournalEntry,
+	)
+	for i := range nbJournalEntries {
+		journalEntry := &nbJournalEntries[i]
+		objectType, objectID := journalEntry.AssignedObjectType, journalEntry.AssignedObjectID
+		nbi.verifyJournalEntryIndexExists(objectType, objectID)
+		nbi.journalEntriesIndex[objectType][objectID][journalEntry.Comments] = journalEntry
+		nbi.OrphanManager.AddItem(journalEntry)
+	}
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected journal entries from Netbox: ",
+		nbi.journalEntriesIndex,
+	)
+	return nil
+}
+
+// Collects all raw objects of configured raw object types from Netbox API
+// and stores them to local inventory.
+func (nbi *NetboxInventory) initRawObjects(ctx context.Context) error {
+	nbi.rawObjectTypes = make(map[constants.APIPath]parser.RawObjectType)
+	nbi.rawObjectsIndex = make(map[constants.APIPath]map[string]*objects.RawObject)
+	rawObjectPaths := make([]constants.APIPath, 0, len(nbi.NetboxConfig.RawObjectTypes))
+	for _, rawObjectType := range nbi.NetboxConfig.RawObjectTypes {
+		path := constants.APIPath(rawObjectType.Path)
+		nbRawObjects, err := service.GetAllFromPath[objects.RawObject](ctx, nbi.NetboxAPI, path, "")
+		if err != nil {
+			return fmt.Errorf("get raw objects from %s: %s", path, err)
+		}
+		nbi.rawObjectTypes[path] = rawObjectType
+		nbi.rawObjectsIndex[path] = make(map[string]*objects.RawObject)
+		for i := range nbRawObjects {
+			rawObject := &nbRawObjects[i]
+			rawObject.Path = path
+			rawObject.ObjectType = constants.ContentType(rawObjectType.ObjectType)
+			nbi.rawObjectsIndex[path][utils.RawObjectKey(rawObject.Fields, rawObjectType.NaturalKey)] = rawObject
+			nbi.OrphanManager.AddItem(rawObject)
+		}
+		rawObjectPaths = append(rawObjectPaths, path)
+	}
+	// Raw objects (e.g. objects of plugins) usually depend on built-in objects,
+	// so they are deleted first. Later configured types are deleted before earlier ones.
+	slices.Reverse(rawObjectPaths)
+	nbi.OrphanManager.PrependObjectPriorities(rawObjectPaths...)
+	nbi.Logger.Debug(ctx, "Successfully collected raw objects from Netbox: ", nbi.rawObjectsIndex)
+	return nil
+}
+
+// rawObjectContentTypes returns object types of all configured raw object types.
+func (nbi *NetboxInventory) rawObjectContentTypes() []constants.ContentType {
+	contentTypes := make([]constants.ContentType, 0, len(nbi.NetboxConfig.RawObjectTypes))
+	for _, rawObjectType := range nbi.NetboxConfig.RawObjectTypes {
+		contentTypes = append(contentTypes, constants.ContentType(rawObjectType.ObjectType))
+	}
+	return contentTypes
+}
+
+// Collects all L2VPNs from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initL2VPNs(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.L2VPN{}),
+	)
+	nbL2VPNs, err := service.GetAll[objects.L2VPN](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.l2vpnsIndexByName = make(map[string]*objects.L2VPN)
+	for i := range nbL2VPNs {
+		l2vpn := &nbL2VPNs[i]
+		nbi.l2vpnsIndexByName[l2vpn.Name] = l2vpn
+		nbi.OrphanManager.AddItem(l2vpn)
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected L2VPNs from Netbox: ", nbi.l2vpnsIndexByName)
+	return nil
+}
+
+// Collects all L2VPN terminations from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initL2VPNTerminations(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.L2VPNTermination{}),
+	)
+	nbTerminations, err := service.GetAll[objects.L2VPNTermination](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.l2vpnTerminationsIndex = make(map[constants.ContentType]map[int]*objects.L2VPNTermination)
+	for i := range nbTerminations {
+		termination := &nbTerminations[i]
+		if nbi.l2vpnTerminationsIndex[termination.AssignedObjectType] == nil {
+			nbi.l2vpnTerminationsIndex[termination.AssignedObjectType] = make(
+				map[int]*objects.L2VPNTermination,
+			)
+		}
+		nbi.l2vpnTerminationsIndex[termination.AssignedObjectType][termination.AssignedObjectID] = termination
+		nbi.OrphanManager.AddItem(termination)
+	}
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected L2VPN terminations from Netbox: ",
+		nbi.l2vpnTerminationsIndex,
+	)
+	return nil
+}
+
+// Collects all tunnel groups from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initTunnelGroups(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.TunnelGroup{}),
+	)
+	nbTunnelGroups, err := service.GetAll[objects.TunnelGroup](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.tunnelGroupsIndexByName = make(map[string]*objects.TunnelGroup)
+	for i := range nbTunnelGroups {
+		tunnelGroup := &nbTunnelGroups[i]
+		nbi.tunnelGroupsIndexByName[tunnelGroup.Name] = tunnelGroup
+		nbi.OrphanManager.AddItem(tunnelGroup)
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected tunnel groups from Netbox: ", nbi.tunnelGroupsIndexByName)
+	return nil
+}
+
+// Collects all tunnels from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initTunnels(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.Tunnel{}),
+	)
+	nbTunnels, err := service.GetAll[objects.Tunnel](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.tunnelsIndexByName = make(map[string]*objects.Tunnel)
+	for i := range nbTunnels {
+		tunnel := &nbTunnels[i]
+		nbi.tunnelsIndexByName[tunnel.Name] = tunnel
+		nbi.OrphanManager.AddItem(tunnel)
+	}
+	nbi.Logger.Debug(ctx, "Successfully collected tunnels from Netbox: ", nbi.tunnelsIndexByName)
+	return nil
+}
+
+// Collects all tunnel terminations from Netbox API and stores them to local inventory.
+func (nbi *NetboxInventory) initTunnelTerminations(ctx context.Context) error {
+	extraArgs := fmt.Sprintf(
+		"&fields=%s",
+		utils.ExtractJSONTagsFromStructIntoString(objects.TunnelTermination{}),
+	)
+	nbTerminations, err := service.GetAll[objects.TunnelTermination](ctx, nbi.NetboxAPI, extraArgs)
+	if err != nil {
+		return err
+	}
+	nbi.tunnelTerminationsIndex = make(map[constants.ContentType]map[int]*objects.TunnelTermination)
+	for i := range nbTerminations {
+		termination := &nbTerminations[i]
+		if nbi.tunnelTerminationsIndex[termination.TerminationType] == nil {
+			nbi.tunnelTerminationsIndex[termination.TerminationType] = make(
+				map[int]*objects.TunnelTermination,
+			)
+		}
+		nbi.tunnelTerminationsIndex[termination.TerminationType][termination.TerminationID] = termination
+		nbi.OrphanManager.AddItem(termination)
+	}
+	nbi.Logger.Debug(
+		ctx,
+		"Successfully collected tunnel terminations from Netbox: ",
+		nbi.tunnelTerminationsIndex,
+	)
+	return nil
+}
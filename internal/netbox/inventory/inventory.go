@@ -31,6 +31,8 @@ type NetboxInventory struct {
 	ArpDataLifeSpan int
 	// OrphanManager object that manages orphaned objects.
 	OrphanManager *OrphanManager
+	// Defaults are global defaults for objects, that netbox-ssot creates by default.
+	Defaults *parser.DefaultsConfig
 	// Tag used by netbox-ssot to mark devices that are managed by it.
 	SsotTag *objects.Tag
 	// Default context for the inventory, we use it to pass sourcename
@@ -73,6 +75,31 @@ type NetboxInventory struct {
 	siteGroupsIndexByName map[string]*objects.SiteGroup
 	siteGroupsLock        sync.Mutex
 
+	// locationsIndex is a map of all locations in the Netbox's inventory,
+	// indexed by their site id and name.
+	locationsIndex map[int]map[string]*objects.Location
+	locationsLock  sync.Mutex
+
+	// rackRolesIndexByName is a map of all rack roles in the Netbox's inventory,
+	// indexed by their name.
+	rackRolesIndexByName map[string]*objects.RackRole
+	rackRolesLock        sync.Mutex
+
+	// racksIndex is a map of all racks in the Netbox's inventory,
+	// indexed by their site id and name.
+	racksIndex map[int]map[string]*objects.Rack
+	racksLock  sync.Mutex
+
+	// powerPanelsIndex is a map of all power panels in the Netbox's inventory,
+	// indexed by their site id and name.
+	powerPanelsIndex map[int]map[string]*objects.PowerPanel
+	powerPanelsLock  sync.Mutex
+
+	// powerFeedsIndex is a map of all power feeds in the Netbox's inventory,
+	// indexed by their power panel id and name.
+	powerFeedsIndex map[int]map[string]*objects.PowerFeed
+	powerFeedsLock  sync.Mutex
+
 	// manufacturersIndexByName is a map of all manufacturers in the Netbox's inventory,
 	// indexed by their name
 	manufacturersIndexByName map[string]*objects.Manufacturer
@@ -105,10 +132,128 @@ type NetboxInventory struct {
 	virtualDeviceContextsIndex map[string]map[int]*objects.VirtualDeviceContext
 	virtualDeviceContextsLock  sync.Mutex
 
-	// prefixesIndexByPrefix is a map of all prefixes in the Netbox's inventory,
+	// moduleTypesIndexByManufacturerIDAndModel is a map of all module types in the Netbox's
+	// inventory, indexed by their manufacturer id and their model.
+	moduleTypesIndexByManufacturerIDAndModel map[int]map[string]*objects.ModuleType
+	moduleTypesLock                          sync.Mutex
+
+	// moduleBaysIndexByDeviceIDAndName is a map of all module bays in the Netbox's
+	// inventory, indexed by their device id and their name.
+	moduleBaysIndexByDeviceIDAndName map[int]map[string]*objects.ModuleBay
+	moduleBaysLock                   sync.Mutex
+
+	// modulesIndexByModuleBayID is a map of all modules in the Netbox's inventory,
+	// indexed by the id of the module bay they are installed in.
+	modulesIndexByModuleBayID map[int]*objects.Module
+	modulesLock               sync.Mutex
+
+	// inventoryItemsIndexByDeviceIDAndName is a map of all inventory items in the
+	// Netbox's inventory, indexed by their device id and their name.
+	inventoryItemsIndexByDeviceIDAndName map[int]map[string]*objects.InventoryItem
+	inventoryItemsLock                   sync.Mutex
+
+	// powerPortsIndexByDeviceIDAndName is a map of all power ports in the
+	// Netbox's inventory, indexed by their device id and their name.
+	powerPortsIndexByDeviceIDAndName map[int]map[string]*objects.PowerPort
+	powerPortsLock                   sync.Mutex
+
+	// consolePortsIndexByDeviceIDAndName is a map of all console ports in the
+	// Netbox's inventory, indexed by their device id and their name.
+	consolePortsIndexByDeviceIDAndName map[int]map[string]*objects.ConsolePort
+	consolePortsLock                   sync.Mutex
+
+	// routeTargetsIndexByName is a map of all route targets in the Netbox's
+	// inventory, indexed by their name.
+	routeTargetsIndexByName map[string]*objects.RouteTarget
+	routeTargetsLock        sync.Mutex
+
+	// vrfsIndexByName is a map of all VRFs in the Netbox's inventory,
+	// indexed by their name.
+	vrfsIndexByName map[string]*objects.VRF
+	vrfsLock        sync.Mutex
+
+	// prefixesIndexByVRFIDAndPrefix is a map of all prefixes in the Netbox's
+	// inventory, indexed by the id of their VRF (0 for the global table)
+	// and their prefix.
+	prefixesIndexByVRFIDAndPrefix map[int]map[string]*objects.Prefix
+	prefixesLock                  sync.Mutex
+
+	// ipRangesIndexByVRFIDAndStartAddress is a map of all ip ranges in the Netbox's
+	// inventory, indexed by the id of their VRF (0 for the global table)
+	// and their start address.
+	ipRangesIndexByVRFIDAndStartAddress map[int]map[string]*objects.IPRange
+	ipRangesLock                        sync.Mutex
+
+	// fhrpGroupsIndexByName is a map of all FHRP groups in the Netbox's inventory,
+	// indexed by their name.
+	fhrpGroupsIndexByName map[string]*objects.FHRPGroup
+	// fhrpGroupsIndexByID is a map of all FHRP groups in the Netbox's inventory,
+	// indexed by their id.
+	fhrpGroupsIndexByID map[int]*objects.FHRPGroup
+	fhrpGroupsLock      sync.Mutex
+
+	// fhrpGroupAssignmentsIndex is a map of all FHRP group assignments in the Netbox's inventory,
+	// indexed by their group id, interface type and interface id.
+	fhrpGroupAssignmentsIndex map[int]map[constants.ContentType]map[int]*objects.FHRPGroupAssignment
+	fhrpGroupAssignmentsLock  sync.Mutex
+
+	// servicesIndex is a map of all services in the Netbox's inventory,
+	// indexed by their parent type (device or virtual machine), parent id, protocol and name.
+	servicesIndex map[constants.ContentType]map[int]map[string]map[string]*objects.Service
+	servicesLock  sync.Mutex
+
+	// rirsIndexByName is a map of all RIRs in the Netbox's inventory,
+	// indexed by their name.
+	rirsIndexByName map[string]*objects.RIR
+	rirsLock        sync.Mutex
+
+	// asnsIndexByASN is a map of all ASNs in the Netbox's inventory,
+	// indexed by their autonomous system number.
+	asnsIndexByASN map[int64]*objects.ASN
+	asnsLock       sync.Mutex
+
+	// aggregatesIndexByPrefix is a map of all aggregates in the Netbox's inventory,
 	// indexed by their prefix.
-	prefixesIndexByPrefix map[string]*objects.Prefix
-	prefixesLock          sync.Mutex
+	aggregatesIndexByPrefix map[string]*objects.Aggregate
+	aggregatesLock          sync.Mutex
+
+	// l2vpnsIndexByName is a map of all L2VPNs in the Netbox's inventory,
+	// indexed by their name.
+	l2vpnsIndexByName map[string]*objects.L2VPN
+	l2vpnsLock        sync.Mutex
+
+	// l2vpnTerminationsIndex is a map of all L2VPN terminations in the Netbox's inventory,
+	// indexed by their assigned object type and assigned object id.
+	l2vpnTerminationsIndex map[constants.ContentType]map[int]*objects.L2VPNTermination
+	l2vpnTerminationsLock  sync.Mutex
+
+	// tunnelGroupsIndexByName is a map of all tunnel groups in the Netbox's inventory,
+	// indexed by their name.
+	tunnelGroupsIndexByName map[string]*objects.TunnelGroup
+	tunnelGroupsLock        sync.Mutex
+
+	// tunnelsIndexByName is a map of all tunnels in the Netbox's inventory,
+	// indexed by their name.
+	tunnelsIndexByName map[string]*objects.Tunnel
+	tunnelsLock        sync.Mutex
+
+	// tunnelTerminationsIndex is a map of all tunnel terminations in the Netbox's inventory,
+	// indexed by their termination type and termination id.
+	tunnelTerminationsIndex map[constants.ContentType]map[int]*objects.TunnelTermination
+	tunnelTerminationsLock  sync.Mutex
+
+	// journalEntriesIndex is a map of all journal entries created by netbox-ssot,
+	// indexed by their assigned object type, assigned object id and comments.
+	journalEntriesIndex map[constants.ContentType]map[int]map[string]*objects.JournalEntry
+	journalEntriesLock  sync.Mutex
+
+	// rawObjectTypes is a map of raw object types configured in netbox config,
+	// indexed by their api path.
+	rawObjectTypes map[constants.APIPath]parser.RawObjectType
+	// rawObjectsIndex is a map of all raw objects in the Netbox's inventory,
+	// indexed by their api path and their natural key.
+	rawObjectsIndex map[constants.APIPath]map[string]*objects.RawObject
+	rawObjectsLock  sync.Mutex
 
 	// vlanGroupsIndexByName is a map of all VlanGroups in the Netbox's inventory,
 	// indexed by their name.
@@ -171,11 +316,12 @@ type NetboxInventory struct {
 
 	// ipAdressesIndex is a map of all IP addresses in the inventory,
 	// indexed:
+	//   * vrf id (0 for the global table)
 	//   * iface type (vmiface or device iface)
 	//   * iface name (name of the vminterface/deviceinterface)
 	//   * iface parent name (name of the vm/device that the interface belongs to)
 	//   * ip address
-	ipAddressesIndex map[constants.ContentType]map[string]map[string]map[string]*objects.IPAddress
+	ipAddressesIndex map[int]map[constants.ContentType]map[string]map[string]map[string]*objects.IPAddress
 	ipAddressesLock  sync.Mutex
 
 	// macAddressesIndex is a map of all MAC addresses in the inventory,
@@ -214,6 +360,7 @@ func NewNetboxInventory(
 	ctx context.Context,
 	logger *logger.Logger,
 	nbConfig *parser.NetboxConfig,
+	defaults *parser.DefaultsConfig,
 ) *NetboxInventory {
 	sourcePriority := make(map[string]int, len(nbConfig.SourcePriority))
 	for i, sourceName := range nbConfig.SourcePriority {
@@ -227,6 +374,7 @@ func NewNetboxInventory(
 		NetboxConfig:   nbConfig,
 		SourcePriority: sourcePriority,
 		OrphanManager:  orphanManager,
+		Defaults:       defaults,
 	}
 	return nbi
 }
@@ -273,16 +421,31 @@ func (nbi *NetboxInventory) Init() error {
 		nbi.initSiteGroups,
 		nbi.initSites,
 		nbi.initDefaultSite,
+		nbi.initLocations,
+		nbi.initRackRoles,
+		nbi.initRacks,
+		nbi.initPowerPanels,
+		nbi.initPowerFeeds,
 		nbi.initManufacturers,
 		nbi.initPlatforms,
 		nbi.initVMs,
 		nbi.initVMInterfaces,
 		nbi.initDevices,
 		nbi.initInterfaces,
+		nbi.initRouteTargets,
+		nbi.initVRFs,
+		nbi.initFHRPGroups,
+		nbi.initFHRPGroupAssignments,
 		nbi.initIPAddresses,
 		nbi.initMACAddresses,
 		nbi.initVlanGroups,
 		nbi.initPrefixes,
+		nbi.initIPRanges,
+		nbi.initServices,
+		nbi.initRIRs,
+		nbi.initASNs,
+		nbi.initAggregates,
+		nbi.initConfiguredAggregates,
 		nbi.initVlans,
 		nbi.initDeviceRoles,
 		nbi.initDeviceTypes,
@@ -290,8 +453,21 @@ func (nbi *NetboxInventory) Init() error {
 		nbi.initClusterTypes,
 		nbi.initClusters,
 		nbi.initVirtualDeviceContexts,
+		nbi.initModuleTypes,
+		nbi.initModuleBays,
+		nbi.initModules,
+		nbi.initInventoryItems,
+		nbi.initPowerPorts,
+		nbi.initConsolePorts,
 		nbi.initWirelessLANs,
 		nbi.initWirelessLANGroups,
+		nbi.initL2VPNs,
+		nbi.initL2VPNTerminations,
+		nbi.initTunnelGroups,
+		nbi.initTunnels,
+		nbi.initTunnelTerminations,
+		nbi.initJournalEntries,
+		nbi.initRawObjects,
 	}
 	for _, initFunc := range initFunctions {
 		startTime := time.Now()
@@ -31,6 +31,7 @@ func TestNewNetboxInventory(t *testing.T) {
 		ctx      context.Context
 		logger   *logger.Logger
 		nbConfig *parser.NetboxConfig
+		defaults *parser.DefaultsConfig
 	}
 	tests := []struct {
 		name string
@@ -41,7 +42,9 @@ func TestNewNetboxInventory(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewNetboxInventory(tt.args.ctx, tt.args.logger, tt.args.nbConfig); !reflect.DeepEqual(
+			if got := NewNetboxInventory(
+				tt.args.ctx, tt.args.logger, tt.args.nbConfig, tt.args.defaults,
+			); !reflect.DeepEqual(
 				got,
 				tt.want,
 			) {
@@ -2,6 +2,7 @@ package inventory
 
 import (
 	"context"
+	"slices"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/logger"
@@ -42,27 +43,47 @@ type OrphanManager struct {
 func NewOrphanManager(logger *logger.Logger) *OrphanManager {
 	// Starts with 0 for easier integration with for loops
 	orphanObjectPriority := map[int]constants.APIPath{
-		0:  constants.VlanGroupsAPIPath,
-		1:  constants.PrefixesAPIPath,
-		2:  constants.VlansAPIPath,
-		3:  constants.IPAddressesAPIPath,
-		4:  constants.VirtualDeviceContextsAPIPath,
-		5:  constants.InterfacesAPIPath,
-		6:  constants.VMInterfacesAPIPath,
-		7:  constants.VirtualMachinesAPIPath,
-		8:  constants.DevicesAPIPath,
-		9:  constants.PlatformsAPIPath,
-		10: constants.DeviceTypesAPIPath,
-		11: constants.ManufacturersAPIPath,
-		12: constants.DeviceRolesAPIPath,
-		13: constants.ClustersAPIPath,
-		14: constants.ClusterTypesAPIPath,
-		15: constants.ClusterGroupsAPIPath,
-		16: constants.ContactAssignmentsAPIPath,
-		17: constants.ContactsAPIPath,
-		18: constants.WirelessLANsAPIPath,
-		19: constants.WirelessLANGroupsAPIPath,
-		20: constants.MACAddressesAPIPath,
+		0:  constants.JournalEntriesAPIPath,
+		1:  constants.ServicesAPIPath,
+		2:  constants.L2VPNTerminationsAPIPath,
+		3:  constants.L2VPNsAPIPath,
+		4:  constants.TunnelTerminationsAPIPath,
+		5:  constants.TunnelsAPIPath,
+		6:  constants.TunnelGroupsAPIPath,
+		7:  constants.VlanGroupsAPIPath,
+		8:  constants.PrefixesAPIPath,
+		9:  constants.AggregatesAPIPath,
+		10: constants.VlansAPIPath,
+		11: constants.IPAddressesAPIPath,
+		12: constants.IPRangesAPIPath,
+		13: constants.FHRPGroupAssignmentsAPIPath,
+		14: constants.FHRPGroupsAPIPath,
+		15: constants.VirtualDeviceContextsAPIPath,
+		16: constants.InventoryItemsAPIPath,
+		17: constants.ModulesAPIPath,
+		18: constants.ModuleBaysAPIPath,
+		19: constants.PowerPortsAPIPath,
+		20: constants.ConsolePortsAPIPath,
+		21: constants.InterfacesAPIPath,
+		22: constants.VMInterfacesAPIPath,
+		23: constants.VRFsAPIPath,
+		24: constants.RouteTargetsAPIPath,
+		25: constants.VirtualMachinesAPIPath,
+		26: constants.DevicesAPIPath,
+		27: constants.PlatformsAPIPath,
+		28: constants.DeviceTypesAPIPath,
+		29: constants.ModuleTypesAPIPath,
+		30: constants.ManufacturersAPIPath,
+		31: constants.DeviceRolesAPIPath,
+		32: constants.ClustersAPIPath,
+		33: constants.ClusterTypesAPIPath,
+		34: constants.ClusterGroupsAPIPath,
+		35: constants.ContactAssignmentsAPIPath,
+		36: constants.ContactsAPIPath,
+		37: constants.WirelessLANsAPIPath,
+		38: constants.WirelessLANGroupsAPIPath,
+		39: constants.MACAddressesAPIPath,
+		40: constants.ASNsAPIPath,
 	}
 	orphanCtx := context.WithValue(context.Background(), constants.CtxSourceKey, "orphanManager")
 
@@ -74,6 +95,22 @@ func NewOrphanManager(logger *logger.Logger) *OrphanManager {
 	}
 }
 
+// PrependObjectPriorities adds api paths to the beginning of the orphan object
+// priority, so orphans on these paths are deleted before all others.
+func (orphanManager *OrphanManager) PrependObjectPriorities(apiPaths ...constants.APIPath) {
+	if len(apiPaths) == 0 {
+		return
+	}
+	priority := make(map[int]constants.APIPath, len(orphanManager.OrphanObjectPriority)+len(apiPaths))
+	for i, apiPath := range apiPaths {
+		priority[i] = apiPath
+	}
+	for i := 0; i < len(orphanManager.OrphanObjectPriority); i++ {
+		priority[len(apiPaths)+i] = orphanManager.OrphanObjectPriority[i]
+	}
+	orphanManager.OrphanObjectPriority = priority
+}
+
 func (orphanManager *OrphanManager) AddItem(orphanItem objects.OrphanItem) {
 	// Manage only objects created with netbox-ssot tag
 	netboxObject := orphanItem.GetNetboxObject()
@@ -88,3 +125,23 @@ func (orphanManager *OrphanManager) AddItem(orphanItem objects.OrphanItem) {
 func (orphanManager *OrphanManager) RemoveItem(obj objects.OrphanItem) {
 	delete(orphanManager.Items[obj.GetAPIPath()], obj.GetID())
 }
+
+// IsOrphan returns true, if the object was created by netbox-ssot, but it
+// hasn't been added or confirmed by any of the sources in this run (yet).
+func (orphanManager *OrphanManager) IsOrphan(obj objects.OrphanItem) bool {
+	_, ok := orphanManager.Items[obj.GetAPIPath()][obj.GetID()]
+	return ok
+}
+
+// RemoveItemsOfSources removes items, that were last synced by any of the given sources,
+// so they are not handled as orphans (e.g. because the sources were not synced in this run).
+func (orphanManager *OrphanManager) RemoveItemsOfSources(sourceNames []string) {
+	for _, items := range orphanManager.Items {
+		for id, item := range items {
+			sourceName, ok := item.GetNetboxObject().GetCustomField(constants.CustomFieldSourceName).(string)
+			if ok && slices.Contains(sourceNames, sourceName) {
+				delete(items, id)
+			}
+		}
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/logger"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
 )
 
 func TestNewOrphanManager(t *testing.T) {
@@ -26,3 +28,65 @@ func TestNewOrphanManager(t *testing.T) {
 		})
 	}
 }
+
+func TestOrphanManager_PrependObjectPriorities(t *testing.T) {
+	orphanManager := &OrphanManager{
+		OrphanObjectPriority: map[int]constants.APIPath{
+			0: constants.VlansAPIPath,
+			1: constants.SitesAPIPath,
+		},
+	}
+	orphanManager.PrependObjectPriorities("/api/plugins/bgp/session/", "/api/plugins/bgp/community/")
+	want := map[int]constants.APIPath{
+		0: "/api/plugins/bgp/session/",
+		1: "/api/plugins/bgp/community/",
+		2: constants.VlansAPIPath,
+		3: constants.SitesAPIPath,
+	}
+	if !reflect.DeepEqual(orphanManager.OrphanObjectPriority, want) {
+		t.Errorf("PrependObjectPriorities() = %v, want %v", orphanManager.OrphanObjectPriority, want)
+	}
+}
+
+func TestOrphanManager_RemoveItemsOfSources(t *testing.T) {
+	newDevice := func(id int, sourceName string) *objects.Device {
+		return &objects.Device{NetboxObject: objects.NetboxObject{
+			ID:           id,
+			CustomFields: map[string]interface{}{constants.CustomFieldSourceName: sourceName},
+		}}
+	}
+	orphanManager := &OrphanManager{
+		Items: map[constants.APIPath]map[int]objects.OrphanItem{
+			constants.DevicesAPIPath: {
+				1: newDevice(1, "vcenter"),
+				2: newDevice(2, "paloalto"),
+				3: newDevice(3, "dnac"),
+			},
+		},
+	}
+	orphanManager.RemoveItemsOfSources([]string{"paloalto", "dnac"})
+	want := map[int]objects.OrphanItem{1: newDevice(1, "vcenter")}
+	if !reflect.DeepEqual(orphanManager.Items[constants.DevicesAPIPath], want) {
+		t.Errorf("RemoveItemsOfSources() items = %v, want %v", orphanManager.Items[constants.DevicesAPIPath], want)
+	}
+}
+
+func TestOrphanManager_IsOrphan(t *testing.T) {
+	orphanIP := &objects.IPAddress{NetboxObject: objects.NetboxObject{ID: 1}, Address: "10.0.0.1/24"}
+	confirmedIP := &objects.IPAddress{NetboxObject: objects.NetboxObject{ID: 2}, Address: "10.0.0.2/24"}
+	orphanManager := &OrphanManager{
+		Items: map[constants.APIPath]map[int]objects.OrphanItem{
+			constants.IPAddressesAPIPath: {1: orphanIP, 2: confirmedIP},
+		},
+	}
+	orphanManager.RemoveItem(confirmedIP)
+	if !orphanManager.IsOrphan(orphanIP) {
+		t.Errorf("IsOrphan(%s) = false, want true", orphanIP.Address)
+	}
+	if orphanManager.IsOrphan(confirmedIP) {
+		t.Errorf("IsOrphan(%s) = true, want false", confirmedIP.Address)
+	}
+	if orphanManager.IsOrphan(&objects.Prefix{NetboxObject: objects.NetboxObject{ID: 1}}) {
+		t.Errorf("IsOrphan() = true for object with different api path, want false")
+	}
+}
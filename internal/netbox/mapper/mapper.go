@@ -32,6 +32,13 @@ var Type2Path = map[reflect.Type]constants.APIPath{
 	reflect.TypeOf((*objects.Interface)(nil)).Elem():            constants.InterfacesAPIPath,
 	reflect.TypeOf((*objects.Site)(nil)).Elem():                 constants.SitesAPIPath,
 	reflect.TypeOf((*objects.SiteGroup)(nil)).Elem():            constants.SiteGroupsAPIPath,
+	reflect.TypeOf((*objects.Location)(nil)).Elem():             constants.LocationsAPIPath,
+	reflect.TypeOf((*objects.Rack)(nil)).Elem():                 constants.RacksAPIPath,
+	reflect.TypeOf((*objects.RackRole)(nil)).Elem():             constants.RackRolesAPIPath,
+	reflect.TypeOf((*objects.PowerPanel)(nil)).Elem():           constants.PowerPanelsAPIPath,
+	reflect.TypeOf((*objects.PowerFeed)(nil)).Elem():            constants.PowerFeedsAPIPath,
+	reflect.TypeOf((*objects.PowerPort)(nil)).Elem():            constants.PowerPortsAPIPath,
+	reflect.TypeOf((*objects.ConsolePort)(nil)).Elem():          constants.ConsolePortsAPIPath,
 	reflect.TypeOf((*objects.Manufacturer)(nil)).Elem():         constants.ManufacturersAPIPath,
 	reflect.TypeOf((*objects.Platform)(nil)).Elem():             constants.PlatformsAPIPath,
 	reflect.TypeOf((*objects.Tenant)(nil)).Elem():               constants.TenantsAPIPath,
@@ -44,6 +51,25 @@ var Type2Path = map[reflect.Type]constants.APIPath{
 	reflect.TypeOf((*objects.Prefix)(nil)).Elem():               constants.PrefixesAPIPath,
 	reflect.TypeOf((*objects.WirelessLAN)(nil)).Elem():          constants.WirelessLANsAPIPath,
 	reflect.TypeOf((*objects.WirelessLANGroup)(nil)).Elem():     constants.WirelessLANGroupsAPIPath,
+	reflect.TypeOf((*objects.ModuleType)(nil)).Elem():           constants.ModuleTypesAPIPath,
+	reflect.TypeOf((*objects.ModuleBay)(nil)).Elem():            constants.ModuleBaysAPIPath,
+	reflect.TypeOf((*objects.Module)(nil)).Elem():               constants.ModulesAPIPath,
+	reflect.TypeOf((*objects.InventoryItem)(nil)).Elem():        constants.InventoryItemsAPIPath,
+	reflect.TypeOf((*objects.L2VPN)(nil)).Elem():                constants.L2VPNsAPIPath,
+	reflect.TypeOf((*objects.L2VPNTermination)(nil)).Elem():     constants.L2VPNTerminationsAPIPath,
+	reflect.TypeOf((*objects.Tunnel)(nil)).Elem():               constants.TunnelsAPIPath,
+	reflect.TypeOf((*objects.TunnelGroup)(nil)).Elem():          constants.TunnelGroupsAPIPath,
+	reflect.TypeOf((*objects.TunnelTermination)(nil)).Elem():    constants.TunnelTerminationsAPIPath,
+	reflect.TypeOf((*objects.VRF)(nil)).Elem():                  constants.VRFsAPIPath,
+	reflect.TypeOf((*objects.RouteTarget)(nil)).Elem():          constants.RouteTargetsAPIPath,
+	reflect.TypeOf((*objects.IPRange)(nil)).Elem():              constants.IPRangesAPIPath,
+	reflect.TypeOf((*objects.FHRPGroup)(nil)).Elem():            constants.FHRPGroupsAPIPath,
+	reflect.TypeOf((*objects.FHRPGroupAssignment)(nil)).Elem():  constants.FHRPGroupAssignmentsAPIPath,
+	reflect.TypeOf((*objects.Service)(nil)).Elem():              constants.ServicesAPIPath,
+	reflect.TypeOf((*objects.RIR)(nil)).Elem():                  constants.RIRsAPIPath,
+	reflect.TypeOf((*objects.ASN)(nil)).Elem():                  constants.ASNsAPIPath,
+	reflect.TypeOf((*objects.Aggregate)(nil)).Elem():            constants.AggregatesAPIPath,
+	reflect.TypeOf((*objects.JournalEntry)(nil)).Elem():         constants.JournalEntriesAPIPath,
 }
 
 var Path2Type = reverseMap(Type2Path)
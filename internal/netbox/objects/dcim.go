@@ -42,6 +42,9 @@ type Site struct {
 	Latitude float64 `json:"latitude,omitempty"`
 	// Longitude of the site.
 	Longitude float64 `json:"longitude,omitempty"`
+
+	// ASNs assigned to the site.
+	ASNs []*ASN `json:"asns,omitempty"`
 }
 
 func (s Site) String() string {
@@ -159,13 +162,13 @@ func (r *Region) GetAPIPath() constants.APIPath {
 type Location struct {
 	NetboxObject
 	// Site is the site to which the location belongs. This field is required.
-	Site *Site
+	Site *Site `json:"site,omitempty"`
 	// Name is the name of the location. This field is required.
-	Name string
+	Name string `json:"name,omitempty"`
 	// URL-friendly unique shorthand. This field is required.
-	Slug string
+	Slug string `json:"slug,omitempty"`
 	// Status is the status of the location. This field is required.
-	Status *SiteStatus
+	Status *SiteStatus `json:"status,omitempty"`
 }
 
 func (l Location) String() string {
@@ -188,6 +191,167 @@ func (l *Location) GetNetboxObject() *NetboxObject {
 	return &l.NetboxObject
 }
 
+// RackRole represents the functional role of a rack.
+type RackRole struct {
+	NetboxObject
+	// Name is the name of the rack role. This field is required.
+	Name string `json:"name,omitempty"`
+	// URL-friendly unique shorthand. This field is required.
+	Slug string `json:"slug,omitempty"`
+	// Color of the rack role.
+	Color constants.Color `json:"color,omitempty"`
+}
+
+func (rr RackRole) String() string {
+	return fmt.Sprintf("RackRole{Name: %s}", rr.Name)
+}
+
+// RackRole implements IDItem interface.
+func (rr *RackRole) GetID() int {
+	return rr.ID
+}
+func (rr *RackRole) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimRackRole
+}
+func (rr *RackRole) GetAPIPath() constants.APIPath {
+	return constants.RackRolesAPIPath
+}
+
+type RackStatus struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v4.2.0/netbox/dcim/choices.py#L102
+var (
+	RackStatusReserved   = RackStatus{Choice{Value: "reserved", Label: "Reserved"}}
+	RackStatusAvailable  = RackStatus{Choice{Value: "available", Label: "Available"}}
+	RackStatusPlanned    = RackStatus{Choice{Value: "planned", Label: "Planned"}}
+	RackStatusActive     = RackStatus{Choice{Value: "active", Label: "Active"}}
+	RackStatusDeprecated = RackStatus{Choice{Value: "deprecated", Label: "Deprecated"}}
+)
+
+// Rack represents a physical rack, that devices are mounted in.
+type Rack struct {
+	NetboxObject
+	// Name is the name of the rack. This field is required.
+	Name string `json:"name,omitempty"`
+	// Site is the site to which the rack belongs. This field is required.
+	Site *Site `json:"site,omitempty"`
+	// Location is the location (e.g. floor, room) of the rack within the site.
+	Location *Location `json:"location,omitempty"`
+	// Status is the status of the rack. This field is required.
+	Status *RackStatus `json:"status,omitempty"`
+	// Role is the functional role of the rack.
+	Role *RackRole `json:"role,omitempty"`
+	// Tenant of the rack.
+	Tenant *Tenant `json:"tenant,omitempty"`
+}
+
+func (r Rack) String() string {
+	return fmt.Sprintf("Rack{Name: %s, Site: %s}", r.Name, r.Site)
+}
+
+// Rack implements IDItem interface.
+func (r *Rack) GetID() int {
+	return r.ID
+}
+func (r *Rack) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimRack
+}
+func (r *Rack) GetAPIPath() constants.APIPath {
+	return constants.RacksAPIPath
+}
+
+// PowerPanel represents an electrical panel, which distributes power to power feeds.
+type PowerPanel struct {
+	NetboxObject
+	// Name is the name of the power panel. This field is required.
+	Name string `json:"name,omitempty"`
+	// Site is the site to which the power panel belongs. This field is required.
+	Site *Site `json:"site,omitempty"`
+	// Location is the location (e.g. floor, room) of the power panel within the site.
+	Location *Location `json:"location,omitempty"`
+}
+
+func (pp PowerPanel) String() string {
+	return fmt.Sprintf("PowerPanel{Name: %s, Site: %s}", pp.Name, pp.Site)
+}
+
+// PowerPanel implements IDItem interface.
+func (pp *PowerPanel) GetID() int {
+	return pp.ID
+}
+func (pp *PowerPanel) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimPowerPanel
+}
+func (pp *PowerPanel) GetAPIPath() constants.APIPath {
+	return constants.PowerPanelsAPIPath
+}
+
+type PowerFeedStatus struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/main/netbox/dcim/choices.py
+var (
+	PowerFeedStatusOffline = PowerFeedStatus{Choice{Value: "offline", Label: "Offline"}}
+	PowerFeedStatusActive  = PowerFeedStatus{Choice{Value: "active", Label: "Active"}}
+	PowerFeedStatusPlanned = PowerFeedStatus{Choice{Value: "planned", Label: "Planned"}}
+	PowerFeedStatusFailed  = PowerFeedStatus{Choice{Value: "failed", Label: "Failed"}}
+)
+
+type PowerFeedType struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/main/netbox/dcim/choices.py
+var (
+	PowerFeedTypePrimary   = PowerFeedType{Choice{Value: "primary", Label: "Primary"}}
+	PowerFeedTypeRedundant = PowerFeedType{Choice{Value: "redundant", Label: "Redundant"}}
+)
+
+// PowerFeed represents a power circuit from the power panel to the rack.
+type PowerFeed struct {
+	NetboxObject
+	// Name is the name of the power feed. This field is required.
+	Name string `json:"name,omitempty"`
+	// PowerPanel is the power panel, the feed originates from. This field is required.
+	PowerPanel *PowerPanel `json:"power_panel,omitempty"`
+	// Rack is the rack, that the feed supplies.
+	Rack *Rack `json:"rack,omitempty"`
+	// Status is the status of the power feed. This field is required.
+	Status *PowerFeedStatus `json:"status,omitempty"`
+	// Type is the type of the power feed (primary or redundant). This field is required.
+	Type *PowerFeedType `json:"type,omitempty"`
+	// Voltage of the power feed. If not set, netbox default is used.
+	Voltage int `json:"voltage,omitempty"`
+	// Amperage of the power feed. If not set, netbox default is used.
+	Amperage int `json:"amperage,omitempty"`
+	// MaxUtilization is maximum permissible draw in percents. If not set, netbox default is used.
+	MaxUtilization int `json:"max_utilization,omitempty"`
+	// Tenant of the power feed.
+	Tenant *Tenant `json:"tenant,omitempty"`
+}
+
+func (pf PowerFeed) String() string {
+	panelName := ""
+	if pf.PowerPanel != nil {
+		panelName = pf.PowerPanel.Name
+	}
+	return fmt.Sprintf("PowerFeed{Name: %s, PowerPanel: %s}", pf.Name, panelName)
+}
+
+// PowerFeed implements IDItem interface.
+func (pf *PowerFeed) GetID() int {
+	return pf.ID
+}
+func (pf *PowerFeed) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimPowerFeed
+}
+func (pf *PowerFeed) GetAPIPath() constants.APIPath {
+	return constants.PowerFeedsAPIPath
+}
+
 // Manufacturer represents a hardware manufacturer (e.g. Cisco, HP, ...).
 type Manufacturer struct {
 	NetboxObject
@@ -299,6 +463,21 @@ var (
 	Mixed       = DeviceAirFlowType{Choice{Value: "mixed", Label: "Mixed"}}
 )
 
+type DeviceFace struct {
+	Choice
+}
+
+var (
+	DeviceFaceFront = DeviceFace{Choice{Value: "front", Label: "Front"}}
+	DeviceFaceRear  = DeviceFace{Choice{Value: "rear", Label: "Rear"}}
+)
+
+// DeviceFaces maps face value to DeviceFace.
+var DeviceFaces = map[string]*DeviceFace{
+	DeviceFaceFront.Value: &DeviceFaceFront,
+	DeviceFaceRear.Value:  &DeviceFaceRear,
+}
+
 type DeviceStatus struct {
 	Choice
 }
@@ -340,6 +519,12 @@ type Device struct {
 	Site *Site `json:"site,omitempty"`
 	// Location is the location of the device.
 	Location *Location `json:"location,omitempty"`
+	// Rack is the rack, that the device is mounted in.
+	Rack *Rack `json:"rack,omitempty"`
+	// Position is the lowest rack unit occupied by the device.
+	Position float64 `json:"position,omitempty"`
+	// Face is the rack face, that the device is mounted on.
+	Face *DeviceFace `json:"face,omitempty"`
 
 	// Management
 	// Status of the device (e.g. active, offline, planned, etc.). This field is required.
@@ -351,6 +536,8 @@ type Device struct {
 	PrimaryIPv4 *IPAddress `json:"primary_ip4,omitempty"`
 	// PrimaryIPv6 is the primary IPv6 address assigned to the server.
 	PrimaryIPv6 *IPAddress `json:"primary_ip6,omitempty"`
+	// OOBIP is the out-of-band management ip address of the device (e.g. ip of iDRAC or mgmt0).
+	OOBIP *IPAddress `json:"oob_ip,omitempty"`
 
 	// Virtualization
 	// Cluster is the cluster to which the device belongs. (e.g. VMWare server belonging to a specific cluster).
@@ -369,6 +556,10 @@ type Device struct {
 	// Priority
 	// Additional comments.
 	Comments string `json:"comments,omitempty"`
+
+	// LocalContextData is device specific configuration data (e.g. dns, ntp and syslog servers),
+	// which takes precedence over config contexts.
+	LocalContextData map[string]interface{} `json:"local_context_data,omitempty"`
 }
 
 func (d Device) String() string {
@@ -671,6 +862,8 @@ type Interface struct {
 	LAG *Interface `json:"lag,omitempty"`
 	// MTU is the maximum transmission unit (MTU) configured for the interface.
 	MTU int `json:"mtu,omitempty"`
+	// MgmtOnly is true, when the interface is used only for out-of-band management.
+	MgmtOnly bool `json:"mgmt_only,omitempty"`
 	// PrimaryMACAddress is the primary MAC address of the interface.
 	PrimaryMACAddress *MACAddress `json:"primary_mac_address,omitempty"`
 
@@ -685,6 +878,8 @@ type Interface struct {
 	UntaggedVlan *Vlan `json:"untagged_vlan,omitempty"`
 	// VirtualDeviceContexts
 	Vdcs []*VirtualDeviceContext `json:"vdcs,omitempty"`
+	// VRF is the virtual routing and forwarding instance the interface is assigned to.
+	VRF *VRF `json:"vrf,omitempty"`
 }
 
 func (i Interface) String() string {
@@ -810,3 +1005,256 @@ func (m *MACAddress) GetAPIPath() constants.APIPath {
 func (m *MACAddress) GetNetboxObject() *NetboxObject {
 	return &m.NetboxObject
 }
+
+// ModuleType represents a type of hardware module (e.g. line card, supervisor),
+// that can be installed into a device's module bay.
+type ModuleType struct {
+	NetboxObject
+	// Manufacturer of the module type. This field is required.
+	Manufacturer *Manufacturer `json:"manufacturer,omitempty"`
+	// Model of the module type. This field is required.
+	Model string `json:"model,omitempty"`
+	// PartNumber is the discrete part number of the module type.
+	PartNumber string `json:"part_number,omitempty"`
+}
+
+func (mt ModuleType) String() string {
+	return fmt.Sprintf("ModuleType{Manufacturer: %s, Model: %s}", mt.Manufacturer, mt.Model)
+}
+
+// ModuleType implements IDItem interface.
+func (mt *ModuleType) GetID() int {
+	return mt.ID
+}
+func (mt *ModuleType) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimModuleType
+}
+func (mt *ModuleType) GetAPIPath() constants.APIPath {
+	return constants.ModuleTypesAPIPath
+}
+
+// ModuleType implements OrphanItem interface.
+func (mt *ModuleType) GetNetboxObject() *NetboxObject {
+	return &mt.NetboxObject
+}
+
+// ModuleBay represents a slot within a device, in which a module can be installed.
+type ModuleBay struct {
+	NetboxObject
+	// Device to which the module bay belongs. This field is required.
+	Device *Device `json:"device,omitempty"`
+	// Name of the module bay. This field is required.
+	Name string `json:"name,omitempty"`
+	// Label is the physical label of the module bay.
+	Label string `json:"label,omitempty"`
+	// Position is the identifier to reference when renaming installed components.
+	Position string `json:"position,omitempty"`
+}
+
+func (mb ModuleBay) String() string {
+	return fmt.Sprintf("ModuleBay{Name: %s, Device: %s}", mb.Name, mb.Device)
+}
+
+// ModuleBay implements IDItem interface.
+func (mb *ModuleBay) GetID() int {
+	return mb.ID
+}
+func (mb *ModuleBay) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimModuleBay
+}
+func (mb *ModuleBay) GetAPIPath() constants.APIPath {
+	return constants.ModuleBaysAPIPath
+}
+
+// ModuleBay implements OrphanItem interface.
+func (mb *ModuleBay) GetNetboxObject() *NetboxObject {
+	return &mb.NetboxObject
+}
+
+type ModuleStatus struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/v4.2.0/netbox/dcim/choices.py#L1656
+var (
+	ModuleStatusOffline         = ModuleStatus{Choice{Value: "offline", Label: "Offline"}}
+	ModuleStatusActive          = ModuleStatus{Choice{Value: "active", Label: "Active"}}
+	ModuleStatusPlanned         = ModuleStatus{Choice{Value: "planned", Label: "Planned"}}
+	ModuleStatusStaged          = ModuleStatus{Choice{Value: "staged", Label: "Staged"}}
+	ModuleStatusFailed          = ModuleStatus{Choice{Value: "failed", Label: "Failed"}}
+	ModuleStatusDecommissioning = ModuleStatus{
+		Choice{Value: "decommissioning", Label: "Decommissioning"},
+	}
+)
+
+// Module represents a hardware component installed within a device's module bay.
+type Module struct {
+	NetboxObject
+	// Device in which the module is installed. This field is required.
+	Device *Device `json:"device,omitempty"`
+	// ModuleBay in which the module is installed. This field is required.
+	ModuleBay *ModuleBay `json:"module_bay,omitempty"`
+	// ModuleType of the module. This field is required.
+	ModuleType *ModuleType `json:"module_type,omitempty"`
+	// Status of the module.
+	Status *ModuleStatus `json:"status,omitempty"`
+	// SerialNumber of the module.
+	SerialNumber string `json:"serial,omitempty"`
+	// AssetTag is an unique tag for identifying the module.
+	AssetTag string `json:"asset_tag,omitempty"`
+}
+
+func (m Module) String() string {
+	return fmt.Sprintf(
+		"Module{Device: %s, ModuleBay: %s, ModuleType: %s}",
+		m.Device,
+		m.ModuleBay,
+		m.ModuleType,
+	)
+}
+
+// Module implements IDItem interface.
+func (m *Module) GetID() int {
+	return m.ID
+}
+func (m *Module) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimModule
+}
+func (m *Module) GetAPIPath() constants.APIPath {
+	return constants.ModulesAPIPath
+}
+
+// Module implements OrphanItem interface.
+func (m *Module) GetNetboxObject() *NetboxObject {
+	return &m.NetboxObject
+}
+
+// InventoryItem represents a hardware component within a device,
+// which is not modeled as a module (e.g. transceivers, fans, power supplies).
+type InventoryItem struct {
+	NetboxObject
+	// Device to which the inventory item belongs. This field is required.
+	Device *Device `json:"device,omitempty"`
+	// Parent inventory item, if any.
+	Parent *InventoryItem `json:"parent,omitempty"`
+	// Name of the inventory item. This field is required.
+	Name string `json:"name,omitempty"`
+	// Label is the physical label of the inventory item.
+	Label string `json:"label,omitempty"`
+	// Manufacturer of the inventory item.
+	Manufacturer *Manufacturer `json:"manufacturer,omitempty"`
+	// PartID is the manufacturer-assigned part identifier.
+	PartID string `json:"part_id,omitempty"`
+	// SerialNumber of the inventory item.
+	SerialNumber string `json:"serial,omitempty"`
+	// AssetTag is an unique tag for identifying the inventory item.
+	AssetTag string `json:"asset_tag,omitempty"`
+	// Discovered is true, if the item was automatically discovered.
+	Discovered bool `json:"discovered,omitempty"`
+}
+
+func (ii InventoryItem) String() string {
+	return fmt.Sprintf("InventoryItem{Name: %s, Device: %s}", ii.Name, ii.Device)
+}
+
+// InventoryItem implements IDItem interface.
+func (ii *InventoryItem) GetID() int {
+	return ii.ID
+}
+func (ii *InventoryItem) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimInventoryItem
+}
+func (ii *InventoryItem) GetAPIPath() constants.APIPath {
+	return constants.InventoryItemsAPIPath
+}
+
+// InventoryItem implements OrphanItem interface.
+func (ii *InventoryItem) GetNetboxObject() *NetboxObject {
+	return &ii.NetboxObject
+}
+
+// PowerPort represents a power inlet of a device (e.g. input of a power supply).
+type PowerPort struct {
+	NetboxObject
+	// Device to which the power port belongs. This field is required.
+	Device *Device `json:"device,omitempty"`
+	// Name of the power port. This field is required.
+	Name string `json:"name,omitempty"`
+	// Label is the physical label of the power port.
+	Label string `json:"label,omitempty"`
+	// MaximumDraw is the maximum power draw in watts.
+	MaximumDraw int `json:"maximum_draw,omitempty"`
+	// AllocatedDraw is the allocated (or measured) power draw in watts.
+	// It must not exceed maximum draw.
+	AllocatedDraw int `json:"allocated_draw,omitempty"`
+}
+
+func (pp PowerPort) String() string {
+	return fmt.Sprintf("PowerPort{Name: %s, Device: %s}", pp.Name, pp.Device)
+}
+
+// PowerPort implements IDItem interface.
+func (pp *PowerPort) GetID() int {
+	return pp.ID
+}
+func (pp *PowerPort) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimPowerPort
+}
+func (pp *PowerPort) GetAPIPath() constants.APIPath {
+	return constants.PowerPortsAPIPath
+}
+
+// PowerPort implements OrphanItem interface.
+func (pp *PowerPort) GetNetboxObject() *NetboxObject {
+	return &pp.NetboxObject
+}
+
+// Available console port types for devices. For more information see:
+// https://github.com/netbox-community/netbox/blob/main/netbox/dcim/choices.py.
+type ConsolePortType struct {
+	Choice
+}
+
+var (
+	ConsolePortTypeDE9       = ConsolePortType{Choice{Value: "de-9", Label: "DE-9"}}
+	ConsolePortTypeRJ45      = ConsolePortType{Choice{Value: "rj-45", Label: "RJ-45"}}
+	ConsolePortTypeUSBMiniB  = ConsolePortType{Choice{Value: "usb-mini-b", Label: "USB Mini B"}}
+	ConsolePortTypeUSBMicroB = ConsolePortType{Choice{Value: "usb-micro-b", Label: "USB Micro B"}}
+	ConsolePortTypeUSBC      = ConsolePortType{Choice{Value: "usb-c", Label: "USB Type C"}}
+	ConsolePortTypeOther     = ConsolePortType{Choice{Value: "other", Label: "Other"}}
+)
+
+// ConsolePort represents a console (serial management) port of a device.
+type ConsolePort struct {
+	NetboxObject
+	// Device to which the console port belongs. This field is required.
+	Device *Device `json:"device,omitempty"`
+	// Name of the console port. This field is required.
+	Name string `json:"name,omitempty"`
+	// Label is the physical label of the console port.
+	Label string `json:"label,omitempty"`
+	// Type is the physical type of the console port.
+	Type *ConsolePortType `json:"type,omitempty"`
+	// Speed of the console port in bps (e.g. 9600).
+	Speed int `json:"speed,omitempty"`
+}
+
+func (cp ConsolePort) String() string {
+	return fmt.Sprintf("ConsolePort{Name: %s, Device: %s}", cp.Name, cp.Device)
+}
+
+// ConsolePort implements IDItem interface.
+func (cp *ConsolePort) GetID() int {
+	return cp.ID
+}
+func (cp *ConsolePort) GetObjectType() constants.ContentType {
+	return constants.ContentTypeDcimConsolePort
+}
+func (cp *ConsolePort) GetAPIPath() constants.APIPath {
+	return constants.ConsolePortsAPIPath
+}
+
+// ConsolePort implements OrphanItem interface.
+func (cp *ConsolePort) GetNetboxObject() *NetboxObject {
+	return &cp.NetboxObject
+}
@@ -538,6 +538,140 @@ func TestLocation_GetNetboxObject(t *testing.T) {
 	}
 }
 
+func TestRack_String(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Rack
+		want string
+	}{
+		{
+			name: "Test rack string output",
+			r: Rack{
+				Name: "R01",
+				Site: &Site{
+					Name: "Test site",
+				},
+			},
+			want: "Rack{Name: R01, Site: Site{Name: Test site}}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.String(); got != tt.want {
+				t.Errorf("Rack.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRackRole_String(t *testing.T) {
+	tests := []struct {
+		name string
+		rr   RackRole
+		want string
+	}{
+		{
+			name: "Test rack role string output",
+			rr: RackRole{
+				Name: "Compute",
+			},
+			want: "RackRole{Name: Compute}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rr.String(); got != tt.want {
+				t.Errorf("RackRole.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPowerPanel_String(t *testing.T) {
+	tests := []struct {
+		name string
+		pp   PowerPanel
+		want string
+	}{
+		{
+			name: "Test power panel string output",
+			pp: PowerPanel{
+				Name: "Panel A",
+				Site: &Site{
+					Name: "Test site",
+				},
+			},
+			want: "PowerPanel{Name: Panel A, Site: Site{Name: Test site}}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pp.String(); got != tt.want {
+				t.Errorf("PowerPanel.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPowerFeed_String(t *testing.T) {
+	tests := []struct {
+		name string
+		pf   PowerFeed
+		want string
+	}{
+		{
+			name: "Test power feed string output",
+			pf: PowerFeed{
+				Name: "Feed A1",
+				PowerPanel: &PowerPanel{
+					Name: "Panel A",
+				},
+			},
+			want: "PowerFeed{Name: Feed A1, PowerPanel: Panel A}",
+		},
+		{
+			name: "Test power feed string output without power panel",
+			pf: PowerFeed{
+				Name: "Feed A1",
+			},
+			want: "PowerFeed{Name: Feed A1, PowerPanel: }",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pf.String(); got != tt.want {
+				t.Errorf("PowerFeed.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsolePort_String(t *testing.T) {
+	tests := []struct {
+		name string
+		cp   ConsolePort
+		want string
+	}{
+		{
+			name: "Test console port string output",
+			cp: ConsolePort{
+				Name: "con 0",
+				Device: &Device{
+					Name: "Test device",
+				},
+			},
+			want: fmt.Sprintf("ConsolePort{Name: con 0, Device: %s}", Device{Name: "Test device"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cp.String(); got != tt.want {
+				t.Errorf("ConsolePort.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestManufacturer_GetID(t *testing.T) {
 	tests := []struct {
 		name string
@@ -885,3 +1019,137 @@ func TestVirtualDeviceContext_GetNetboxObject(t *testing.T) {
 		})
 	}
 }
+
+func TestModuleType_String(t *testing.T) {
+	tests := []struct {
+		name string
+		mt   ModuleType
+		want string
+	}{
+		{
+			name: "Correct string representation of module type",
+			mt: ModuleType{
+				Manufacturer: &Manufacturer{Name: "Cisco"},
+				Model:        "C9300-NM-8X",
+			},
+			want: "ModuleType{Manufacturer: Manufacturer{Name: Cisco}, Model: C9300-NM-8X}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mt.String(); got != tt.want {
+				t.Errorf("ModuleType.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModuleBay_String(t *testing.T) {
+	tests := []struct {
+		name string
+		mb   ModuleBay
+		want string
+	}{
+		{
+			name: "Correct string representation of module bay",
+			mb: ModuleBay{
+				Name:   "Slot 1",
+				Device: &Device{Name: "testdevice"},
+			},
+			want: fmt.Sprintf("ModuleBay{Name: %s, Device: %s}", "Slot 1", &Device{Name: "testdevice"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mb.String(); got != tt.want {
+				t.Errorf("ModuleBay.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryItem_String(t *testing.T) {
+	tests := []struct {
+		name string
+		ii   InventoryItem
+		want string
+	}{
+		{
+			name: "Correct string representation of inventory item",
+			ii: InventoryItem{
+				Name:   "Power Supply 1",
+				Device: &Device{Name: "testdevice"},
+			},
+			want: fmt.Sprintf(
+				"InventoryItem{Name: %s, Device: %s}",
+				"Power Supply 1",
+				&Device{Name: "testdevice"},
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ii.String(); got != tt.want {
+				t.Errorf("InventoryItem.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_GetID(t *testing.T) {
+	tests := []struct {
+		name   string
+		module *Module
+		want   int
+	}{
+		{
+			name: "Test module get id",
+			module: &Module{
+				NetboxObject: NetboxObject{
+					ID: 1,
+				},
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.module.GetID(); got != tt.want {
+				t.Errorf("Module.GetID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryItem_GetNetboxObject(t *testing.T) {
+	tests := []struct {
+		name string
+		ii   *InventoryItem
+		want *NetboxObject
+	}{
+		{
+			name: "Test inventory item get netbox object",
+			ii: &InventoryItem{
+				NetboxObject: NetboxObject{
+					ID: 1,
+					CustomFields: map[string]interface{}{
+						"x": "y",
+					},
+				},
+			},
+			want: &NetboxObject{
+				ID: 1,
+				CustomFields: map[string]interface{}{
+					"x": "y",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ii.GetNetboxObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InventoryItem.GetNetboxObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -125,3 +125,55 @@ func (cf *CustomField) GetObjectType() constants.ContentType {
 func (cf *CustomField) GetAPIPath() constants.APIPath {
 	return constants.CustomFieldsAPIPath
 }
+
+type JournalEntryKind struct {
+	Choice
+}
+
+var (
+	JournalEntryKindInfo    = JournalEntryKind{Choice{Value: "info", Label: "Info"}}
+	JournalEntryKindSuccess = JournalEntryKind{Choice{Value: "success", Label: "Success"}}
+	JournalEntryKindWarning = JournalEntryKind{Choice{Value: "warning", Label: "Warning"}}
+	JournalEntryKindDanger  = JournalEntryKind{Choice{Value: "danger", Label: "Danger"}}
+)
+
+// JournalEntry is a note attached to any netbox object. It is used for data,
+// that doesn't fit into any of the netbox's core models (e.g. BGP sessions).
+type JournalEntry struct {
+	NetboxObject
+	// AssignedObjectType is the type of the object, that the journal entry is attached to.
+	// This field is required.
+	AssignedObjectType constants.ContentType `json:"assigned_object_type,omitempty"`
+	// AssignedObjectID is the id of the object, that the journal entry is attached to.
+	// This field is required.
+	AssignedObjectID int `json:"assigned_object_id,omitempty"`
+	// Kind of the journal entry.
+	Kind *JournalEntryKind `json:"kind,omitempty"`
+	// Comments is the content of the journal entry. This field is required.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (je JournalEntry) String() string {
+	return fmt.Sprintf(
+		"JournalEntry{AssignedObjectType: %s, AssignedObjectID: %d, Comments: %s}",
+		je.AssignedObjectType,
+		je.AssignedObjectID,
+		je.Comments,
+	)
+}
+
+// JournalEntry implements IDItem interface.
+func (je *JournalEntry) GetID() int {
+	return je.ID
+}
+func (je *JournalEntry) GetObjectType() constants.ContentType {
+	return constants.ContentTypeExtrasJournalEntry
+}
+func (je *JournalEntry) GetAPIPath() constants.APIPath {
+	return constants.JournalEntriesAPIPath
+}
+
+// JournalEntry implements OrphanItem interface.
+func (je *JournalEntry) GetNetboxObject() *NetboxObject {
+	return &je.NetboxObject
+}
@@ -97,3 +97,29 @@ func TestCustomField_GetID(t *testing.T) {
 		})
 	}
 }
+
+func TestJournalEntry_String(t *testing.T) {
+	tests := []struct {
+		name string
+		je   JournalEntry
+		want string
+	}{
+		{
+			name: "Test journal entry correct string",
+			je: JournalEntry{
+				AssignedObjectType: "dcim.device",
+				AssignedObjectID:   5,
+				Kind:               &JournalEntryKindInfo,
+				Comments:           "Test comments",
+			},
+			want: "JournalEntry{AssignedObjectType: dcim.device, AssignedObjectID: 5, Comments: Test comments}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.je.String(); got != tt.want {
+				t.Errorf("JournalEntry.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -44,10 +44,12 @@ type IPAddress struct {
 	DNSName string `json:"dns_name,omitempty"`
 	// Tenancy
 	Tenant *Tenant `json:"tenant,omitempty"`
+	// VRF that this IP address belongs to. Nil means the global table.
+	VRF *VRF `json:"vrf,omitempty"`
 
-	// AssignedObjectType is either a DeviceInterface or a VMInterface.
+	// AssignedObjectType is either a DeviceInterface, a VMInterface or a FHRPGroup.
 	AssignedObjectType constants.ContentType `json:"assigned_object_type,omitempty"`
-	// ID of the assigned object (either an ID of DeviceInterface or an ID of VMInterface).
+	// ID of the assigned object (ID of DeviceInterface, VMInterface or FHRPGroup).
 	AssignedObjectID int `json:"assigned_object_id,omitempty"`
 }
 
@@ -161,8 +163,205 @@ func (v *Vlan) GetNetboxObject() *NetboxObject {
 	return &v.NetboxObject
 }
 
+type RouteTarget struct {
+	NetboxObject
+	// Name of the route target (RFC 4360 format). This field is required.
+	Name string `json:"name,omitempty"`
+	// Tenant that this route target belongs to.
+	Tenant *Tenant `json:"tenant,omitempty"`
+}
+
+func (rt RouteTarget) String() string {
+	return fmt.Sprintf("RouteTarget{Name: %s}", rt.Name)
+}
+
+// RouteTarget implements IDItem interface.
+func (rt *RouteTarget) GetID() int {
+	return rt.ID
+}
+func (rt *RouteTarget) GetObjectType() constants.ContentType {
+	return constants.ContentTypeIpamRouteTarget
+}
+func (rt *RouteTarget) GetAPIPath() constants.APIPath {
+	return constants.RouteTargetsAPIPath
+}
+
+// RouteTarget implements OrphanItem interface.
+func (rt *RouteTarget) GetNetboxObject() *NetboxObject {
+	return &rt.NetboxObject
+}
+
+type VRF struct {
+	NetboxObject
+	// Name of the VRF. This field is required.
+	Name string `json:"name,omitempty"`
+	// RD is the route distinguisher of the VRF (RFC 4364 format).
+	RD string `json:"rd,omitempty"`
+	// Tenant that this VRF belongs to.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// EnforceUnique prevents duplicate prefixes/IP addresses within this VRF.
+	EnforceUnique bool `json:"enforce_unique,omitempty"`
+	// ImportTargets is a list of route targets imported into this VRF.
+	ImportTargets []*RouteTarget `json:"import_targets,omitempty"`
+	// ExportTargets is a list of route targets exported from this VRF.
+	ExportTargets []*RouteTarget `json:"export_targets,omitempty"`
+}
+
+func (v VRF) String() string {
+	return fmt.Sprintf("VRF{ID: %d, Name: %s, RD: %s}", v.ID, v.Name, v.RD)
+}
+
+// VRF implements IDItem interface.
+func (v *VRF) GetID() int {
+	return v.ID
+}
+func (v *VRF) GetObjectType() constants.ContentType {
+	return constants.ContentTypeIpamVRF
+}
+func (v *VRF) GetAPIPath() constants.APIPath {
+	return constants.VRFsAPIPath
+}
+
+// VRF implements OrphanItem interface.
+func (v *VRF) GetNetboxObject() *NetboxObject {
+	return &v.NetboxObject
+}
+
+type IPRangeStatus struct {
+	Choice
+}
+
+var (
+	IPRangeStatusActive     = IPRangeStatus{Choice{Value: "active", Label: "Active"}}
+	IPRangeStatusReserved   = IPRangeStatus{Choice{Value: "reserved", Label: "Reserved"}}
+	IPRangeStatusDeprecated = IPRangeStatus{Choice{Value: "deprecated", Label: "Deprecated"}}
+)
+
 type IPRange struct {
 	NetboxObject
+	// StartAddress is the first IPv4 or IPv6 address (with mask) of the range. This field is required.
+	StartAddress string `json:"start_address,omitempty"`
+	// EndAddress is the last IPv4 or IPv6 address (with mask) of the range. This field is required.
+	EndAddress string `json:"end_address,omitempty"`
+	// Status of the IP range (default "active").
+	Status *IPRangeStatus `json:"status,omitempty"`
+	// VRF that this IP range belongs to. Nil means the global table.
+	VRF *VRF `json:"vrf,omitempty"`
+	// Tenant that this IP range belongs to.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// Comments about the IP range.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (r IPRange) String() string {
+	return fmt.Sprintf("IPRange{StartAddress: %s, EndAddress: %s}", r.StartAddress, r.EndAddress)
+}
+
+// IPRange implements IDItem interface.
+func (r *IPRange) GetID() int {
+	return r.ID
+}
+func (r *IPRange) GetObjectType() constants.ContentType {
+	return constants.ContentTypeIpamIPRange
+}
+func (r *IPRange) GetAPIPath() constants.APIPath {
+	return constants.IPRangesAPIPath
+}
+
+// IPRange implements OrphanItem interface.
+func (r *IPRange) GetNetboxObject() *NetboxObject {
+	return &r.NetboxObject
+}
+
+type FHRPGroupProtocol struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/main/netbox/ipam/choices.py
+var (
+	FHRPGroupProtocolVRRP2     = FHRPGroupProtocol{Choice{Value: "vrrp2", Label: "VRRPv2"}}
+	FHRPGroupProtocolVRRP3     = FHRPGroupProtocol{Choice{Value: "vrrp3", Label: "VRRPv3"}}
+	FHRPGroupProtocolCARP      = FHRPGroupProtocol{Choice{Value: "carp", Label: "CARP"}}
+	FHRPGroupProtocolClusterXL = FHRPGroupProtocol{Choice{Value: "clusterxl", Label: "ClusterXL"}}
+	FHRPGroupProtocolHSRP      = FHRPGroupProtocol{Choice{Value: "hsrp", Label: "HSRP"}}
+	FHRPGroupProtocolGLBP      = FHRPGroupProtocol{Choice{Value: "glbp", Label: "GLBP"}}
+	FHRPGroupProtocolOther     = FHRPGroupProtocol{Choice{Value: "other", Label: "Other"}}
+)
+
+type FHRPGroup struct {
+	NetboxObject
+	// Protocol of the FHRP group. This field is required.
+	Protocol *FHRPGroupProtocol `json:"protocol,omitempty"`
+	// GroupID is the protocol specific group identifier (e.g. VRRP VRID). This field is required.
+	GroupID int `json:"group_id,omitempty"`
+	// Name of the FHRP group.
+	Name string `json:"name,omitempty"`
+	// AuthType is the authentication type (plaintext or md5).
+	AuthType string `json:"auth_type,omitempty"`
+	// AuthKey is the authentication key.
+	AuthKey string `json:"auth_key,omitempty"`
+}
+
+func (fg FHRPGroup) String() string {
+	return fmt.Sprintf("FHRPGroup{Name: %s, Protocol: %s, GroupID: %d}", fg.Name, fg.Protocol, fg.GroupID)
+}
+
+// FHRPGroup implements IDItem interface.
+func (fg *FHRPGroup) GetID() int {
+	return fg.ID
+}
+func (fg *FHRPGroup) GetObjectType() constants.ContentType {
+	return constants.ContentTypeIpamFHRPGroup
+}
+func (fg *FHRPGroup) GetAPIPath() constants.APIPath {
+	return constants.FHRPGroupsAPIPath
+}
+
+// FHRPGroup implements OrphanItem interface.
+func (fg *FHRPGroup) GetNetboxObject() *NetboxObject {
+	return &fg.NetboxObject
+}
+
+type FHRPGroupAssignment struct {
+	NetboxObject
+	// Group is the FHRP group this assignment belongs to. This field is required.
+	Group *FHRPGroup `json:"group,omitempty"`
+	// InterfaceType is the content type of the interface (dcim.interface or virtualization.vminterface).
+	InterfaceType constants.ContentType `json:"interface_type,omitempty"`
+	// InterfaceID is the ID of the assigned interface.
+	InterfaceID int `json:"interface_id,omitempty"`
+	// Priority of the interface within the FHRP group. This field is required.
+	Priority int `json:"priority"`
+}
+
+func (fga FHRPGroupAssignment) String() string {
+	groupName := ""
+	if fga.Group != nil {
+		groupName = fga.Group.Name
+	}
+	return fmt.Sprintf(
+		"FHRPGroupAssignment{Group: %s, InterfaceType: %s, InterfaceID: %d, Priority: %d}",
+		groupName,
+		fga.InterfaceType,
+		fga.InterfaceID,
+		fga.Priority,
+	)
+}
+
+// FHRPGroupAssignment implements IDItem interface.
+func (fga *FHRPGroupAssignment) GetID() int {
+	return fga.ID
+}
+func (fga *FHRPGroupAssignment) GetObjectType() constants.ContentType {
+	return constants.ContentTypeIpamFHRPGroupAssignment
+}
+func (fga *FHRPGroupAssignment) GetAPIPath() constants.APIPath {
+	return constants.FHRPGroupAssignmentsAPIPath
+}
+
+// FHRPGroupAssignment implements OrphanItem interface.
+func (fga *FHRPGroupAssignment) GetNetboxObject() *NetboxObject {
+	return &fga.NetboxObject
 }
 
 type PrefixStatus struct {
@@ -194,6 +393,9 @@ type Prefix struct {
 	// Tenant that this prefix belongs to.
 	Tenant *Tenant `json:"tenant,omitempty"`
 
+	// VRF that this prefix belongs to. Nil means the global table.
+	VRF *VRF `json:"vrf,omitempty"`
+
 	Comments string `json:"comments,omitempty"`
 }
 
@@ -216,3 +418,146 @@ func (p *Prefix) GetAPIPath() constants.APIPath {
 func (p *Prefix) GetNetboxObject() *NetboxObject {
 	return &p.NetboxObject
 }
+
+type ServiceProtocol struct {
+	Choice
+}
+
+var (
+	ServiceProtocolTCP  = ServiceProtocol{Choice{Value: "tcp", Label: "TCP"}}
+	ServiceProtocolUDP  = ServiceProtocol{Choice{Value: "udp", Label: "UDP"}}
+	ServiceProtocolSCTP = ServiceProtocol{Choice{Value: "sctp", Label: "SCTP"}}
+)
+
+// ServiceProtocols maps protocol value to ServiceProtocol.
+var ServiceProtocols = map[string]*ServiceProtocol{
+	ServiceProtocolTCP.Value:  &ServiceProtocolTCP,
+	ServiceProtocolUDP.Value:  &ServiceProtocolUDP,
+	ServiceProtocolSCTP.Value: &ServiceProtocolSCTP,
+}
+
+type Service struct {
+	NetboxObject
+	// Device that this service runs on. Either Device or VM is required.
+	Device *Device `json:"device,omitempty"`
+	// VM that this service runs on. Either Device or VM is required.
+	VM *VM `json:"virtual_machine,omitempty"`
+	// Name of the service. This field is required.
+	Name string `json:"name,omitempty"`
+	// Protocol of the service. This field is required.
+	Protocol *ServiceProtocol `json:"protocol,omitempty"`
+	// Ports that the service listens on. This field is required.
+	Ports []int `json:"ports,omitempty"`
+	// IPAddresses that the service listens on. Empty means all addresses of the parent.
+	IPAddresses []*IPAddress `json:"ipaddresses,omitempty"`
+}
+
+func (s Service) String() string {
+	return fmt.Sprintf("Service{Name: %s, Protocol: %s, Ports: %v}", s.Name, s.Protocol, s.Ports)
+}
+
+// Service implements IDItem interface.
+func (s *Service) GetID() int {
+	return s.ID
+}
+func (s *Service) GetObjectType() constants.ContentType {
+	return constants.ContentTypeIpamService
+}
+func (s *Service) GetAPIPath() constants.APIPath {
+	return constants.ServicesAPIPath
+}
+
+// Service implements OrphanItem interface.
+func (s *Service) GetNetboxObject() *NetboxObject {
+	return &s.NetboxObject
+}
+
+// RIR represents a Regional Internet Registry, responsible for allocation of ASNs.
+type RIR struct {
+	NetboxObject
+	// Name of the RIR. This field is required.
+	Name string `json:"name,omitempty"`
+	// Slug of the RIR. This field is required.
+	Slug string `json:"slug,omitempty"`
+	// IsPrivate marks RIRs that manage private address space and ASNs.
+	IsPrivate bool `json:"is_private,omitempty"`
+}
+
+func (r RIR) String() string {
+	return fmt.Sprintf("RIR{Name: %s}", r.Name)
+}
+
+// RIR implements IDItem interface.
+func (r *RIR) GetID() int {
+	return r.ID
+}
+func (r *RIR) GetObjectType() constants.ContentType {
+	return constants.ContentTypeIpamRIR
+}
+func (r *RIR) GetAPIPath() constants.APIPath {
+	return constants.RIRsAPIPath
+}
+
+// ASN represents an autonomous system number.
+type ASN struct {
+	NetboxObject
+	// ASN is the 16 or 32-bit autonomous system number. This field is required.
+	ASN int64 `json:"asn,omitempty"`
+	// RIR responsible for this ASN. This field is required.
+	RIR *RIR `json:"rir,omitempty"`
+	// Tenant of the ASN.
+	Tenant *Tenant `json:"tenant,omitempty"`
+}
+
+func (a ASN) String() string {
+	return fmt.Sprintf("ASN{ASN: %d}", a.ASN)
+}
+
+// ASN implements IDItem interface.
+func (a *ASN) GetID() int {
+	return a.ID
+}
+func (a *ASN) GetObjectType() constants.ContentType {
+	return constants.ContentTypeIpamASN
+}
+func (a *ASN) GetAPIPath() constants.APIPath {
+	return constants.ASNsAPIPath
+}
+
+// ASN implements OrphanItem interface.
+func (a *ASN) GetNetboxObject() *NetboxObject {
+	return &a.NetboxObject
+}
+
+// Aggregate represents the top of the ip address space hierarchy, allocated by a RIR.
+type Aggregate struct {
+	NetboxObject
+	// Prefix is a IPv4 or IPv6 network address (with mask). This field is required.
+	Prefix string `json:"prefix,omitempty"`
+	// RIR responsible for this aggregate. This field is required.
+	RIR *RIR `json:"rir,omitempty"`
+	// Tenant of the aggregate.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// Comments of the aggregate.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (a Aggregate) String() string {
+	return fmt.Sprintf("Aggregate{Prefix: %s}", a.Prefix)
+}
+
+// Aggregate implements IDItem interface.
+func (a *Aggregate) GetID() int {
+	return a.ID
+}
+func (a *Aggregate) GetObjectType() constants.ContentType {
+	return constants.ContentTypeIpamAggregate
+}
+func (a *Aggregate) GetAPIPath() constants.APIPath {
+	return constants.AggregatesAPIPath
+}
+
+// Aggregate implements OrphanItem interface.
+func (a *Aggregate) GetNetboxObject() *NetboxObject {
+	return &a.NetboxObject
+}
@@ -339,3 +339,210 @@ func TestPrefix_GetNetboxObject(t *testing.T) {
 		})
 	}
 }
+
+func TestVRF_String(t *testing.T) {
+	tests := []struct {
+		name string
+		v    VRF
+		want string
+	}{
+		{
+			name: "Test vrf correct string",
+			v: VRF{
+				NetboxObject: NetboxObject{
+					ID: 1,
+				},
+				Name: "guest",
+				RD:   "65000:100",
+			},
+			want: "VRF{ID: 1, Name: guest, RD: 65000:100}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.String(); got != tt.want {
+				t.Errorf("VRF.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouteTarget_String(t *testing.T) {
+	tests := []struct {
+		name string
+		rt   RouteTarget
+		want string
+	}{
+		{
+			name: "Test route target correct string",
+			rt: RouteTarget{
+				Name: "65000:100",
+			},
+			want: "RouteTarget{Name: 65000:100}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rt.String(); got != tt.want {
+				t.Errorf("RouteTarget.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVRF_GetNetboxObject(t *testing.T) {
+	tests := []struct {
+		name string
+		v    *VRF
+		want *NetboxObject
+	}{
+		{
+			name: "Test vrf get netbox object",
+			v: &VRF{
+				NetboxObject: NetboxObject{
+					ID: 1,
+					CustomFields: map[string]interface{}{
+						"x": "y",
+					},
+				},
+			},
+			want: &NetboxObject{
+				ID: 1,
+				CustomFields: map[string]interface{}{
+					"x": "y",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.GetNetboxObject(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VRF.GetNetboxObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIPRange_String(t *testing.T) {
+	tests := []struct {
+		name string
+		r    IPRange
+		want string
+	}{
+		{
+			name: "Test ip range correct string",
+			r: IPRange{
+				StartAddress: "192.168.1.10/24",
+				EndAddress:   "192.168.1.100/24",
+			},
+			want: "IPRange{StartAddress: 192.168.1.10/24, EndAddress: 192.168.1.100/24}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.String(); got != tt.want {
+				t.Errorf("IPRange.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFHRPGroup_String(t *testing.T) {
+	tests := []struct {
+		name string
+		fg   FHRPGroup
+		want string
+	}{
+		{
+			name: "Test fhrp group correct string",
+			fg: FHRPGroup{
+				Name:     "vrrp2-10-192.168.1.1",
+				Protocol: &FHRPGroupProtocolVRRP2,
+				GroupID:  10,
+			},
+			want: "FHRPGroup{Name: vrrp2-10-192.168.1.1, Protocol: vrrp2, GroupID: 10}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fg.String(); got != tt.want {
+				t.Errorf("FHRPGroup.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFHRPGroupAssignment_String(t *testing.T) {
+	tests := []struct {
+		name string
+		fga  FHRPGroupAssignment
+		want string
+	}{
+		{
+			name: "Test fhrp group assignment correct string",
+			fga: FHRPGroupAssignment{
+				Group:         &FHRPGroup{Name: "hsrp-1-10.0.0.1"},
+				InterfaceType: "dcim.interface",
+				InterfaceID:   5,
+				Priority:      110,
+			},
+			want: "FHRPGroupAssignment{Group: hsrp-1-10.0.0.1, InterfaceType: dcim.interface, InterfaceID: 5, Priority: 110}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fga.String(); got != tt.want {
+				t.Errorf("FHRPGroupAssignment.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_String(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Service
+		want string
+	}{
+		{
+			name: "Test service correct string",
+			s: Service{
+				Name:     "https",
+				Protocol: &ServiceProtocolTCP,
+				Ports:    []int{443, 8443},
+			},
+			want: "Service{Name: https, Protocol: tcp, Ports: [443 8443]}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.String(); got != tt.want {
+				t.Errorf("Service.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestASN_String(t *testing.T) {
+	tests := []struct {
+		name string
+		a    ASN
+		want string
+	}{
+		{
+			name: "Test asn correct string",
+			a: ASN{
+				ASN: 65001,
+				RIR: &RIR{Name: "RFC 6996", Slug: "rfc-6996", IsPrivate: true},
+			},
+			want: "ASN{ASN: 65001}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.String(); got != tt.want {
+				t.Errorf("ASN.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
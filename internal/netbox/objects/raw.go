@@ -0,0 +1,75 @@
+package objects
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+)
+
+// netboxObjectJSONKeys are json keys of attributes stored in NetboxObject.
+var netboxObjectJSONKeys = []string{"id", "tags", "description", "custom_fields"}
+
+// RawObject represents an object on an arbitrary api path, that has no dedicated
+// model in netbox-ssot (e.g. objects of netbox plugins). Attributes common to all
+// netbox objects are stored in NetboxObject, all other attributes in Fields.
+type RawObject struct {
+	NetboxObject
+	// Path is the api path of the object, e.g. /api/plugins/bgp/session/.
+	Path constants.APIPath `json:"-"`
+	// ObjectType is netbox's object type of the object, e.g. netbox_bgp.bgpsession.
+	ObjectType constants.ContentType `json:"-"`
+	// Fields are all other attributes of the object in their json form.
+	Fields map[string]interface{} `json:"-"`
+}
+
+// NewRawObject creates a raw object on the given api path from its json document.
+// Document is converted to its json form, so numbers are always float64.
+func NewRawObject(path constants.APIPath, document map[string]interface{}) (*RawObject, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("marshal raw object document: %s", err)
+	}
+	rawObject := &RawObject{Path: path}
+	if err := json.Unmarshal(data, rawObject); err != nil {
+		return nil, fmt.Errorf("unmarshal raw object document: %s", err)
+	}
+	return rawObject, nil
+}
+
+// UnmarshalJSON unmarshals attributes common to all netbox objects into NetboxObject,
+// and all other attributes into Fields.
+func (ro *RawObject) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &ro.NetboxObject); err != nil {
+		return err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, key := range netboxObjectJSONKeys {
+		delete(fields, key)
+	}
+	ro.Fields = fields
+	return nil
+}
+
+func (ro RawObject) String() string {
+	return fmt.Sprintf("RawObject{Path: %s, ID: %d, Fields: %v}", ro.Path, ro.ID, ro.Fields)
+}
+
+// RawObject implements IDItem interface.
+func (ro *RawObject) GetID() int {
+	return ro.ID
+}
+func (ro *RawObject) GetObjectType() constants.ContentType {
+	return ro.ObjectType
+}
+func (ro *RawObject) GetAPIPath() constants.APIPath {
+	return ro.Path
+}
+
+// RawObject implements OrphanItem interface.
+func (ro *RawObject) GetNetboxObject() *NetboxObject {
+	return &ro.NetboxObject
+}
@@ -0,0 +1,87 @@
+package objects
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+)
+
+func TestRawObject_String(t *testing.T) {
+	tests := []struct {
+		name string
+		ro   RawObject
+		want string
+	}{
+		{
+			name: "Test raw object string output",
+			ro: RawObject{
+				NetboxObject: NetboxObject{ID: 3},
+				Path:         "/api/plugins/bgp/session/",
+				Fields:       map[string]interface{}{"name": "peer1"},
+			},
+			want: "RawObject{Path: /api/plugins/bgp/session/, ID: 3, Fields: map[name:peer1]}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ro.String(); got != tt.want {
+				t.Errorf("RawObject.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRawObject(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     constants.APIPath
+		document map[string]interface{}
+		want     *RawObject
+		wantErr  bool
+	}{
+		{
+			name: "Netbox object attributes are separated from fields",
+			path: "/api/plugins/bgp/session/",
+			document: map[string]interface{}{
+				"id":            5,
+				"description":   "Test session",
+				"custom_fields": map[string]interface{}{"owner": "noc"},
+				"name":          "peer1",
+				"remote_as":     65001,
+				"device":        map[string]interface{}{"id": 1, "name": "router1"},
+			},
+			want: &RawObject{
+				NetboxObject: NetboxObject{
+					ID:           5,
+					Description:  "Test session",
+					CustomFields: map[string]interface{}{"owner": "noc"},
+				},
+				Path: "/api/plugins/bgp/session/",
+				Fields: map[string]interface{}{
+					"name":      "peer1",
+					"remote_as": float64(65001),
+					"device":    map[string]interface{}{"id": float64(1), "name": "router1"},
+				},
+			},
+		},
+		{
+			name:     "Invalid netbox object attribute",
+			path:     "/api/plugins/bgp/session/",
+			document: map[string]interface{}{"id": "not a number"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRawObject(tt.path, tt.document)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRawObject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRawObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
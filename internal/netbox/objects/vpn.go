@@ -0,0 +1,252 @@
+package objects
+
+import (
+	"fmt"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+)
+
+type L2VPNType struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/main/netbox/vpn/choices.py
+var (
+	L2VPNTypeVPWS      = L2VPNType{Choice{Value: "vpws", Label: "VPWS"}}
+	L2VPNTypeVPLS      = L2VPNType{Choice{Value: "vpls", Label: "VPLS"}}
+	L2VPNTypeVXLAN     = L2VPNType{Choice{Value: "vxlan", Label: "VXLAN"}}
+	L2VPNTypeVXLANEVPN = L2VPNType{Choice{Value: "vxlan-evpn", Label: "VXLAN-EVPN"}}
+	L2VPNTypeMPLSEVPN  = L2VPNType{Choice{Value: "mpls-evpn", Label: "MPLS EVPN"}}
+)
+
+// L2VPN represents a layer 2 overlay network (e.g. VXLAN segment).
+type L2VPN struct {
+	NetboxObject
+	// Name of the L2VPN. This field is required.
+	Name string `json:"name,omitempty"`
+	// URL-friendly unique shorthand. This field is required.
+	Slug string `json:"slug,omitempty"`
+	// Type of the L2VPN. This field is required.
+	Type *L2VPNType `json:"type,omitempty"`
+	// Identifier of the L2VPN (e.g. VNI of the VXLAN segment).
+	Identifier int64 `json:"identifier,omitempty"`
+	// Tenant of the L2VPN.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// Comments for the L2VPN.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (l L2VPN) String() string {
+	return fmt.Sprintf("L2VPN{Name: %s, Identifier: %d}", l.Name, l.Identifier)
+}
+
+// L2VPN implements IDItem interface.
+func (l *L2VPN) GetID() int {
+	return l.ID
+}
+func (l *L2VPN) GetObjectType() constants.ContentType {
+	return constants.ContentTypeVpnL2VPN
+}
+func (l *L2VPN) GetAPIPath() constants.APIPath {
+	return constants.L2VPNsAPIPath
+}
+
+// L2VPN implements OrphanItem interface.
+func (l *L2VPN) GetNetboxObject() *NetboxObject {
+	return &l.NetboxObject
+}
+
+// L2VPNTermination represents attachment of an interface or a vlan to the L2VPN.
+// Each object can be terminated to only one L2VPN.
+type L2VPNTermination struct {
+	NetboxObject
+	// L2VPN is the L2VPN of the termination. This field is required.
+	L2VPN *L2VPN `json:"l2vpn,omitempty"`
+	// AssignedObjectType is the content type of the terminated object
+	// (dcim.interface, virtualization.vminterface or ipam.vlan). This field is required.
+	AssignedObjectType constants.ContentType `json:"assigned_object_type,omitempty"`
+	// AssignedObjectID is the ID of the terminated object. This field is required.
+	AssignedObjectID int `json:"assigned_object_id,omitempty"`
+}
+
+func (lt L2VPNTermination) String() string {
+	l2vpnName := ""
+	if lt.L2VPN != nil {
+		l2vpnName = lt.L2VPN.Name
+	}
+	return fmt.Sprintf(
+		"L2VPNTermination{L2VPN: %s, AssignedObjectType: %s, AssignedObjectID: %d}",
+		l2vpnName,
+		lt.AssignedObjectType,
+		lt.AssignedObjectID,
+	)
+}
+
+// L2VPNTermination implements IDItem interface.
+func (lt *L2VPNTermination) GetID() int {
+	return lt.ID
+}
+func (lt *L2VPNTermination) GetObjectType() constants.ContentType {
+	return constants.ContentTypeVpnL2VPNTermination
+}
+func (lt *L2VPNTermination) GetAPIPath() constants.APIPath {
+	return constants.L2VPNTerminationsAPIPath
+}
+
+// L2VPNTermination implements OrphanItem interface.
+func (lt *L2VPNTermination) GetNetboxObject() *NetboxObject {
+	return &lt.NetboxObject
+}
+
+// TunnelGroup represents a group of tunnels.
+type TunnelGroup struct {
+	NetboxObject
+	// Name of the tunnel group. This field is required.
+	Name string `json:"name,omitempty"`
+	// URL-friendly unique shorthand. This field is required.
+	Slug string `json:"slug,omitempty"`
+}
+
+func (tg TunnelGroup) String() string {
+	return fmt.Sprintf("TunnelGroup{Name: %s}", tg.Name)
+}
+
+// TunnelGroup implements IDItem interface.
+func (tg *TunnelGroup) GetID() int {
+	return tg.ID
+}
+func (tg *TunnelGroup) GetObjectType() constants.ContentType {
+	return constants.ContentTypeVpnTunnelGroup
+}
+func (tg *TunnelGroup) GetAPIPath() constants.APIPath {
+	return constants.TunnelGroupsAPIPath
+}
+
+// TunnelGroup implements OrphanItem interface.
+func (tg *TunnelGroup) GetNetboxObject() *NetboxObject {
+	return &tg.NetboxObject
+}
+
+type TunnelStatus struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/main/netbox/vpn/choices.py
+var (
+	TunnelStatusPlanned  = TunnelStatus{Choice{Value: "planned", Label: "Planned"}}
+	TunnelStatusActive   = TunnelStatus{Choice{Value: "active", Label: "Active"}}
+	TunnelStatusDisabled = TunnelStatus{Choice{Value: "disabled", Label: "Disabled"}}
+)
+
+type TunnelEncapsulation struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/main/netbox/vpn/choices.py
+var (
+	TunnelEncapsulationIPsecTransport = TunnelEncapsulation{Choice{Value: "ipsec-transport", Label: "IPsec - Transport"}}
+	TunnelEncapsulationIPsecTunnel    = TunnelEncapsulation{Choice{Value: "ipsec-tunnel", Label: "IPsec - Tunnel"}}
+	TunnelEncapsulationIPIP           = TunnelEncapsulation{Choice{Value: "ip-ip", Label: "IP-in-IP"}}
+	TunnelEncapsulationGRE            = TunnelEncapsulation{Choice{Value: "gre", Label: "GRE"}}
+)
+
+// Tunnel represents a virtual point-to-point or hub-and-spoke connection
+// (e.g. IPsec site-to-site VPN) between two or more endpoints.
+type Tunnel struct {
+	NetboxObject
+	// Name of the tunnel. This field is required.
+	Name string `json:"name,omitempty"`
+	// Status of the tunnel. This field is required.
+	Status *TunnelStatus `json:"status,omitempty"`
+	// Group of the tunnel.
+	Group *TunnelGroup `json:"group,omitempty"`
+	// Encapsulation of the tunnel. This field is required.
+	Encapsulation *TunnelEncapsulation `json:"encapsulation,omitempty"`
+	// Tenant of the tunnel.
+	Tenant *Tenant `json:"tenant,omitempty"`
+	// TunnelID is numeric identifier of the tunnel.
+	TunnelID int64 `json:"tunnel_id,omitempty"`
+	// Comments for the tunnel.
+	Comments string `json:"comments,omitempty"`
+}
+
+func (t Tunnel) String() string {
+	status := ""
+	if t.Status != nil {
+		status = t.Status.Value
+	}
+	return fmt.Sprintf("Tunnel{Name: %s, Status: %s}", t.Name, status)
+}
+
+// Tunnel implements IDItem interface.
+func (t *Tunnel) GetID() int {
+	return t.ID
+}
+func (t *Tunnel) GetObjectType() constants.ContentType {
+	return constants.ContentTypeVpnTunnel
+}
+func (t *Tunnel) GetAPIPath() constants.APIPath {
+	return constants.TunnelsAPIPath
+}
+
+// Tunnel implements OrphanItem interface.
+func (t *Tunnel) GetNetboxObject() *NetboxObject {
+	return &t.NetboxObject
+}
+
+type TunnelTerminationRole struct {
+	Choice
+}
+
+// https://github.com/netbox-community/netbox/blob/main/netbox/vpn/choices.py
+var (
+	TunnelTerminationRolePeer  = TunnelTerminationRole{Choice{Value: "peer", Label: "Peer"}}
+	TunnelTerminationRoleHub   = TunnelTerminationRole{Choice{Value: "hub", Label: "Hub"}}
+	TunnelTerminationRoleSpoke = TunnelTerminationRole{Choice{Value: "spoke", Label: "Spoke"}}
+)
+
+// TunnelTermination represents attachment of an interface to the tunnel.
+// Each interface can be terminated to only one tunnel.
+type TunnelTermination struct {
+	NetboxObject
+	// Tunnel is the tunnel of the termination. This field is required.
+	Tunnel *Tunnel `json:"tunnel,omitempty"`
+	// Role of the termination within the tunnel. This field is required.
+	Role *TunnelTerminationRole `json:"role,omitempty"`
+	// TerminationType is the content type of the terminated interface
+	// (dcim.interface or virtualization.vminterface). This field is required.
+	TerminationType constants.ContentType `json:"termination_type,omitempty"`
+	// TerminationID is the ID of the terminated interface. This field is required.
+	TerminationID int `json:"termination_id,omitempty"`
+	// OutsideIP is the public (outer) ip address of the termination.
+	OutsideIP *IPAddress `json:"outside_ip,omitempty"`
+}
+
+func (tt TunnelTermination) String() string {
+	tunnelName := ""
+	if tt.Tunnel != nil {
+		tunnelName = tt.Tunnel.Name
+	}
+	return fmt.Sprintf(
+		"TunnelTermination{Tunnel: %s, TerminationType: %s, TerminationID: %d}",
+		tunnelName,
+		tt.TerminationType,
+		tt.TerminationID,
+	)
+}
+
+// TunnelTermination implements IDItem interface.
+func (tt *TunnelTermination) GetID() int {
+	return tt.ID
+}
+func (tt *TunnelTermination) GetObjectType() constants.ContentType {
+	return constants.ContentTypeVpnTunnelTermination
+}
+func (tt *TunnelTermination) GetAPIPath() constants.APIPath {
+	return constants.TunnelTerminationsAPIPath
+}
+
+// TunnelTermination implements OrphanItem interface.
+func (tt *TunnelTermination) GetNetboxObject() *NetboxObject {
+	return &tt.NetboxObject
+}
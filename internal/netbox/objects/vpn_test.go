@@ -0,0 +1,142 @@
+package objects
+
+import (
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+)
+
+func TestL2VPN_String(t *testing.T) {
+	tests := []struct {
+		name string
+		l    L2VPN
+		want string
+	}{
+		{
+			name: "Test l2vpn string output",
+			l: L2VPN{
+				Name:       "vnet1",
+				Type:       &L2VPNTypeVXLAN,
+				Identifier: 10001,
+			},
+			want: "L2VPN{Name: vnet1, Identifier: 10001}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.l.String(); got != tt.want {
+				t.Errorf("L2VPN.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestL2VPNTermination_String(t *testing.T) {
+	tests := []struct {
+		name string
+		lt   L2VPNTermination
+		want string
+	}{
+		{
+			name: "Test l2vpn termination string output",
+			lt: L2VPNTermination{
+				L2VPN:              &L2VPN{Name: "vnet1"},
+				AssignedObjectType: constants.ContentTypeVirtualizationVMInterface,
+				AssignedObjectID:   5,
+			},
+			want: "L2VPNTermination{L2VPN: vnet1, AssignedObjectType: virtualization.vminterface, AssignedObjectID: 5}",
+		},
+		{
+			name: "Test l2vpn termination without l2vpn string output",
+			lt: L2VPNTermination{
+				AssignedObjectType: constants.ContentTypeIpamVlan,
+				AssignedObjectID:   1,
+			},
+			want: "L2VPNTermination{L2VPN: , AssignedObjectType: ipam.vlan, AssignedObjectID: 1}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.lt.String(); got != tt.want {
+				t.Errorf("L2VPNTermination.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTunnelGroup_String(t *testing.T) {
+	tests := []struct {
+		name string
+		tg   TunnelGroup
+		want string
+	}{
+		{
+			name: "Test tunnel group string output",
+			tg:   TunnelGroup{Name: "fortigate"},
+			want: "TunnelGroup{Name: fortigate}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tg.String(); got != tt.want {
+				t.Errorf("TunnelGroup.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTunnel_String(t *testing.T) {
+	tests := []struct {
+		name string
+		t    Tunnel
+		want string
+	}{
+		{
+			name: "Test tunnel string output",
+			t: Tunnel{
+				Name:          "fw01-to-branch",
+				Status:        &TunnelStatusActive,
+				Encapsulation: &TunnelEncapsulationIPsecTunnel,
+			},
+			want: "Tunnel{Name: fw01-to-branch, Status: active}",
+		},
+		{
+			name: "Test tunnel without status string output",
+			t:    Tunnel{Name: "fw01-to-branch"},
+			want: "Tunnel{Name: fw01-to-branch, Status: }",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.String(); got != tt.want {
+				t.Errorf("Tunnel.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTunnelTermination_String(t *testing.T) {
+	tests := []struct {
+		name string
+		tt   TunnelTermination
+		want string
+	}{
+		{
+			name: "Test tunnel termination string output",
+			tt: TunnelTermination{
+				Tunnel:          &Tunnel{Name: "fw01-to-branch"},
+				Role:            &TunnelTerminationRolePeer,
+				TerminationType: constants.ContentTypeDcimInterface,
+				TerminationID:   7,
+			},
+			want: "TunnelTermination{Tunnel: fw01-to-branch, TerminationType: dcim.interface, TerminationID: 7}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tt.String(); got != tt.want {
+				t.Errorf("TunnelTermination.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
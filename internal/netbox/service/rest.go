@@ -54,12 +54,24 @@ func GetAll[T any](
 	netboxClient *NetboxClient,
 	extraParams string,
 ) ([]T, error) {
-	var allResults []T
 	var dummy T // Dummy variable for extracting type of generic
 	path := mapper.Type2Path[reflect.TypeOf(dummy)]
 	if path == "" {
 		return nil, fmt.Errorf("path not found for type %T", dummy)
 	}
+	return GetAllFromPath[T](ctx, netboxClient, path, extraParams)
+}
+
+// GetAllFromPath queries all objects of type T from the given api path.
+// It is used for objects, that don't have a fixed path (e.g. raw objects).
+func GetAllFromPath[T any](
+	ctx context.Context,
+	netboxClient *NetboxClient,
+	path constants.APIPath,
+	extraParams string,
+) ([]T, error) {
+	var allResults []T
+	var dummy T // Dummy variable for printf
 	limit := 250
 	offset := 0
 
@@ -119,6 +131,19 @@ func Patch[T any](
 	if objectPath == "" {
 		return nil, fmt.Errorf("path not found for type %T", dummy)
 	}
+	return PatchOnPath[T](ctx, netboxClient, objectPath, objectID, body)
+}
+
+// PatchOnPath patches the object of type T with the given id on the given api path.
+// It is used for objects, that don't have a fixed path (e.g. raw objects).
+func PatchOnPath[T any](
+	ctx context.Context,
+	netboxClient *NetboxClient,
+	objectPath constants.APIPath,
+	objectID int,
+	body map[string]interface{},
+) (*T, error) {
+	var dummy T // dummy variable for printf
 	path := fmt.Sprintf("%s%d/", objectPath, objectID)
 	netboxClient.Logger.Debugf(
 		ctx,
@@ -160,15 +185,26 @@ func Create[T any](ctx context.Context, netboxClient *NetboxClient, object *T) (
 	if objectPath == "" {
 		return nil, fmt.Errorf("path not found for type %T", dummy)
 	}
+	return CreateOnPath[T](ctx, netboxClient, objectPath, utils.StructToNetboxJSONMap(object))
+}
 
+// CreateOnPath creates a new object of type T on the given api path, with the given body.
+// It is used for objects, that don't have a fixed path (e.g. raw objects).
+func CreateOnPath[T any](
+	ctx context.Context,
+	netboxClient *NetboxClient,
+	objectPath constants.APIPath,
+	body map[string]interface{},
+) (*T, error) {
+	var dummy T // dummy variable for printf
 	netboxClient.Logger.Debugf(
 		ctx,
 		"Creating %T with path %s with data: %v",
 		dummy,
 		objectPath,
-		object,
+		body,
 	)
-	requestBody, err := utils.NetboxJSONMarshal(object)
+	requestBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,291 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// DefaultObject configures an object, that netbox-ssot creates by default (e.g. default site
+// or device role of servers). Attributes, that are not set, keep their default values.
+type DefaultObject struct {
+	Name        string `yaml:"name"`
+	Slug        string `yaml:"slug"`
+	Color       string `yaml:"color"`
+	Description string `yaml:"description"`
+	// PreviousNames are former names of the object. Existing object with any of these names
+	// is renamed to Name, instead of creating a new object.
+	PreviousNames []string `yaml:"previousNames"`
+}
+
+// DefaultRoles are device roles, that netbox-ssot assigns to devices and vms.
+type DefaultRoles struct {
+	Server     DefaultObject `yaml:"server"`
+	Switch     DefaultObject `yaml:"switch"`
+	Firewall   DefaultObject `yaml:"firewall"`
+	VM         DefaultObject `yaml:"vm"`
+	VMTemplate DefaultObject `yaml:"vmTemplate"`
+	Container  DefaultObject `yaml:"container"`
+}
+
+// DefaultsConfig configures objects, that netbox-ssot creates by default.
+type DefaultsConfig struct {
+	// Site is used for hosts, that have no site matched.
+	Site DefaultObject `yaml:"site"`
+	// VlanGroup groups vlans without site, that have no vlan group matched.
+	VlanGroup DefaultObject `yaml:"vlanGroup"`
+	// SiteVlanGroup groups vlans of a site, that have no vlan group matched.
+	// Its name and slug must contain {site}, which is replaced with the site name.
+	SiteVlanGroup DefaultObject `yaml:"siteVlanGroup"`
+	// Manufacturer is used for devices with unknown manufacturer.
+	Manufacturer DefaultObject `yaml:"manufacturer"`
+	// DeviceType is used for devices with unknown model. Its name is the model.
+	DeviceType DefaultObject `yaml:"deviceType"`
+	// OrphanTag marks orphaned objects. It can only be configured globally.
+	OrphanTag DefaultObject `yaml:"orphanTag"`
+	Roles     DefaultRoles  `yaml:"roles"`
+}
+
+// defaultObjectSpec describes a default object of DefaultsConfig.
+type defaultObjectSpec struct {
+	path   string
+	object func(defaults *DefaultsConfig) *DefaultObject
+	// hasColor is true for objects with color.
+	hasColor bool
+	// hasSlug is true for objects, whose slug can be configured. If slug is not set,
+	// it is derived from the name, unless name is a template.
+	hasSlug bool
+	// isTemplate is true for objects, whose name and slug contain constants.SitePlaceholder.
+	isTemplate bool
+}
+
+// defaultObjectSpecs are all default objects of DefaultsConfig.
+var defaultObjectSpecs = []defaultObjectSpec{
+	{path: "site", object: func(d *DefaultsConfig) *DefaultObject { return &d.Site }, hasSlug: true},
+	{path: "vlanGroup", object: func(d *DefaultsConfig) *DefaultObject { return &d.VlanGroup }, hasSlug: true},
+	{
+		path:       "siteVlanGroup",
+		object:     func(d *DefaultsConfig) *DefaultObject { return &d.SiteVlanGroup },
+		hasSlug:    true,
+		isTemplate: true,
+	},
+	{path: "manufacturer", object: func(d *DefaultsConfig) *DefaultObject { return &d.Manufacturer }, hasSlug: true},
+	// Slugs of device types are derived from manufacturer and model
+	{path: "deviceType", object: func(d *DefaultsConfig) *DefaultObject { return &d.DeviceType }},
+	{
+		path:     "orphanTag",
+		object:   func(d *DefaultsConfig) *DefaultObject { return &d.OrphanTag },
+		hasColor: true,
+		hasSlug:  true,
+	},
+	{
+		path:     "roles.server",
+		object:   func(d *DefaultsConfig) *DefaultObject { return &d.Roles.Server },
+		hasColor: true,
+		hasSlug:  true,
+	},
+	{
+		path:     "roles.switch",
+		object:   func(d *DefaultsConfig) *DefaultObject { return &d.Roles.Switch },
+		hasColor: true,
+		hasSlug:  true,
+	},
+	{
+		path:     "roles.firewall",
+		object:   func(d *DefaultsConfig) *DefaultObject { return &d.Roles.Firewall },
+		hasColor: true,
+		hasSlug:  true,
+	},
+	{
+		path:     "roles.vm",
+		object:   func(d *DefaultsConfig) *DefaultObject { return &d.Roles.VM },
+		hasColor: true,
+		hasSlug:  true,
+	},
+	{
+		path:     "roles.vmTemplate",
+		object:   func(d *DefaultsConfig) *DefaultObject { return &d.Roles.VMTemplate },
+		hasColor: true,
+		hasSlug:  true,
+	},
+	{
+		path:     "roles.container",
+		object:   func(d *DefaultsConfig) *DefaultObject { return &d.Roles.Container },
+		hasColor: true,
+		hasSlug:  true,
+	},
+}
+
+// colorRegex matches colors in netbox format, e.g. 00add8.
+var colorRegex = regexp.MustCompile(`^[0-9a-f]{6}$`)
+
+// BuiltinDefaults returns default objects, that are used when defaults are not configured.
+func BuiltinDefaults() *DefaultsConfig {
+	defaults := &DefaultsConfig{
+		Site: DefaultObject{
+			Name:        constants.DefaultSite,
+			Description: constants.DefaultSiteDescription,
+		},
+		VlanGroup: DefaultObject{
+			Name:        constants.DefaultVlanGroupName,
+			Description: constants.DefaultVlanGroupDescription,
+		},
+		SiteVlanGroup: DefaultObject{
+			Name:        constants.DefaultSiteVlanGroupName,
+			Description: constants.DefaultVlanGroupDescription,
+		},
+		Manufacturer: DefaultObject{
+			Name:        constants.DefaultManufacturer,
+			Description: constants.DefaultManufacturerDescription,
+		},
+		DeviceType: DefaultObject{
+			Name:        constants.DefaultModel,
+			Description: constants.DefaultDeviceTypeDescription,
+		},
+		OrphanTag: DefaultObject{
+			Name:        constants.OrphanTagName,
+			Color:       constants.OrphanTagColor,
+			Description: constants.OrphanTagDescription,
+		},
+		Roles: DefaultRoles{
+			Server: DefaultObject{
+				Name:        constants.DeviceRoleServer,
+				Color:       constants.DeviceRoleServerColor,
+				Description: constants.DeviceRoleServerDescription,
+			},
+			Switch: DefaultObject{
+				Name:        constants.DeviceRoleSwitch,
+				Color:       constants.DeviceRoleSwitchColor,
+				Description: constants.DeviceRoleSwitchDescription,
+			},
+			Firewall: DefaultObject{
+				Name:        constants.DeviceRoleFirewall,
+				Color:       constants.DeviceRoleFirewallColor,
+				Description: constants.DeviceRoleFirewallDescription,
+			},
+			VM: DefaultObject{
+				Name:        constants.DeviceRoleVM,
+				Color:       constants.DeviceRoleVMColor,
+				Description: constants.DeviceRoleVMDescription,
+			},
+			VMTemplate: DefaultObject{
+				Name:        constants.DeviceRoleVMTemplate,
+				Color:       constants.DeviceRoleVMTemplateColor,
+				Description: constants.DeviceRoleVMTemplateDescription,
+			},
+			Container: DefaultObject{
+				Name:        constants.DeviceRoleContainer,
+				Color:       constants.DeviceRoleContainerColor,
+				Description: constants.DeviceRoleContainerDescription,
+			},
+		},
+	}
+	for _, spec := range defaultObjectSpecs {
+		if spec.hasSlug && !spec.isTemplate {
+			spec.object(defaults).Slug = utils.Slugify(spec.object(defaults).Name)
+		}
+	}
+	return defaults
+}
+
+// mergeDefaults returns defaults with attributes of overrides, that are set. When name
+// of an object is overridden, its slug and previous names are not inherited.
+func mergeDefaults(defaults *DefaultsConfig, overrides *DefaultsConfig) *DefaultsConfig {
+	merged := *defaults
+	if overrides == nil {
+		return &merged
+	}
+	for _, spec := range defaultObjectSpecs {
+		object, override := spec.object(&merged), spec.object(overrides)
+		if override.Name != "" && override.Name != object.Name {
+			object.Name = override.Name
+			object.Slug = ""
+			object.PreviousNames = nil
+			if spec.hasSlug && !spec.isTemplate {
+				object.Slug = utils.Slugify(override.Name)
+			}
+		}
+		if override.Slug != "" {
+			object.Slug = override.Slug
+		}
+		if override.Color != "" {
+			object.Color = override.Color
+		}
+		if override.Description != "" {
+			object.Description = override.Description
+		}
+		if override.PreviousNames != nil {
+			object.PreviousNames = override.PreviousNames
+		}
+	}
+	return &merged
+}
+
+// validateDefaultsConfig validates global defaults, and merges them with the built-in defaults.
+func validateDefaultsConfig(config *Config) error {
+	err := validateDefaults("defaults", config.Defaults)
+	config.Defaults = mergeDefaults(BuiltinDefaults(), config.Defaults)
+	return err
+}
+
+// validateDefaults validates configured default objects. Path is the path of defaults
+// in the configuration, e.g. "defaults".
+func validateDefaults(path string, overrides *DefaultsConfig) error {
+	if overrides == nil {
+		return nil
+	}
+	for _, spec := range defaultObjectSpecs {
+		object := spec.object(overrides)
+		objectPath := fmt.Sprintf("%s.%s", path, spec.path)
+		if object.Color != "" {
+			if !spec.hasColor {
+				return fmt.Errorf("%s.color: %s has no color", objectPath, spec.path)
+			}
+			if !colorRegex.MatchString(object.Color) {
+				return fmt.Errorf("%s.color: must be 6 lowercase hex digits (e.g. 00add8). Is %s", objectPath, object.Color)
+			}
+		}
+		if object.Slug != "" && !spec.hasSlug {
+			return fmt.Errorf("%s.slug: slug of %s can't be configured", objectPath, spec.path)
+		}
+		if spec.isTemplate {
+			if object.Name != "" && !strings.Contains(object.Name, constants.SitePlaceholder) {
+				return fmt.Errorf("%s.name: must contain %s", objectPath, constants.SitePlaceholder)
+			}
+			if object.Slug != "" && !strings.Contains(object.Slug, constants.SitePlaceholder) {
+				return fmt.Errorf("%s.slug: must contain %s", objectPath, constants.SitePlaceholder)
+			}
+		}
+		if object.Name != "" && slices.Contains(object.PreviousNames, object.Name) {
+			return fmt.Errorf("%s.previousNames: %s is the current name", objectPath, object.Name)
+		}
+	}
+	return nil
+}
+
+// validateSourceDefaults validates default objects of the source, and merges them with
+// the global defaults.
+func validateSourceDefaults(defaults *DefaultsConfig, sourceConfig *SourceConfig) error {
+	overrides := &sourceConfig.Defaults
+	path := sourceConfig.Name + ".defaults"
+	if err := validateDefaults(path, overrides); err != nil {
+		return err
+	}
+	if overrides.OrphanTag.Name != "" || overrides.OrphanTag.Slug != "" || overrides.OrphanTag.Color != "" ||
+		overrides.OrphanTag.Description != "" || overrides.OrphanTag.PreviousNames != nil {
+		return fmt.Errorf("%s.orphanTag: can only be configured in defaults", path)
+	}
+	// Objects with the source's name would be renamed by other sources, and created again
+	for _, spec := range defaultObjectSpecs {
+		name := spec.object(overrides).Name
+		if name != "" && slices.Contains(spec.object(defaults).PreviousNames, name) {
+			return fmt.Errorf("%s.%s.name: %s is a previous name in defaults.%s", path, spec.path, name, spec.path)
+		}
+	}
+	sourceConfig.Defaults = *mergeDefaults(defaults, overrides)
+	return nil
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+)
+
+func TestMergeDefaults(t *testing.T) {
+	config, err := ParseConfig(filepath.Join("../../testdata/parser", "valid_config21.yaml"))
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+
+	wantSite := DefaultObject{
+		Name:          "Unassigned",
+		Slug:          "unassigned",
+		Description:   constants.DefaultSiteDescription,
+		PreviousNames: []string{"DefaultSite"},
+	}
+	if !reflect.DeepEqual(config.Defaults.Site, wantSite) {
+		t.Errorf("Defaults.Site = %+v, want %+v", config.Defaults.Site, wantSite)
+	}
+	wantServer := DefaultObject{
+		Name:          "Hypervisor",
+		Slug:          "hypervisor",
+		Color:         "2196f3",
+		Description:   constants.DeviceRoleServerDescription,
+		PreviousNames: []string{"Server"},
+	}
+	if !reflect.DeepEqual(config.Defaults.Roles.Server, wantServer) {
+		t.Errorf("Defaults.Roles.Server = %+v, want %+v", config.Defaults.Roles.Server, wantServer)
+	}
+	if config.Defaults.OrphanTag.Name != constants.OrphanTagName || config.Defaults.OrphanTag.Color != "ff0000" {
+		t.Errorf("Defaults.OrphanTag = %+v", config.Defaults.OrphanTag)
+	}
+	if config.Defaults.SiteVlanGroup.Name != "{site} vlans" || config.Defaults.SiteVlanGroup.Slug != "" {
+		t.Errorf("Defaults.SiteVlanGroup = %+v", config.Defaults.SiteVlanGroup)
+	}
+
+	// Source defaults inherit global defaults
+	sourceDefaults := config.Sources[0].Defaults
+	if !reflect.DeepEqual(sourceDefaults.Site, wantSite) {
+		t.Errorf("source Defaults.Site = %+v, want %+v", sourceDefaults.Site, wantSite)
+	}
+	if !reflect.DeepEqual(sourceDefaults.OrphanTag, config.Defaults.OrphanTag) {
+		t.Errorf("source Defaults.OrphanTag = %+v, want %+v", sourceDefaults.OrphanTag, config.Defaults.OrphanTag)
+	}
+	wantManufacturer := DefaultObject{
+		Name:        "Unknown",
+		Slug:        "unknown",
+		Description: constants.DefaultManufacturerDescription,
+	}
+	if !reflect.DeepEqual(sourceDefaults.Manufacturer, wantManufacturer) {
+		t.Errorf("source Defaults.Manufacturer = %+v, want %+v", sourceDefaults.Manufacturer, wantManufacturer)
+	}
+	wantVM := DefaultObject{
+		Name:        constants.DeviceRoleVM,
+		Slug:        "vm",
+		Color:       constants.DeviceRoleVMColor,
+		Description: "Virtual machine from vcenter",
+	}
+	if !reflect.DeepEqual(sourceDefaults.Roles.VM, wantVM) {
+		t.Errorf("source Defaults.Roles.VM = %+v, want %+v", sourceDefaults.Roles.VM, wantVM)
+	}
+	// Global defaults are not changed by source overrides
+	if config.Defaults.Manufacturer.Name != constants.DefaultManufacturer {
+		t.Errorf("Defaults.Manufacturer.Name = %s, want %s", config.Defaults.Manufacturer.Name, constants.DefaultManufacturer)
+	}
+}
@@ -0,0 +1,395 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configDocument is a parsed configuration file.
+type configDocument struct {
+	filename string
+	root     *yaml.Node
+}
+
+// configLoader loads configuration files, together with files they include.
+type configLoader struct {
+	// loaded are absolute paths of already loaded files, so each file is loaded only once.
+	loaded    map[string]bool
+	documents []configDocument
+	// sources are nodes of all sources, set when merging documents.
+	sources []configDocument
+}
+
+// fileError is an error in the configuration file. Its message is the message of
+// the wrapped error, file is only used to locate the error.
+type fileError struct {
+	filename string
+	err      error
+}
+
+func (fe fileError) Error() string {
+	return fe.err.Error()
+}
+
+func (fe fileError) Unwrap() error {
+	return fe.err
+}
+
+// loadConfigFiles loads all configuration files on the config path.
+func loadConfigFiles(configPath string) (*configLoader, error) {
+	filenames, err := configFilenames(configPath)
+	if err != nil {
+		return nil, err
+	}
+	loader := &configLoader{loaded: make(map[string]bool)}
+	for _, filename := range filenames {
+		if err := loader.load(filename); err != nil {
+			return nil, err
+		}
+	}
+	return loader, nil
+}
+
+// configFilenames returns configuration files on the config path. If config path
+// is a directory, all yaml files in the directory are returned in lexical order.
+func configFilenames(configPath string) ([]string, error) {
+	fileInfo, err := os.Stat(configPath)
+	if err != nil || !fileInfo.IsDir() {
+		// Errors of missing files are reported when reading them
+		return []string{configPath}, nil
+	}
+	filenames := []string{}
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(configPath, pattern))
+		if err != nil {
+			return nil, err
+		}
+		filenames = append(filenames, matches...)
+	}
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("no configuration files found in %s", configPath)
+	}
+	slices.Sort(filenames)
+	return filenames, nil
+}
+
+// load loads the configuration file and all files it includes.
+func (cl *configLoader) load(filename string) error {
+	absFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return err
+	}
+	if cl.loaded[absFilename] {
+		return nil
+	}
+	cl.loaded[absFilename] = true
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return fmt.Errorf("%s: %s", filename, err)
+	}
+	// Empty file
+	if len(document.Content) == 0 {
+		return nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: configuration must be a mapping", filename)
+	}
+	cl.documents = append(cl.documents, configDocument{filename: filename, root: root})
+
+	includeNode := mappingValue(root, "include")
+	if includeNode == nil {
+		return nil
+	}
+	var includes []string
+	if err := includeNode.Decode(&includes); err != nil {
+		return fmt.Errorf("%s: include: %s", filename, err)
+	}
+	for _, include := range includes {
+		// Included paths are relative to the including file
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(filename), include)
+		}
+		matches, err := filepath.Glob(include)
+		if err != nil {
+			return fmt.Errorf("%s: include: %s", filename, err)
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("%s: include: no files match %s", filename, include)
+		}
+		for _, match := range matches {
+			if err := cl.load(match); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mappingValue returns value node of the key in the mapping node, or nil if key is missing.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if index := mappingValueIndex(mapping, key); index >= 0 {
+		return resolveAlias(mapping.Content[index])
+	}
+	return nil
+}
+
+// mappingValueIndex returns index of value node of the key in the mapping node's content,
+// or -1 if key is missing.
+func mappingValueIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// resolveAlias returns the node, that the alias node (e.g. *relations) points to.
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// relationAttributes are yaml names of source attributes, that can be defined
+// in relation profiles (all attributes of format "regex = value").
+var relationAttributes = map[string]bool{
+	"datacenterClusterGroupRelations": true,
+	"hostSiteRelations":               true,
+	"hostRoleRelations":               true,
+	"clusterSiteRelations":            true,
+	"clusterTenantRelations":          true,
+	"hostTenantRelations":             true,
+	"vmTenantRelations":               true,
+	"vmRoleRelations":                 true,
+	"vlanGroupRelations":              true,
+	"vlanGroupSiteRelations":          true,
+	"vlanTenantRelations":             true,
+	"vlanSiteRelations":               true,
+	"wlanTenantRelations":             true,
+	"hostRackRelations":               true,
+	"hostRackPositionRelations":       true,
+	"hostRackFaceRelations":           true,
+	"rackLocationRelations":           true,
+	"rackRoleRelations":               true,
+	"hostPowerFeedRelations":          true,
+	"customFieldMappings":             true,
+	"vmRoleServices":                  true,
+}
+
+// mergeDocuments merges loaded configuration documents into the config.
+// Logger, netbox, secrets and defaults blocks can be defined in only one document, sources,
+// relation profiles and relation lookups of all documents are merged. All errors are returned.
+//
+//nolint:gocyclo
+func (cl *configLoader) mergeDocuments(config *Config) []error {
+	blocks := map[string]interface{}{
+		"logger":   config.Logger,
+		"netbox":   config.Netbox,
+		"secrets":  &config.Secrets,
+		"defaults": &config.Defaults,
+	}
+	blockFilenames := make(map[string]string)
+	profiles := make(map[string]*yaml.Node)
+	profileFilenames := make(map[string]string)
+	lookupFilenames := make(map[string]string)
+	errs := []error{}
+
+	for _, document := range cl.documents {
+		for i := 0; i+1 < len(document.root.Content); i += 2 {
+			key, value := document.root.Content[i].Value, document.root.Content[i+1]
+			switch key {
+			case "logger", "netbox", "secrets", "defaults":
+				if filename, ok := blockFilenames[key]; ok {
+					errs = append(errs, fmt.Errorf("%s: defined in both %s and %s", key, filename, document.filename))
+					continue
+				}
+				blockFilenames[key] = document.filename
+				if err := value.Decode(blocks[key]); err != nil {
+					errs = append(errs, fileError{filename: document.filename, err: err})
+				}
+			case "source":
+				if value.Tag == "!!null" {
+					continue
+				}
+				if value.Kind != yaml.SequenceNode {
+					errs = append(errs, fmt.Errorf("%s: source must be a list", document.filename))
+					continue
+				}
+				for _, sourceNode := range value.Content {
+					cl.sources = append(
+						cl.sources,
+						configDocument{filename: document.filename, root: resolveAlias(sourceNode)},
+					)
+				}
+			case "relationLookups":
+				if value.Kind != yaml.MappingNode {
+					errs = append(errs, fmt.Errorf("%s: relationLookups must be a mapping", document.filename))
+					continue
+				}
+				if config.RelationLookups == nil {
+					config.RelationLookups = make(map[string]map[string]string)
+				}
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					tableName := value.Content[j].Value
+					if filename, ok := lookupFilenames[tableName]; ok {
+						errs = append(errs, fmt.Errorf(
+							"relationLookups.%s: defined in both %s and %s",
+							tableName, filename, document.filename,
+						))
+						continue
+					}
+					lookupFilenames[tableName] = document.filename
+					var table map[string]string
+					if err := value.Content[j+1].Decode(&table); err != nil {
+						errs = append(errs, fileError{filename: document.filename, err: err})
+						continue
+					}
+					config.RelationLookups[tableName] = table
+				}
+			case "relationProfiles":
+				if value.Kind != yaml.MappingNode {
+					errs = append(errs, fmt.Errorf("%s: relationProfiles must be a mapping", document.filename))
+					continue
+				}
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					profileName := value.Content[j].Value
+					if filename, ok := profileFilenames[profileName]; ok {
+						errs = append(errs, fmt.Errorf(
+							"relationProfiles.%s: defined in both %s and %s",
+							profileName, filename, document.filename,
+						))
+						continue
+					}
+					profileFilenames[profileName] = document.filename
+					profiles[profileName] = resolveAlias(value.Content[j+1])
+				}
+			}
+		}
+	}
+
+	errs = append(errs, validateRelationProfiles(profiles)...)
+
+	sourceFilenames := make(map[string]string)
+	for _, sourceNode := range cl.sources {
+		if err := applyRelationProfiles(sourceNode.root, profiles); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var sourceConfig SourceConfig
+		if err := sourceNode.root.Decode(&sourceConfig); err != nil {
+			errs = append(errs, fileError{filename: sourceNode.filename, err: err})
+			continue
+		}
+		if filename, ok := sourceFilenames[sourceConfig.Name]; ok && sourceConfig.Name != "" {
+			errs = append(errs, fmt.Errorf(
+				"source %s: duplicate source name, defined in %s and %s",
+				sourceConfig.Name, filename, sourceNode.filename,
+			))
+			continue
+		}
+		sourceFilenames[sourceConfig.Name] = sourceNode.filename
+		config.Sources = append(config.Sources, sourceConfig)
+	}
+	return errs
+}
+
+// validateRelationProfiles validates, that relation profiles only contain lists of relations.
+func validateRelationProfiles(profiles map[string]*yaml.Node) []error {
+	errs := []error{}
+	profileNames := slices.Sorted(maps.Keys(profiles))
+	for _, profileName := range profileNames {
+		profile := profiles[profileName]
+		if profile.Kind != yaml.MappingNode {
+			errs = append(errs, fmt.Errorf("relationProfiles.%s: must be a mapping of relations", profileName))
+			continue
+		}
+		for i := 0; i+1 < len(profile.Content); i += 2 {
+			attribute := profile.Content[i].Value
+			if !relationAttributes[attribute] {
+				errs = append(
+					errs,
+					fmt.Errorf("relationProfiles.%s: %s is not a relation attribute", profileName, attribute),
+				)
+			} else if resolveAlias(profile.Content[i+1]).Kind != yaml.SequenceNode {
+				errs = append(errs, fmt.Errorf("relationProfiles.%s.%s: must be a list", profileName, attribute))
+			}
+		}
+	}
+	return errs
+}
+
+// applyRelationProfiles adds relations of profiles, referenced in source's relationProfiles
+// attribute, to the source node. Relations defined in the source itself take precedence
+// over relations of profiles, and relations of earlier profiles over the later ones.
+func applyRelationProfiles(sourceNode *yaml.Node, profiles map[string]*yaml.Node) error {
+	// Invalid source nodes are reported when decoding them
+	if sourceNode.Kind != yaml.MappingNode {
+		return nil
+	}
+	profilesNode := mappingValue(sourceNode, "relationProfiles")
+	if profilesNode == nil {
+		return nil
+	}
+	var profileNames []string
+	if err := profilesNode.Decode(&profileNames); err != nil {
+		return fmt.Errorf("%s.relationProfiles: %s", sourceName(sourceNode), err)
+	}
+	// Later relations override earlier ones, so profiles are applied in reverse order
+	for i := len(profileNames) - 1; i >= 0; i-- {
+		profile, ok := profiles[profileNames[i]]
+		if !ok {
+			return fmt.Errorf(
+				"%s.relationProfiles: profile %s is not defined",
+				sourceName(sourceNode), profileNames[i],
+			)
+		}
+		for j := 0; j+1 < len(profile.Content); j += 2 {
+			attribute, relations := profile.Content[j].Value, resolveAlias(profile.Content[j+1])
+			// Invalid profile attributes are reported by validateRelationProfiles
+			if !relationAttributes[attribute] || relations.Kind != yaml.SequenceNode {
+				continue
+			}
+			// Nodes are replaced instead of modified, because they can be shared with yaml anchors
+			mergedRelations := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			mergedRelations.Content = slices.Clone(relations.Content)
+			index := mappingValueIndex(sourceNode, attribute)
+			if index < 0 {
+				sourceNode.Content = append(
+					sourceNode.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: attribute},
+					mergedRelations,
+				)
+				continue
+			}
+			sourceRelations := resolveAlias(sourceNode.Content[index])
+			if sourceRelations.Kind != yaml.SequenceNode {
+				return fmt.Errorf("%s.%s: must be a list", sourceName(sourceNode), attribute)
+			}
+			mergedRelations.Content = append(mergedRelations.Content, sourceRelations.Content...)
+			sourceNode.Content[index] = mergedRelations
+		}
+	}
+	return nil
+}
+
+// sourceName returns name of the source node, used in error messages.
+func sourceName(sourceNode *yaml.Node) string {
+	if nameNode := mappingValue(sourceNode, "name"); nameNode != nil {
+		return strings.TrimSpace(nameNode.Value)
+	}
+	return "source"
+}
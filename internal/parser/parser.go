@@ -3,10 +3,19 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"net"
 	"os"
+	"reflect"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/mapper"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
 	"github.com/bl4ko/netbox-ssot/internal/utils"
 	"gopkg.in/yaml.v3"
 )
@@ -15,6 +24,14 @@ type Config struct {
 	Logger  *LoggerConfig  `yaml:"logger"`
 	Netbox  *NetboxConfig  `yaml:"netbox"`
 	Sources []SourceConfig `yaml:"source"`
+	// Secrets configures external secret stores, that secrets can reference.
+	Secrets *SecretsConfig `yaml:"secrets"`
+	// RelationLookups are lookup tables, that relation values can reference
+	// with lookup filter, e.g. ${1|lookup:sites}.
+	RelationLookups map[string]map[string]string `yaml:"relationLookups"`
+	// Defaults configures objects, that netbox-ssot creates by default. Sources can
+	// override them with their own defaults.
+	Defaults *DefaultsConfig `yaml:"defaults"`
 }
 
 type LoggerConfig struct {
@@ -79,8 +96,10 @@ const (
 // In netbox block.
 type NetboxConfig struct {
 	APIToken string `yaml:"apiToken"`
-	Hostname string `yaml:"hostname"`
-	Port     int    `yaml:"port"`
+	// APITokenFile is a path to the file containing api token (e.g. mounted kubernetes secret).
+	APITokenFile string `yaml:"apiTokenFile"`
+	Hostname     string `yaml:"hostname"`
+	Port         int    `yaml:"port"`
 	// Can be http or https (default)
 	HTTPScheme             HTTPScheme `yaml:"httpScheme"`
 	ValidateCert           bool       `yaml:"validateCert"`
@@ -91,6 +110,43 @@ type NetboxConfig struct {
 	RemoveOrphansAfterDays int        `yaml:"removeOrphansAfterDays"`
 	SourcePriority         []string   `yaml:"sourcePriority"`
 	CAFile                 string     `yaml:"caFile"`
+
+	// RIRs that are created in netbox. RIRs of aggregates, that are not listed here,
+	// are created as public RIRs.
+	RIRs []RIRConfig `yaml:"rirs"`
+	// Aggregates that are created in netbox.
+	Aggregates []AggregateConfig `yaml:"aggregates"`
+	// CreateParentPrefixes enables creation of missing container prefixes,
+	// derived from synced ip addresses and prefixes.
+	CreateParentPrefixes    bool  `yaml:"createParentPrefixes"`
+	ParentPrefixLengthsIPv4 []int `yaml:"parentPrefixLengthsIPv4"`
+	ParentPrefixLengthsIPv6 []int `yaml:"parentPrefixLengthsIPv6"`
+	// RawObjectTypes are api paths of objects, that have no dedicated model
+	// in netbox-ssot (e.g. objects of netbox plugins), but can be synced as raw objects.
+	RawObjectTypes []RawObjectType `yaml:"rawObjectTypes"`
+}
+
+// RIRConfig represents a regional internet registry configured in the netbox config.
+type RIRConfig struct {
+	Name    string `yaml:"name"`
+	Private bool   `yaml:"private"`
+}
+
+// AggregateConfig represents an aggregate configured in the netbox config.
+type AggregateConfig struct {
+	Prefix      string `yaml:"prefix"`
+	RIR         string `yaml:"rir"`
+	Description string `yaml:"description"`
+}
+
+// RawObjectType represents an api path of raw objects configured in the netbox config.
+type RawObjectType struct {
+	// Path is the api path of objects, e.g. /api/plugins/bgp/session/.
+	Path string `yaml:"path"`
+	// ObjectType is netbox's object type of objects, e.g. netbox_bgp.bgpsession.
+	ObjectType string `yaml:"objectType"`
+	// NaturalKey are names of attributes, which together uniquely identify an object.
+	NaturalKey []string `yaml:"naturalKey"`
 }
 
 func (n NetboxConfig) String() string {
@@ -98,7 +154,7 @@ func (n NetboxConfig) String() string {
 		"NetboxConfig{ApiToken: %s, Hostname: %s, Port: %d, "+
 			"HTTPScheme: %s, ValidateCert: %t, Timeout: %d, "+
 			"Tag: %s, TagColor: %s, RemoveOrphans: %t, RemoveOrphansAfterDays: %d}",
-		n.APIToken,
+		redactSecret(n.APIToken),
 		n.Hostname,
 		n.Port,
 		n.HTTPScheme,
@@ -120,7 +176,9 @@ type SourceConfig struct {
 	Port                int                  `yaml:"port"`
 	Username            string               `yaml:"username"`
 	Password            string               `yaml:"password"`
+	PasswordFile        string               `yaml:"passwordFile"`
 	APIToken            string               `yaml:"apiToken"`
+	APITokenFile        string               `yaml:"apiTokenFile"`
 	ValidateCert        bool                 `yaml:"validateCert"`
 	Tag                 string               `yaml:"tag"`
 	TagColor            string               `yaml:"tagColor"`
@@ -133,6 +191,46 @@ type SourceConfig struct {
 	IgnoreSerialNumbers bool                 `yaml:"ignoreSerialNumbers"`
 	IgnoreVMTemplates   bool                 `yaml:"ignoreVMTemplates"`
 
+	// CollectLocalContext enables writing configuration data (e.g. dns, ntp and syslog servers),
+	// collected from the source, to local context data of the devices.
+	CollectLocalContext bool `yaml:"collectLocalContext"`
+	// LocalContextKeys is a whitelist of keys, that are written to local context data.
+	LocalContextKeys []string `yaml:"localContextKeys"`
+
+	// PrimaryIPPolicy configures, how primary and oob ip addresses of devices and vms are chosen.
+	PrimaryIPPolicy PrimaryIPPolicy `yaml:"primaryIPPolicy"`
+
+	// RawObjects are documents of raw objects, that are synced with the source.
+	RawObjects []RawObjectConfig `yaml:"rawObjects"`
+
+	// RelationProfiles are names of relation profiles, whose relations are added to the source.
+	RelationProfiles []string `yaml:"relationProfiles"`
+
+	// Transforms are transforms for each object type, that are applied to objects
+	// before they are added to the inventory.
+	Transforms map[string][]utils.Transform `yaml:"transforms"`
+
+	// Filters are filters for each object type. Objects, that don't pass the filter,
+	// are not added to the inventory.
+	Filters map[string]utils.Filter `yaml:"filters"`
+
+	// Enabled sources are synced. Disabled sources are skipped, and their objects are not
+	// removed as orphans. Defaults to true.
+	Enabled bool `yaml:"enabled"`
+	// Priority orders sources in a run. Sources with higher priority are synced first,
+	// sources with the same priority are synced in parallel.
+	Priority int `yaml:"priority"`
+	// Schedule is a cron expression, when the source is synced in long-running mode.
+	// Sources without schedule are synced on every run.
+	Schedule string `yaml:"schedule"`
+	// MaintenanceWindows are time windows, when the source can be synced. If there are
+	// none, the source can be synced at any time.
+	MaintenanceWindows []utils.MaintenanceWindow `yaml:"maintenanceWindows"`
+
+	// Defaults override global defaults for objects of this source. After parsing
+	// they are merged with global defaults.
+	Defaults DefaultsConfig `yaml:"defaults"`
+
 	// Relations
 	DatacenterClusterGroupRelations map[string]string `yaml:"datacenterClusterGroupRelations"`
 	HostSiteRelations               map[string]string `yaml:"hostSiteRelations"`
@@ -147,49 +245,117 @@ type SourceConfig struct {
 	VlanTenantRelations             map[string]string `yaml:"vlanTenantRelations"`
 	VlanSiteRelations               map[string]string `yaml:"vlanSiteRelations"`
 	WlanTenantRelations             map[string]string `yaml:"wlanTenantRelations"`
+	HostRackRelations               map[string]string `yaml:"hostRackRelations"`
+	HostRackPositionRelations       map[string]string `yaml:"hostRackPositionRelations"`
+	HostRackFaceRelations           map[string]string `yaml:"hostRackFaceRelations"`
+	RackLocationRelations           map[string]string `yaml:"rackLocationRelations"`
+	RackRoleRelations               map[string]string `yaml:"rackRoleRelations"`
+	HostPowerFeedRelations          map[string]string `yaml:"hostPowerFeedRelations"`
 	CustomFieldMappings             map[string]string `yaml:"customFieldMappings"`
+	// RelationRules are relation rules, defined in lists of relations together with regex relations.
+	RelationRules RelationRules `yaml:"-"`
+
+	// VMRoleServices maps vm role name to services, that run on each vm with that role.
+	VMRoleServices map[string][]ServiceDefinition `yaml:"vmRoleServices"`
+}
+
+// PrimaryIPPolicy configures, how primary and oob ip addresses are chosen from
+// ip addresses of a device or a vm. Rules are applied in the following order:
+// preferred subnets, interface regex, dns resolvability, and finally source specific
+// hints (e.g. address in the subnet of vm's default gateway).
+type PrimaryIPPolicy struct {
+	// PreferredSubnets are subnets in order of preference. Addresses in earlier subnets are preferred.
+	PreferredSubnets []string `yaml:"preferredSubnets"`
+	// InterfaceRegex prefers addresses of interfaces, whose name matches the regex.
+	InterfaceRegex string `yaml:"interfaceRegex"`
+	// PreferDNSResolvable prefers addresses, that the object's name resolves to,
+	// or that have a dns name (ptr record).
+	PreferDNSResolvable bool `yaml:"preferDNSResolvable"`
+	// IPVersions are ip versions in order of priority (e.g. [4, 6]). Primary ip is only set
+	// for the listed versions. Oob ip is chosen from the first version with oob addresses.
+	IPVersions []int `yaml:"ipVersions"`
+	// OOBInterfaceRegex matches interfaces, whose addresses are used as oob ip of the device.
+	// Addresses of mgmt_only interfaces are always used as oob ip.
+	OOBInterfaceRegex string `yaml:"oobInterfaceRegex"`
+}
+
+// RawObjectConfig is a json document of a raw object on the given api path.
+// Path must be one of netbox.rawObjectTypes.
+type RawObjectConfig struct {
+	Path   string                 `yaml:"path"`
+	Object map[string]interface{} `yaml:"object"`
+}
+
+// ServiceDefinition is a user configured service, that is added to matching objects.
+type ServiceDefinition struct {
+	Name     string
+	Protocol string
+	Ports    []int
 }
 
 // UnmarshalYAML is a custom unmarshal function for SourceConfig.
 // This is needed because we map relations to the map[string]string.
-func (sc *SourceConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+func (sc *SourceConfig) UnmarshalYAML(sourceNode *yaml.Node) error {
 	type realSourceConfig struct {
-		Name                            string               `yaml:"name"`
-		Type                            constants.SourceType `yaml:"type"`
-		HTTPScheme                      HTTPScheme           `yaml:"httpScheme"`
-		Hostname                        string               `yaml:"hostname"`
-		Port                            int                  `yaml:"port"`
-		Username                        string               `yaml:"username"`
-		Password                        string               `yaml:"password"`
-		APIToken                        string               `yaml:"apiToken"`
-		ValidateCert                    bool                 `yaml:"validateCert"`
-		Tag                             string               `yaml:"tag"`
-		TagColor                        string               `yaml:"tagColor"`
-		IgnoredSubnets                  []string             `yaml:"ignoredSubnets"`
-		PermittedSubnets                []string             `yaml:"permittedSubnets"`
-		InterfaceFilter                 string               `yaml:"interfaceFilter"`
-		CollectArpData                  bool                 `yaml:"collectArpData"`
-		CAFile                          string               `yaml:"caFile"`
-		IgnoreSerialNumbers             bool                 `yaml:"ignoreSerialNumbers"`
-		IgnoreAssetTags                 bool                 `yaml:"ignoreAssetTags"`
-		IgnoreVMTemplates               bool                 `yaml:"ignoreVMTemplates"`
-		DatacenterClusterGroupRelations []string             `yaml:"datacenterClusterGroupRelations"`
-		HostSiteRelations               []string             `yaml:"hostSiteRelations"`
-		HostRoleRelations               []string             `yaml:"hostRoleRelations"`
-		ClusterSiteRelations            []string             `yaml:"clusterSiteRelations"`
-		ClusterTenantRelations          []string             `yaml:"clusterTenantRelations"`
-		HostTenantRelations             []string             `yaml:"hostTenantRelations"`
-		VMTenantRelations               []string             `yaml:"vmTenantRelations"`
-		VMRoleRelations                 []string             `yaml:"vmRoleRelations"`
-		VlanGroupRelations              []string             `yaml:"vlanGroupRelations"`
-		VlanGroupSiteRelations          []string             `yaml:"vlanGroupSiteRelations"`
-		VlanTenantRelations             []string             `yaml:"vlanTenantRelations"`
-		VlanSiteRelations               []string             `yaml:"vlanSiteRelations"`
-		WlanTenantRelations             []string             `yaml:"wlanTenantRelations"`
-		CustomFieldMappings             []string             `yaml:"customFieldMappings"`
+		Name                            string                       `yaml:"name"`
+		Type                            constants.SourceType         `yaml:"type"`
+		HTTPScheme                      HTTPScheme                   `yaml:"httpScheme"`
+		Hostname                        string                       `yaml:"hostname"`
+		Port                            int                          `yaml:"port"`
+		Username                        string                       `yaml:"username"`
+		Password                        string                       `yaml:"password"`
+		PasswordFile                    string                       `yaml:"passwordFile"`
+		APIToken                        string                       `yaml:"apiToken"`
+		APITokenFile                    string                       `yaml:"apiTokenFile"`
+		ValidateCert                    bool                         `yaml:"validateCert"`
+		Tag                             string                       `yaml:"tag"`
+		TagColor                        string                       `yaml:"tagColor"`
+		IgnoredSubnets                  []string                     `yaml:"ignoredSubnets"`
+		PermittedSubnets                []string                     `yaml:"permittedSubnets"`
+		InterfaceFilter                 string                       `yaml:"interfaceFilter"`
+		CollectArpData                  bool                         `yaml:"collectArpData"`
+		CAFile                          string                       `yaml:"caFile"`
+		IgnoreSerialNumbers             bool                         `yaml:"ignoreSerialNumbers"`
+		IgnoreAssetTags                 bool                         `yaml:"ignoreAssetTags"`
+		IgnoreVMTemplates               bool                         `yaml:"ignoreVMTemplates"`
+		CollectLocalContext             bool                         `yaml:"collectLocalContext"`
+		LocalContextKeys                []string                     `yaml:"localContextKeys"`
+		PrimaryIPPolicy                 PrimaryIPPolicy              `yaml:"primaryIPPolicy"`
+		RawObjects                      []RawObjectConfig            `yaml:"rawObjects"`
+		RelationProfiles                []string                     `yaml:"relationProfiles"`
+		Transforms                      map[string][]utils.Transform `yaml:"transforms"`
+		Filters                         map[string]utils.Filter      `yaml:"filters"`
+		Enabled                         *bool                        `yaml:"enabled"`
+		Priority                        int                          `yaml:"priority"`
+		Schedule                        string                       `yaml:"schedule"`
+		MaintenanceWindows              []utils.MaintenanceWindow    `yaml:"maintenanceWindows"`
+		Defaults                        DefaultsConfig               `yaml:"defaults"`
+		DatacenterClusterGroupRelations []string                     `yaml:"datacenterClusterGroupRelations"`
+		HostSiteRelations               []string                     `yaml:"hostSiteRelations"`
+		HostRoleRelations               []string                     `yaml:"hostRoleRelations"`
+		ClusterSiteRelations            []string                     `yaml:"clusterSiteRelations"`
+		ClusterTenantRelations          []string                     `yaml:"clusterTenantRelations"`
+		HostTenantRelations             []string                     `yaml:"hostTenantRelations"`
+		VMTenantRelations               []string                     `yaml:"vmTenantRelations"`
+		VMRoleRelations                 []string                     `yaml:"vmRoleRelations"`
+		VlanGroupRelations              []string                     `yaml:"vlanGroupRelations"`
+		VlanGroupSiteRelations          []string                     `yaml:"vlanGroupSiteRelations"`
+		VlanTenantRelations             []string                     `yaml:"vlanTenantRelations"`
+		VlanSiteRelations               []string                     `yaml:"vlanSiteRelations"`
+		WlanTenantRelations             []string                     `yaml:"wlanTenantRelations"`
+		HostRackRelations               []string                     `yaml:"hostRackRelations"`
+		HostRackPositionRelations       []string                     `yaml:"hostRackPositionRelations"`
+		HostRackFaceRelations           []string                     `yaml:"hostRackFaceRelations"`
+		RackLocationRelations           []string                     `yaml:"rackLocationRelations"`
+		RackRoleRelations               []string                     `yaml:"rackRoleRelations"`
+		HostPowerFeedRelations          []string                     `yaml:"hostPowerFeedRelations"`
+		CustomFieldMappings             []string                     `yaml:"customFieldMappings"`
+		VMRoleServices                  []string                     `yaml:"vmRoleServices"`
 	}
+	// Relation rules are mappings in lists of relations, so they are parsed separately
+	relationsNode, ruleNodes := extractRelationRules(sourceNode)
 	rawMarshal := realSourceConfig{}
-	if err := unmarshal(&rawMarshal); err != nil {
+	if err := relationsNode.Decode(&rawMarshal); err != nil {
 		return err
 	}
 	sc.Name = rawMarshal.Name
@@ -199,7 +365,9 @@ func (sc *SourceConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sc.Port = rawMarshal.Port
 	sc.Username = rawMarshal.Username
 	sc.Password = rawMarshal.Password
+	sc.PasswordFile = rawMarshal.PasswordFile
 	sc.APIToken = rawMarshal.APIToken
+	sc.APITokenFile = rawMarshal.APITokenFile
 	sc.ValidateCert = rawMarshal.ValidateCert
 	sc.Tag = rawMarshal.Tag
 	sc.TagColor = rawMarshal.TagColor
@@ -211,6 +379,19 @@ func (sc *SourceConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sc.IgnoreSerialNumbers = rawMarshal.IgnoreSerialNumbers
 	sc.IgnoreAssetTags = rawMarshal.IgnoreAssetTags
 	sc.IgnoreVMTemplates = rawMarshal.IgnoreVMTemplates
+	sc.CollectLocalContext = rawMarshal.CollectLocalContext
+	sc.LocalContextKeys = rawMarshal.LocalContextKeys
+	sc.PrimaryIPPolicy = rawMarshal.PrimaryIPPolicy
+	sc.RawObjects = rawMarshal.RawObjects
+	sc.RelationProfiles = rawMarshal.RelationProfiles
+	sc.Transforms = rawMarshal.Transforms
+	sc.Filters = rawMarshal.Filters
+	// Sources are enabled, unless explicitly disabled
+	sc.Enabled = rawMarshal.Enabled == nil || *rawMarshal.Enabled
+	sc.Priority = rawMarshal.Priority
+	sc.Schedule = rawMarshal.Schedule
+	sc.MaintenanceWindows = rawMarshal.MaintenanceWindows
+	sc.Defaults = rawMarshal.Defaults
 
 	if len(rawMarshal.DatacenterClusterGroupRelations) > 0 {
 		err := utils.ValidateRegexRelations(rawMarshal.DatacenterClusterGroupRelations)
@@ -309,6 +490,79 @@ func (sc *SourceConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		sc.WlanTenantRelations = utils.ConvertStringsToRegexPairs(rawMarshal.WlanTenantRelations)
 	}
+	if len(rawMarshal.HostRackRelations) > 0 {
+		err := utils.ValidateRegexRelations(rawMarshal.HostRackRelations)
+		if err != nil {
+			return fmt.Errorf("%s.hostRackRelations: %v", rawMarshal.Name, err)
+		}
+		sc.HostRackRelations = utils.ConvertStringsToRegexPairs(rawMarshal.HostRackRelations)
+	}
+	if len(rawMarshal.HostRackPositionRelations) > 0 {
+		err := utils.ValidateRegexRelations(rawMarshal.HostRackPositionRelations)
+		if err != nil {
+			return fmt.Errorf("%s.hostRackPositionRelations: %v", rawMarshal.Name, err)
+		}
+		sc.HostRackPositionRelations = utils.ConvertStringsToRegexPairs(
+			rawMarshal.HostRackPositionRelations,
+		)
+		for _, position := range sc.HostRackPositionRelations {
+			// Values with capture groups can only be validated after matching
+			if utils.IsRelationTemplate(position) {
+				continue
+			}
+			if _, err := ParseRackPosition(position); err != nil {
+				return fmt.Errorf("%s.hostRackPositionRelations: %v", rawMarshal.Name, err)
+			}
+		}
+	}
+	if len(rawMarshal.HostRackFaceRelations) > 0 {
+		err := utils.ValidateRegexRelations(rawMarshal.HostRackFaceRelations)
+		if err != nil {
+			return fmt.Errorf("%s.hostRackFaceRelations: %v", rawMarshal.Name, err)
+		}
+		sc.HostRackFaceRelations = utils.ConvertStringsToRegexPairs(rawMarshal.HostRackFaceRelations)
+		for _, face := range sc.HostRackFaceRelations {
+			if utils.IsRelationTemplate(face) {
+				continue
+			}
+			if _, ok := objects.DeviceFaces[face]; !ok {
+				return fmt.Errorf(
+					"%s.hostRackFaceRelations: invalid rack face %s. Must be front or rear",
+					rawMarshal.Name,
+					face,
+				)
+			}
+		}
+	}
+	if len(rawMarshal.RackLocationRelations) > 0 {
+		err := utils.ValidateRegexRelations(rawMarshal.RackLocationRelations)
+		if err != nil {
+			return fmt.Errorf("%s.rackLocationRelations: %v", rawMarshal.Name, err)
+		}
+		sc.RackLocationRelations = utils.ConvertStringsToRegexPairs(rawMarshal.RackLocationRelations)
+	}
+	if len(rawMarshal.RackRoleRelations) > 0 {
+		err := utils.ValidateRegexRelations(rawMarshal.RackRoleRelations)
+		if err != nil {
+			return fmt.Errorf("%s.rackRoleRelations: %v", rawMarshal.Name, err)
+		}
+		sc.RackRoleRelations = utils.ConvertStringsToRegexPairs(rawMarshal.RackRoleRelations)
+	}
+	if len(rawMarshal.HostPowerFeedRelations) > 0 {
+		err := utils.ValidateRegexRelations(rawMarshal.HostPowerFeedRelations)
+		if err != nil {
+			return fmt.Errorf("%s.hostPowerFeedRelations: %v", rawMarshal.Name, err)
+		}
+		sc.HostPowerFeedRelations = utils.ConvertStringsToRegexPairs(rawMarshal.HostPowerFeedRelations)
+		for _, feeds := range sc.HostPowerFeedRelations {
+			if utils.IsRelationTemplate(feeds) {
+				continue
+			}
+			if _, err := ParsePowerFeeds(feeds); err != nil {
+				return fmt.Errorf("%s.hostPowerFeedRelations: %v", rawMarshal.Name, err)
+			}
+		}
+	}
 	if len(rawMarshal.CustomFieldMappings) > 0 {
 		err := utils.ValidateRegexRelations((rawMarshal.CustomFieldMappings))
 		if err != nil {
@@ -316,13 +570,93 @@ func (sc *SourceConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		}
 		sc.CustomFieldMappings = utils.ConvertStringsToRegexPairs(rawMarshal.CustomFieldMappings)
 	}
+	if len(rawMarshal.VMRoleServices) > 0 {
+		vmRoleServices, err := parseRoleServices(rawMarshal.VMRoleServices)
+		if err != nil {
+			return fmt.Errorf("%s.vmRoleServices: %v", rawMarshal.Name, err)
+		}
+		sc.VMRoleServices = vmRoleServices
+	}
+	relationRules, err := parseRelationRules(rawMarshal.Name, ruleNodes)
+	if err != nil {
+		return err
+	}
+	sc.RelationRules = relationRules
 	return nil
 }
 
+// parseRoleServices parses role services of format "roleName = serviceName:protocol/ports",
+// where ports is a comma separated list of ports and port ranges (e.g. "web = https:tcp/443,8443").
+func parseRoleServices(roleServices []string) (map[string][]ServiceDefinition, error) {
+	output := make(map[string][]ServiceDefinition, len(roleServices))
+	for _, roleService := range roleServices {
+		roleName, serviceStr, ok := strings.Cut(roleService, "=")
+		serviceName, protocolAndPorts, ok2 := strings.Cut(serviceStr, ":")
+		protocol, portsStr, ok3 := strings.Cut(protocolAndPorts, "/")
+		roleName = strings.TrimSpace(roleName)
+		serviceName = strings.TrimSpace(serviceName)
+		protocol = strings.ToLower(strings.TrimSpace(protocol))
+		if !ok || !ok2 || !ok3 || roleName == "" || serviceName == "" {
+			return nil, fmt.Errorf(
+				"invalid role service: %s. Should be of format: roleName = serviceName:protocol/ports",
+				roleService,
+			)
+		}
+		if _, ok := objects.ServiceProtocols[protocol]; !ok {
+			return nil, fmt.Errorf("invalid protocol %s in role service: %s", protocol, roleService)
+		}
+		ports, err := utils.ParsePorts(portsStr)
+		if err != nil {
+			return nil, fmt.Errorf("%s in role service: %s", err, roleService)
+		}
+		output[roleName] = append(output[roleName], ServiceDefinition{
+			Name:     serviceName,
+			Protocol: protocol,
+			Ports:    ports,
+		})
+	}
+	return output, nil
+}
+
+// ParseRackPosition parses rack position (lowest occupied rack unit) from string.
+// Position must be a positive multiple of 0.5 (e.g. "12" or "12.5").
+func ParseRackPosition(position string) (float64, error) {
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(position), 64)
+	if err != nil || parsed <= 0 || math.Mod(parsed*2, 1) != 0 { //nolint:mnd
+		return 0, fmt.Errorf("invalid rack position %s. Must be a positive multiple of 0.5", position)
+	}
+	return parsed, nil
+}
+
+// PowerFeedReference references a power feed by its power panel and name.
+type PowerFeedReference struct {
+	PowerPanel string
+	Name       string
+}
+
+// ParsePowerFeeds parses comma separated list of power feeds of format "panel/feed"
+// (e.g. "Panel A/Feed A1, Panel B/Feed B1").
+func ParsePowerFeeds(feeds string) ([]PowerFeedReference, error) {
+	var output []PowerFeedReference
+	for _, feed := range strings.Split(feeds, ",") {
+		panel, name, ok := strings.Cut(feed, "/")
+		panel = strings.TrimSpace(panel)
+		name = strings.TrimSpace(name)
+		if !ok || panel == "" || name == "" {
+			return nil, fmt.Errorf(
+				"invalid power feed %s. Must be in format panel/feed",
+				strings.TrimSpace(feed),
+			)
+		}
+		output = append(output, PowerFeedReference{PowerPanel: panel, Name: name})
+	}
+	return output, nil
+}
+
 func (sc SourceConfig) String() string {
 	return fmt.Sprintf(
 		"SourceConfig{Name: %s, Type: %s, HTTPScheme: %s, Hostname: %s, Port: %d, "+
-			"Username: %s, Password: %s, PermittedSubnets: %v, ValidateCert: %t, "+
+			"Username: %s, Password: %s, APIToken: %s, PermittedSubnets: %v, ValidateCert: %t, "+
 			"Tag: %s, TagColor: %s, DatacenterClusterGroupRelations: %s, "+
 			"HostSiteRelations: %v, ClusterSiteRelations: %v, ClusterTenantRelations: %v, "+
 			"HostTenantRelations: %v, VmTenantRelations: %v, VlanGroupRelations: %v, "+
@@ -333,7 +667,8 @@ func (sc SourceConfig) String() string {
 		sc.Hostname,
 		sc.Port,
 		sc.Username,
-		sc.Password,
+		redactSecret(sc.Password),
+		redactSecret(sc.APIToken),
 		sc.IgnoredSubnets,
 		sc.ValidateCert,
 		sc.Tag,
@@ -351,23 +686,73 @@ func (sc SourceConfig) String() string {
 }
 
 // Validates the user's config for limits and required fields.
-func validateConfig(config *Config) error {
-	err := validateLoggerConfig(config)
-	if err != nil {
-		return err
+// All found problems are returned in the order they were found.
+func validateConfig(config *Config) []error {
+	errs := []error{}
+	if err := validateLoggerConfig(config); err != nil {
+		errs = append(errs, err)
 	}
-
-	err = validateNetboxConfig(config)
-	if err != nil {
-		return err
+	errs = append(errs, validateNetboxConfig(config)...)
+	if err := validateDefaultsConfig(config); err != nil {
+		errs = append(errs, err)
 	}
+	errs = append(errs, validateSourceConfig(config)...)
+	errs = append(errs, validateRelationLookups(config)...)
+	return errs
+}
 
-	err = validateSourceConfig(config)
-	if err != nil {
-		return err
+// validateRelationLookups validates, that lookup tables referenced in relations and
+// relation rules of all sources exist.
+func validateRelationLookups(config *Config) []error {
+	errs := []error{}
+	for _, sourceConfig := range config.Sources {
+		sourceConfigValue := reflect.ValueOf(sourceConfig)
+		for i := 0; i < sourceConfigValue.NumField(); i++ {
+			relations, ok := sourceConfigValue.Field(i).Interface().(map[string]string)
+			if !ok {
+				continue
+			}
+			attribute := sourceConfigValue.Type().Field(i).Tag.Get("yaml")
+			for regexStr, value := range relations {
+				// Regexes and capture groups are already validated when parsing relations
+				regex, err := regexp.Compile(regexStr)
+				if err != nil {
+					continue
+				}
+				lookupTables, err := utils.ValidateRelationTemplate(regex, value)
+				if err != nil {
+					continue
+				}
+				errs = append(errs, undefinedLookupTables(config, sourceConfig.Name, attribute, lookupTables)...)
+			}
+		}
+		rulesValue := reflect.ValueOf(sourceConfig.RelationRules)
+		for i := 0; i < rulesValue.NumField(); i++ {
+			field, _ := sourceConfigValue.Type().FieldByName(rulesValue.Type().Field(i).Name)
+			attribute := field.Tag.Get("yaml")
+			rules, _ := rulesValue.Field(i).Interface().([]utils.RelationRule)
+			for _, rule := range rules {
+				// Rules are already validated when parsing them
+				lookupTables, _ := utils.ValidateRelationRule(rule)
+				errs = append(errs, undefinedLookupTables(config, sourceConfig.Name, attribute, lookupTables)...)
+			}
+		}
 	}
+	return errs
+}
 
-	return nil
+// undefinedLookupTables returns errors for lookup tables, that are not defined in relationLookups.
+func undefinedLookupTables(config *Config, sourceName string, attribute string, lookupTables []string) []error {
+	errs := []error{}
+	for _, table := range lookupTables {
+		if _, ok := config.RelationLookups[table]; !ok {
+			errs = append(errs, fmt.Errorf(
+				"%s.%s: lookup table %s is not defined in relationLookups",
+				sourceName, attribute, table,
+			))
+		}
+	}
+	return errs
 }
 
 func validateLoggerConfig(config *Config) error {
@@ -378,60 +763,71 @@ func validateLoggerConfig(config *Config) error {
 }
 
 // Function that validates NetboxConfig.
-func validateNetboxConfig(config *Config) error {
+//
+//nolint:gocyclo
+func validateNetboxConfig(config *Config) []error {
+	errs := []error{}
 	// Validate Netbox config
 	if config.Netbox.APIToken == "" {
-		return errors.New("netbox.apiToken: cannot be empty")
+		errs = append(errs, errors.New("netbox.apiToken: cannot be empty"))
 	}
 	if config.Netbox.HTTPScheme != HTTP && config.Netbox.HTTPScheme != HTTPS {
-		return errors.New(
-			"netbox.httpScheme: must be either http or https. Is " + string(
+		errs = append(errs, errors.New(
+			"netbox.httpScheme: must be either http or https. Is "+string(
 				config.Netbox.HTTPScheme,
 			),
-		)
+		))
 	}
 	if config.Netbox.Hostname == "" {
-		return errors.New("netbox.hostname: cannot be empty")
+		errs = append(errs, errors.New("netbox.hostname: cannot be empty"))
 	}
 	if config.Netbox.Port < 0 || config.Netbox.Port > 65535 {
-		return errors.New(
-			"netbox.port: must be between 0 and 65535. Is " + fmt.Sprintf("%d", config.Netbox.Port),
-		)
+		errs = append(errs, errors.New(
+			"netbox.port: must be between 0 and 65535. Is "+fmt.Sprintf("%d", config.Netbox.Port),
+		))
 	}
 	if config.Netbox.Timeout < 0 {
-		return errors.New("netbox.timeout: cannot be negative")
+		errs = append(errs, errors.New("netbox.timeout: cannot be negative"))
 	}
 	if config.Netbox.Tag == "" {
 		config.Netbox.Tag = constants.SsotTagName
 	}
 	if !config.Netbox.RemoveOrphans {
 		if config.Netbox.RemoveOrphansAfterDays < 0 {
-			return fmt.Errorf("netbox.RemoveOrphansAfterDays: must be positive integer")
+			errs = append(errs, fmt.Errorf("netbox.RemoveOrphansAfterDays: must be positive integer"))
 		}
 		if config.Netbox.RemoveOrphansAfterDays == 0 {
 			config.Netbox.RemoveOrphansAfterDays = constants.CustomFieldOrphanLastSeenDefaultValue
 		}
 	} else if config.Netbox.RemoveOrphansAfterDays != 0 {
-		return fmt.Errorf("netbox.removeOrphansAfterDays has no effect when netbox.removeOrphans is set to true")
+		errs = append(
+			errs,
+			fmt.Errorf("netbox.removeOrphansAfterDays has no effect when netbox.removeOrphans is set to true"),
+		)
 	}
 	if config.Netbox.TagColor == "" {
 		config.Netbox.TagColor = constants.SsotTagColor
 	} else {
 		// Ensure that TagColor is a string of 6 hexadecimal characters
 		if len(config.Netbox.TagColor) != len("ffffff") {
-			return errors.New("netbox.tagColor: must be a string of 6 hexadecimal characters")
-		}
-		for _, c := range config.Netbox.TagColor {
-			if c < '0' || c > '9' && c < 'a' || c > 'f' {
-				return errors.New("netbox.tagColor: must be a string of 6 lowercase hexadecimal characters")
+			errs = append(errs, errors.New("netbox.tagColor: must be a string of 6 hexadecimal characters"))
+		} else {
+			for _, c := range config.Netbox.TagColor {
+				if c < '0' || c > '9' && c < 'a' || c > 'f' {
+					errs = append(
+						errs,
+						errors.New("netbox.tagColor: must be a string of 6 lowercase hexadecimal characters"),
+					)
+					break
+				}
 			}
 		}
 	}
 	if len(config.Netbox.SourcePriority) > 0 {
 		if len(config.Netbox.SourcePriority) != len(config.Sources) {
-			return fmt.Errorf(
+			errs = append(errs, fmt.Errorf(
 				"netbox.sourcePriority: len(config.Netbox.SourcePriority) != len(config.Sources)",
-			)
+			))
 		}
 		for _, sourceName := range config.Netbox.SourcePriority {
 			contains := false
@@ -442,30 +838,139 @@ func validateNetboxConfig(config *Config) error {
 				}
 			}
 			if !contains {
-				return fmt.Errorf(
+				errs = append(errs, fmt.Errorf(
 					"netbox.sourcePriority: %s doesn't exist in the sources array",
 					sourceName,
-				)
+				))
 			}
 		}
 	}
 	if config.Netbox.CAFile != "" {
 		_, err := os.ReadFile(config.Netbox.CAFile)
 		if err != nil {
-			return fmt.Errorf("netbox.caFile: %s", err)
+			errs = append(errs, fmt.Errorf("netbox.caFile: %s", err))
+		}
+	}
+	if err := validateAggregates(config.Netbox); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateRawObjectTypes(config.Netbox); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateParentPrefixLengths(config.Netbox); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// validateAggregates validates rirs and aggregates of the netbox config.
+func validateAggregates(nbConfig *NetboxConfig) error {
+	rirNames := make(map[string]bool, len(nbConfig.RIRs))
+	for _, rir := range nbConfig.RIRs {
+		if rir.Name == "" {
+			return errors.New("netbox.rirs: name cannot be empty")
+		}
+		if rirNames[rir.Name] {
+			return fmt.Errorf("netbox.rirs: duplicate rir %s", rir.Name)
+		}
+		rirNames[rir.Name] = true
+	}
+	aggregatePrefixes := make(map[string]bool, len(nbConfig.Aggregates))
+	for _, aggregate := range nbConfig.Aggregates {
+		ip, ipNet, err := net.ParseCIDR(aggregate.Prefix)
+		if err != nil || !ip.Equal(ipNet.IP) {
+			return fmt.Errorf("netbox.aggregates: invalid prefix %s", aggregate.Prefix)
+		}
+		if aggregate.RIR == "" {
+			return fmt.Errorf("netbox.aggregates: rir of aggregate %s cannot be empty", aggregate.Prefix)
+		}
+		if aggregatePrefixes[aggregate.Prefix] {
+			return fmt.Errorf("netbox.aggregates: duplicate aggregate %s", aggregate.Prefix)
+		}
+		aggregatePrefixes[aggregate.Prefix] = true
+	}
+	return nil
+}
+
+// rawObjectPathRegex matches api paths of raw object types, e.g. /api/plugins/bgp/session/.
+var rawObjectPathRegex = regexp.MustCompile(`^/api/[a-z0-9_-]+(/[a-z0-9_-]+)+/$`)
+
+// rawObjectTypeRegex matches netbox's object types in format app_label.model.
+var rawObjectTypeRegex = regexp.MustCompile(`^[a-z0-9_]+\.[a-z0-9_]+$`)
+
+// validateRawObjectTypes validates raw object types of the netbox config.
+// Paths of objects, that have a dedicated model in netbox-ssot, can't be used.
+func validateRawObjectTypes(nbConfig *NetboxConfig) error {
+	paths := make(map[string]bool, len(nbConfig.RawObjectTypes))
+	for _, rawObjectType := range nbConfig.RawObjectTypes {
+		if !rawObjectPathRegex.MatchString(rawObjectType.Path) {
+			return fmt.Errorf(
+				"netbox.rawObjectTypes: invalid path %s. Must be in format /api/app/model/",
+				rawObjectType.Path,
+			)
+		}
+		if _, ok := mapper.Path2Type[constants.APIPath(rawObjectType.Path)]; ok {
+			return fmt.Errorf("netbox.rawObjectTypes: path %s is already synced by netbox-ssot", rawObjectType.Path)
+		}
+		if paths[rawObjectType.Path] {
+			return fmt.Errorf("netbox.rawObjectTypes: duplicate path %s", rawObjectType.Path)
+		}
+		paths[rawObjectType.Path] = true
+		if !rawObjectTypeRegex.MatchString(rawObjectType.ObjectType) {
+			return fmt.Errorf(
+				"netbox.rawObjectTypes: invalid objectType %s of path %s. Must be in format app_label.model",
+				rawObjectType.ObjectType,
+				rawObjectType.Path,
+			)
+		}
+		if len(rawObjectType.NaturalKey) == 0 {
+			return fmt.Errorf("netbox.rawObjectTypes: naturalKey of path %s cannot be empty", rawObjectType.Path)
+		}
+	}
+	return nil
+}
+
+// validateParentPrefixLengths validates lengths of parent prefixes and sets
+// default lengths, when creation of parent prefixes is enabled.
+func validateParentPrefixLengths(nbConfig *NetboxConfig) error {
+	if !nbConfig.CreateParentPrefixes {
+		if len(nbConfig.ParentPrefixLengthsIPv4) > 0 || len(nbConfig.ParentPrefixLengthsIPv6) > 0 {
+			return errors.New(
+				"netbox.parentPrefixLengths has no effect when netbox.createParentPrefixes is set to false",
+			)
+		}
+		return nil
+	}
+	if len(nbConfig.ParentPrefixLengthsIPv4) == 0 {
+		nbConfig.ParentPrefixLengthsIPv4 = []int{constants.DefaultParentPrefixLengthIPv4}
+	}
+	if len(nbConfig.ParentPrefixLengthsIPv6) == 0 {
+		nbConfig.ParentPrefixLengthsIPv6 = []int{constants.DefaultParentPrefixLengthIPv6}
+	}
+	for _, length := range nbConfig.ParentPrefixLengthsIPv4 {
+		if length < 1 || length >= constants.MaxIPv4MaskBits {
+			return fmt.Errorf("netbox.parentPrefixLengthsIPv4: must be between 1 and 31. Is %d", length)
+		}
+	}
+	for _, length := range nbConfig.ParentPrefixLengthsIPv6 {
+		if length < 1 || length >= constants.MaxIPv6MaskBits {
+			return fmt.Errorf("netbox.parentPrefixLengthsIPv6: must be between 1 and 127. Is %d", length)
 		}
 	}
 	return nil
 }
 
 //nolint:gocyclo
-func validateSourceConfig(config *Config) error {
+func validateSourceConfig(config *Config) []error {
+	errs := []error{}
 	// Validate Sources
 	for i := range config.Sources {
 		externalSource := &config.Sources[i]
 		externalSourceStr := externalSource.Name
 		if externalSource.Name == "" {
-			return fmt.Errorf("source name: cannot be empty")
+			// Other errors of the source can't be reported without its name
+			errs = append(errs, fmt.Errorf("source name: cannot be empty"))
+			continue
 		}
 		switch externalSource.Type {
 		case constants.Ovirt:
@@ -477,37 +982,40 @@ func validateSourceConfig(config *Config) error {
 		case constants.FMC:
 		case constants.IOSXE:
 		default:
-			return fmt.Errorf("%s.type is not valid", externalSourceStr)
+			errs = append(errs, fmt.Errorf("%s.type is not valid", externalSourceStr))
 		}
 		if externalSource.HTTPScheme == "" {
 			externalSource.HTTPScheme = "https"
 		} else if externalSource.HTTPScheme != HTTP && externalSource.HTTPScheme != HTTPS {
-			return fmt.Errorf(
+			errs = append(errs, fmt.Errorf(
 				"%s.httpScheme: must be either http or https. Is %s",
 				externalSourceStr,
 				string(externalSource.HTTPScheme),
-			)
+			))
 		}
 		if externalSource.Hostname == "" {
-			return fmt.Errorf("%s.hostname: cannot be empty", externalSourceStr)
+			errs = append(errs, fmt.Errorf("%s.hostname: cannot be empty", externalSourceStr))
 		}
 		if externalSource.Port == 0 {
 			externalSource.Port = 443
 		} else if externalSource.Port < 0 || externalSource.Port > 65535 {
-			return fmt.Errorf("%s.port: must be between 0 and 65535. Is %d", externalSourceStr, externalSource.Port)
+			errs = append(
+				errs,
+				fmt.Errorf("%s.port: must be between 0 and 65535. Is %d", externalSourceStr, externalSource.Port),
+			)
 		}
 		if externalSource.APIToken == "" && externalSource.Type == constants.Fortigate {
-			return fmt.Errorf(
+			errs = append(errs, fmt.Errorf(
 				"%s.apiToken is required for %s",
 				externalSourceStr,
 				constants.Fortigate,
-			)
+			))
 		}
 		if externalSource.Username == "" && externalSource.Type != constants.Fortigate {
-			return fmt.Errorf("%s.username: cannot be empty", externalSourceStr)
+			errs = append(errs, fmt.Errorf("%s.username: cannot be empty", externalSourceStr))
 		}
 		if externalSource.Password == "" && externalSource.Type != constants.Fortigate {
-			return fmt.Errorf("%s.password: cannot be empty", externalSourceStr)
+			errs = append(errs, fmt.Errorf("%s.password: cannot be empty", externalSourceStr))
 		}
 		if externalSource.Tag == "" {
 			externalSource.Tag = fmt.Sprintf("Source: %s", externalSource.Name)
@@ -517,51 +1025,262 @@ func validateSourceConfig(config *Config) error {
 		}
 		if externalSource.CAFile != "" {
 			if _, err := os.ReadFile(externalSource.CAFile); err != nil {
-				return fmt.Errorf("%s.caFile: %s", externalSourceStr, err)
+				errs = append(errs, fmt.Errorf("%s.caFile: %s", externalSourceStr, err))
 			}
 		}
-		if len(externalSource.IgnoredSubnets) > 0 {
-			for _, ignoredSubnet := range externalSource.IgnoredSubnets {
-				if !utils.VerifySubnet(ignoredSubnet) {
-					return fmt.Errorf(
-						"%s.ignoredSubnets: wrong format: %s",
-						externalSourceStr,
-						ignoredSubnet,
-					)
-				}
+		for _, ignoredSubnet := range externalSource.IgnoredSubnets {
+			if !utils.VerifySubnet(ignoredSubnet) {
+				errs = append(errs, fmt.Errorf(
+					"%s.ignoredSubnets: wrong format: %s",
+					externalSourceStr,
+					ignoredSubnet,
+				))
 			}
 		}
-		if len(externalSource.PermittedSubnets) > 0 {
-			for _, permittedSubnet := range externalSource.PermittedSubnets {
-				if !utils.VerifySubnet(permittedSubnet) {
-					return fmt.Errorf(
-						"%s.permittedSubnets: wrong format: %s",
-						externalSourceStr,
-						permittedSubnet,
-					)
-				}
+		for _, permittedSubnet := range externalSource.PermittedSubnets {
+			if !utils.VerifySubnet(permittedSubnet) {
+				errs = append(errs, fmt.Errorf(
+					"%s.permittedSubnets: wrong format: %s",
+					externalSourceStr,
+					permittedSubnet,
+				))
 			}
 		}
 
 		// Try to compile interfaceFilter
-		_, err := regexp.Compile(externalSource.InterfaceFilter)
-		if err != nil {
-			return fmt.Errorf("%s.interfaceFilter: wrong format: %s", externalSourceStr, err)
+		if _, err := regexp.Compile(externalSource.InterfaceFilter); err != nil {
+			errs = append(errs, fmt.Errorf("%s.interfaceFilter: wrong format: %s", externalSourceStr, err))
+		}
+
+		if err := validateLocalContextConfig(externalSource); err != nil {
+			errs = append(errs, err)
+		}
+
+		if err := validatePrimaryIPPolicy(externalSource); err != nil {
+			errs = append(errs, err)
 		}
+
+		if err := validateRawObjects(config.Netbox, externalSource); err != nil {
+			errs = append(errs, err)
+		}
+
+		if err := validateTransforms(config.Netbox, externalSource); err != nil {
+			errs = append(errs, err)
+		}
+
+		if err := validateFilters(externalSource); err != nil {
+			errs = append(errs, err)
+		}
+
+		if err := validateScheduling(externalSource); err != nil {
+			errs = append(errs, err)
+		}
+
+		if err := validateSourceDefaults(config.Defaults, externalSource); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// validateLocalContextConfig validates collectLocalContext and localContextKeys of the source.
+// If no keys are whitelisted, default keys are used.
+func validateLocalContextConfig(sourceConfig *SourceConfig) error {
+	if !sourceConfig.CollectLocalContext {
+		if len(sourceConfig.LocalContextKeys) > 0 {
+			return fmt.Errorf(
+				"%s.localContextKeys has no effect when %s.collectLocalContext is set to false",
+				sourceConfig.Name,
+				sourceConfig.Name,
+			)
+		}
+		return nil
+	}
+	switch sourceConfig.Type {
+	case constants.Vmware, constants.Proxmox, constants.PaloAlto, constants.Fortigate:
+	default:
+		return fmt.Errorf(
+			"%s.collectLocalContext: not supported for source type %s",
+			sourceConfig.Name,
+			sourceConfig.Type,
+		)
+	}
+	if len(sourceConfig.LocalContextKeys) == 0 {
+		sourceConfig.LocalContextKeys = constants.DefaultLocalContextKeys
+		return nil
+	}
+	for _, key := range sourceConfig.LocalContextKeys {
+		if slices.Contains(constants.DefaultLocalContextKeys, key) {
+			continue
+		}
+		setting, isAdvancedSetting := strings.CutPrefix(key, constants.LocalContextKeyAdvancedSettings+".")
+		if isAdvancedSetting && setting != "" && sourceConfig.Type == constants.Vmware {
+			continue
+		}
+		return fmt.Errorf("%s.localContextKeys: invalid key %s", sourceConfig.Name, key)
 	}
 	return nil
 }
 
-func ParseConfig(configFilename string) (*Config, error) {
-	// First we read the config file
-	file, err := os.Open(configFilename)
+// validatePrimaryIPPolicy validates primary ip policy of the source.
+// If no ip versions are set, both ipv4 and ipv6 primary ips are set, with ipv4 having priority.
+func validatePrimaryIPPolicy(sourceConfig *SourceConfig) error {
+	policy := &sourceConfig.PrimaryIPPolicy
+	for _, subnet := range policy.PreferredSubnets {
+		if !utils.VerifySubnet(subnet) {
+			return fmt.Errorf("%s.primaryIPPolicy.preferredSubnets: wrong format: %s", sourceConfig.Name, subnet)
+		}
+	}
+	if _, err := regexp.Compile(policy.InterfaceRegex); err != nil {
+		return fmt.Errorf("%s.primaryIPPolicy.interfaceRegex: wrong format: %s", sourceConfig.Name, err)
+	}
+	if _, err := regexp.Compile(policy.OOBInterfaceRegex); err != nil {
+		return fmt.Errorf("%s.primaryIPPolicy.oobInterfaceRegex: wrong format: %s", sourceConfig.Name, err)
+	}
+	if len(policy.IPVersions) == 0 {
+		policy.IPVersions = []int{constants.IPv4, constants.IPv6}
+		return nil
+	}
+	for i, ipVersion := range policy.IPVersions {
+		if ipVersion != constants.IPv4 && ipVersion != constants.IPv6 {
+			return fmt.Errorf(
+				"%s.primaryIPPolicy.ipVersions: must be 4 or 6. Is %d",
+				sourceConfig.Name,
+				ipVersion,
+			)
+		}
+		if slices.Contains(policy.IPVersions[:i], ipVersion) {
+			return fmt.Errorf("%s.primaryIPPolicy.ipVersions: duplicate version %d", sourceConfig.Name, ipVersion)
+		}
+	}
+	return nil
+}
+
+// validateRawObjects validates, that raw objects of the source belong to
+// one of netbox.rawObjectTypes and contain all attributes of its natural key.
+func validateRawObjects(nbConfig *NetboxConfig, sourceConfig *SourceConfig) error {
+	for _, rawObject := range sourceConfig.RawObjects {
+		if err := validateRawObject(nbConfig, rawObject.Path, func(attribute string) bool {
+			_, ok := rawObject.Object[attribute]
+			return ok
+		}); err != nil {
+			return fmt.Errorf("%s.rawObjects: %s", sourceConfig.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateRawObject validates, that path of the raw object is one of netbox.rawObjectTypes,
+// and that the raw object has all attributes of the natural key of its type.
+func validateRawObject(nbConfig *NetboxConfig, path string, hasAttribute func(string) bool) error {
+	typeIndex := slices.IndexFunc(nbConfig.RawObjectTypes, func(rawObjectType RawObjectType) bool {
+		return rawObjectType.Path == path
+	})
+	if typeIndex < 0 {
+		return fmt.Errorf("path %s is not one of netbox.rawObjectTypes", path)
+	}
+	for _, keyAttribute := range nbConfig.RawObjectTypes[typeIndex].NaturalKey {
+		if !hasAttribute(keyAttribute) {
+			return fmt.Errorf("object on path %s is missing natural key attribute %s", path, keyAttribute)
+		}
+	}
+	return nil
+}
+
+// validateTransforms validates object types, fields and expressions of transforms of the source,
+// and raw objects emitted by the transforms.
+func validateTransforms(nbConfig *NetboxConfig, sourceConfig *SourceConfig) error {
+	for _, objectType := range slices.Sorted(maps.Keys(sourceConfig.Transforms)) {
+		if !slices.Contains(utils.TransformObjectTypes, objectType) {
+			return fmt.Errorf(
+				"%s.transforms: object type %s is not supported. Supported types: %s",
+				sourceConfig.Name,
+				objectType,
+				strings.Join(utils.TransformObjectTypes, ", "),
+			)
+		}
+		for i, transform := range sourceConfig.Transforms[objectType] {
+			if err := utils.ValidateTransform(transform); err != nil {
+				return fmt.Errorf("%s.transforms.%s[%d]: %s", sourceConfig.Name, objectType, i, err)
+			}
+			for j, emit := range transform.Emit {
+				if err := validateRawObject(nbConfig, emit.Path, func(attribute string) bool {
+					_, ok := emit.Object[attribute]
+					return ok
+				}); err != nil {
+					return fmt.Errorf("%s.transforms.%s[%d].emit[%d]: %s", sourceConfig.Name, objectType, i, j, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateFilters validates object types and conditions of filters of the source.
+func validateFilters(sourceConfig *SourceConfig) error {
+	for _, objectType := range slices.Sorted(maps.Keys(sourceConfig.Filters)) {
+		if !slices.Contains(utils.FilterObjectTypes, objectType) {
+			return fmt.Errorf(
+				"%s.filters: object type %s is not supported. Supported types: %s",
+				sourceConfig.Name,
+				objectType,
+				strings.Join(utils.FilterObjectTypes, ", "),
+			)
+		}
+		if err := utils.ValidateFilter(sourceConfig.Filters[objectType]); err != nil {
+			return fmt.Errorf("%s.filters.%s.%s", sourceConfig.Name, objectType, err)
+		}
+	}
+	return nil
+}
+
+// validateScheduling validates schedule and maintenance windows of the source.
+func validateScheduling(sourceConfig *SourceConfig) error {
+	if sourceConfig.Schedule != "" {
+		if _, err := utils.ParseSchedule(sourceConfig.Schedule); err != nil {
+			return fmt.Errorf("%s.schedule: %s", sourceConfig.Name, err)
+		}
+	}
+	for i, window := range sourceConfig.MaintenanceWindows {
+		if err := utils.ValidateMaintenanceWindow(window); err != nil {
+			return fmt.Errorf("%s.maintenanceWindows[%d].%s", sourceConfig.Name, i, err)
+		}
+	}
+	return nil
+}
+
+// ParseConfig parses configuration from the config file, or from all yaml files
+// in the directory, if config path is a directory. Configuration files can include
+// other files using include attribute.
+func ParseConfig(configPath string) (*Config, error) {
+	// First we read all config files
+	loader, err := loadConfigFiles(configPath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
-	// Define Config with default values
-	config := &Config{
+	// Merge config files into a Config struct with default values
+	config := defaultConfig()
+	if errs := loader.mergeDocuments(config); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	// Resolve secrets from environment variables, files and secret providers
+	if errs := resolveSecrets(config); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	// Validate the config for limits and required fields
+	if errs := validateConfig(config); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return config, nil
+}
+
+// defaultConfig returns Config with default values.
+func defaultConfig() *Config {
+	return &Config{
 		Logger: &LoggerConfig{
 			Level: 1,
 			Dest:  "",
@@ -574,18 +1293,4 @@ func ParseConfig(configFilename string) (*Config, error) {
 		},
 		Sources: []SourceConfig{},
 	}
-
-	// Parse the config file into a Config struct
-	err = yaml.NewDecoder(file).Decode(config)
-	if err != nil {
-		return nil, err
-	}
-
-	// Validate the config for limits and required fields
-	err = validateConfig(config)
-	if err != nil {
-		return nil, err
-	}
-
-	return config, nil
 }
@@ -46,6 +46,11 @@ func TestValidonfig(t *testing.T) {
 				ValidateCert: true,
 				Tag:          "testing",
 				TagColor:     "ff0000",
+				PrimaryIPPolicy: PrimaryIPPolicy{
+					IPVersions: []int{constants.IPv4, constants.IPv6}, // Default
+				},
+				Enabled:  true,               // Default
+				Defaults: *BuiltinDefaults(), // Default
 			}, {
 				Name:       "paloalto",
 				Type:       "paloalto",
@@ -74,6 +79,11 @@ func TestValidonfig(t *testing.T) {
 				VlanTenantRelations: map[string]string{
 					".*": "Default",
 				},
+				PrimaryIPPolicy: PrimaryIPPolicy{
+					IPVersions: []int{constants.IPv4, constants.IPv6}, // Default
+				},
+				Enabled:  true,               // Default
+				Defaults: *BuiltinDefaults(), // Default
 			},
 			{
 				Name:       "prodolvm",
@@ -112,8 +122,14 @@ func TestValidonfig(t *testing.T) {
 				DatacenterClusterGroupRelations: map[string]string{
 					".*": "Default",
 				},
+				PrimaryIPPolicy: PrimaryIPPolicy{
+					IPVersions: []int{constants.IPv4, constants.IPv6}, // Default
+				},
+				Enabled:  true,               // Default
+				Defaults: *BuiltinDefaults(), // Default
 			},
 		},
+		Defaults: BuiltinDefaults(), // Default
 	}
 	got, err := ParseConfig(filename)
 	if err != nil {
@@ -153,6 +169,48 @@ func TestParseValidConfigs(t *testing.T) {
 		{
 			filename: "valid_config7.yaml",
 		},
+		{
+			filename: "valid_config8.yaml",
+		},
+		{
+			filename: "valid_config9.yaml",
+		},
+		{
+			filename: "valid_config10.yaml",
+		},
+		{
+			filename: "valid_config11.yaml",
+		},
+		{
+			filename: "valid_config12.yaml",
+		},
+		{
+			filename: "valid_config13.yaml",
+		},
+		{
+			filename: "valid_config14.yaml",
+		},
+		{
+			filename: "valid_config15.yaml",
+		},
+		{
+			filename: "valid_config16.yaml",
+		},
+		{
+			filename: "valid_config17.yaml",
+		},
+		{
+			filename: "valid_config18.yaml",
+		},
+		{
+			filename: "valid_config19.yaml",
+		},
+		{
+			filename: "valid_config20.yaml",
+		},
+		{
+			filename: "valid_config21.yaml",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.filename, func(t *testing.T) {
@@ -340,6 +398,225 @@ func TestParseConfigInvalidConfigs(t *testing.T) {
 			filename:    "invalid_config48.yaml",
 			expectedErr: "wrong.vlanGroupSiteRelations: invalid regex: (wrong(), in relation: (wrong() = wwrong",
 		},
+		{
+			filename:    "invalid_config49.yaml",
+			expectedErr: "wrong.vmRoleServices: invalid protocol icmp in role service: Web server = https:icmp/443",
+		},
+		{
+			filename:    "invalid_config50.yaml",
+			expectedErr: "netbox.aggregates: invalid prefix 10.0.0.1/8",
+		},
+		{
+			filename:    "invalid_config51.yaml",
+			expectedErr: "netbox.parentPrefixLengthsIPv4: must be between 1 and 31. Is 32",
+		},
+		{
+			filename:    "invalid_config52.yaml",
+			expectedErr: "netbox.parentPrefixLengths has no effect when netbox.createParentPrefixes is set to false",
+		},
+		{
+			filename:    "invalid_config53.yaml",
+			expectedErr: "wrong.hostRackPositionRelations: invalid rack position -3. Must be a positive multiple of 0.5",
+		},
+		{
+			filename:    "invalid_config54.yaml",
+			expectedErr: "wrong.hostRackFaceRelations: invalid rack face side. Must be front or rear",
+		},
+		{
+			filename:    "invalid_config55.yaml",
+			expectedErr: "wrong.localContextKeys has no effect when wrong.collectLocalContext is set to false",
+		},
+		{
+			filename:    "invalid_config56.yaml",
+			expectedErr: "wrong.collectLocalContext: not supported for source type ovirt",
+		},
+		{
+			filename:    "invalid_config57.yaml",
+			expectedErr: "wrong.localContextKeys: invalid key advanced_settings.Syslog.global.logHost",
+		},
+		{
+			filename:    "invalid_config58.yaml",
+			expectedErr: "wrong.hostPowerFeedRelations: invalid power feed Feed B1. Must be in format panel/feed",
+		},
+		{
+			filename:    "invalid_config59.yaml",
+			expectedErr: "wrong.primaryIPPolicy.ipVersions: must be 4 or 6. Is 5",
+		},
+		{
+			filename:    "invalid_config60.yaml",
+			expectedErr: "wrong.primaryIPPolicy.preferredSubnets: wrong format: 10.0.0.1",
+		},
+		{
+			filename:    "invalid_config61.yaml",
+			expectedErr: "netbox.rawObjectTypes: invalid path /plugins/bgp/session. Must be in format /api/app/model/",
+		},
+		{
+			filename:    "invalid_config62.yaml",
+			expectedErr: "wrong.rawObjects: path /api/plugins/bgp/community/ is not one of netbox.rawObjectTypes",
+		},
+		{
+			filename:    "invalid_config63.yaml",
+			expectedErr: "wrong.rawObjects: object on path /api/plugins/bgp/session/ is missing natural key attribute device",
+		},
+		{
+			filename:    "invalid_config64.yaml",
+			expectedErr: "wrong.password: environment variable NETBOX_SSOT_UNSET_VARIABLE is not set",
+		},
+		{
+			filename:    "invalid_config65.yaml",
+			expectedErr: "wrong.password: secret and secret file are mutually exclusive",
+		},
+		{
+			filename:    "invalid_config66.yaml",
+			expectedErr: "wrong.password: secret provider vault is not configured",
+		},
+		{
+			filename: "invalid_config67.yaml",
+			expectedErr: "source wrong: duplicate source name, defined in ../../testdata/parser/invalid_config67.yaml " +
+				"and ../../testdata/parser/include/duplicate_source.yaml",
+		},
+		{
+			filename: "invalid_config68.yaml",
+			expectedErr: "netbox: defined in both ../../testdata/parser/invalid_config68.yaml " +
+				"and ../../testdata/parser/include/netbox.yaml",
+		},
+		{
+			filename:    "invalid_config69.yaml",
+			expectedErr: "wrong.relationProfiles: profile missing is not defined",
+		},
+		{
+			filename:    "invalid_config70.yaml",
+			expectedErr: "relationProfiles.common: hostname is not a relation attribute",
+		},
+		{
+			filename: "invalid_config71.yaml",
+			expectedErr: "../../testdata/parser/invalid_config71.yaml: include: " +
+				"no files match ../../testdata/parser/include/missing*.yaml",
+		},
+		{
+			filename: "invalid_config72.yaml",
+			expectedErr: "testvmware.hostSiteRelations: capture group 2 referenced in Site-$2 doesn't exist " +
+				"in regex ^([a-z]{3})-.*, in relation: ^([a-z]{3})-.* = Site-$2",
+		},
+		{
+			filename: "invalid_config73.yaml",
+			expectedErr: "testvmware.hostSiteRelations: capture group site referenced in ${site|upper} doesn't exist " +
+				"in regex ^([a-z]{3})-.*, in relation: ^([a-z]{3})-.* = ${site|upper}",
+		},
+		{
+			filename: "invalid_config74.yaml",
+			expectedErr: "testvmware.hostSiteRelations: unknown filter reverse in Site-${1|reverse}. " +
+				"Must be one of upper, lower, title, slug or lookup:table, in relation: ^([a-z]{3})-.* = Site-${1|reverse}",
+		},
+		{
+			filename:    "invalid_config75.yaml",
+			expectedErr: "testvmware.hostSiteRelations: lookup table sites is not defined in relationLookups",
+		},
+		{
+			filename: "invalid_config76.yaml",
+			expectedErr: "testvmware.hostRackRelations: relation rules are not supported, " +
+				"relations must be in format regex = value",
+		},
+		{
+			filename:    "invalid_config77.yaml",
+			expectedErr: "testvmware.hostSiteRelations: invalid subnet: 10.0.0.0/33, in rule on line 16",
+		},
+		{
+			filename:    "invalid_config78.yaml",
+			expectedErr: "testvmware.hostSiteRelations: value of relation rule cannot be empty, in rule on line 16",
+		},
+		{
+			filename: "invalid_config79.yaml",
+			expectedErr: "testvmware.vmTenantRelations: value Tenant $1 references capture groups, " +
+				"but rule has no name regex, in rule on line 16",
+		},
+		{
+			filename:    "invalid_config80.yaml",
+			expectedErr: "testvmware.hostSiteRelations: lookup table sites is not defined in relationLookups",
+		},
+		{
+			filename: "invalid_config81.yaml",
+			expectedErr: "testvmware.hostSiteRelations: relation condition must match at least one attribute, " +
+				"in rule on line 16",
+		},
+		{
+			filename:    "invalid_config82.yaml",
+			expectedErr: "testvmware.vmTenantRelations: invalid regex: ^tenant-(a$, in rule on line 16",
+		},
+		{
+			filename:    "invalid_config83.yaml",
+			expectedErr: "testvmware.transforms: object type interface is not supported. Supported types: device, vm",
+		},
+		{
+			filename: "invalid_config84.yaml",
+			expectedErr: "testvmware.transforms.vm[0]: invalid expression \"lower(hostname)\" of field name: " +
+				"unknown name hostname (1:7)",
+		},
+		{
+			filename: "invalid_config85.yaml",
+			expectedErr: "testvmware.transforms.device[0]: field serial can't be set. " +
+				"Must be one of name, description, comments, role, platform or customFields.<name>",
+		},
+		{
+			filename:    "invalid_config86.yaml",
+			expectedErr: "testvmware.transforms.vm[0]: transform must either set fields, emit raw objects or drop objects",
+		},
+		{
+			filename: "invalid_config87.yaml",
+			expectedErr: "testvmware.filters: object type interface is not supported. " +
+				"Supported types: cluster, device, vm, vlan",
+		},
+		{
+			filename:    "invalid_config88.yaml",
+			expectedErr: "testvmware.filters.vm.exclude[0]: relation condition must match at least one attribute",
+		},
+		{
+			filename:    "invalid_config89.yaml",
+			expectedErr: "testvmware.filters.device.include[1]: invalid regex: (on",
+		},
+		{
+			filename: "invalid_config90.yaml",
+			expectedErr: "testvmware.schedule: expected 5 fields " +
+				"(minute hour day-of-month month day-of-week), got 4",
+		},
+		{
+			filename: "invalid_config91.yaml",
+			expectedErr: "testvmware.maintenanceWindows[0].days: invalid day monday. " +
+				"Valid days: mon, tue, wed, thu, fri, sat, sun",
+		},
+		{
+			filename:    "invalid_config92.yaml",
+			expectedErr: "testvmware.maintenanceWindows[1].end: invalid time \"7:00am\", expected format HH:MM",
+		},
+		{
+			filename:    "invalid_config93.yaml",
+			expectedErr: "defaults.roles.firewall.color: must be 6 lowercase hex digits (e.g. 00add8). Is #ff0000",
+		},
+		{
+			filename:    "invalid_config94.yaml",
+			expectedErr: "defaults.siteVlanGroup.name: must contain {site}",
+		},
+		{
+			filename:    "invalid_config95.yaml",
+			expectedErr: "defaults.deviceType.slug: slug of deviceType can't be configured",
+		},
+		{
+			filename:    "invalid_config96.yaml",
+			expectedErr: "defaults.site.previousNames: Unassigned is the current name",
+		},
+		{
+			filename:    "invalid_config97.yaml",
+			expectedErr: "testvmware.defaults.orphanTag: can only be configured in defaults",
+		},
+		{
+			filename:    "invalid_config98.yaml",
+			expectedErr: "testvmware.defaults.site.name: DefaultSite is a previous name in defaults.site",
+		},
+		{
+			filename: "invalid_config99.yaml",
+			expectedErr: "testvmware.transforms.vm[0].emit[0]: " +
+				"object on path /api/plugins/netbox-dns/records/ is missing natural key attribute type",
+		},
 		{
 			filename:    "invalid_config1111.yaml",
 			expectedErr: "open ../../testdata/parser/invalid_config1111.yaml: no such file or directory",
@@ -358,3 +635,69 @@ func TestParseConfigInvalidConfigs(t *testing.T) {
 		})
 	}
 }
+
+// TestParseConfigDirectory parses configuration split across multiple files.
+func TestParseConfigDirectory(t *testing.T) {
+	config, err := ParseConfig(filepath.Join("../../testdata/parser", "multi_config1"))
+	if err != nil {
+		t.Fatalf("error parsing config: %s", err)
+	}
+	sourceNames := []string{}
+	for _, sourceConfig := range config.Sources {
+		sourceNames = append(sourceNames, sourceConfig.Name)
+	}
+	// Included files are loaded after the including file
+	if want := []string{"ovirt1", "ovirt2", "vcenter"}; !reflect.DeepEqual(sourceNames, want) {
+		t.Errorf("source names = %v, want %v", sourceNames, want)
+	}
+
+	wantOvirt1HostSiteRelations := map[string]string{".*": "Ljubljana"}
+	if !reflect.DeepEqual(config.Sources[0].HostSiteRelations, wantOvirt1HostSiteRelations) {
+		t.Errorf("ovirt1.hostSiteRelations = %v, want %v", config.Sources[0].HostSiteRelations, wantOvirt1HostSiteRelations)
+	}
+	wantVMRoleRelations := map[string]string{".*": "oVirt"}
+	for _, sourceConfig := range config.Sources[:2] {
+		if !reflect.DeepEqual(sourceConfig.VMRoleRelations, wantVMRoleRelations) {
+			t.Errorf("%s.vmRoleRelations = %v, want %v", sourceConfig.Name, sourceConfig.VMRoleRelations, wantVMRoleRelations)
+		}
+	}
+	if config.Sources[1].HostSiteRelations != nil {
+		t.Errorf("ovirt2.hostSiteRelations = %v, want nil", config.Sources[1].HostSiteRelations)
+	}
+
+	wantVcenterHostSiteRelations := map[string]string{".*": "Ljubljana", "^mb-": "Maribor"}
+	if !reflect.DeepEqual(config.Sources[2].HostSiteRelations, wantVcenterHostSiteRelations) {
+		t.Errorf("vcenter.hostSiteRelations = %v, want %v", config.Sources[2].HostSiteRelations, wantVcenterHostSiteRelations)
+	}
+	wantClusterTenantRelations := map[string]string{".*": "Default"}
+	if !reflect.DeepEqual(config.Sources[2].ClusterTenantRelations, wantClusterTenantRelations) {
+		t.Errorf(
+			"vcenter.clusterTenantRelations = %v, want %v",
+			config.Sources[2].ClusterTenantRelations,
+			wantClusterTenantRelations,
+		)
+	}
+}
+
+func TestRelationAttributesMatchSourceConfig(t *testing.T) {
+	yamlTags := make(map[string]reflect.Type)
+	sourceConfigType := reflect.TypeOf(SourceConfig{})
+	for i := 0; i < sourceConfigType.NumField(); i++ {
+		field := sourceConfigType.Field(i)
+		yamlTags[field.Tag.Get("yaml")] = field.Type
+	}
+	for attribute := range relationAttributes {
+		fieldType, ok := yamlTags[attribute]
+		if !ok {
+			t.Errorf("relation attribute %s is not an attribute of the source", attribute)
+		} else if fieldType.Kind() != reflect.Map {
+			t.Errorf("relation attribute %s is of type %s, want map", attribute, fieldType)
+		}
+	}
+	// All "regex = value" attributes of the source must be listed as relation attributes
+	for yamlTag, fieldType := range yamlTags {
+		if fieldType == reflect.TypeOf(map[string]string{}) && !relationAttributes[yamlTag] {
+			t.Errorf("source attribute %s is missing in relation attributes", yamlTag)
+		}
+	}
+}
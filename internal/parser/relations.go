@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RelationRules are relation rules of the source, that match objects on multiple attributes
+// (e.g. name, ip, tags, folder...). They are defined as mappings in lists of relations, together
+// with relations in format "regex = value". Fields have the same names as relations of SourceConfig.
+type RelationRules struct {
+	ClusterSiteRelations   []utils.RelationRule
+	ClusterTenantRelations []utils.RelationRule
+	HostSiteRelations      []utils.RelationRule
+	HostTenantRelations    []utils.RelationRule
+	HostRoleRelations      []utils.RelationRule
+	VMTenantRelations      []utils.RelationRule
+	VMRoleRelations        []utils.RelationRule
+	VlanGroupRelations     []utils.RelationRule
+	VlanGroupSiteRelations []utils.RelationRule
+	VlanSiteRelations      []utils.RelationRule
+	VlanTenantRelations    []utils.RelationRule
+}
+
+// ruleAttributes returns yaml names of relation attributes, that support relation rules,
+// mapped to names of their fields.
+func ruleAttributes() map[string]string {
+	attributes := make(map[string]string)
+	rulesType := reflect.TypeOf(RelationRules{})
+	sourceConfigType := reflect.TypeOf(SourceConfig{})
+	for i := 0; i < rulesType.NumField(); i++ {
+		fieldName := rulesType.Field(i).Name
+		if field, ok := sourceConfigType.FieldByName(fieldName); ok {
+			attributes[field.Tag.Get("yaml")] = fieldName
+		}
+	}
+	return attributes
+}
+
+// extractRelationRules removes relation rules (mappings) from lists of relations of the
+// source node, and returns nodes of the rules for each relation attribute. Source node
+// is not modified, because its nodes can be shared with yaml anchors.
+func extractRelationRules(sourceNode *yaml.Node) (*yaml.Node, map[string][]*yaml.Node) {
+	ruleNodes := make(map[string][]*yaml.Node)
+	if sourceNode.Kind != yaml.MappingNode {
+		return sourceNode, ruleNodes
+	}
+	strippedNode := *sourceNode
+	strippedNode.Content = slices.Clone(sourceNode.Content)
+	for i := 0; i+1 < len(strippedNode.Content); i += 2 {
+		attribute := strippedNode.Content[i].Value
+		relationsNode := resolveAlias(strippedNode.Content[i+1])
+		if !relationAttributes[attribute] || relationsNode.Kind != yaml.SequenceNode {
+			continue
+		}
+		strippedRelations := *relationsNode
+		strippedRelations.Content = nil
+		for _, relationNode := range relationsNode.Content {
+			if resolveAlias(relationNode).Kind == yaml.MappingNode {
+				ruleNodes[attribute] = append(ruleNodes[attribute], resolveAlias(relationNode))
+				continue
+			}
+			strippedRelations.Content = append(strippedRelations.Content, relationNode)
+		}
+		strippedNode.Content[i+1] = &strippedRelations
+	}
+	return &strippedNode, ruleNodes
+}
+
+// parseRelationRules decodes and validates relation rules of the source.
+func parseRelationRules(sourceName string, ruleNodes map[string][]*yaml.Node) (RelationRules, error) {
+	relationRules := RelationRules{}
+	rulesValue := reflect.ValueOf(&relationRules).Elem()
+	attributes := ruleAttributes()
+	for _, attribute := range slices.Sorted(maps.Keys(ruleNodes)) {
+		fieldName, ok := attributes[attribute]
+		if !ok {
+			return relationRules, fmt.Errorf(
+				"%s.%s: relation rules are not supported, relations must be in format regex = value",
+				sourceName, attribute,
+			)
+		}
+		rules := make([]utils.RelationRule, 0, len(ruleNodes[attribute]))
+		for _, ruleNode := range ruleNodes[attribute] {
+			var rule utils.RelationRule
+			if err := ruleNode.Decode(&rule); err != nil {
+				return relationRules, fmt.Errorf("%s.%s: %s", sourceName, attribute, err)
+			}
+			if _, err := utils.ValidateRelationRule(rule); err != nil {
+				return relationRules, fmt.Errorf("%s.%s: %s, in rule on line %d", sourceName, attribute, err, ruleNode.Line)
+			}
+			rules = append(rules, rule)
+		}
+		rulesValue.FieldByName(fieldName).Set(reflect.ValueOf(rules))
+	}
+	return relationRules, nil
+}
@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ConfigFingerprint returns fingerprint of all configuration files on the config path,
+// together with the files they include. Fingerprint changes, when any of the files
+// is changed, added or removed.
+func ConfigFingerprint(configPath string) (string, error) {
+	loader, err := loadConfigFiles(configPath)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	for _, filename := range slices.Sorted(maps.Keys(loader.loaded)) {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", filename, len(content))
+		hash.Write(content)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// secretAttributes are names of attributes, whose values are not shown in the diff.
+var secretAttributes = map[string]bool{"apiToken": true, "password": true, "token": true}
+
+// DiffConfigs returns changed settings between the old and the new configuration, one
+// per line, e.g. "netbox.timeout: 30 -> 60". Sources are identified by their names,
+// and values of secrets are redacted.
+func DiffConfigs(oldConfig *Config, newConfig *Config) []string {
+	oldValues := make(map[string]string)
+	flattenConfigValue(reflect.ValueOf(oldConfig), "", oldValues)
+	newValues := make(map[string]string)
+	flattenConfigValue(reflect.ValueOf(newConfig), "", newValues)
+
+	oldSources := sourceNames(oldConfig)
+	newSources := sourceNames(newConfig)
+	diff := []string{}
+	for _, name := range slices.Sorted(maps.Keys(newSources)) {
+		if !oldSources[name] {
+			diff = append(diff, fmt.Sprintf("source.%s: added", name))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(oldSources)) {
+		if !newSources[name] {
+			diff = append(diff, fmt.Sprintf("source.%s: removed", name))
+		}
+	}
+
+	paths := slices.Sorted(maps.Keys(oldValues))
+	for path := range newValues {
+		if _, ok := oldValues[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		// Added and removed sources are reported as a whole
+		if sourceName, ok := configPathSource(path); ok && oldSources[sourceName] != newSources[sourceName] {
+			continue
+		}
+		oldValue, inOld := oldValues[path]
+		newValue, inNew := newValues[path]
+		if isSecretPath(path) {
+			oldValue, newValue = redactSecret(oldValue), redactSecret(newValue)
+		}
+		switch {
+		case !inOld:
+			diff = append(diff, fmt.Sprintf("%s: added %s", path, newValue))
+		case !inNew:
+			diff = append(diff, fmt.Sprintf("%s: removed", path))
+		case oldValues[path] != newValues[path] && isSecretPath(path):
+			diff = append(diff, fmt.Sprintf("%s: changed", path))
+		case oldValues[path] != newValues[path]:
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", path, oldValue, newValue))
+		}
+	}
+	return diff
+}
+
+// sourceNames returns set of names of sources of the configuration.
+func sourceNames(config *Config) map[string]bool {
+	names := make(map[string]bool, len(config.Sources))
+	for _, sourceConfig := range config.Sources {
+		names[sourceConfig.Name] = true
+	}
+	return names
+}
+
+// configPathSource returns name of the source, if the path is a path of source attribute.
+func configPathSource(path string) (string, bool) {
+	sourcePath, ok := strings.CutPrefix(path, "source.")
+	if !ok {
+		return "", false
+	}
+	sourceName, _, _ := strings.Cut(sourcePath, ".")
+	return sourceName, true
+}
+
+// isSecretPath returns true, if the last attribute of the path is a secret.
+func isSecretPath(path string) bool {
+	return secretAttributes[path[strings.LastIndex(path, ".")+1:]]
+}
+
+// flattenConfigValue adds values of all attributes of the value to values, keyed by their
+// yaml paths. Lists of scalars are a single value, sources are keyed by their names.
+func flattenConfigValue(value reflect.Value, path string, values map[string]string) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			flattenConfigValue(value.Elem(), path, values)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if strings.HasSuffix(field.Tag.Get("yaml"), ",inline") {
+				flattenConfigValue(value.Field(i), path, values)
+				continue
+			}
+			flattenConfigValue(value.Field(i), joinConfigPath(path, configFieldName(field)), values)
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			flattenConfigValue(value.MapIndex(key), joinConfigPath(path, fmt.Sprint(key.Interface())), values)
+		}
+	case reflect.Slice:
+		if value.Type() == reflect.TypeOf([]SourceConfig{}) {
+			for i := 0; i < value.Len(); i++ {
+				sourceConfig := value.Index(i)
+				sourcePath := joinConfigPath(path, sourceConfig.FieldByName("Name").String())
+				flattenConfigValue(sourceConfig, sourcePath, values)
+			}
+			return
+		}
+		switch value.Type().Elem().Kind() {
+		case reflect.Struct, reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+			for i := 0; i < value.Len(); i++ {
+				flattenConfigValue(value.Index(i), fmt.Sprintf("%s[%d]", path, i), values)
+			}
+		default:
+			if value.Len() > 0 {
+				values[path] = fmt.Sprint(value.Interface())
+			}
+		}
+	default:
+		values[path] = fmt.Sprint(value.Interface())
+	}
+}
+
+// configFieldName returns yaml name of the field. Fields, that are not decoded from
+// yaml directly (e.g. relation rules), are named after the field.
+func configFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" || name == "-" {
+		runes := []rune(field.Name)
+		runes[0] = unicode.ToLower(runes[0])
+		name = string(runes)
+	}
+	return name
+}
+
+// joinConfigPath joins the attribute name to the path.
+func joinConfigPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
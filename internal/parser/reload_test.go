@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	oldConfig, err := ParseConfig(filepath.Join("../../testdata/parser", "valid_config1.yaml"))
+	if err != nil {
+		t.Fatalf("error parsing config: %s", err)
+	}
+	newConfig, err := ParseConfig(filepath.Join("../../testdata/parser", "valid_config1.yaml"))
+	if err != nil {
+		t.Fatalf("error parsing config: %s", err)
+	}
+	if diff := DiffConfigs(oldConfig, newConfig); len(diff) != 0 {
+		t.Errorf("diff of equal configs = %v, want empty", diff)
+	}
+
+	newConfig.Netbox.Timeout = oldConfig.Netbox.Timeout + 1
+	newConfig.Sources[0].Password = "newpass"
+	newConfig.Sources[1].Name = "renamed"
+	want := []string{
+		"source.renamed: added",
+		"source." + oldConfig.Sources[1].Name + ": removed",
+		"netbox.timeout: 15 -> 16",
+		"source." + oldConfig.Sources[0].Name + ".password: changed",
+	}
+	if diff := DiffConfigs(oldConfig, newConfig); !reflect.DeepEqual(diff, want) {
+		t.Errorf("diff = %v, want %v", diff, want)
+	}
+}
+
+func TestConfigFingerprint(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content, err := os.ReadFile(filepath.Join("../../testdata/parser", "valid_config1.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fingerprint, err := ConfigFingerprint(configPath)
+	if err != nil {
+		t.Fatalf("error computing fingerprint: %s", err)
+	}
+	if unchanged, _ := ConfigFingerprint(configPath); unchanged != fingerprint {
+		t.Errorf("fingerprint of unchanged file = %s, want %s", unchanged, fingerprint)
+	}
+
+	if err := os.WriteFile(configPath, append(content, []byte("\n# comment\n")...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if changed, _ := ConfigFingerprint(configPath); changed == fingerprint {
+		t.Errorf("fingerprint of changed file = %s, want different", changed)
+	}
+}
@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// jsonSchema is a JSON Schema of a configuration attribute.
+type jsonSchema map[string]interface{}
+
+// sourceTypes are all supported source types.
+var sourceTypes = []constants.SourceType{
+	constants.Ovirt,
+	constants.Vmware,
+	constants.Dnac,
+	constants.Proxmox,
+	constants.PaloAlto,
+	constants.Fortigate,
+	constants.FMC,
+	constants.IOSXE,
+}
+
+// relationPattern matches relations in format "regex = value".
+const relationPattern = `^[^=]+=[^=]+$`
+
+// relationConditionRef references schema of relation condition in $defs.
+const relationConditionRef = "#/$defs/relationCondition"
+
+// JSONSchema returns JSON Schema of the configuration file, generated from the parser structs.
+func JSONSchema() ([]byte, error) {
+	schema, err := json.MarshalIndent(configSchema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, '\n'), nil
+}
+
+// configSchema returns JSON Schema of the configuration file.
+func configSchema() jsonSchema {
+	schema := typeSchema(reflect.TypeOf(Config{}))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "netbox-ssot configuration"
+	properties := schema["properties"].(map[string]jsonSchema) //nolint:forcetypeassert
+	properties["include"] = jsonSchema{
+		"description": "Paths or globs of included configuration files, relative to this file.",
+		"type":        "array",
+		"items":       jsonSchema{"type": "string"},
+	}
+	properties["relationProfiles"] = jsonSchema{
+		"description":          "Named sets of relations, that sources can reference with relationProfiles.",
+		"type":                 "object",
+		"additionalProperties": relationProfileSchema(),
+	}
+	// Top level x- keys can hold yaml anchors, e.g. shared relations
+	schema["patternProperties"] = map[string]jsonSchema{"^x-": {}}
+	schema["$defs"] = map[string]jsonSchema{
+		strings.TrimPrefix(relationConditionRef, "#/$defs/"): structSchema(reflect.TypeOf(utils.RelationCondition{})),
+	}
+	return schema
+}
+
+// relationProfileSchema returns JSON Schema of a relation profile, which can contain
+// all relation attributes of the source.
+func relationProfileSchema() jsonSchema {
+	sourceSchema := typeSchema(reflect.TypeOf(SourceConfig{}))
+	sourceProperties := sourceSchema["properties"].(map[string]jsonSchema) //nolint:forcetypeassert
+	properties := make(map[string]jsonSchema)
+	for attribute := range relationAttributes {
+		properties[attribute] = sourceProperties[attribute]
+	}
+	return jsonSchema{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// typeSchema returns JSON Schema of the go type, based on its yaml representation.
+func typeSchema(t reflect.Type) jsonSchema {
+	switch t {
+	case reflect.TypeOf(HTTPScheme("")):
+		return jsonSchema{"type": "string", "enum": []HTTPScheme{HTTP, HTTPS}}
+	case reflect.TypeOf(constants.SourceType("")):
+		return jsonSchema{"type": "string", "enum": sourceTypes}
+	case reflect.TypeOf(utils.RelationCondition{}):
+		// Relation conditions are recursive, so they are referenced
+		return jsonSchema{"$ref": relationConditionRef}
+	case reflect.TypeOf(LoggerConfig{}):
+		// LoggerConfig has custom unmarshal function, which also accepts level names
+		return jsonSchema{
+			"type": "object",
+			"properties": map[string]jsonSchema{
+				"level": {"oneOf": []jsonSchema{
+					{"type": "integer", "minimum": 0, "maximum": 3},
+					{"type": "string", "enum": []string{"debug", "info", "warn", "warning", "error"}},
+				}},
+				"dest": {"type": "string"},
+			},
+			"additionalProperties": false,
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeSchema(t.Elem())
+	case reflect.String:
+		return jsonSchema{"type": "string"}
+	case reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return jsonSchema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return jsonSchema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return jsonSchema{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return jsonSchema{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		// interface{} can be any value
+		return jsonSchema{}
+	}
+}
+
+// structSchema returns JSON Schema of the struct, with properties for all fields with yaml tag.
+func structSchema(t reflect.Type) jsonSchema {
+	properties := make(map[string]jsonSchema)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		// Properties of inlined structs are properties of the struct itself
+		if options == "inline" {
+			maps.Copy(properties, structSchema(field.Type)["properties"].(map[string]jsonSchema)) //nolint:forcetypeassert
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		// Maps of SourceConfig are configured as lists in format "regex = value",
+		// and converted to maps in SourceConfig's UnmarshalYAML
+		if t == reflect.TypeOf(SourceConfig{}) && relationAttributes[name] {
+			relationSchema := jsonSchema{"type": "string", "pattern": relationPattern}
+			// Relations, that support relation rules, can also contain rules
+			if _, ok := ruleAttributes()[name]; ok {
+				relationSchema = jsonSchema{"oneOf": []jsonSchema{
+					relationSchema,
+					typeSchema(reflect.TypeOf(utils.RelationRule{})),
+				}}
+			}
+			properties[name] = jsonSchema{"type": "array", "items": relationSchema}
+			continue
+		}
+		properties[name] = typeSchema(field.Type)
+	}
+	return jsonSchema{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
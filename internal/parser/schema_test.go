@@ -0,0 +1,22 @@
+package parser
+
+import (
+	"os"
+	"testing"
+)
+
+// TestJSONSchemaIsUpToDate checks, that the published config.schema.json matches
+// the parser structs. Regenerate it with make schema.
+func TestJSONSchemaIsUpToDate(t *testing.T) {
+	schema, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	publishedSchema, err := os.ReadFile("../../config.schema.json")
+	if err != nil {
+		t.Fatalf("read config.schema.json: %s", err)
+	}
+	if string(schema) != string(publishedSchema) {
+		t.Errorf("config.schema.json is out of date, regenerate it with make schema")
+	}
+}
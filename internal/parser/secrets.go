@@ -0,0 +1,332 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// redactedSecret replaces secrets in String() outputs.
+const redactedSecret = "********"
+
+// redactSecret returns redactedSecret for non empty secrets.
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redactedSecret
+}
+
+// secretReferenceRegex matches ${NAME} (environment variable) and ${provider:reference}
+// (secret provider) references. References prefixed with additional $ are escaped.
+var secretReferenceRegex = regexp.MustCompile(`\$?\$\{([^{}]+)\}`)
+
+// envVarNameRegex matches valid names of environment variables.
+var envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// secretProviderRegex matches ${provider:reference} references.
+var secretProviderRegex = regexp.MustCompile(`^([a-z]+):(.+)$`)
+
+// SecretProvider resolves references to secrets stored in an external secret store.
+// Secrets are referenced in config as ${<provider>:<reference>}.
+type SecretProvider interface {
+	GetSecret(reference string) (string, error)
+}
+
+// SecretsConfig configures external secret stores, that secrets in config can reference.
+// In secrets block.
+type SecretsConfig struct {
+	Vault *VaultConfig `yaml:"vault"`
+}
+
+// VaultConfig configures a HashiCorp Vault compatible KV secret store.
+// Secrets are referenced in config as ${vault:path#key}.
+type VaultConfig struct {
+	// Address of the vault server. Defaults to VAULT_ADDR environment variable.
+	Address string `yaml:"address"`
+	// Token used for authentication. Defaults to VAULT_TOKEN environment variable.
+	Token     string `yaml:"token"`
+	TokenFile string `yaml:"tokenFile"`
+	Namespace string `yaml:"namespace"`
+	// Mount is the mount path of the KV secrets engine.
+	Mount string `yaml:"mount"`
+	// KVVersion is the version of the KV secrets engine (1 or 2).
+	KVVersion    int    `yaml:"kvVersion"`
+	ValidateCert bool   `yaml:"validateCert"`
+	CAFile       string `yaml:"caFile"`
+	Timeout      int    `yaml:"timeout"`
+}
+
+func (vc VaultConfig) String() string {
+	return fmt.Sprintf(
+		"VaultConfig{Address: %s, Token: %s, Namespace: %s, Mount: %s, KVVersion: %d}",
+		vc.Address,
+		redactSecret(vc.Token),
+		vc.Namespace,
+		vc.Mount,
+		vc.KVVersion,
+	)
+}
+
+// VaultProvider is a SecretProvider, that reads secrets from a HashiCorp Vault
+// compatible KV secrets engine.
+type VaultProvider struct {
+	httpClient *http.Client
+	address    string
+	token      string
+	namespace  string
+	mount      string
+	kvVersion  int
+	timeout    time.Duration
+	// secrets caches data of already read secrets: path -> key -> value.
+	secrets map[string]map[string]interface{}
+}
+
+// NewVaultProvider creates a VaultProvider from the vault config.
+func NewVaultProvider(vaultConfig VaultConfig) (*VaultProvider, error) {
+	if vaultConfig.Address == "" {
+		vaultConfig.Address = os.Getenv("VAULT_ADDR")
+	}
+	if vaultConfig.Address == "" {
+		return nil, fmt.Errorf("secrets.vault.address: cannot be empty")
+	}
+	if _, err := url.ParseRequestURI(vaultConfig.Address); err != nil {
+		return nil, fmt.Errorf("secrets.vault.address: %s", err)
+	}
+	// Vault token can't reference other secret providers
+	token, err := resolveSecretField(vaultConfig.Token, vaultConfig.TokenFile, nil)
+	if err != nil {
+		return nil, fmt.Errorf("secrets.vault.token: %s", err)
+	}
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("secrets.vault.token: cannot be empty")
+	}
+	if vaultConfig.Mount == "" {
+		vaultConfig.Mount = "secret"
+	}
+	if vaultConfig.KVVersion == 0 {
+		vaultConfig.KVVersion = 2
+	} else if vaultConfig.KVVersion != 1 && vaultConfig.KVVersion != 2 {
+		return nil, fmt.Errorf("secrets.vault.kvVersion: must be 1 or 2. Is %d", vaultConfig.KVVersion)
+	}
+	if vaultConfig.Timeout < 0 {
+		return nil, fmt.Errorf("secrets.vault.timeout: cannot be negative")
+	} else if vaultConfig.Timeout == 0 {
+		vaultConfig.Timeout = constants.DefaultAPITimeout
+	}
+	httpClient, err := utils.NewHTTPClient(vaultConfig.ValidateCert, vaultConfig.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("secrets.vault: %s", err)
+	}
+	return &VaultProvider{
+		httpClient: httpClient,
+		address:    strings.TrimRight(vaultConfig.Address, "/"),
+		token:      token,
+		namespace:  vaultConfig.Namespace,
+		mount:      strings.Trim(vaultConfig.Mount, "/"),
+		kvVersion:  vaultConfig.KVVersion,
+		timeout:    time.Duration(vaultConfig.Timeout) * time.Second,
+		secrets:    make(map[string]map[string]interface{}),
+	}, nil
+}
+
+// GetSecret returns value of the key of the secret, referenced in format path#key.
+func (vp *VaultProvider) GetSecret(reference string) (string, error) {
+	path, key, found := strings.Cut(reference, "#")
+	path = strings.Trim(path, "/")
+	if !found || path == "" || key == "" {
+		return "", fmt.Errorf("invalid vault reference %s. Must be in format path#key", reference)
+	}
+	data, err := vp.readSecret(path)
+	if err != nil {
+		return "", err
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in vault secret %s", key, path)
+	}
+	secret, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %s of vault secret %s is not a string", key, path)
+	}
+	return secret, nil
+}
+
+// readSecret reads data of the secret on the given path from vault.
+func (vp *VaultProvider) readSecret(path string) (map[string]interface{}, error) {
+	if data, ok := vp.secrets[path]; ok {
+		return data, nil
+	}
+	secretURL := fmt.Sprintf("%s/v1/%s/%s", vp.address, vp.mount, path)
+	if vp.kvVersion == 2 {
+		secretURL = fmt.Sprintf("%s/v1/%s/data/%s", vp.address, vp.mount, path)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), vp.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create vault request: %s", err)
+	}
+	req.Header.Set("X-Vault-Token", vp.token)
+	if vp.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", vp.namespace)
+	}
+	resp, err := vp.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("read vault secret %s: %s", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("read vault secret %s: unexpected status code %d", path, resp.StatusCode)
+	}
+
+	var data map[string]interface{}
+	if vp.kvVersion == 2 {
+		// KV version 2 wraps secret data together with its metadata
+		var kv2Response struct {
+			Data struct {
+				Data map[string]interface{} `json:"data"`
+			} `json:"data"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&kv2Response)
+		data = kv2Response.Data.Data
+	} else {
+		var kv1Response struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&kv1Response)
+		data = kv1Response.Data
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode vault secret %s: %s", path, err)
+	}
+	vp.secrets[path] = data
+	return data, nil
+}
+
+// newSecretProviders creates secret providers configured in the secrets block.
+func newSecretProviders(secretsConfig *SecretsConfig) (map[string]SecretProvider, error) {
+	providers := make(map[string]SecretProvider)
+	if secretsConfig == nil {
+		return providers, nil
+	}
+	if secretsConfig.Vault != nil {
+		vaultProvider, err := NewVaultProvider(*secretsConfig.Vault)
+		if err != nil {
+			return nil, err
+		}
+		providers["vault"] = vaultProvider
+	}
+	return providers, nil
+}
+
+// expandSecret replaces ${NAME} references in value with values of environment
+// variables, and ${provider:reference} references with secrets from secret providers.
+// Escaped references ($${NAME}) are replaced with literal ${NAME}.
+func expandSecret(value string, providers map[string]SecretProvider) (string, error) {
+	var expandErr error
+	expanded := secretReferenceRegex.ReplaceAllStringFunc(value, func(match string) string {
+		if expandErr != nil {
+			return match
+		}
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+		reference := secretReferenceRegex.FindStringSubmatch(match)[1]
+		if envVarNameRegex.MatchString(reference) {
+			envValue, ok := os.LookupEnv(reference)
+			if !ok {
+				expandErr = fmt.Errorf("environment variable %s is not set", reference)
+			}
+			return envValue
+		}
+		if providerMatch := secretProviderRegex.FindStringSubmatch(reference); providerMatch != nil {
+			provider, ok := providers[providerMatch[1]]
+			if !ok {
+				expandErr = fmt.Errorf("secret provider %s is not configured", providerMatch[1])
+				return match
+			}
+			secret, err := provider.GetSecret(providerMatch[2])
+			if err != nil {
+				expandErr = err
+			}
+			return secret
+		}
+		// Not a reference, e.g. part of a password
+		return match
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
+
+// resolveSecretField returns the secret either from secretFile (e.g. mounted
+// kubernetes secret), or from the value with expanded references.
+func resolveSecretField(
+	value string,
+	secretFile string,
+	providers map[string]SecretProvider,
+) (string, error) {
+	if secretFile != "" {
+		if value != "" {
+			return "", fmt.Errorf("secret and secret file are mutually exclusive")
+		}
+		content, err := os.ReadFile(secretFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+	return expandSecret(value, providers)
+}
+
+// resolveSecrets resolves secrets of netbox and all sources from environment
+// variables, files and secret providers. All errors are returned.
+func resolveSecrets(config *Config) []error {
+	providers, err := newSecretProviders(config.Secrets)
+	if err != nil {
+		return []error{err}
+	}
+	errs := []error{}
+	config.Netbox.APIToken, err = resolveSecretField(
+		config.Netbox.APIToken,
+		config.Netbox.APITokenFile,
+		providers,
+	)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("netbox.apiToken: %s", err))
+	}
+	for i := range config.Sources {
+		sourceConfig := &config.Sources[i]
+		sourceConfig.Password, err = resolveSecretField(
+			sourceConfig.Password,
+			sourceConfig.PasswordFile,
+			providers,
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.password: %s", sourceConfig.Name, err))
+		}
+		sourceConfig.APIToken, err = resolveSecretField(
+			sourceConfig.APIToken,
+			sourceConfig.APITokenFile,
+			providers,
+		)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.apiToken: %s", sourceConfig.Name, err))
+		}
+	}
+	return errs
+}
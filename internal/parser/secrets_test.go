@@ -0,0 +1,236 @@
+package parser
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newVaultStub returns a stub of vault server, that serves secrets of KV engine
+// mounted on secret/ (version 2) and kv/ (version 1).
+func newVaultStub(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/netbox-ssot/vcenter":
+			fmt.Fprint(w, `{"data": {"data": {"password": "vcenter-pass", "port": 443}, "metadata": {"version": 1}}}`)
+		case "/v1/secret/data/netbox-ssot/netbox":
+			fmt.Fprint(w, `{"data": {"data": {"token": "netbox-token"}, "metadata": {"version": 3}}}`)
+		case "/v1/kv/netbox-ssot/netbox":
+			fmt.Fprint(w, `{"data": {"token": "netbox-token"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestVaultProvider_GetSecret(t *testing.T) {
+	vaultStub := newVaultStub(t, "vault-token")
+	defer vaultStub.Close()
+
+	tests := []struct {
+		name        string
+		vaultConfig VaultConfig
+		reference   string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "KV version 2 secret",
+			vaultConfig: VaultConfig{Address: vaultStub.URL, Token: "vault-token"},
+			reference:   "netbox-ssot/vcenter#password",
+			want:        "vcenter-pass",
+		},
+		{
+			name:        "KV version 1 secret",
+			vaultConfig: VaultConfig{Address: vaultStub.URL, Token: "vault-token", Mount: "kv", KVVersion: 1},
+			reference:   "netbox-ssot/netbox#token",
+			want:        "netbox-token",
+		},
+		{
+			name:        "Missing key",
+			vaultConfig: VaultConfig{Address: vaultStub.URL, Token: "vault-token"},
+			reference:   "netbox-ssot/vcenter#username",
+			wantErr:     true,
+		},
+		{
+			name:        "Value is not a string",
+			vaultConfig: VaultConfig{Address: vaultStub.URL, Token: "vault-token"},
+			reference:   "netbox-ssot/vcenter#port",
+			wantErr:     true,
+		},
+		{
+			name:        "Missing secret",
+			vaultConfig: VaultConfig{Address: vaultStub.URL, Token: "vault-token"},
+			reference:   "netbox-ssot/missing#password",
+			wantErr:     true,
+		},
+		{
+			name:        "Wrong token",
+			vaultConfig: VaultConfig{Address: vaultStub.URL, Token: "wrong-token"},
+			reference:   "netbox-ssot/vcenter#password",
+			wantErr:     true,
+		},
+		{
+			name:        "Reference without key",
+			vaultConfig: VaultConfig{Address: vaultStub.URL, Token: "vault-token"},
+			reference:   "netbox-ssot/vcenter",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vaultProvider, err := NewVaultProvider(tt.vaultConfig)
+			if err != nil {
+				t.Fatalf("NewVaultProvider() error = %v", err)
+			}
+			got, err := vaultProvider.GetSecret(tt.reference)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VaultProvider.GetSecret() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("VaultProvider.GetSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVaultProvider(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+	tests := []struct {
+		name        string
+		vaultConfig VaultConfig
+		wantErr     string
+	}{
+		{
+			name:        "Missing address",
+			vaultConfig: VaultConfig{Token: "vault-token"},
+			wantErr:     "secrets.vault.address: cannot be empty",
+		},
+		{
+			name:        "Missing token",
+			vaultConfig: VaultConfig{Address: "https://vault.example.com"},
+			wantErr:     "secrets.vault.token: cannot be empty",
+		},
+		{
+			name:        "Invalid kv version",
+			vaultConfig: VaultConfig{Address: "https://vault.example.com", Token: "vault-token", KVVersion: 3},
+			wantErr:     "secrets.vault.kvVersion: must be 1 or 2. Is 3",
+		},
+		{
+			name:        "Token referencing secret provider",
+			vaultConfig: VaultConfig{Address: "https://vault.example.com", Token: "${vault:token#token}"},
+			wantErr:     "secrets.vault.token: secret provider vault is not configured",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewVaultProvider(tt.vaultConfig)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("NewVaultProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpandSecret(t *testing.T) {
+	t.Setenv("NETBOX_SSOT_TEST_SECRET", "env-secret")
+	vaultStub := newVaultStub(t, "vault-token")
+	defer vaultStub.Close()
+	vaultProvider, err := NewVaultProvider(VaultConfig{Address: vaultStub.URL, Token: "vault-token"})
+	if err != nil {
+		t.Fatalf("NewVaultProvider() error = %v", err)
+	}
+	providers := map[string]SecretProvider{"vault": vaultProvider}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Plain secret", value: "pa$$word", want: "pa$$word"},
+		{name: "Environment variable", value: "${NETBOX_SSOT_TEST_SECRET}", want: "env-secret"},
+		{name: "Environment variable inside value", value: "prefix-${NETBOX_SSOT_TEST_SECRET}", want: "prefix-env-secret"},
+		{name: "Escaped reference", value: "$${NETBOX_SSOT_TEST_SECRET}", want: "${NETBOX_SSOT_TEST_SECRET}"},
+		{name: "Not a reference", value: "pass${word with spaces}", want: "pass${word with spaces}"},
+		{name: "Secret provider", value: "${vault:netbox-ssot/vcenter#password}", want: "vcenter-pass"},
+		{name: "Unset environment variable", value: "${NETBOX_SSOT_UNSET_VARIABLE}", wantErr: true},
+		{name: "Unknown secret provider", value: "${aws:netbox-ssot#password}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandSecret(tt.value, providers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expandSecret() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("expandSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConfigWithVaultSecrets(t *testing.T) {
+	vaultStub := newVaultStub(t, "vault-token")
+	defer vaultStub.Close()
+	t.Setenv("NETBOX_SSOT_TEST_VAULT_TOKEN", "vault-token")
+
+	configContent := fmt.Sprintf(`
+netbox:
+  apiToken: "${vault:netbox-ssot/netbox#token}"
+  hostname: netbox.example.com
+source:
+  - name: testvmware
+    type: vmware
+    hostname: vcenter.example.com
+    username: "test"
+    password: "${vault:netbox-ssot/vcenter#password}"
+secrets:
+  vault:
+    address: %s
+    token: "${NETBOX_SSOT_TEST_VAULT_TOKEN}"
+`, vaultStub.URL)
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte(configContent), 0600); err != nil {
+		t.Fatalf("write config: %s", err)
+	}
+
+	config, err := ParseConfig(configFile)
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if config.Netbox.APIToken != "netbox-token" {
+		t.Errorf("netbox.apiToken = %s, want netbox-token", config.Netbox.APIToken)
+	}
+	if config.Sources[0].Password != "vcenter-pass" {
+		t.Errorf("source.password = %s, want vcenter-pass", config.Sources[0].Password)
+	}
+}
+
+func TestSecretsAreRedacted(t *testing.T) {
+	netboxConfig := NetboxConfig{APIToken: "netbox-token"}
+	sourceConfig := SourceConfig{Password: "source-password", APIToken: "source-token"}
+	vaultConfig := VaultConfig{Token: "vault-token"}
+	for _, output := range []string{netboxConfig.String(), sourceConfig.String(), vaultConfig.String()} {
+		for _, secret := range []string{"netbox-token", "source-password", "source-token", "vault-token"} {
+			if strings.Contains(output, secret) {
+				t.Errorf("%s contains secret %s", output, secret)
+			}
+		}
+		if !strings.Contains(output, redactedSecret) {
+			t.Errorf("%s doesn't contain redacted secret", output)
+		}
+	}
+}
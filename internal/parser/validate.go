@@ -0,0 +1,389 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is severity of a configuration problem.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is a problem found in the configuration. Line is 0, when problem
+// couldn't be located in the configuration file.
+type Problem struct {
+	Filename string
+	Line     int
+	Severity Severity
+	Message  string
+}
+
+func (p Problem) String() string {
+	switch {
+	case p.Filename == "":
+		return fmt.Sprintf("%s: %s", p.Severity, p.Message)
+	case p.Line == 0:
+		return fmt.Sprintf("%s: %s: %s", p.Filename, p.Severity, p.Message)
+	default:
+		return fmt.Sprintf("%s:%d: %s: %s", p.Filename, p.Line, p.Severity, p.Message)
+	}
+}
+
+// sourceTypeAttributes are source attributes, that only have effect for some source types.
+var sourceTypeAttributes = map[string][]constants.SourceType{
+	"apiToken":                        {constants.Fortigate},
+	"apiTokenFile":                    {constants.Fortigate},
+	"collectArpData":                  {constants.PaloAlto, constants.IOSXE},
+	"ignoreAssetTags":                 {constants.Vmware},
+	"ignoreVMTemplates":               {constants.Vmware},
+	"collectLocalContext":             {constants.Vmware, constants.Proxmox, constants.PaloAlto, constants.Fortigate},
+	"localContextKeys":                {constants.Vmware, constants.Proxmox, constants.PaloAlto, constants.Fortigate},
+	"datacenterClusterGroupRelations": {constants.Vmware, constants.Ovirt},
+	"hostPowerFeedRelations":          {constants.Vmware, constants.IOSXE},
+	"vmRoleServices":                  {constants.Ovirt, constants.Vmware, constants.Proxmox},
+	"wlanTenantRelations":             {constants.Dnac},
+	"customFieldMappings":             {constants.Vmware},
+}
+
+// fortigateIgnoredAttributes are attributes, that fortigate source doesn't use,
+// because it authenticates with api token.
+var fortigateIgnoredAttributes = []string{"username", "password", "passwordFile"}
+
+// dependentAttribute is an attribute, that only has effect together with another attribute.
+// Combinations, that are already errors (e.g. localContextKeys without collectLocalContext),
+// are not listed.
+type dependentAttribute struct {
+	attribute string
+	requires  string
+}
+
+var sourceDependentAttributes = []dependentAttribute{
+	{attribute: "hostRackPositionRelations", requires: "hostRackRelations"},
+	{attribute: "hostRackFaceRelations", requires: "hostRackRelations"},
+}
+
+// yamlErrorLineRegex matches lines of yaml.TypeError messages, e.g. "line 5: cannot unmarshal...".
+var yamlErrorLineRegex = regexp.MustCompile(`^line (\d+): (.*)$`)
+
+// ValidateConfig validates configuration on the config path and returns all found problems,
+// located in configuration files where possible. Unlike ParseConfig, validation doesn't stop
+// at the first error, and also warns about unknown attributes and attributes, that have no effect.
+func ValidateConfig(configPath string) []Problem {
+	loader, err := loadConfigFiles(configPath)
+	if err != nil {
+		return []Problem{{Severity: SeverityError, Message: err.Error()}}
+	}
+
+	problems := []Problem{}
+	schema := configSchema()
+	for _, document := range loader.documents {
+		problems = append(problems, unknownKeyProblems(document, schema)...)
+	}
+
+	config := defaultConfig()
+	errs := loader.mergeDocuments(config)
+	errs = append(errs, resolveSecrets(config)...)
+	errs = append(errs, validateConfig(config)...)
+	for _, err := range errs {
+		problems = append(problems, loader.errorProblems(err)...)
+	}
+
+	for _, sourceNode := range loader.sources {
+		problems = append(problems, sourceTypeProblems(sourceNode)...)
+		problems = append(
+			problems,
+			dependentAttributeProblems(
+				sourceNode.filename, sourceName(sourceNode.root), sourceNode.root, sourceDependentAttributes,
+			)...,
+		)
+	}
+
+	slices.SortStableFunc(problems, func(a, b Problem) int {
+		if a.Filename != b.Filename {
+			return strings.Compare(a.Filename, b.Filename)
+		}
+		return a.Line - b.Line
+	})
+	return problems
+}
+
+// HasErrors returns true, if any of the problems is an error.
+func HasErrors(problems []Problem) bool {
+	return slices.ContainsFunc(problems, func(problem Problem) bool {
+		return problem.Severity == SeverityError
+	})
+}
+
+// errorProblems converts validation error to problems, located in configuration files.
+func (cl *configLoader) errorProblems(err error) []Problem {
+	var fe fileError
+	var typeError *yaml.TypeError
+	if errors.As(err, &fe) && errors.As(err, &typeError) {
+		// yaml type errors already contain lines of all errors
+		problems := make([]Problem, 0, len(typeError.Errors))
+		for _, typeErrorMessage := range typeError.Errors {
+			problem := Problem{Filename: fe.filename, Severity: SeverityError, Message: typeErrorMessage}
+			if match := yamlErrorLineRegex.FindStringSubmatch(typeErrorMessage); match != nil {
+				problem.Line, _ = strconv.Atoi(match[1])
+				problem.Message = match[2]
+			}
+			problems = append(problems, problem)
+		}
+		return problems
+	}
+	filename, line := cl.locate(err.Error())
+	if filename == "" && errors.As(err, &fe) {
+		filename = fe.filename
+	}
+	return []Problem{{Filename: filename, Line: line, Severity: SeverityError, Message: err.Error()}}
+}
+
+// locate returns file and line of the attribute, that the error message starts with
+// (e.g. "source1.primaryIPPolicy.ipVersions: ..."). If the attribute is missing,
+// line of its closest parent is returned.
+func (cl *configLoader) locate(message string) (string, int) {
+	var filename string
+	var node *yaml.Node
+	var line int
+	var attributePath string
+	for _, block := range []string{"logger", "netbox", "secrets", "defaults", "relationProfiles", "relationLookups"} {
+		if !strings.HasPrefix(message, block+".") && !strings.HasPrefix(message, block+":") {
+			continue
+		}
+		for _, document := range cl.documents {
+			if index := mappingValueIndex(document.root, block); index >= 0 {
+				filename, line = document.filename, document.root.Content[index-1].Line
+				node = resolveAlias(document.root.Content[index])
+				attributePath = strings.TrimPrefix(message, block)
+				break
+			}
+		}
+	}
+	if node == nil {
+		// Errors of duplicate sources are prefixed with "source "
+		message = strings.TrimPrefix(message, "source ")
+		// Longest source name matches, in case name of one source is prefix of another.
+		// Of sources with the same name, the last one is matched (e.g. the duplicate one).
+		matchedName := ""
+		for _, sourceNode := range cl.sources {
+			name := sourceName(sourceNode.root)
+			if len(name) >= len(matchedName) && name != "" &&
+				(strings.HasPrefix(message, name+".") || strings.HasPrefix(message, name+":")) {
+				matchedName = name
+				filename, line, node = sourceNode.filename, sourceNode.root.Line, sourceNode.root
+			}
+		}
+		if node == nil {
+			return "", 0
+		}
+		attributePath = strings.TrimPrefix(message, matchedName)
+	}
+
+	// Attribute path ends with ":" or " ", e.g. ".hostname: cannot be empty" or ".type is not valid"
+	attributePath, _, _ = strings.Cut(attributePath, ":")
+	attributePath, _, _ = strings.Cut(attributePath, " ")
+	for _, key := range strings.Split(strings.TrimPrefix(attributePath, "."), ".") {
+		if node.Kind != yaml.MappingNode {
+			break
+		}
+		index := mappingValueIndex(node, key)
+		if index < 0 {
+			break
+		}
+		line = node.Content[index-1].Line
+		node = resolveAlias(node.Content[index])
+	}
+	return filename, line
+}
+
+// unknownKeyProblems returns warnings for keys of the document, that are not part of the schema.
+func unknownKeyProblems(document configDocument, rootSchema jsonSchema) []Problem {
+	problems := []Problem{}
+	var walk func(node *yaml.Node, schema jsonSchema, path string)
+	walk = func(node *yaml.Node, schema jsonSchema, path string) {
+		node = resolveAlias(node)
+		if ref, ok := schema["$ref"].(string); ok {
+			definitions, _ := rootSchema["$defs"].(map[string]jsonSchema)
+			schema = definitions[strings.TrimPrefix(ref, "#/$defs/")]
+		}
+		// Mappings are checked with the object alternative, e.g. relation rules
+		if alternatives, ok := schema["oneOf"].([]jsonSchema); ok && node.Kind == yaml.MappingNode {
+			for _, alternative := range alternatives {
+				if alternative["type"] == "object" {
+					schema = alternative
+				}
+			}
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			properties, ok := schema["properties"].(map[string]jsonSchema)
+			if !ok {
+				if additionalProperties, ok := schema["additionalProperties"].(jsonSchema); ok {
+					for i := 0; i+1 < len(node.Content); i += 2 {
+						walk(node.Content[i+1], additionalProperties, path+"."+node.Content[i].Value)
+					}
+				}
+				return
+			}
+			patternProperties, _ := schema["patternProperties"].(map[string]jsonSchema)
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i].Value
+				if key == "<<" {
+					// Merged mappings are checked with the same schema
+					walk(node.Content[i+1], schema, path)
+					continue
+				}
+				// Relation profiles are validated when merging documents
+				if path == "" && key == "relationProfiles" {
+					continue
+				}
+				if propertySchema, ok := properties[key]; ok {
+					walk(node.Content[i+1], propertySchema, strings.TrimPrefix(path+"."+key, "."))
+					continue
+				}
+				if _, ok := patternProperties["^x-"]; ok && strings.HasPrefix(key, "x-") {
+					continue
+				}
+				message := fmt.Sprintf("%s: unknown attribute %s", strings.TrimPrefix(path, "."), key)
+				if path == "" {
+					message = "unknown attribute " + key
+				}
+				if suggestion := closestKey(key, properties); suggestion != "" {
+					message += fmt.Sprintf(". Did you mean %s?", suggestion)
+				}
+				problems = append(problems, Problem{
+					Filename: document.filename,
+					Line:     node.Content[i].Line,
+					Severity: SeverityWarning,
+					Message:  message,
+				})
+			}
+		case yaml.SequenceNode:
+			if items, ok := schema["items"].(jsonSchema); ok {
+				for i, item := range node.Content {
+					itemPath := fmt.Sprintf("%s[%d]", path, i)
+					// Sources are identified by their name
+					if path == "source" {
+						itemPath = sourceName(resolveAlias(item))
+					}
+					walk(item, items, itemPath)
+				}
+			}
+		}
+	}
+	walk(document.root, rootSchema, "")
+	return problems
+}
+
+// closestKey returns the key, which is the most similar to the unknown key,
+// if they differ in at most 2 characters.
+func closestKey(key string, properties map[string]jsonSchema) string {
+	closest := ""
+	closestDistance := 3
+	for property := range properties {
+		distance := levenshteinDistance(strings.ToLower(key), strings.ToLower(property))
+		if distance < closestDistance || distance == closestDistance && property < closest {
+			closest, closestDistance = property, distance
+		}
+	}
+	return closest
+}
+
+// levenshteinDistance returns edit distance between two strings.
+func levenshteinDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// sourceTypeProblems returns warnings for source attributes, that have no effect
+// for the type of the source.
+func sourceTypeProblems(sourceNode configDocument) []Problem {
+	problems := []Problem{}
+	if sourceNode.root.Kind != yaml.MappingNode {
+		return problems
+	}
+	typeNode := mappingValue(sourceNode.root, "type")
+	if typeNode == nil {
+		return problems
+	}
+	sourceType := constants.SourceType(typeNode.Value)
+	for i := 0; i+1 < len(sourceNode.root.Content); i += 2 {
+		attribute := sourceNode.root.Content[i].Value
+		supportedTypes, ok := sourceTypeAttributes[attribute]
+		ignored := ok && !slices.Contains(supportedTypes, sourceType) ||
+			sourceType == constants.Fortigate && slices.Contains(fortigateIgnoredAttributes, attribute)
+		if !ignored {
+			continue
+		}
+		problems = append(problems, Problem{
+			Filename: sourceNode.filename,
+			Line:     sourceNode.root.Content[i].Line,
+			Severity: SeverityWarning,
+			Message: fmt.Sprintf(
+				"%s.%s: has no effect for source type %s",
+				sourceName(sourceNode.root), attribute, sourceType,
+			),
+		})
+	}
+	return problems
+}
+
+// dependentAttributeProblems returns warnings for attributes, that are set without
+// the attribute they depend on.
+func dependentAttributeProblems(
+	filename string,
+	path string,
+	node *yaml.Node,
+	dependentAttributes []dependentAttribute,
+) []Problem {
+	problems := []Problem{}
+	if node.Kind != yaml.MappingNode {
+		return problems
+	}
+	for _, dependent := range dependentAttributes {
+		index := mappingValueIndex(node, dependent.attribute)
+		if index < 0 {
+			continue
+		}
+		required := mappingValue(node, dependent.requires)
+		if required != nil && required.Value != "false" {
+			continue
+		}
+		problems = append(problems, Problem{
+			Filename: filename,
+			Line:     node.Content[index-1].Line,
+			Severity: SeverityWarning,
+			Message: fmt.Sprintf(
+				"%s.%s: has no effect without %s.%s",
+				path, dependent.attribute, path, dependent.requires,
+			),
+		})
+	}
+	return problems
+}
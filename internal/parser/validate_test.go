@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		want     []Problem
+	}{
+		{
+			name:     "Valid config",
+			filename: "valid_config1.yaml",
+			want:     []Problem{},
+		},
+		{
+			name:     "All problems are reported",
+			filename: "validate_config1.yaml",
+			want: []Problem{
+				{Line: 7, Severity: SeverityError, Message: "netbox.timeout: cannot be negative"},
+				{
+					Line:     14,
+					Severity: SeverityWarning,
+					Message:  "testolvm: unknown attribute hostSiteRelation. Did you mean hostSiteRelations?",
+				},
+				{Line: 16, Severity: SeverityWarning, Message: "testolvm.ignoreVMTemplates: has no effect for source type ovirt"},
+				{Line: 21, Severity: SeverityWarning, Message: "testfortigate.username: has no effect for source type fortigate"},
+				{Line: 22, Severity: SeverityError, Message: "testfortigate.port: must be between 0 and 65535. Is -5"},
+				{
+					Line:     28,
+					Severity: SeverityWarning,
+					Message:  "testvmware.hostRackPositionRelations: has no effect without testvmware.hostRackRelations",
+				},
+			},
+		},
+		{
+			name:     "Type errors are reported",
+			filename: "validate_config2.yaml",
+			want: []Problem{
+				{Line: 4, Severity: SeverityError, Message: "cannot unmarshal !!str `abc` into int"},
+				{Line: 11, Severity: SeverityError, Message: "cannot unmarshal !!str `notbool` into bool"},
+				{Line: 12, Severity: SeverityError, Message: "cannot unmarshal !!str `abc` into int"},
+			},
+		},
+		{
+			name:     "Duplicate source is located",
+			filename: "invalid_config67.yaml",
+			want: []Problem{
+				{
+					Filename: "../../testdata/parser/include/duplicate_source.yaml",
+					Line:     2,
+					Severity: SeverityError,
+					Message: "source wrong: duplicate source name, defined in ../../testdata/parser/invalid_config67.yaml " +
+						"and ../../testdata/parser/include/duplicate_source.yaml",
+				},
+			},
+		},
+		{
+			name:     "Missing file",
+			filename: "invalid_config1111.yaml",
+			want: []Problem{
+				{Severity: SeverityError, Message: "open ../../testdata/parser/invalid_config1111.yaml: no such file or directory"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join("../../testdata/parser", tt.filename)
+			for i := range tt.want {
+				if tt.want[i].Filename == "" && tt.want[i].Line != 0 {
+					tt.want[i].Filename = filename
+				}
+			}
+			got := ValidateConfig(filename)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProblem_String(t *testing.T) {
+	problem := Problem{Filename: "config.yaml", Line: 3, Severity: SeverityWarning, Message: "unknown attribute test"}
+	if got, want := problem.String(), "config.yaml:3: warning: unknown attribute test"; got != want {
+		t.Errorf("Problem.String() = %s, want %s", got, want)
+	}
+}
+
+func TestHasErrors(t *testing.T) {
+	if HasErrors([]Problem{{Severity: SeverityWarning}}) {
+		t.Errorf("HasErrors() = true for warnings only")
+	}
+	if !HasErrors([]Problem{{Severity: SeverityWarning}, {Severity: SeverityError}}) {
+		t.Errorf("HasErrors() = false, want true")
+	}
+}
@@ -16,6 +16,10 @@ type Source interface {
 	Init() error
 	// Sync syncs the source to Netbox inventory
 	Sync(*inventory.NetboxInventory) error
+	// GetSourceTags returns tags, that are added to all objects of the source
+	GetSourceTags() []*objects.Tag
+	// GetFilteredCounts returns number of objects of each type, that were filtered out
+	GetFilteredCounts() map[string]int
 }
 
 // Config is a common configuration that all sources share.
@@ -27,6 +31,8 @@ type Config struct {
 	SourceTypeTag  *objects.Tag
 	Ctx            context.Context //nolint:containedctx
 	CAFile         string          // path to the ca file
+	// FilteredObjects are objects, that are filtered out by filters of the source.
+	FilteredObjects *FilteredObjects
 }
 
 func (c Config) GetSourceTags() []*objects.Tag {
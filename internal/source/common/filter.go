@@ -0,0 +1,66 @@
+package common
+
+import (
+	"sync"
+
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// FilteredObjects holds names of objects of each type, that are filtered out by filters
+// of the source. Sources can be synced concurrently, so access is synchronized.
+type FilteredObjects struct {
+	mu    sync.Mutex
+	names map[string]map[string]bool
+}
+
+// add adds the object of the given type to filtered objects.
+func (fo *FilteredObjects) add(objectType string, name string) {
+	fo.mu.Lock()
+	defer fo.mu.Unlock()
+	if fo.names == nil {
+		fo.names = make(map[string]map[string]bool)
+	}
+	if fo.names[objectType] == nil {
+		fo.names[objectType] = make(map[string]bool)
+	}
+	fo.names[objectType][name] = true
+}
+
+// counts returns number of filtered objects of each type.
+func (fo *FilteredObjects) counts() map[string]int {
+	fo.mu.Lock()
+	defer fo.mu.Unlock()
+	counts := make(map[string]int, len(fo.names))
+	for objectType, names := range fo.names {
+		counts[objectType] = len(names)
+	}
+	return counts
+}
+
+// IsFiltered returns true, if the object of the given type (e.g. vm) doesn't pass the filter
+// of the source, so it must not be added to the inventory. Filtered objects are counted.
+func (c Config) IsFiltered(objectType string, object utils.RelationAttributes) (bool, error) {
+	filter, ok := c.SourceConfig.Filters[objectType]
+	if !ok {
+		return false, nil
+	}
+	filtered, err := filter.Excludes(object)
+	if err != nil {
+		return false, err
+	}
+	if filtered {
+		c.Logger.Debugf(c.Ctx, "%s %s is filtered out", objectType, object.Name)
+		if c.FilteredObjects != nil {
+			c.FilteredObjects.add(objectType, object.Name)
+		}
+	}
+	return filtered, nil
+}
+
+// GetFilteredCounts returns number of filtered objects of each type.
+func (c Config) GetFilteredCounts() map[string]int {
+	if c.FilteredObjects == nil {
+		return map[string]int{}
+	}
+	return c.FilteredObjects.counts()
+}
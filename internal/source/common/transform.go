@@ -0,0 +1,238 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// ErrDeviceDropped is returned by sync functions of sources with a single device,
+// if the device is filtered out or dropped by a transform. Other objects of the source
+// belong to the device, so they are not synced.
+var ErrDeviceDropped = errors.New("device is dropped")
+
+// TransformDevice applies device transforms of the source to the device, before it is added
+// to the inventory. Raw objects emitted by the transforms are added to the inventory with tags.
+// It returns false, if the device is dropped by a transform.
+func TransformDevice(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	sourceConfig *parser.SourceConfig,
+	device *objects.Device,
+	tags []*objects.Tag,
+) (bool, error) {
+	transforms := sourceConfig.Transforms["device"]
+	if len(transforms) == 0 {
+		return true, nil
+	}
+	object := newTransformObject(sourceConfig, device.NetboxObject, map[string]any{
+		"name":     device.Name,
+		"comments": device.Comments,
+		"serial":   device.SerialNumber,
+	})
+	if device.DeviceRole != nil {
+		object["role"] = device.DeviceRole.Name
+	}
+	if device.Platform != nil {
+		object["platform"] = device.Platform.Name
+	}
+	if device.Status != nil {
+		object["status"] = device.Status.Value
+	}
+	if device.Site != nil {
+		object["site"] = device.Site.Name
+	}
+	if device.Cluster != nil {
+		object["cluster"] = device.Cluster.Name
+	}
+	if device.Tenant != nil {
+		object["tenant"] = device.Tenant.Name
+	}
+	dropped, emitted, err := utils.ApplyTransforms(transforms, object)
+	if err != nil {
+		return false, fmt.Errorf("transform %s: %s", device, err)
+	}
+	if dropped {
+		nbi.Logger.Debugf(ctx, "%s is dropped by a transform", device)
+		return false, nil
+	}
+	if err := addEmittedObjects(ctx, nbi, device, emitted, tags); err != nil {
+		return false, err
+	}
+	device.Name = transformedString(object, "name")
+	device.Description = transformedString(object, "description")
+	device.Comments = transformedString(object, "comments")
+	device.CustomFields, _ = object["customFields"].(map[string]any)
+	device.DeviceRole, err = transformedRole(ctx, nbi, device.DeviceRole, transformedString(object, "role"), false)
+	if err != nil {
+		return false, err
+	}
+	device.Platform, err = transformedPlatform(ctx, nbi, device.Platform, transformedString(object, "platform"))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TransformVM applies vm transforms of the source to the vm, before it is added
+// to the inventory. Raw objects emitted by the transforms are added to the inventory with tags.
+// It returns false, if the vm is dropped by a transform.
+func TransformVM(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	sourceConfig *parser.SourceConfig,
+	vm *objects.VM,
+	tags []*objects.Tag,
+) (bool, error) {
+	transforms := sourceConfig.Transforms["vm"]
+	if len(transforms) == 0 {
+		return true, nil
+	}
+	object := newTransformObject(sourceConfig, vm.NetboxObject, map[string]any{
+		"name":     vm.Name,
+		"comments": vm.Comments,
+	})
+	if vm.Role != nil {
+		object["role"] = vm.Role.Name
+	}
+	if vm.Platform != nil {
+		object["platform"] = vm.Platform.Name
+	}
+	if vm.Status != nil {
+		object["status"] = vm.Status.Value
+	}
+	if vm.Site != nil {
+		object["site"] = vm.Site.Name
+	}
+	if vm.Cluster != nil {
+		object["cluster"] = vm.Cluster.Name
+	}
+	if vm.Tenant != nil {
+		object["tenant"] = vm.Tenant.Name
+	}
+	dropped, emitted, err := utils.ApplyTransforms(transforms, object)
+	if err != nil {
+		return false, fmt.Errorf("transform %s: %s", vm, err)
+	}
+	if dropped {
+		nbi.Logger.Debugf(ctx, "%s is dropped by a transform", vm)
+		return false, nil
+	}
+	if err := addEmittedObjects(ctx, nbi, vm, emitted, tags); err != nil {
+		return false, err
+	}
+	vm.Name = transformedString(object, "name")
+	vm.Description = transformedString(object, "description")
+	vm.Comments = transformedString(object, "comments")
+	vm.CustomFields, _ = object["customFields"].(map[string]any)
+	vm.Role, err = transformedRole(ctx, nbi, vm.Role, transformedString(object, "role"), true)
+	if err != nil {
+		return false, err
+	}
+	vm.Platform, err = transformedPlatform(ctx, nbi, vm.Platform, transformedString(object, "platform"))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// addEmittedObjects adds raw objects emitted by transforms of the object to the inventory.
+func addEmittedObjects(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	object fmt.Stringer,
+	emitted []utils.EmittedObject,
+	tags []*objects.Tag,
+) error {
+	for _, emittedObject := range emitted {
+		if _, err := AddRawObject(ctx, nbi, emittedObject.Path, emittedObject.Object, tags); err != nil {
+			return fmt.Errorf("raw object emitted by transform of %s: %s", object, err)
+		}
+	}
+	return nil
+}
+
+// newTransformObject returns transform object with common attributes of netbox object
+// and the given values.
+func newTransformObject(
+	sourceConfig *parser.SourceConfig,
+	netboxObject objects.NetboxObject,
+	values map[string]any,
+) map[string]any {
+	tagNames := make([]string, 0, len(netboxObject.Tags))
+	for _, tag := range netboxObject.Tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	customFields := maps.Clone(netboxObject.CustomFields)
+	if customFields == nil {
+		customFields = make(map[string]any)
+	}
+	object := utils.NewTransformObject(values)
+	object["description"] = netboxObject.Description
+	object["tags"] = tagNames
+	object["customFields"] = customFields
+	object["source"] = sourceConfig.Name
+	return object
+}
+
+// transformedString returns string field of the transformed object.
+func transformedString(object map[string]any, field string) string {
+	value, _ := object[field].(string)
+	return value
+}
+
+// transformedRole returns role with the transformed name. If the name is not changed,
+// the original role is returned.
+func transformedRole(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	role *objects.DeviceRole,
+	roleName string,
+	vmRole bool,
+) (*objects.DeviceRole, error) {
+	if role != nil && role.Name == roleName {
+		return role, nil
+	}
+	if roleName == "" {
+		return nil, nil
+	}
+	newRole, err := nbi.AddDeviceRole(ctx, &objects.DeviceRole{
+		Name:   roleName,
+		Slug:   utils.Slugify(roleName),
+		VMRole: vmRole,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add transformed role %s: %s", roleName, err)
+	}
+	return newRole, nil
+}
+
+// transformedPlatform returns platform with the transformed name. If the name is not changed,
+// the original platform is returned.
+func transformedPlatform(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	platform *objects.Platform,
+	platformName string,
+) (*objects.Platform, error) {
+	if platform != nil && platform.Name == platformName {
+		return platform, nil
+	}
+	if platformName == "" {
+		return nil, nil
+	}
+	newPlatform, err := nbi.AddPlatform(ctx, &objects.Platform{
+		Name: platformName,
+		Slug: utils.Slugify(platformName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add transformed platform %s: %s", platformName, err)
+	}
+	return newPlatform, nil
+}
@@ -3,26 +3,36 @@ package common
 import (
 	"context"
 	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
 	"github.com/bl4ko/netbox-ssot/internal/utils"
 )
 
-// Function that matches cluster to tenant using regexRelationsMap.
+// Function that matches cluster to tenant using clusterTenantRelations and relation rules.
 //
-// In case there is no match or regexRelations is nil, it will return nil.
+// In case there is no match or there are no relations, it will return nil.
 func MatchClusterToTenant(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	clusterName string,
-	clusterTenantRelations map[string]string,
+	cluster utils.RelationAttributes,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.Tenant, error) {
-	if clusterTenantRelations == nil {
+	if sourceConfig == nil ||
+		sourceConfig.ClusterTenantRelations == nil && sourceConfig.RelationRules.ClusterTenantRelations == nil {
 		return nil, nil
 	}
-	tenantName, err := utils.MatchStringToValue(clusterName, clusterTenantRelations)
+	tenantName, err := utils.MatchRelations(
+		cluster,
+		sourceConfig.ClusterTenantRelations,
+		sourceConfig.RelationRules.ClusterTenantRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching cluster to tenant: %s", err)
 	}
@@ -43,19 +53,24 @@ func MatchClusterToTenant(
 	return nil, nil
 }
 
-// Function that matches cluster to tenant using regexRelationsMap.
+// Function that matches cluster to site using clusterSiteRelations and relation rules.
 //
-// In case there is no match or regexRelations is nil, it will return nil.
+// In case there is no match or there are no relations, it will return nil.
 func MatchClusterToSite(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	clusterName string,
-	clusterSiteRelations map[string]string,
+	cluster utils.RelationAttributes,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.Site, error) {
-	if clusterSiteRelations == nil {
+	if sourceConfig == nil ||
+		sourceConfig.ClusterSiteRelations == nil && sourceConfig.RelationRules.ClusterSiteRelations == nil {
 		return nil, nil
 	}
-	siteName, err := utils.MatchStringToValue(clusterName, clusterSiteRelations)
+	siteName, err := utils.MatchRelations(
+		cluster,
+		sourceConfig.ClusterSiteRelations,
+		sourceConfig.RelationRules.ClusterSiteRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching cluster to tenant: %s", err)
 	}
@@ -76,28 +91,39 @@ func MatchClusterToSite(
 	return nil, nil
 }
 
-// Function that matches vlanName to vlanGroupName using regexRelationsMap.
+// Function that matches vlan to vlan group using vlanGroupRelations and relation rules.
 //
-// In case there is no match or regexRelations is nil, it will return default VlanGroup.
+// In case there are no relations, it will return default VlanGroup.
 func MatchVlanToGroup(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	vlanName string,
+	vlan utils.RelationAttributes,
 	vlanSite *objects.Site,
-	vlanGroupRelations map[string]string,
-	vlanGroupSiteRelations map[string]string,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.VlanGroup, error) {
-	if vlanGroupRelations == nil {
-		vlanGroup, _ := nbi.CreateDefaultVlanGroupForVlan(ctx, vlanSite)
+	if sourceConfig == nil {
+		vlanGroup, _ := nbi.CreateDefaultVlanGroupForVlan(ctx, vlanSite, nil)
 		return vlanGroup, nil
 	}
-	vlanGroupName, err := utils.MatchStringToValue(vlanName, vlanGroupRelations)
+	if sourceConfig.VlanGroupRelations == nil && sourceConfig.RelationRules.VlanGroupRelations == nil {
+		vlanGroup, _ := nbi.CreateDefaultVlanGroupForVlan(ctx, vlanSite, &sourceConfig.Defaults)
+		return vlanGroup, nil
+	}
+	vlanGroupName, err := utils.MatchRelations(
+		vlan,
+		sourceConfig.VlanGroupRelations,
+		sourceConfig.RelationRules.VlanGroupRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching vlan to group: %s", err)
 	}
 	var vlanGroupSite *objects.Site
-	if vlanGroupSiteRelations != nil {
-		siteName, err := utils.MatchStringToValue(vlanName, vlanGroupSiteRelations)
+	if sourceConfig.VlanGroupSiteRelations != nil || sourceConfig.RelationRules.VlanGroupSiteRelations != nil {
+		siteName, err := utils.MatchRelations(
+			vlan,
+			sourceConfig.VlanGroupSiteRelations,
+			sourceConfig.RelationRules.VlanGroupSiteRelations,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("matching vlan to site: %s", err)
 		}
@@ -131,19 +157,24 @@ func MatchVlanToGroup(
 	return vlanGroup, nil
 }
 
-// Function that matches vlanName to tenant using vlanTenantRelations regex relations map.
+// Function that matches vlan to tenant using vlanTenantRelations and relation rules.
 //
-// In case there is no match or vlanTenantRelations is nil, it will return nil.
+// In case there is no match or there are no relations, it will return nil.
 func MatchVlanToTenant(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	vlanName string,
-	vlanTenantRelations map[string]string,
+	vlan utils.RelationAttributes,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.Tenant, error) {
-	if vlanTenantRelations == nil {
+	if sourceConfig == nil ||
+		sourceConfig.VlanTenantRelations == nil && sourceConfig.RelationRules.VlanTenantRelations == nil {
 		return nil, nil
 	}
-	tenantName, err := utils.MatchStringToValue(vlanName, vlanTenantRelations)
+	tenantName, err := utils.MatchRelations(
+		vlan,
+		sourceConfig.VlanTenantRelations,
+		sourceConfig.RelationRules.VlanTenantRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching vlan to tenant: %s", err)
 	}
@@ -165,19 +196,24 @@ func MatchVlanToTenant(
 	return nil, nil
 }
 
-// MathcVlanToSite matches vlanName to Site using vlanSiteRelations.
+// MathcVlanToSite matches vlan to Site using vlanSiteRelations and relation rules.
 //
-// In case there is no match or vlanSiteRelations is nil, it returns nil.
+// In case there is no match or there are no relations, it returns nil.
 func MatchVlanToSite(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	vlanName string,
-	vlanSiteRelations map[string]string,
+	vlan utils.RelationAttributes,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.Site, error) {
-	if vlanSiteRelations == nil {
+	if sourceConfig == nil ||
+		sourceConfig.VlanSiteRelations == nil && sourceConfig.RelationRules.VlanSiteRelations == nil {
 		return nil, nil
 	}
-	siteName, err := utils.MatchStringToValue(vlanName, vlanSiteRelations)
+	siteName, err := utils.MatchRelations(
+		vlan,
+		sourceConfig.VlanSiteRelations,
+		sourceConfig.RelationRules.VlanSiteRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching vlan to site: %s", err)
 	}
@@ -198,19 +234,24 @@ func MatchVlanToSite(
 	return nil, nil
 }
 
-// Function that matches Host from hostName to Site using hostSiteRelations.
+// Function that matches Host to Site using hostSiteRelations and relation rules.
 //
-// In case that there is not match or hostSiteRelations is nil, it will return default site.
+// In case that there are no relations it will return nil, and in case there is no match default site.
 func MatchHostToSite(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	hostName string,
-	hostSiteRelations map[string]string,
+	host utils.RelationAttributes,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.Site, error) {
-	if hostSiteRelations == nil {
+	if sourceConfig == nil ||
+		sourceConfig.HostSiteRelations == nil && sourceConfig.RelationRules.HostSiteRelations == nil {
 		return nil, nil
 	}
-	siteName, err := utils.MatchStringToValue(hostName, hostSiteRelations)
+	siteName, err := utils.MatchRelations(
+		host,
+		sourceConfig.HostSiteRelations,
+		sourceConfig.RelationRules.HostSiteRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching host to site: %s", err)
 	}
@@ -228,23 +269,43 @@ func MatchHostToSite(
 		}
 		return site, nil
 	}
-	site, _ := nbi.GetSite(constants.DefaultSite)
+	defaultSite := sourceConfig.Defaults.Site
+	site, ok := nbi.GetSite(defaultSite.Name)
+	if !ok {
+		// Default site of the source differs from the global default site
+		newSite, err := nbi.AddSite(ctx, &objects.Site{
+			NetboxObject: objects.NetboxObject{
+				Description: defaultSite.Description,
+			},
+			Name: defaultSite.Name,
+			Slug: defaultSite.Slug,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add default site: %s", err)
+		}
+		return newSite, nil
+	}
 	return site, nil
 }
 
-// Function that matches Host from hostName to Tenant using hostTenantRelations.
+// Function that matches Host to Tenant using hostTenantRelations and relation rules.
 //
-// In case that there is not match or hostTenantRelations is nil, it will return nil.
+// In case that there is not match or there are no relations, it will return nil.
 func MatchHostToTenant(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	hostName string,
-	hostTenantRelations map[string]string,
+	host utils.RelationAttributes,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.Tenant, error) {
-	if hostTenantRelations == nil {
+	if sourceConfig == nil ||
+		sourceConfig.HostTenantRelations == nil && sourceConfig.RelationRules.HostTenantRelations == nil {
 		return nil, nil
 	}
-	tenantName, err := utils.MatchStringToValue(hostName, hostTenantRelations)
+	tenantName, err := utils.MatchRelations(
+		host,
+		sourceConfig.HostTenantRelations,
+		sourceConfig.RelationRules.HostTenantRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching host to tenant: %s", err)
 	}
@@ -265,19 +326,24 @@ func MatchHostToTenant(
 	return nil, nil
 }
 
-// MatchHostToRole matches Host from hostName to DeviceRole using hostRoleRelations.
+// MatchHostToRole matches Host to DeviceRole using hostRoleRelations and relation rules.
 //
-// In case that there is not match or hostRoleRelations is nil, it will return nil.
+// In case that there is not match or there are no relations, it will return nil.
 func MatchHostToRole(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	hostName string,
-	hostRoleRelations map[string]string,
+	host utils.RelationAttributes,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.DeviceRole, error) {
-	if hostRoleRelations == nil {
+	if sourceConfig == nil ||
+		sourceConfig.HostRoleRelations == nil && sourceConfig.RelationRules.HostRoleRelations == nil {
 		return nil, nil
 	}
-	roleName, err := utils.MatchStringToValue(hostName, hostRoleRelations)
+	roleName, err := utils.MatchRelations(
+		host,
+		sourceConfig.HostRoleRelations,
+		sourceConfig.RelationRules.HostRoleRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching host to role: %s", err)
 	}
@@ -294,19 +360,24 @@ func MatchHostToRole(
 	return nil, nil
 }
 
-// Function that matches Vm from vmName to Tenant using vmTenantRelations.
+// Function that matches Vm to Tenant using vmTenantRelations and relation rules.
 //
-// In case that there is not match or hostTenantRelations is nil, it will return nil.
+// In case that there is not match or there are no relations, it will return nil.
 func MatchVMToTenant(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	vmName string,
-	vmTenantRelations map[string]string,
+	vm utils.RelationAttributes,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.Tenant, error) {
-	if vmTenantRelations == nil {
+	if sourceConfig == nil ||
+		sourceConfig.VMTenantRelations == nil && sourceConfig.RelationRules.VMTenantRelations == nil {
 		return nil, nil
 	}
-	tenantName, err := utils.MatchStringToValue(vmName, vmTenantRelations)
+	tenantName, err := utils.MatchRelations(
+		vm,
+		sourceConfig.VMTenantRelations,
+		sourceConfig.RelationRules.VMTenantRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching vm to tenant: %s", err)
 	}
@@ -327,19 +398,24 @@ func MatchVMToTenant(
 	return nil, nil
 }
 
-// MatchVMToRole matches VM from vmName to DeviceRole using vmRoleRelations.
+// MatchVMToRole matches VM to DeviceRole using vmRoleRelations and relation rules.
 //
-// In case that there is not match or hostRoleRelations is nil, it will return nil.
+// In case that there is not match or there are no relations, it will return nil.
 func MatchVMToRole(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
-	vmName string,
-	vmRoleRelations map[string]string,
+	vm utils.RelationAttributes,
+	sourceConfig *parser.SourceConfig,
 ) (*objects.DeviceRole, error) {
-	if vmRoleRelations == nil {
+	if sourceConfig == nil ||
+		sourceConfig.VMRoleRelations == nil && sourceConfig.RelationRules.VMRoleRelations == nil {
 		return nil, nil
 	}
-	roleName, err := utils.MatchStringToValue(vmName, vmRoleRelations)
+	roleName, err := utils.MatchRelations(
+		vm,
+		sourceConfig.VMRoleRelations,
+		sourceConfig.RelationRules.VMRoleRelations,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("matching vm to role: %s", err)
 	}
@@ -380,34 +456,169 @@ func CreateMACAddressForObjectType(
 	return nbMACAddress, nil
 }
 
-func SetPrimaryIPAddressForObject(
+// ipAddressCandidate is an ip address of the object, ranked by the primary ip policy.
+type ipAddressCandidate struct {
+	ipAddress *objects.IPAddress
+	version   int
+	// subnetRank is the index of the first preferred subnet containing the address.
+	subnetRank     int
+	interfaceMatch bool
+	dnsResolvable  bool
+	mgmtOnly       bool
+	oob            bool
+	preferred      bool
+}
+
+// betterPrimaryThan returns true, if candidate c should be chosen as primary ip over candidate o.
+func (c ipAddressCandidate) betterPrimaryThan(o ipAddressCandidate) bool {
+	switch {
+	case c.subnetRank != o.subnetRank:
+		return c.subnetRank < o.subnetRank
+	case c.interfaceMatch != o.interfaceMatch:
+		return c.interfaceMatch
+	case c.dnsResolvable != o.dnsResolvable:
+		return c.dnsResolvable
+	case c.preferred != o.preferred:
+		return c.preferred
+	default:
+		// Addresses of mgmt_only interfaces are demoted only between otherwise equal candidates
+		return !c.mgmtOnly && o.mgmtOnly
+	}
+}
+
+// SetPrimaryIPAddresses chooses primary ipv4, primary ipv6 and oob ip address of the object
+// from its ip addresses using the primary ip policy of the source, and sets them.
+//
+// Preferred addresses are source specific hints (e.g. addresses in the subnet of vm's
+// default gateway, or the address the host name resolves to), which break ties after all
+// policy rules. Addresses of mgmt_only interfaces lose only to otherwise equal candidates.
+// Oob ip is set only for devices, from addresses of mgmt_only interfaces
+// and interfaces matching oobInterfaceRegex.
+func SetPrimaryIPAddresses(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
 	targetObject objects.IPAddressOwner,
-	ipv4 *objects.IPAddress,
-	ipv6 *objects.IPAddress,
+	ipAddresses []*objects.IPAddress,
+	preferredIPAddresses []*objects.IPAddress,
+	sourceConfig *parser.SourceConfig,
 ) error {
+	if len(ipAddresses) == 0 {
+		return nil
+	}
+	var policy parser.PrimaryIPPolicy
+	if sourceConfig != nil {
+		policy = sourceConfig.PrimaryIPPolicy
+	}
+	ipVersions := policy.IPVersions
+	if len(ipVersions) == 0 {
+		ipVersions = []int{constants.IPv4, constants.IPv6}
+	}
+	interfaceRegex, err := regexp.Compile(policy.InterfaceRegex)
+	if err != nil {
+		return fmt.Errorf("primary ip policy interface regex: %s", err)
+	}
+	oobInterfaceRegex, err := regexp.Compile(policy.OOBInterfaceRegex)
+	if err != nil {
+		return fmt.Errorf("primary ip policy oob interface regex: %s", err)
+	}
+	var objectName string
+	switch targetObject := targetObject.(type) {
+	case *objects.Device:
+		objectName = targetObject.Name
+	case *objects.VM:
+		objectName = targetObject.Name
+	}
+	var resolvedIP string
+	if policy.PreferDNSResolvable {
+		resolvedIP = utils.Lookup(objectName)
+	}
+
+	candidates := make([]ipAddressCandidate, 0, len(ipAddresses))
+	for _, ipAddress := range ipAddresses {
+		address, _, _ := strings.Cut(ipAddress.Address, "/")
+		candidate := ipAddressCandidate{
+			ipAddress:     ipAddress,
+			version:       utils.GetIPVersion(address),
+			subnetRank:    len(policy.PreferredSubnets),
+			dnsResolvable: policy.PreferDNSResolvable && (address == resolvedIP || ipAddress.DNSName != ""),
+			preferred:     slices.Contains(preferredIPAddresses, ipAddress),
+		}
+		for i, subnet := range policy.PreferredSubnets {
+			if utils.SubnetContainsIPAddress(address, subnet) {
+				candidate.subnetRank = i
+				break
+			}
+		}
+		var interfaceName string
+		interfaceName, candidate.mgmtOnly = getAssignedInterface(nbi, ipAddress)
+		candidate.interfaceMatch = policy.InterfaceRegex != "" && interfaceRegex.MatchString(interfaceName)
+		candidate.oob = candidate.mgmtOnly ||
+			(policy.OOBInterfaceRegex != "" && oobInterfaceRegex.MatchString(interfaceName))
+		candidates = append(candidates, candidate)
+	}
+
+	primaryIPs := make(map[int]*objects.IPAddress, len(ipVersions))
+	var oobIP *objects.IPAddress
+	for _, ipVersion := range ipVersions {
+		var bestPrimary, bestOOB *ipAddressCandidate
+		for i := range candidates {
+			candidate := &candidates[i]
+			if candidate.version != ipVersion {
+				continue
+			}
+			if bestPrimary == nil || candidate.betterPrimaryThan(*bestPrimary) {
+				bestPrimary = candidate
+			}
+			if candidate.oob && (bestOOB == nil || candidate.betterPrimaryThan(*bestOOB)) {
+				bestOOB = candidate
+			}
+		}
+		if bestPrimary != nil {
+			primaryIPs[ipVersion] = bestPrimary.ipAddress
+		}
+		if bestOOB != nil && oobIP == nil {
+			oobIP = bestOOB.ipAddress
+		}
+	}
+
 	switch targetObject := targetObject.(type) {
 	case *objects.Device:
 		deviceCopy := *targetObject
-		deviceCopy.PrimaryIPv4 = ipv4
-		deviceCopy.PrimaryIPv6 = ipv6
+		deviceCopy.PrimaryIPv4 = primaryIPs[constants.IPv4]
+		deviceCopy.PrimaryIPv6 = primaryIPs[constants.IPv6]
+		deviceCopy.OOBIP = oobIP
 		_, err := nbi.AddDevice(ctx, &deviceCopy)
 		if err != nil {
-			return fmt.Errorf("set primary ip for device %+v: %s", deviceCopy, err)
+			return fmt.Errorf("set primary ip for device %s: %s", deviceCopy.Name, err)
 		}
 	case *objects.VM:
 		vmCopy := *targetObject
-		vmCopy.PrimaryIPv4 = ipv4
-		vmCopy.PrimaryIPv6 = ipv6
+		vmCopy.PrimaryIPv4 = primaryIPs[constants.IPv4]
+		vmCopy.PrimaryIPv6 = primaryIPs[constants.IPv6]
 		_, err := nbi.AddVM(ctx, &vmCopy)
 		if err != nil {
-			return fmt.Errorf("set primary ip for vm %+v: %s", vmCopy, err)
+			return fmt.Errorf("set primary ip for vm %s: %s", vmCopy.Name, err)
 		}
 	}
 	return nil
 }
 
+// getAssignedInterface returns name of the device or vm interface, the ip address is assigned to,
+// and whether the interface is used only for out-of-band management.
+func getAssignedInterface(nbi *inventory.NetboxInventory, ipAddress *objects.IPAddress) (string, bool) {
+	switch ipAddress.AssignedObjectType {
+	case constants.ContentTypeDcimInterface:
+		if iface := nbi.GetInterfaceByID(ipAddress.AssignedObjectID); iface != nil {
+			return iface.Name, iface.MgmtOnly
+		}
+	case constants.ContentTypeVirtualizationVMInterface:
+		if vmIface := nbi.GetVMInterfaceByID(ipAddress.AssignedObjectID); vmIface != nil {
+			return vmIface.Name, false
+		}
+	}
+	return "", false
+}
+
 func SetPrimaryMACForInterface(
 	ctx context.Context,
 	nbi *inventory.NetboxInventory,
@@ -432,3 +643,841 @@ func SetPrimaryMACForInterface(
 	}
 	return nil
 }
+
+// HardwareComponent is a source independent representation of a hardware
+// component (e.g. line card, transceiver, fan, power supply) found on a device.
+type HardwareComponent struct {
+	// Name of the component, unique within the device.
+	Name string
+	// Manufacturer of the component.
+	Manufacturer *objects.Manufacturer
+	// PartNumber is the manufacturer's part number (product id) of the component.
+	PartNumber string
+	// SerialNumber of the component.
+	SerialNumber string
+	// Description of the component.
+	Description string
+	// IsModule marks components that are installed in a module bay (e.g. line cards).
+	IsModule bool
+}
+
+// AddHardwareComponent adds hardware component of the device to the netbox inventory.
+//
+// Modules are installed into the module bay with the same name as the component,
+// while all other components are added as inventory items. Modules without part number
+// are also added as inventory items, because module type can't be determined.
+func AddHardwareComponent(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	device *objects.Device,
+	component HardwareComponent,
+	tags []*objects.Tag,
+) error {
+	if component.Name == "" {
+		return fmt.Errorf("hardware component %+v has no name", component)
+	}
+	description := component.Description
+	if len(description) > objects.MaxDescriptionLength {
+		description = description[:objects.MaxDescriptionLength]
+	}
+	if component.IsModule && component.PartNumber != "" {
+		moduleType, err := nbi.AddModuleType(ctx, &objects.ModuleType{
+			NetboxObject: objects.NetboxObject{Tags: tags},
+			Manufacturer: component.Manufacturer,
+			Model:        component.PartNumber,
+			PartNumber:   component.PartNumber,
+		})
+		if err != nil {
+			return fmt.Errorf("add module type: %s", err)
+		}
+		moduleBay, err := nbi.AddModuleBay(ctx, &objects.ModuleBay{
+			NetboxObject: objects.NetboxObject{Tags: tags},
+			Device:       device,
+			Name:         component.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("add module bay: %s", err)
+		}
+		_, err = nbi.AddModule(ctx, &objects.Module{
+			NetboxObject: objects.NetboxObject{
+				Tags:        tags,
+				Description: description,
+			},
+			Device:       device,
+			ModuleBay:    moduleBay,
+			ModuleType:   moduleType,
+			Status:       &objects.ModuleStatusActive,
+			SerialNumber: component.SerialNumber,
+		})
+		if err != nil {
+			return fmt.Errorf("add module: %s", err)
+		}
+		return nil
+	}
+	_, err := nbi.AddInventoryItem(ctx, &objects.InventoryItem{
+		NetboxObject: objects.NetboxObject{
+			Tags:        tags,
+			Description: description,
+		},
+		Device:       device,
+		Name:         component.Name,
+		Manufacturer: component.Manufacturer,
+		PartID:       component.PartNumber,
+		SerialNumber: component.SerialNumber,
+		Discovered:   true,
+	})
+	if err != nil {
+		return fmt.Errorf("add inventory item: %s", err)
+	}
+	return nil
+}
+
+// FHRPVirtualIP is a source independent representation of a virtual ip
+// of a first hop redundancy protocol group (e.g. VRRP, HSRP), configured on an interface.
+type FHRPVirtualIP struct {
+	// Protocol of the FHRP group.
+	Protocol *objects.FHRPGroupProtocol
+	// GroupID is the protocol specific group identifier (e.g. VRRP VRID).
+	GroupID int
+	// Address is the virtual ip address with mask (e.g. 192.168.1.1/24).
+	Address string
+	// Priority of the interface within the FHRP group.
+	Priority int
+}
+
+// DeviceVRFName returns name of the VRF of the device. VRF names of firewalls (e.g. vdoms
+// and virtual routers) are only unique on the device, so they are prefixed with the device
+// name, to prevent VRFs of different devices from being merged into one VRF.
+func DeviceVRFName(deviceName string, vrfName string) string {
+	return fmt.Sprintf("%s:%s", deviceName, vrfName)
+}
+
+// AddFHRPVirtualIP adds FHRP group of the virtual ip to the netbox inventory,
+// assigns it to the participating interface and creates the virtual ip address
+// assigned to the FHRP group.
+//
+// Group name is constructed from protocol, group id, virtual ip and vrf of the interface,
+// so interfaces of different devices participating in the same group share one FHRP group.
+func AddFHRPVirtualIP(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	iface *objects.Interface,
+	vip FHRPVirtualIP,
+	tags []*objects.Tag,
+) error {
+	if vip.Protocol == nil {
+		return fmt.Errorf("fhrp virtual ip %+v has no protocol", vip)
+	}
+	ipAddress, _, err := net.ParseCIDR(vip.Address)
+	if err != nil {
+		return fmt.Errorf("parse virtual ip %s: %s", vip.Address, err)
+	}
+	groupName := fmt.Sprintf("%s-%d-%s", vip.Protocol.Value, vip.GroupID, ipAddress)
+	if iface.VRF != nil {
+		groupName = fmt.Sprintf("%s-%s", groupName, iface.VRF.Name)
+	}
+	nbFHRPGroup, err := nbi.AddFHRPGroup(ctx, &objects.FHRPGroup{
+		NetboxObject: objects.NetboxObject{Tags: tags},
+		Protocol:     vip.Protocol,
+		GroupID:      vip.GroupID,
+		Name:         groupName,
+	})
+	if err != nil {
+		return fmt.Errorf("add fhrp group: %s", err)
+	}
+	_, err = nbi.AddFHRPGroupAssignment(ctx, &objects.FHRPGroupAssignment{
+		NetboxObject:  objects.NetboxObject{Tags: tags},
+		Group:         nbFHRPGroup,
+		InterfaceType: constants.ContentTypeDcimInterface,
+		InterfaceID:   iface.ID,
+		Priority:      vip.Priority,
+	})
+	if err != nil {
+		return fmt.Errorf("add fhrp group assignment: %s", err)
+	}
+	_, err = nbi.AddIPAddress(ctx, &objects.IPAddress{
+		NetboxObject: objects.NetboxObject{
+			Tags: tags,
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldArpEntryName: false,
+			},
+		},
+		Address:            vip.Address,
+		Role:               getFHRPIPAddressRole(vip.Protocol),
+		VRF:                iface.VRF,
+		AssignedObjectType: constants.ContentTypeIpamFHRPGroup,
+		AssignedObjectID:   nbFHRPGroup.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("add fhrp virtual ip address: %s", err)
+	}
+	return nil
+}
+
+// getFHRPIPAddressRole returns the ip address role matching the given FHRP protocol.
+func getFHRPIPAddressRole(protocol *objects.FHRPGroupProtocol) *objects.IPAddressRole {
+	switch *protocol {
+	case objects.FHRPGroupProtocolVRRP2, objects.FHRPGroupProtocolVRRP3:
+		return &objects.IPAddressRoleVRRP
+	case objects.FHRPGroupProtocolHSRP:
+		return &objects.IPAddressRoleHSRP
+	case objects.FHRPGroupProtocolGLBP:
+		return &objects.IPAddressRoleGLBP
+	case objects.FHRPGroupProtocolCARP:
+		return &objects.IPAddressRoleCARP
+	default:
+		return &objects.IPAddressRoleVIP
+	}
+}
+
+// AddVMRoleServices adds services, configured for the role of the vm
+// in vmRoleServices, to the netbox inventory.
+func AddVMRoleServices(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	vm *objects.VM,
+	vmRoleServices map[string][]parser.ServiceDefinition,
+	tags []*objects.Tag,
+) error {
+	if vm.Role == nil || len(vmRoleServices) == 0 {
+		return nil
+	}
+	for _, serviceDefinition := range vmRoleServices[vm.Role.Name] {
+		_, err := nbi.AddService(ctx, &objects.Service{
+			NetboxObject: objects.NetboxObject{Tags: tags},
+			VM:           vm,
+			Name:         serviceDefinition.Name,
+			Protocol:     objects.ServiceProtocols[serviceDefinition.Protocol],
+			Ports:        serviceDefinition.Ports,
+		})
+		if err != nil {
+			return fmt.Errorf("add service %s for vm %s: %s", serviceDefinition.Name, vm.Name, err)
+		}
+	}
+	return nil
+}
+
+// AddServiceForIPAddress adds service to the device or the virtual machine,
+// that has the given ip (without mask) assigned in the given vrf (nil for the global table).
+// Service is bound to that ip address.
+//
+// In case there is no such ip address in the inventory, it returns nil.
+func AddServiceForIPAddress(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	vrf *objects.VRF,
+	ip string,
+	newService *objects.Service,
+) (*objects.Service, error) {
+	nbIPAddress := nbi.GetIPAddressByIP(vrf, ip)
+	if nbIPAddress == nil {
+		return nil, nil
+	}
+	switch nbIPAddress.AssignedObjectType {
+	case constants.ContentTypeDcimInterface:
+		nbIface := nbi.GetInterfaceByID(nbIPAddress.AssignedObjectID)
+		if nbIface == nil || nbIface.Device == nil {
+			return nil, nil
+		}
+		newService.Device = nbIface.Device
+	case constants.ContentTypeVirtualizationVMInterface:
+		nbVMIface := nbi.GetVMInterfaceByID(nbIPAddress.AssignedObjectID)
+		if nbVMIface == nil || nbVMIface.VM == nil {
+			return nil, nil
+		}
+		newService.VM = nbVMIface.VM
+	default:
+		return nil, nil
+	}
+	newService.IPAddresses = []*objects.IPAddress{nbIPAddress}
+	return nbi.AddService(ctx, newService)
+}
+
+// BGPSession is a source independent representation of a BGP peering,
+// configured on a device.
+type BGPSession struct {
+	// LocalASN is the autonomous system number of the device.
+	LocalASN int64
+	// LocalAddress is the ip address (without mask) used for the peering. Optional.
+	LocalAddress string
+	// RemoteASN is the autonomous system number of the neighbor.
+	RemoteASN int64
+	// RemoteAddress is the ip address (without mask) of the neighbor.
+	RemoteAddress string
+	// VRF is the name of the vrf (or virtual router), the session belongs to. Optional.
+	VRF string
+	// Name is the name or the description of the neighbor. Optional.
+	Name string
+}
+
+// String returns text representation of the BGP session, that is used
+// as comments of the session's journal entry.
+func (s BGPSession) String() string {
+	local := fmt.Sprintf("AS%d", s.LocalASN)
+	if s.LocalAddress != "" {
+		local = fmt.Sprintf("%s (%s)", local, s.LocalAddress)
+	}
+	session := fmt.Sprintf("BGP session %s <-> AS%d (%s)", local, s.RemoteASN, s.RemoteAddress)
+	if s.VRF != "" {
+		session = fmt.Sprintf("%s in vrf %s", session, s.VRF)
+	}
+	if s.Name != "" {
+		session = fmt.Sprintf("%s: %s", session, s.Name)
+	}
+	return session
+}
+
+// AddBGPSessions adds local and remote ASNs of the BGP sessions to the netbox inventory
+// and records each session as a journal entry of the device.
+//
+// If all sessions of the device share the same local ASN, the ASN is also
+// assigned to the device's site.
+func AddBGPSessions(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	device *objects.Device,
+	sessions []BGPSession,
+	tags []*objects.Tag,
+) error {
+	localASNs := make(map[int64]*objects.ASN)
+	for _, session := range sessions {
+		localASN, err := AddASN(ctx, nbi, session.LocalASN, tags)
+		if err != nil {
+			return fmt.Errorf("add local asn: %s", err)
+		}
+		localASNs[localASN.ASN] = localASN
+		if _, err := AddASN(ctx, nbi, session.RemoteASN, tags); err != nil {
+			return fmt.Errorf("add remote asn: %s", err)
+		}
+		_, err = nbi.AddJournalEntry(ctx, &objects.JournalEntry{
+			NetboxObject:       objects.NetboxObject{Tags: tags},
+			AssignedObjectType: constants.ContentTypeDcimDevice,
+			AssignedObjectID:   device.ID,
+			Kind:               &objects.JournalEntryKindInfo,
+			Comments:           session.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("add journal entry for %s: %s", session, err)
+		}
+	}
+	if len(localASNs) != 1 || device.Site == nil {
+		return nil
+	}
+	for _, localASN := range localASNs {
+		if err := addASNToSite(ctx, nbi, device.Site.Name, localASN); err != nil {
+			return fmt.Errorf("add asn to site: %s", err)
+		}
+	}
+	return nil
+}
+
+// AddASN adds autonomous system number to the netbox inventory.
+// RIR of an existing ASN is preserved, while new ASNs are assigned to the
+// private RIR (RFC 6996) or to the default RIR.
+func AddASN(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	asn int64,
+	tags []*objects.Tag,
+) (*objects.ASN, error) {
+	var rir *objects.RIR
+	if existingASN, ok := nbi.GetASN(asn); ok && existingASN.RIR != nil {
+		rir = existingASN.RIR
+	} else {
+		rirName, rirDescription := constants.DefaultRIRName, constants.DefaultRIRDescription
+		if utils.IsPrivateASN(asn) {
+			rirName, rirDescription = constants.PrivateRIRName, constants.PrivateRIRDescription
+		}
+		var err error
+		rir, err = nbi.AddRIR(ctx, &objects.RIR{
+			NetboxObject: objects.NetboxObject{Description: rirDescription},
+			Name:         rirName,
+			Slug:         utils.Slugify(rirName),
+			IsPrivate:    utils.IsPrivateASN(asn),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add rir %s: %s", rirName, err)
+		}
+	}
+	return nbi.AddASN(ctx, &objects.ASN{
+		NetboxObject: objects.NetboxObject{Tags: tags},
+		ASN:          asn,
+		RIR:          rir,
+	})
+}
+
+// addASNToSite assigns asn to the site with the given name,
+// while keeping all ASNs already assigned to the site.
+func addASNToSite(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	siteName string,
+	asn *objects.ASN,
+) error {
+	site, ok := nbi.GetSite(siteName)
+	if !ok {
+		return fmt.Errorf("site %s not found", siteName)
+	}
+	for _, siteASN := range site.ASNs {
+		if siteASN.ID == asn.ID {
+			return nil
+		}
+	}
+	updatedSite := *site
+	updatedSite.ASNs = append(slices.Clone(site.ASNs), asn)
+	_, err := nbi.AddSite(ctx, &updatedSite)
+	return err
+}
+
+// RackPlacement represents the placement of a device within a rack.
+type RackPlacement struct {
+	// RackName is the name of the rack, that the device is mounted in.
+	RackName string
+	// Position is the lowest rack unit occupied by the device (e.g. "12" or "12.5").
+	Position string
+	// Face is the rack face (front or rear), that the device is mounted on.
+	Face string
+}
+
+// MatchHostToRackPlacement matches Host from hostName to rack placement using
+// hostRackRelations, hostRackPositionRelations and hostRackFaceRelations.
+//
+// In case that there is no match, returned placement has an empty RackName.
+func MatchHostToRackPlacement(
+	hostName string,
+	sourceConfig *parser.SourceConfig,
+) (RackPlacement, error) {
+	placement := RackPlacement{}
+	if sourceConfig == nil || sourceConfig.HostRackRelations == nil {
+		return placement, nil
+	}
+	var err error
+	placement.RackName, err = utils.MatchStringToValue(hostName, sourceConfig.HostRackRelations)
+	if err != nil {
+		return placement, fmt.Errorf("matching host to rack: %s", err)
+	}
+	if sourceConfig.HostRackPositionRelations != nil {
+		placement.Position, err = utils.MatchStringToValue(
+			hostName,
+			sourceConfig.HostRackPositionRelations,
+		)
+		if err != nil {
+			return placement, fmt.Errorf("matching host to rack position: %s", err)
+		}
+	}
+	if sourceConfig.HostRackFaceRelations != nil {
+		placement.Face, err = utils.MatchStringToValue(hostName, sourceConfig.HostRackFaceRelations)
+		if err != nil {
+			return placement, fmt.Errorf("matching host to rack face: %s", err)
+		}
+	}
+	return placement, nil
+}
+
+// MatchDeviceToRack matches device by its name to rack placement using host rack relations
+// and sets the device's rack, location, position and face.
+func MatchDeviceToRack(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	device *objects.Device,
+	sourceConfig *parser.SourceConfig,
+) error {
+	placement, err := MatchHostToRackPlacement(device.Name, sourceConfig)
+	if err != nil {
+		return err
+	}
+	return SetDeviceRackPlacement(ctx, nbi, device, placement, sourceConfig)
+}
+
+// SetDeviceRackPlacement adds the rack from placement to the netbox inventory
+// and sets the device's rack, location, position and face.
+//
+// The rack is created in the device's site. Its location and role are matched from
+// the rack name using rackLocationRelations and rackRoleRelations.
+// If the position is set without a face, the device is placed on the front face.
+func SetDeviceRackPlacement(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	device *objects.Device,
+	placement RackPlacement,
+	sourceConfig *parser.SourceConfig,
+) error {
+	if placement.RackName == "" {
+		return nil
+	}
+	if device.Site == nil {
+		return fmt.Errorf("device %s has no site, so it can't be placed in rack %s", device.Name, placement.RackName)
+	}
+	rack, err := addRack(ctx, nbi, placement.RackName, device.Site, device.Tenant, sourceConfig)
+	if err != nil {
+		return err
+	}
+	device.Rack = rack
+	device.Location = rack.Location
+	if placement.Position == "" {
+		return nil
+	}
+	position, err := parser.ParseRackPosition(placement.Position)
+	if err != nil {
+		return fmt.Errorf("device %s: %s", device.Name, err)
+	}
+	face := &objects.DeviceFaceFront
+	if placement.Face != "" {
+		var ok bool
+		face, ok = objects.DeviceFaces[strings.ToLower(placement.Face)]
+		if !ok {
+			return fmt.Errorf("device %s: invalid rack face %s", device.Name, placement.Face)
+		}
+	}
+	device.Position = position
+	device.Face = face
+	return nil
+}
+
+// addRack adds rack with rackName to the given site. Location and role of the rack
+// are matched using rackLocationRelations and rackRoleRelations.
+func addRack(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	rackName string,
+	site *objects.Site,
+	tenant *objects.Tenant,
+	sourceConfig *parser.SourceConfig,
+) (*objects.Rack, error) {
+	var location *objects.Location
+	var role *objects.RackRole
+	if sourceConfig != nil && sourceConfig.RackLocationRelations != nil {
+		locationName, err := utils.MatchStringToValue(rackName, sourceConfig.RackLocationRelations)
+		if err != nil {
+			return nil, fmt.Errorf("matching rack to location: %s", err)
+		}
+		if locationName != "" {
+			location, err = nbi.AddLocation(ctx, &objects.Location{
+				Site:   site,
+				Name:   locationName,
+				Slug:   utils.Slugify(locationName),
+				Status: &objects.SiteStatusActive,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("add location %s: %s", locationName, err)
+			}
+		}
+	}
+	if sourceConfig != nil && sourceConfig.RackRoleRelations != nil {
+		roleName, err := utils.MatchStringToValue(rackName, sourceConfig.RackRoleRelations)
+		if err != nil {
+			return nil, fmt.Errorf("matching rack to role: %s", err)
+		}
+		if roleName != "" {
+			role, err = nbi.AddRackRole(ctx, &objects.RackRole{
+				Name:  roleName,
+				Slug:  utils.Slugify(roleName),
+				Color: constants.ColorGrey,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("add rack role %s: %s", roleName, err)
+			}
+		}
+	}
+	rack, err := nbi.AddRack(ctx, &objects.Rack{
+		Name:     rackName,
+		Site:     site,
+		Location: location,
+		Status:   &objects.RackStatusActive,
+		Role:     role,
+		Tenant:   tenant,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add rack %s: %s", rackName, err)
+	}
+	return rack, nil
+}
+
+// FilterLocalContextData returns local context data of the device, which contains
+// only keys of localContextData, that are whitelisted in localContextKeys of the source.
+// Advanced settings are filtered separately, each setting has to be whitelisted
+// as advanced_settings.<Setting.Key>.
+//
+// In case that collectLocalContext is disabled or there is no data, it returns nil.
+func FilterLocalContextData(
+	localContextData map[string]interface{},
+	sourceConfig *parser.SourceConfig,
+) map[string]interface{} {
+	if sourceConfig == nil || !sourceConfig.CollectLocalContext || len(localContextData) == 0 {
+		return nil
+	}
+	filteredData := make(map[string]interface{})
+	advancedSettings, _ := localContextData[constants.LocalContextKeyAdvancedSettings].(map[string]string)
+	filteredAdvancedSettings := make(map[string]string)
+	for _, key := range sourceConfig.LocalContextKeys {
+		if setting, ok := strings.CutPrefix(key, constants.LocalContextKeyAdvancedSettings+"."); ok {
+			if value, ok := advancedSettings[setting]; ok {
+				filteredAdvancedSettings[setting] = value
+			}
+			continue
+		}
+		value, ok := localContextData[key]
+		if !ok {
+			continue
+		}
+		if values, isSlice := value.([]string); isSlice && len(values) == 0 {
+			continue
+		}
+		filteredData[key] = value
+	}
+	if len(filteredAdvancedSettings) > 0 {
+		filteredData[constants.LocalContextKeyAdvancedSettings] = filteredAdvancedSettings
+	}
+	if len(filteredData) == 0 {
+		return nil
+	}
+	return filteredData
+}
+
+// IPsecTunnel is a source independent representation of an IPsec tunnel
+// (e.g. fortigate phase1 interface or palo alto ipsec tunnel).
+type IPsecTunnel struct {
+	// Name of the tunnel. Tunnel names are unique in netbox.
+	Name string
+	// Group is the name of the tunnel group, the tunnel belongs to. Optional.
+	Group string
+	// Disabled is true, when the tunnel is administratively disabled.
+	Disabled bool
+	// Description of the tunnel. Optional.
+	Description string
+	// Comments of the tunnel (e.g. remote gateway and phase2 selectors). Optional.
+	Comments string
+	// Tenant of the tunnel. Optional.
+	Tenant *objects.Tenant
+	// Endpoints are local terminations of the tunnel on already synced interfaces.
+	Endpoints []IPsecTunnelEndpoint
+}
+
+// IPsecTunnelEndpoint represents termination of the IPsec tunnel on an interface.
+type IPsecTunnelEndpoint struct {
+	// Interface is the netbox interface terminating the tunnel (e.g. tunnel or vti interface).
+	Interface *objects.Interface
+	// Role of the endpoint. If nil, peer role is used.
+	Role *objects.TunnelTerminationRole
+	// OutsideAddress is the ip address (without mask) used for ike negotiation. Optional.
+	OutsideAddress string
+	// OutsideVRF is the vrf of the outside address. Nil represents the global table.
+	OutsideVRF *objects.VRF
+}
+
+// AddIPsecTunnel adds the IPsec tunnel with its group and terminations to the netbox inventory.
+// Outside ip of the termination is set only when the outside address is
+// already assigned to an interface in netbox.
+func AddIPsecTunnel(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	tunnel IPsecTunnel,
+	tags []*objects.Tag,
+) (*objects.Tunnel, error) {
+	var tunnelGroup *objects.TunnelGroup
+	if tunnel.Group != "" {
+		var err error
+		tunnelGroup, err = nbi.AddTunnelGroup(ctx, &objects.TunnelGroup{
+			NetboxObject: objects.NetboxObject{Tags: tags},
+			Name:         tunnel.Group,
+			Slug:         utils.Slugify(tunnel.Group),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add tunnel group %s: %s", tunnel.Group, err)
+		}
+	}
+	tunnelStatus := &objects.TunnelStatusActive
+	if tunnel.Disabled {
+		tunnelStatus = &objects.TunnelStatusDisabled
+	}
+	nbTunnel, err := nbi.AddTunnel(ctx, &objects.Tunnel{
+		NetboxObject: objects.NetboxObject{
+			Tags:        tags,
+			Description: tunnel.Description,
+		},
+		Name:          tunnel.Name,
+		Status:        tunnelStatus,
+		Group:         tunnelGroup,
+		Encapsulation: &objects.TunnelEncapsulationIPsecTunnel,
+		Tenant:        tunnel.Tenant,
+		Comments:      tunnel.Comments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add tunnel %s: %s", tunnel.Name, err)
+	}
+	for _, endpoint := range tunnel.Endpoints {
+		if endpoint.Interface == nil {
+			continue
+		}
+		role := endpoint.Role
+		if role == nil {
+			role = &objects.TunnelTerminationRolePeer
+		}
+		var outsideIP *objects.IPAddress
+		if endpoint.OutsideAddress != "" {
+			outsideIP = nbi.GetIPAddressByIP(endpoint.OutsideVRF, endpoint.OutsideAddress)
+		}
+		_, err := nbi.AddTunnelTermination(ctx, &objects.TunnelTermination{
+			NetboxObject:    objects.NetboxObject{Tags: tags},
+			Tunnel:          nbTunnel,
+			Role:            role,
+			TerminationType: constants.ContentTypeDcimInterface,
+			TerminationID:   endpoint.Interface.ID,
+			OutsideIP:       outsideIP,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add termination of tunnel %s on %s: %s", tunnel.Name, endpoint.Interface, err)
+		}
+	}
+	return nbTunnel, nil
+}
+
+// PowerSupply is a source independent representation of a power supply of a device
+// (e.g. psu found in the device's hardware inventory or hardware sensors).
+type PowerSupply struct {
+	// Name of the power supply, unique within the device.
+	Name string
+	// Description of the power supply. Optional.
+	Description string
+	// MaximumDraw is the rated power of the power supply in watts. Optional.
+	MaximumDraw int
+	// AllocatedDraw is the measured power draw of the power supply in watts. Optional.
+	AllocatedDraw int
+}
+
+// AddPowerSupply adds power supply of the device as a power port to the netbox inventory.
+// Allocated draw is capped to maximum draw, because netbox doesn't allow
+// allocated draw to exceed maximum draw.
+func AddPowerSupply(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	device *objects.Device,
+	powerSupply PowerSupply,
+	tags []*objects.Tag,
+) (*objects.PowerPort, error) {
+	if powerSupply.Name == "" {
+		return nil, fmt.Errorf("power supply %+v has no name", powerSupply)
+	}
+	description := powerSupply.Description
+	if len(description) > objects.MaxDescriptionLength {
+		description = description[:objects.MaxDescriptionLength]
+	}
+	allocatedDraw := powerSupply.AllocatedDraw
+	if powerSupply.MaximumDraw > 0 && allocatedDraw > powerSupply.MaximumDraw {
+		allocatedDraw = powerSupply.MaximumDraw
+	}
+	powerPort, err := nbi.AddPowerPort(ctx, &objects.PowerPort{
+		NetboxObject: objects.NetboxObject{
+			Tags:        tags,
+			Description: description,
+		},
+		Device:        device,
+		Name:          powerSupply.Name,
+		MaximumDraw:   powerSupply.MaximumDraw,
+		AllocatedDraw: allocatedDraw,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add power port %s: %s", powerSupply.Name, err)
+	}
+	return powerPort, nil
+}
+
+// MatchDeviceToPowerFeeds matches device by its name to power feeds using host power feed
+// relations and adds matched power panels and feeds to the netbox inventory.
+//
+// Power panels are created in the device's site and location, and power feeds
+// in the device's rack. The first matched feed is primary, all others are redundant.
+// Power ports are not connected to power feeds, cabling must be done in netbox.
+func MatchDeviceToPowerFeeds(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	device *objects.Device,
+	sourceConfig *parser.SourceConfig,
+) ([]*objects.PowerFeed, error) {
+	if sourceConfig == nil || sourceConfig.HostPowerFeedRelations == nil {
+		return nil, nil
+	}
+	feedsStr, err := utils.MatchStringToValue(device.Name, sourceConfig.HostPowerFeedRelations)
+	if err != nil {
+		return nil, fmt.Errorf("matching host to power feeds: %s", err)
+	}
+	if feedsStr == "" {
+		return nil, nil
+	}
+	if device.Site == nil {
+		return nil, fmt.Errorf("device %s has no site, so power feeds can't be added", device.Name)
+	}
+	feedRefs, err := parser.ParsePowerFeeds(feedsStr)
+	if err != nil {
+		return nil, fmt.Errorf("device %s: %s", device.Name, err)
+	}
+	powerFeeds := make([]*objects.PowerFeed, 0, len(feedRefs))
+	for i, feedRef := range feedRefs {
+		powerPanel, err := nbi.AddPowerPanel(ctx, &objects.PowerPanel{
+			Name:     feedRef.PowerPanel,
+			Site:     device.Site,
+			Location: device.Location,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add power panel %s: %s", feedRef.PowerPanel, err)
+		}
+		feedType := &objects.PowerFeedTypePrimary
+		if i > 0 {
+			feedType = &objects.PowerFeedTypeRedundant
+		}
+		powerFeed, err := nbi.AddPowerFeed(ctx, &objects.PowerFeed{
+			Name:       feedRef.Name,
+			PowerPanel: powerPanel,
+			Rack:       device.Rack,
+			Status:     &objects.PowerFeedStatusActive,
+			Type:       feedType,
+			Tenant:     device.Tenant,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add power feed %s: %s", feedRef.Name, err)
+		}
+		powerFeeds = append(powerFeeds, powerFeed)
+	}
+	return powerFeeds, nil
+}
+
+// AddRawObject adds raw object with the document on the path (one of netbox.rawObjectTypes)
+// to netbox inventory. Sources can call it during Sync, to emit raw objects for
+// objects they sync. Raw object is tagged with tags and handled by the orphan manager,
+// same as other objects of the source.
+func AddRawObject(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	path string,
+	document map[string]any,
+	tags []*objects.Tag,
+) (*objects.RawObject, error) {
+	rawObject, err := objects.NewRawObject(constants.APIPath(path), document)
+	if err != nil {
+		return nil, fmt.Errorf("raw object on path %s: %s", path, err)
+	}
+	for _, tag := range tags {
+		rawObject.AddTag(tag)
+	}
+	nbRawObject, err := nbi.AddRawObject(ctx, rawObject)
+	if err != nil {
+		return nil, fmt.Errorf("add raw object %s: %s", rawObject, err)
+	}
+	return nbRawObject, nil
+}
+
+// SyncRawObjects syncs raw objects configured for the source (see source.rawObjects)
+// to netbox inventory.
+func SyncRawObjects(
+	ctx context.Context,
+	nbi *inventory.NetboxInventory,
+	sourceConfig *parser.SourceConfig,
+	tags []*objects.Tag,
+) error {
+	for _, rawObjectConfig := range sourceConfig.RawObjects {
+		if _, err := AddRawObject(ctx, nbi, rawObjectConfig.Path, rawObjectConfig.Object, tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package common
+
+import "testing"
+
+func TestIPAddressCandidateBetterPrimaryThan(t *testing.T) {
+	tests := []struct {
+		name string
+		c    ipAddressCandidate
+		o    ipAddressCandidate
+		want bool
+	}{
+		{
+			name: "Preferred subnet wins",
+			c:    ipAddressCandidate{subnetRank: 0, mgmtOnly: true},
+			o:    ipAddressCandidate{subnetRank: 1, dnsResolvable: true},
+			want: true,
+		},
+		{
+			name: "Dns resolvable wins over mgmt only demotion",
+			c:    ipAddressCandidate{dnsResolvable: true, mgmtOnly: true},
+			o:    ipAddressCandidate{},
+			want: true,
+		},
+		{
+			name: "Preferred hint wins over mgmt only demotion",
+			c:    ipAddressCandidate{preferred: true, mgmtOnly: true},
+			o:    ipAddressCandidate{},
+			want: true,
+		},
+		{
+			name: "Mgmt only loses between equal candidates",
+			c:    ipAddressCandidate{mgmtOnly: true},
+			o:    ipAddressCandidate{},
+			want: false,
+		},
+		{
+			name: "Equal candidates",
+			c:    ipAddressCandidate{},
+			o:    ipAddressCandidate{},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.betterPrimaryThan(tt.o); got != tt.want {
+				t.Errorf("betterPrimaryThan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -27,6 +27,8 @@ type DnacSource struct {
 	SSID2WlanGroupName map[string]string
 	// SSID2SecurityDetails WirelessLANName -> SSIDDetails
 	SSID2SecurityDetails map[string]dnac.ResponseItemWirelessGetEnterpriseSSIDSSIDDetails
+	// DeviceID2Equipment DeviceID -> EquipmentType -> []Equipment
+	DeviceID2Equipment map[string]map[string][]dnac.ResponseDevicesGetTheDetailsOfPhysicalComponentsOfTheGivenDeviceResponse
 
 	// Relations between dnac data. Initialized in init functions.
 	Site2Parent           map[string]string          // Site ID -> Parent Site ID
@@ -42,6 +44,7 @@ type DnacSource struct {
 	// SiteID2nbSite: SiteID -> nbSite
 	SiteID2nbSite           sync.Map
 	DeviceID2nbDevice       sync.Map // DeviceID -> nbDevice
+	DroppedDeviceIDs        sync.Map // DeviceID -> true, for filtered out devices and devices dropped by transforms
 	InterfaceID2nbInterface sync.Map // InterfaceID -> nbInterface
 }
 
@@ -68,6 +71,7 @@ func (ds *DnacSource) Init() error {
 		ds.initSites,
 		ds.initMemberships,
 		ds.initDevices,
+		ds.initEquipment,
 		ds.initInterfaces,
 		ds.initWirelessLANs,
 	}
@@ -93,6 +97,7 @@ func (ds *DnacSource) Sync(nbi *inventory.NetboxInventory) error {
 		ds.syncSites,
 		ds.syncVlans,
 		ds.syncDevices,
+		ds.syncDeviceEquipment,
 		ds.syncDeviceInterfaces,
 		ds.syncWirelessLANs,
 		ds.syncMissingDevicePrimaryIPs,
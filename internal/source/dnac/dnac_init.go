@@ -71,6 +71,46 @@ func (ds *DnacSource) initDevices(c *dnac.Client) error {
 	return nil
 }
 
+// Equipment types that are synced as modules or inventory items.
+// Chassis is skipped, because it is represented by the device itself.
+var dnacEquipmentTypes = []string{"Module", "PowerSupply", "Fan", "SFP"}
+
+// initEquipment collects physical components (line cards, power supplies,
+// fans and transceivers) for each device from DNAC API and stores
+// them in the local source inventory.
+func (ds *DnacSource) initEquipment(c *dnac.Client) error {
+	ds.DeviceID2Equipment = make(
+		map[string]map[string][]dnac.ResponseDevicesGetTheDetailsOfPhysicalComponentsOfTheGivenDeviceResponse,
+		len(ds.Devices),
+	)
+	for deviceID := range ds.Devices {
+		ds.DeviceID2Equipment[deviceID] = make(
+			map[string][]dnac.ResponseDevicesGetTheDetailsOfPhysicalComponentsOfTheGivenDeviceResponse,
+		)
+		for _, equipmentType := range dnacEquipmentTypes {
+			equipment, _, err := c.Devices.GetTheDetailsOfPhysicalComponentsOfTheGivenDevice(
+				deviceID,
+				&dnac.GetTheDetailsOfPhysicalComponentsOfTheGivenDeviceQueryParams{Type: equipmentType},
+			)
+			if err != nil {
+				// Not all devices (e.g. access points) support equipment API.
+				ds.Logger.Debugf(
+					ds.Ctx,
+					"get %s equipment for device %s: %s",
+					equipmentType,
+					deviceID,
+					err,
+				)
+				continue
+			}
+			if equipment != nil && equipment.Response != nil {
+				ds.DeviceID2Equipment[deviceID][equipmentType] = *equipment.Response
+			}
+		}
+	}
+	return nil
+}
+
 // initVlansForDevice collects all VLANs for a device from DNAC API
 // and stores them in the local source inventory.
 func (ds *DnacSource) initVlansForDevice(c *dnac.Client, deviceID string) {
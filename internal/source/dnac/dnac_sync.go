@@ -52,11 +52,18 @@ func (ds *DnacSource) syncSites(nbi *inventory.NetboxInventory) error {
 // Syncs dnac vlans to netbox inventory.
 func (ds *DnacSource) syncVlans(nbi *inventory.NetboxInventory) error {
 	for vid, vlan := range ds.Vlans {
+		filtered, err := ds.IsFiltered("vlan", utils.RelationAttributes{Name: vlan.InterfaceName})
+		if err != nil {
+			return fmt.Errorf("filter vlan: %s", err)
+		}
+		if filtered {
+			continue
+		}
 		vlanSite, err := common.MatchVlanToSite(
 			ds.Ctx,
 			nbi,
-			vlan.InterfaceName,
-			ds.SourceConfig.VlanSiteRelations,
+			utils.RelationAttributes{Name: vlan.InterfaceName},
+			ds.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match vlan to site: %s", err)
@@ -64,10 +71,9 @@ func (ds *DnacSource) syncVlans(nbi *inventory.NetboxInventory) error {
 		vlanGroup, err := common.MatchVlanToGroup(
 			ds.Ctx,
 			nbi,
-			vlan.InterfaceName,
+			utils.RelationAttributes{Name: vlan.InterfaceName},
 			vlanSite,
-			ds.SourceConfig.VlanGroupRelations,
-			ds.SourceConfig.VlanGroupSiteRelations,
+			ds.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("vlanGroup: %s", err)
@@ -75,8 +81,8 @@ func (ds *DnacSource) syncVlans(nbi *inventory.NetboxInventory) error {
 		vlanTenant, err := common.MatchVlanToTenant(
 			ds.Ctx,
 			nbi,
-			vlan.InterfaceName,
-			ds.SourceConfig.VlanTenantRelations,
+			utils.RelationAttributes{Name: vlan.InterfaceName},
+			ds.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("vlanTenant: %s", err)
@@ -161,6 +167,16 @@ func (ds *DnacSource) syncDevice(
 	deviceID string,
 	device dnac.ResponseDevicesGetDeviceListResponse,
 ) error {
+	filtered, err := ds.IsFiltered("device", utils.RelationAttributes{Name: device.Hostname, Family: device.Family})
+	if err != nil {
+		return fmt.Errorf("filter device: %s", err)
+	}
+	if filtered {
+		ds.DroppedDeviceIDs.Store(device.ID, true)
+		ds.DeviceID2isMissingPrimaryIP.Delete(device.ID)
+		return nil
+	}
+
 	var description, comments string
 	if device.Description != "" {
 		description = strings.TrimSpace(device.Description)
@@ -180,12 +196,12 @@ func (ds *DnacSource) syncDevice(
 
 	// Match device to a role.
 	var deviceRole *objects.DeviceRole
-	if len(ds.SourceConfig.HostRoleRelations) > 0 {
+	if len(ds.SourceConfig.HostRoleRelations) > 0 || len(ds.SourceConfig.RelationRules.HostRoleRelations) > 0 {
 		deviceRole, err = common.MatchHostToRole(
 			ds.Ctx,
 			nbi,
-			device.Hostname,
-			ds.SourceConfig.HostRoleRelations,
+			utils.RelationAttributes{Name: device.Hostname},
+			ds.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match host to role: %s", err)
@@ -259,8 +275,8 @@ func (ds *DnacSource) syncDevice(
 	deviceTenant, err := common.MatchHostToTenant(
 		ds.Ctx,
 		nbi,
-		device.Hostname,
-		ds.SourceConfig.HostTenantRelations,
+		utils.RelationAttributes{Name: device.Hostname},
+		ds.SourceConfig,
 	)
 	if err != nil {
 		return fmt.Errorf("hostTenant: %s", err)
@@ -276,7 +292,7 @@ func (ds *DnacSource) syncDevice(
 		deviceSerialNumber = device.SerialNumber
 	}
 
-	nbDevice, err := nbi.AddDevice(ds.Ctx, &objects.Device{
+	deviceStruct := &objects.Device{
 		NetboxObject: objects.NetboxObject{
 			Tags:        ds.GetSourceTags(),
 			Description: description,
@@ -295,7 +311,20 @@ func (ds *DnacSource) syncDevice(
 		Comments:     comments,
 		Site:         deviceSite,
 		DeviceType:   deviceType,
-	})
+	}
+	if err := common.MatchDeviceToRack(ds.Ctx, nbi, deviceStruct, ds.SourceConfig); err != nil {
+		ds.Logger.Warningf(ds.Ctx, "device %s rack placement: %s", deviceStruct.Name, err)
+	}
+	keep, err := common.TransformDevice(ds.Ctx, nbi, ds.SourceConfig, deviceStruct, ds.GetSourceTags())
+	if err != nil {
+		return err
+	}
+	if !keep {
+		ds.DroppedDeviceIDs.Store(device.ID, true)
+		ds.DeviceID2isMissingPrimaryIP.Delete(device.ID)
+		return nil
+	}
+	nbDevice, err := nbi.AddDevice(ds.Ctx, deviceStruct)
 
 	if err != nil {
 		return fmt.Errorf("adding dnac device: %s", err)
@@ -305,6 +334,55 @@ func (ds *DnacSource) syncDevice(
 	return nil
 }
 
+// syncDeviceEquipment syncs physical components of each device as
+// modules (line cards) and inventory items (power supplies, fans, transceivers).
+func (ds *DnacSource) syncDeviceEquipment(nbi *inventory.NetboxInventory) error {
+	for deviceID, equipmentByType := range ds.DeviceID2Equipment {
+		nbDevice, err := ds.getDevice(deviceID)
+		if err != nil {
+			ds.Logger.Debugf(ds.Ctx, "skipping equipment for device %s: %s", deviceID, err)
+			continue
+		}
+		for equipmentType, equipment := range equipmentByType {
+			for _, component := range equipment {
+				manufacturerName := "Cisco"
+				if component.Manufacturer != "" {
+					manufacturerName = utils.SerializeManufacturerName(component.Manufacturer)
+				}
+				manufacturer, err := nbi.AddManufacturer(ds.Ctx, &objects.Manufacturer{
+					Name: manufacturerName,
+					Slug: utils.Slugify(manufacturerName),
+				})
+				if err != nil {
+					return fmt.Errorf("add manufacturer: %s", err)
+				}
+				var serialNumber string
+				if !ds.SourceConfig.IgnoreSerialNumbers {
+					serialNumber = strings.TrimSpace(component.SerialNumber)
+				}
+				err = common.AddHardwareComponent(ds.Ctx, nbi, nbDevice, common.HardwareComponent{
+					Name:         strings.TrimSpace(component.Name),
+					Manufacturer: manufacturer,
+					PartNumber:   strings.TrimSpace(component.ProductID),
+					SerialNumber: serialNumber,
+					Description:  strings.TrimSpace(component.Description),
+					IsModule:     equipmentType == "Module",
+				}, ds.GetSourceTags())
+				if err != nil {
+					ds.Logger.Warningf(
+						ds.Ctx,
+						"add equipment %s for device %s: %s",
+						component.Name,
+						nbDevice.Name,
+						err,
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (ds *DnacSource) syncDeviceInterfaces(nbi *inventory.NetboxInventory) error {
 	const maxGoroutines = 50
 	guard := make(chan struct{}, maxGoroutines)
@@ -346,6 +424,9 @@ func (ds *DnacSource) syncDeviceInterface(
 ) error {
 	ifaceDescription := iface.Description
 
+	if _, dropped := ds.DroppedDeviceIDs.Load(iface.DeviceID); dropped {
+		return nil
+	}
 	ifaceDevice, err := ds.getDevice(iface.DeviceID)
 	if err != nil {
 		ds.Logger.Errorf(ds.Ctx, "%s This interface will be skipped", err)
@@ -593,7 +674,9 @@ func (ds *DnacSource) addIPAddressToInterface(
 	dnacDevice := ds.Devices[ifaceDetails.DeviceID]
 	deviceManagementIP := dnacDevice.ManagementIPAddress
 	if deviceManagementIP == ifaceDetails.IPv4Address {
-		if err := common.SetPrimaryIPAddressForObject(ds.Ctx, nbi, ifaceDevice, nbIPAddress, nil); err != nil {
+		ipAddresses := []*objects.IPAddress{nbIPAddress}
+		err := common.SetPrimaryIPAddresses(ds.Ctx, nbi, ifaceDevice, ipAddresses, ipAddresses, ds.SourceConfig)
+		if err != nil {
 			return fmt.Errorf("setting primary IPv4 for device: %s", err)
 		}
 	}
@@ -622,8 +705,8 @@ func (ds *DnacSource) syncWirelessLANs(nbi *inventory.NetboxInventory) error {
 		vlanSite, err := common.MatchVlanToSite(
 			ds.Ctx,
 			nbi,
-			wlanWirelessProfile.InterfaceName,
-			ds.SourceConfig.VlanSiteRelations,
+			utils.RelationAttributes{Name: wlanWirelessProfile.InterfaceName},
+			ds.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match vlan to site: %s", err)
@@ -631,10 +714,9 @@ func (ds *DnacSource) syncWirelessLANs(nbi *inventory.NetboxInventory) error {
 		vlanGroup, err := common.MatchVlanToGroup(
 			ds.Ctx,
 			nbi,
-			wlanWirelessProfile.InterfaceName,
+			utils.RelationAttributes{Name: wlanWirelessProfile.InterfaceName},
 			vlanSite,
-			ds.SourceConfig.VlanGroupRelations,
-			ds.SourceConfig.VlanGroupSiteRelations,
+			ds.SourceConfig,
 		)
 		if err != nil {
 			return err
@@ -769,11 +851,10 @@ func (ds *DnacSource) syncMissingDevicePrimaryIPs(nbi *inventory.NetboxInventory
 				syncErr = fmt.Errorf("add IP address %+v: %s", nbIPAddressStruct, err)
 				return false
 			}
-			updatedDevice := *nbDevice
-			updatedDevice.PrimaryIPv4 = nbIPAddress
-			_, err = nbi.AddDevice(ds.Ctx, &updatedDevice)
+			ipAddresses := []*objects.IPAddress{nbIPAddress}
+			err = common.SetPrimaryIPAddresses(ds.Ctx, nbi, nbDevice, ipAddresses, ipAddresses, ds.SourceConfig)
 			if err != nil {
-				syncErr = fmt.Errorf("add primary IPv4 address %+v: %s", updatedDevice, err)
+				syncErr = fmt.Errorf("set primary ip addresses for %s: %s", nbDevice, err)
 				return false
 			}
 		}
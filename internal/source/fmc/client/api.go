@@ -476,6 +476,115 @@ func (fmcc *FMCClient) GetDeviceSubInterfaces(
 	return subIfaces, nil
 }
 
+// GetDeviceVirtualRouters returns a list of user defined virtual routers for the specified
+// device in the specified domain. Global virtual router is not part of the response.
+func (fmcc *FMCClient) GetDeviceVirtualRouters(
+	domainUUID string,
+	deviceID string,
+) ([]VirtualRouter, error) {
+	offset := 0
+	limit := 25
+	virtualRouters := []VirtualRouter{}
+	ctx := context.Background()
+
+	for {
+		virtualRoutersURL := fmt.Sprintf(
+			"fmc_config/v1/domain/%s/devices/devicerecords/%s/routing/virtualrouters?expanded=true&offset=%d&limit=%d",
+			domainUUID,
+			deviceID,
+			offset,
+			limit,
+		)
+		var marshaledResponse APIResponse[VirtualRouter]
+		err := fmcc.MakeRequest(ctx, http.MethodGet, virtualRoutersURL, nil, &marshaledResponse)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"make request for virtual routers with (%s): %w",
+				virtualRoutersURL,
+				err,
+			)
+		}
+
+		if len(marshaledResponse.Items) > 0 {
+			virtualRouters = append(virtualRouters, marshaledResponse.Items...)
+		}
+
+		if len(marshaledResponse.Items) < limit {
+			break
+		}
+		offset += limit
+	}
+	return virtualRouters, nil
+}
+
+// GetS2SVPNTopologies returns a list of site to site vpn topologies in the specified domain.
+func (fmcc *FMCClient) GetS2SVPNTopologies(domainUUID string) ([]S2SVPNTopology, error) {
+	offset := 0
+	limit := 25
+	topologies := []S2SVPNTopology{}
+	ctx := context.Background()
+
+	for {
+		topologiesURL := fmt.Sprintf(
+			"fmc_config/v1/domain/%s/policy/ftds2svpns?expanded=true&offset=%d&limit=%d",
+			domainUUID,
+			offset,
+			limit,
+		)
+		var marshaledResponse APIResponse[S2SVPNTopology]
+		err := fmcc.MakeRequest(ctx, http.MethodGet, topologiesURL, nil, &marshaledResponse)
+		if err != nil {
+			return nil, fmt.Errorf("make request for s2s vpn topologies (%s): %w", topologiesURL, err)
+		}
+
+		if len(marshaledResponse.Items) > 0 {
+			topologies = append(topologies, marshaledResponse.Items...)
+		}
+
+		if len(marshaledResponse.Items) < limit {
+			break
+		}
+		offset += limit
+	}
+	return topologies, nil
+}
+
+// GetS2SVPNEndpoints returns a list of endpoints of the specified site to site vpn topology.
+func (fmcc *FMCClient) GetS2SVPNEndpoints(
+	domainUUID string,
+	topologyID string,
+) ([]S2SVPNEndpoint, error) {
+	offset := 0
+	limit := 25
+	endpoints := []S2SVPNEndpoint{}
+	ctx := context.Background()
+
+	for {
+		endpointsURL := fmt.Sprintf(
+			"fmc_config/v1/domain/%s/policy/ftds2svpns/%s/endpoints?expanded=true&offset=%d&limit=%d",
+			domainUUID,
+			topologyID,
+			offset,
+			limit,
+		)
+		var marshaledResponse APIResponse[S2SVPNEndpoint]
+		err := fmcc.MakeRequest(ctx, http.MethodGet, endpointsURL, nil, &marshaledResponse)
+		if err != nil {
+			return nil, fmt.Errorf("make request for s2s vpn endpoints (%s): %w", endpointsURL, err)
+		}
+
+		if len(marshaledResponse.Items) > 0 {
+			endpoints = append(endpoints, marshaledResponse.Items...)
+		}
+
+		if len(marshaledResponse.Items) < limit {
+			break
+		}
+		offset += limit
+	}
+	return endpoints, nil
+}
+
 func (fmcc *FMCClient) GetPhysicalInterfaceInfo(
 	domainUUID string,
 	deviceID string,
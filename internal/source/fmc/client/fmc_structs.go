@@ -47,6 +47,22 @@ type SubInterface struct {
 	Name string `json:"name"`
 }
 
+// InterfaceReference represents a reference to an interface of a device.
+type InterfaceReference struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// VirtualRouter represents a user defined virtual router (VRF) of a device.
+type VirtualRouter struct {
+	ID          string               `json:"id"`
+	Type        string               `json:"type"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Interfaces  []InterfaceReference `json:"interfaces"`
+}
+
 // PaginationResponse represents the paging information in the API response.
 type PaginationResponse struct {
 	Offset int `json:"offset"`
@@ -81,6 +97,33 @@ type Device struct {
 	Name string `json:"name"`
 }
 
+// S2SVPNTopology represents a site to site vpn topology of FTD devices.
+type S2SVPNTopology struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	Name         string `json:"name"`
+	TopologyType string `json:"topologyType"`
+	RouteBased   bool   `json:"routeBased"`
+	IKEv1Enabled bool   `json:"ikeV1Enabled"`
+	IKEv2Enabled bool   `json:"ikeV2Enabled"`
+}
+
+// S2SVPNEndpoint represents an endpoint (peer) of the site to site vpn topology.
+// Endpoint is either a managed device or an extranet peer.
+type S2SVPNEndpoint struct {
+	ID           string              `json:"id"`
+	Type         string              `json:"type"`
+	Name         string              `json:"name"`
+	PeerType     string              `json:"peerType"`
+	Extranet     bool                `json:"extranet"`
+	Device       *Device             `json:"device"`
+	Interface    *InterfaceReference `json:"interface"`
+	ExtranetInfo *struct {
+		Name      string `json:"name"`
+		IPAddress string `json:"ipAddress"`
+	} `json:"extranetInfo"`
+}
+
 type InterfaceIPv4 struct {
 	Static *struct {
 		Address string `json:"address"`
@@ -30,12 +30,24 @@ type FMCSource struct {
 	DeviceEtherChannelIfaces map[string][]*client.EtherChannelInterfaceInfo
 	// DeviceSubIfaces is a map of device IDs to a slice of SubInterfaceInfo objects.
 	DeviceSubIfaces map[string][]*client.SubInterfaceInfo
+	// DeviceVirtualRouters is a map of device IDs to a slice of user defined VirtualRouter objects.
+	DeviceVirtualRouters map[string][]client.VirtualRouter
+	// S2SVPNTopologies is a slice of site to site vpn topologies of all domains.
+	S2SVPNTopologies []client.S2SVPNTopology
+	// S2SVPNs is a map of topology IDs to a slice of endpoints of the topology.
+	S2SVPNs map[string][]client.S2SVPNEndpoint
 
 	// Netbox devices representing firewalls.
 	NBDevices map[string]*objects.Device
+	// DeviceIPAddresses is a map of device IDs to ip addresses synced for the device.
+	// They are used as candidates for device's primary and oob ip addresses.
+	DeviceIPAddresses map[string][]*objects.IPAddress
 	// NBInterfaces represents all fmc interfaces that have been synced to netbox.
 	// It is a map of interface name to interface, so we can find parents of sub interfaces.
 	Name2NBInterface map[string]*objects.Interface
+	// IfaceID2NBVRF is a map of fmc interface IDs to VRFs synced from virtual routers.
+	// Interfaces in the global virtual router are not part of this map.
+	IfaceID2NBVRF map[string]*objects.VRF
 }
 
 func (fmcs *FMCSource) Init() error {
@@ -58,7 +70,19 @@ func (fmcs *FMCSource) Init() error {
 		return fmt.Errorf("create FMC client: %s", err)
 	}
 
+	// Devices and interfaces are collected for all domains and devices,
+	// so maps are initialized only once.
+	fmcs.Devices = make(map[string]*client.DeviceInfo)
+	fmcs.DevicePhysicalIfaces = make(map[string][]*client.PhysicalInterfaceInfo)
+	fmcs.DeviceVlanIfaces = make(map[string][]*client.VLANInterfaceInfo)
+	fmcs.DeviceEtherChannelIfaces = make(map[string][]*client.EtherChannelInterfaceInfo)
+	fmcs.DeviceSubIfaces = make(map[string][]*client.SubInterfaceInfo)
+	fmcs.S2SVPNs = make(map[string][]client.S2SVPNEndpoint)
+	fmcs.NBDevices = make(map[string]*objects.Device)
+	fmcs.DeviceIPAddresses = make(map[string][]*objects.IPAddress)
 	fmcs.Name2NBInterface = make(map[string]*objects.Interface)
+	fmcs.IfaceID2NBVRF = make(map[string]*objects.VRF)
+	fmcs.DeviceVirtualRouters = make(map[string][]client.VirtualRouter)
 
 	initFunctions := []func(*client.FMCClient) error{
 		fmcs.initObjects,
@@ -82,6 +106,7 @@ func (fmcs *FMCSource) Init() error {
 func (fmcs *FMCSource) Sync(nbi *inventory.NetboxInventory) error {
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		fmcs.syncDevices,
+		fmcs.syncS2SVPNs,
 	}
 
 	for _, syncFunc := range syncFunctions {
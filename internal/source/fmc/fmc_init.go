@@ -17,6 +17,11 @@ func (fmcs *FMCSource) initObjects(c *client.FMCClient) error {
 		if err := fmcs.initDevices(c, domain); err != nil {
 			return fmt.Errorf("init devices: %s", err)
 		}
+		// Site to site vpns require additional permissions,
+		// so we only warn on failure.
+		if err := fmcs.initS2SVPNs(c, domain); err != nil {
+			fmcs.Logger.Warningf(fmcs.Ctx, "init s2s vpns for %s domain: %s", domain.Name, err)
+		}
 	}
 	return nil
 }
@@ -43,7 +48,6 @@ func (fmcs *FMCSource) initDevices(c *client.FMCClient, domain client.Domain) er
 	}
 	fmcs.Logger.Debugf(fmcs.Ctx, "Received devices %v", devices)
 
-	fmcs.Devices = make(map[string]*client.DeviceInfo, len(devices))
 	for _, device := range devices {
 		deviceInfo, err := c.GetDeviceInfo(domain.UUID, device.ID)
 		if err != nil {
@@ -82,6 +86,21 @@ func (fmcs *FMCSource) initDevices(c *client.FMCClient, domain client.Domain) er
 		if err != nil {
 			return fmt.Errorf("error initializing subinterfaces: %s", err)
 		}
+
+		// Initialize virtual routers. Not all device models support
+		// virtual routers, so we only warn on failure.
+		fmcs.Logger.Debugf(fmcs.Ctx, "Getting virtual routers for device %s", deviceInfo.Name)
+		virtualRouters, err := c.GetDeviceVirtualRouters(domain.UUID, device.ID)
+		if err != nil {
+			fmcs.Logger.Warningf(
+				fmcs.Ctx,
+				"get virtual routers for device %s: %s",
+				deviceInfo.Name,
+				err,
+			)
+			continue
+		}
+		fmcs.DeviceVirtualRouters[device.ID] = virtualRouters
 	}
 	return nil
 }
@@ -95,7 +114,6 @@ func (fmcs *FMCSource) initDevicePhysicalInterfaces(
 	if err != nil {
 		return fmt.Errorf("error getting physical interfaces: %s", err)
 	}
-	fmcs.DevicePhysicalIfaces = make(map[string][]*client.PhysicalInterfaceInfo, len(pIfaces))
 	for _, pInterface := range pIfaces {
 		pIfaceInfo, err := c.GetPhysicalInterfaceInfo(domain.UUID, device.ID, pInterface.ID)
 		if err != nil {
@@ -118,7 +136,6 @@ func (fmcs *FMCSource) initDeviceVLANInterfaces(
 	if err != nil {
 		return fmt.Errorf("error getting vlan interfaces: %s", err)
 	}
-	fmcs.DeviceVlanIfaces = make(map[string][]*client.VLANInterfaceInfo, len(vlanIfaces))
 	for _, vlanIface := range vlanIfaces {
 		vlanIfaceInfo, err := c.GetVLANInterfaceInfo(domain.UUID, device.ID, vlanIface.ID)
 		if err != nil {
@@ -138,10 +155,6 @@ func (fmcs *FMCSource) initDeviceEtherChannelInterfaces(
 	if err != nil {
 		return fmt.Errorf("error getting etherchannel interfaces: %s", err)
 	}
-	fmcs.DeviceEtherChannelIfaces = make(
-		map[string][]*client.EtherChannelInterfaceInfo,
-		len(etherChannelIfaces),
-	)
 	for _, etherChannelIface := range etherChannelIfaces {
 		etherChannelIfaceInfo, err := c.GetEtherChannelInterfaceInfo(
 			domain.UUID,
@@ -168,10 +181,6 @@ func (fmcs *FMCSource) initDeviceSubInterfaces(
 	if err != nil {
 		return fmt.Errorf("error getting subinterfaces: %s", err)
 	}
-	fmcs.DeviceSubIfaces = make(
-		map[string][]*client.SubInterfaceInfo,
-		len(subInterfaces),
-	)
 	for _, subInterface := range subInterfaces {
 		subInterfaceInfo, err := c.GetSubInterfaceInfo(
 			domain.UUID,
@@ -188,3 +197,22 @@ func (fmcs *FMCSource) initDeviceSubInterfaces(
 	}
 	return nil
 }
+
+// initS2SVPNs collects site to site vpn topologies with their endpoints
+// for the given domain.
+func (fmcs *FMCSource) initS2SVPNs(c *client.FMCClient, domain client.Domain) error {
+	fmcs.Logger.Debugf(fmcs.Ctx, "Getting s2s vpn topologies for %s domain...", domain.Name)
+	topologies, err := c.GetS2SVPNTopologies(domain.UUID)
+	if err != nil {
+		return fmt.Errorf("get s2s vpn topologies: %s", err)
+	}
+	for _, topology := range topologies {
+		endpoints, err := c.GetS2SVPNEndpoints(domain.UUID, topology.ID)
+		if err != nil {
+			return fmt.Errorf("get endpoints of s2s vpn topology %s: %s", topology.Name, err)
+		}
+		fmcs.S2SVPNTopologies = append(fmcs.S2SVPNTopologies, topology)
+		fmcs.S2SVPNs[topology.ID] = endpoints
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package fmc
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
@@ -18,6 +20,13 @@ func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
 			fmcs.Logger.Warningf(fmcs.Ctx, "device with empty name. Skipping...")
 			continue
 		}
+		filtered, err := fmcs.IsFiltered("device", utils.RelationAttributes{Name: deviceName})
+		if err != nil {
+			return fmt.Errorf("filter device: %s", err)
+		}
+		if filtered {
+			continue
+		}
 		var deviceSerialNumber string
 		if !fmcs.SourceConfig.IgnoreSerialNumbers {
 			deviceSerialNumber = device.Metadata.SerialNumber
@@ -25,7 +34,7 @@ func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
 		deviceModel := device.Model
 		if deviceModel == "" {
 			fmcs.Logger.Warning(fmcs.Ctx, "model field for device is emptpy. Using fallback model.")
-			deviceModel = constants.DefaultModel
+			deviceModel = fmcs.SourceConfig.Defaults.DeviceType.Name
 		}
 		deviceManufacturer, err := nbi.AddManufacturer(fmcs.Ctx, &objects.Manufacturer{
 			Name: "Cisco",
@@ -45,8 +54,8 @@ func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
 		deviceTenant, err := common.MatchHostToTenant(
 			fmcs.Ctx,
 			nbi,
-			deviceName,
-			fmcs.SourceConfig.HostTenantRelations,
+			utils.RelationAttributes{Name: deviceName},
+			fmcs.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match host to tenant %s", err)
@@ -55,19 +64,19 @@ func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
 		// Match host to a role. First test if user provided relations, if not
 		// use default firewall role.
 		var deviceRole *objects.DeviceRole
-		if len(fmcs.SourceConfig.HostRoleRelations) > 0 {
+		if len(fmcs.SourceConfig.HostRoleRelations) > 0 || len(fmcs.SourceConfig.RelationRules.HostRoleRelations) > 0 {
 			deviceRole, err = common.MatchHostToRole(
 				fmcs.Ctx,
 				nbi,
-				deviceName,
-				fmcs.SourceConfig.HostRoleRelations,
+				utils.RelationAttributes{Name: deviceName},
+				fmcs.SourceConfig,
 			)
 			if err != nil {
 				return fmt.Errorf("match host to role: %s", err)
 			}
 		}
 		if deviceRole == nil {
-			deviceRole, err = nbi.AddFirewallDeviceRole(fmcs.Ctx)
+			deviceRole, err = nbi.AddFirewallDeviceRole(fmcs.Ctx, fmcs.SourceConfig.Defaults.Roles)
 			if err != nil {
 				return fmt.Errorf("add DeviceRole firewall: %s", err)
 			}
@@ -76,8 +85,8 @@ func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
 		deviceSite, err := common.MatchHostToSite(
 			fmcs.Ctx,
 			nbi,
-			deviceName,
-			fmcs.SourceConfig.HostSiteRelations,
+			utils.RelationAttributes{Name: deviceName},
+			fmcs.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match host to site: %s", err)
@@ -91,7 +100,7 @@ func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
 		if err != nil {
 			return fmt.Errorf("add platform: %s", err)
 		}
-		NBDevice, err := nbi.AddDevice(fmcs.Ctx, &objects.Device{
+		deviceStruct := &objects.Device{
 			NetboxObject: objects.NetboxObject{
 				Description: device.Description,
 				Tags:        fmcs.GetSourceTags(),
@@ -113,10 +122,26 @@ func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
 			Tenant:       deviceTenant,
 			Platform:     devicePlatform,
 			SerialNumber: deviceSerialNumber,
-		})
+		}
+		if err := common.MatchDeviceToRack(fmcs.Ctx, nbi, deviceStruct, fmcs.SourceConfig); err != nil {
+			fmcs.Logger.Warningf(fmcs.Ctx, "device %s rack placement: %s", deviceStruct.Name, err)
+		}
+		keep, err := common.TransformDevice(fmcs.Ctx, nbi, fmcs.SourceConfig, deviceStruct, fmcs.GetSourceTags())
+		if err != nil {
+			return err
+		}
+		if !keep {
+			continue
+		}
+		NBDevice, err := nbi.AddDevice(fmcs.Ctx, deviceStruct)
 		if err != nil {
 			return fmt.Errorf("add device: %s", err)
 		}
+		fmcs.NBDevices[deviceUUID] = NBDevice
+		err = fmcs.syncVirtualRouters(nbi, NBDevice, deviceUUID)
+		if err != nil {
+			return fmt.Errorf("sync virtual routers: %s", err)
+		}
 		err = fmcs.syncPhysicalInterfaces(nbi, NBDevice, deviceUUID)
 		if err != nil {
 			return fmt.Errorf("sync physical interfaces: %s", err)
@@ -135,6 +160,51 @@ func (fmcs *FMCSource) syncDevices(nbi *inventory.NetboxInventory) error {
 		if err != nil {
 			return fmt.Errorf("sync subinterfaces: %s", err)
 		}
+		err = common.SetPrimaryIPAddresses(
+			fmcs.Ctx,
+			nbi,
+			NBDevice,
+			fmcs.DeviceIPAddresses[deviceUUID],
+			nil,
+			fmcs.SourceConfig,
+		)
+		if err != nil {
+			return fmt.Errorf("set primary ip addresses: %s", err)
+		}
+	}
+	return nil
+}
+
+// syncVirtualRouters syncs user defined virtual routers of the given device
+// as VRFs into netbox inventory. Interfaces of the global virtual router
+// remain in the global table.
+func (fmcs *FMCSource) syncVirtualRouters(
+	nbi *inventory.NetboxInventory,
+	nbDevice *objects.Device,
+	deviceUUID string,
+) error {
+	for _, virtualRouter := range fmcs.DeviceVirtualRouters[deviceUUID] {
+		if virtualRouter.Name == "" {
+			continue
+		}
+		vrfStruct := &objects.VRF{
+			NetboxObject: objects.NetboxObject{
+				Tags:        fmcs.GetSourceTags(),
+				Description: virtualRouter.Description,
+				CustomFields: map[string]interface{}{
+					constants.CustomFieldSourceIDName: virtualRouter.ID,
+				},
+			},
+			Name:   common.DeviceVRFName(nbDevice.Name, virtualRouter.Name),
+			Tenant: nbDevice.Tenant,
+		}
+		nbVRF, err := nbi.AddVRF(fmcs.Ctx, vrfStruct)
+		if err != nil {
+			return fmt.Errorf("add vrf %+v: %s", vrfStruct, err)
+		}
+		for _, iface := range virtualRouter.Interfaces {
+			fmcs.IfaceID2NBVRF[iface.ID] = nbVRF
+		}
 	}
 	return nil
 }
@@ -177,13 +247,21 @@ func (fmcs *FMCSource) syncVlanInterfaces(
 		for _, vlanIface := range vlanIfaces {
 			// Add vlan
 			ifaceTaggedVlans := []*objects.Vlan{}
+			vlanFiltered := false
 			if vlanIface.VID != 0 {
+				var err error
+				vlanFiltered, err = fmcs.IsFiltered("vlan", utils.RelationAttributes{Name: vlanIface.Name})
+				if err != nil {
+					return fmt.Errorf("filter vlan: %s", err)
+				}
+			}
+			if vlanIface.VID != 0 && !vlanFiltered {
 				// Match vlan to site
 				vlanSite, err := common.MatchVlanToSite(
 					fmcs.Ctx,
 					nbi,
-					vlanIface.Name,
-					fmcs.SourceConfig.VlanSiteRelations,
+					utils.RelationAttributes{Name: vlanIface.Name},
+					fmcs.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match vlan to site: %s", err)
@@ -192,10 +270,9 @@ func (fmcs *FMCSource) syncVlanInterfaces(
 				vlanGroup, err := common.MatchVlanToGroup(
 					fmcs.Ctx,
 					nbi,
-					vlanIface.Name,
+					utils.RelationAttributes{Name: vlanIface.Name},
 					vlanSite,
-					fmcs.SourceConfig.VlanGroupRelations,
-					fmcs.SourceConfig.VlanGroupSiteRelations,
+					fmcs.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match vlan to group: %s", err)
@@ -203,8 +280,8 @@ func (fmcs *FMCSource) syncVlanInterfaces(
 				vlanTenant, err := common.MatchVlanToTenant(
 					fmcs.Ctx,
 					nbi,
-					vlanIface.Name,
-					fmcs.SourceConfig.VlanTenantRelations,
+					utils.RelationAttributes{Name: vlanIface.Name},
+					fmcs.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match vlan to tenant: %s", err)
@@ -241,6 +318,7 @@ func (fmcs *FMCSource) syncVlanInterfaces(
 				MTU:         vlanIface.MTU,
 				TaggedVlans: ifaceTaggedVlans,
 				Type:        &objects.VirtualInterfaceType,
+				VRF:         fmcs.IfaceID2NBVRF[vlanIface.ID],
 			})
 			if err != nil {
 				return fmt.Errorf("add vlan interface: %s", err)
@@ -253,7 +331,7 @@ func (fmcs *FMCSource) syncVlanInterfaces(
 					fmcs.SourceConfig.IgnoredSubnets,
 				) {
 					dnsName := utils.ReverseLookup(vlanIface.IPv4.Static.Address)
-					_, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
+					nbIPAddress, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
 						NetboxObject: objects.NetboxObject{
 							Tags: fmcs.GetSourceTags(),
 							CustomFields: map[string]interface{}{
@@ -264,10 +342,15 @@ func (fmcs *FMCSource) syncVlanInterfaces(
 						DNSName:            dnsName,
 						AssignedObjectID:   NBIface.ID,
 						AssignedObjectType: constants.ContentTypeDcimInterface,
+						VRF:                NBIface.VRF,
 					})
 					if err != nil {
 						return fmt.Errorf("add ip address")
 					}
+					fmcs.DeviceIPAddresses[deviceUUID] = append(
+						fmcs.DeviceIPAddresses[deviceUUID],
+						nbIPAddress,
+					)
 					// Also add prefix
 					prefix, mask, err := utils.GetPrefixAndMaskFromIPAddress(ipAddress)
 					if err != nil {
@@ -283,6 +366,7 @@ func (fmcs *FMCSource) syncVlanInterfaces(
 							Prefix: prefix,
 							Tenant: prefixTenant,
 							Vlan:   prefixVlan,
+							VRF:    NBIface.VRF,
 						})
 						if err != nil {
 							return fmt.Errorf("add prefix: %s", err)
@@ -298,6 +382,10 @@ func (fmcs *FMCSource) syncVlanInterfaces(
 	return nil
 }
 
+// fmcManagementIfaceRegex matches names of diagnostic and management interfaces
+// (e.g. Diagnostic0/0), which are synced as out-of-band management interfaces.
+var fmcManagementIfaceRegex = regexp.MustCompile(`^(Diagnostic|Management)\d+/\d+$`)
+
 // syncPhysicalInterfaces syncs physical interfaces for given device,
 // into netbox inventory.
 func (fmcs *FMCSource) syncPhysicalInterfaces(
@@ -320,6 +408,9 @@ func (fmcs *FMCSource) syncPhysicalInterfaces(
 				Status: pIface.Enabled,
 				MTU:    pIface.MTU,
 				Type:   &objects.OtherInterfaceType,
+				VRF:    fmcs.IfaceID2NBVRF[pIface.ID],
+				// Diagnostic interface is used only for out-of-band management
+				MgmtOnly: fmcManagementIfaceRegex.MatchString(pIface.Name),
 			}
 			NBIface, err := nbi.AddInterface(fmcs.Ctx, iface)
 			if err != nil {
@@ -332,7 +423,7 @@ func (fmcs *FMCSource) syncPhysicalInterfaces(
 					fmcs.SourceConfig.IgnoredSubnets,
 				) {
 					dnsName := utils.ReverseLookup(pIface.IPv4.Static.Address)
-					_, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
+					nbIPAddress, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
 						NetboxObject: objects.NetboxObject{
 							Tags: fmcs.GetSourceTags(),
 							CustomFields: map[string]interface{}{
@@ -343,10 +434,15 @@ func (fmcs *FMCSource) syncPhysicalInterfaces(
 						DNSName:            dnsName,
 						AssignedObjectID:   NBIface.ID,
 						AssignedObjectType: constants.ContentTypeDcimInterface,
+						VRF:                NBIface.VRF,
 					})
 					if err != nil {
 						return fmt.Errorf("add ip address")
 					}
+					fmcs.DeviceIPAddresses[deviceUUID] = append(
+						fmcs.DeviceIPAddresses[deviceUUID],
+						nbIPAddress,
+					)
 				}
 			}
 			// Add to internal map so we can connect subinterfaces
@@ -377,6 +473,7 @@ func (fmcs *FMCSource) syncEtherChannelInterfaces(
 				Status: eIface.Enabled,
 				MTU:    eIface.MTU,
 				Type:   &objects.OtherInterfaceType, // TODO
+				VRF:    fmcs.IfaceID2NBVRF[eIface.ID],
 			})
 			if err != nil {
 				return fmt.Errorf("add ether channel interface: %s", err)
@@ -389,7 +486,7 @@ func (fmcs *FMCSource) syncEtherChannelInterfaces(
 					fmcs.SourceConfig.IgnoredSubnets,
 				) {
 					dnsName := utils.ReverseLookup(eIface.IPv4.Static.Address)
-					_, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
+					nbIPAddress, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
 						NetboxObject: objects.NetboxObject{
 							Tags: fmcs.GetSourceTags(),
 							CustomFields: map[string]interface{}{
@@ -400,10 +497,15 @@ func (fmcs *FMCSource) syncEtherChannelInterfaces(
 						DNSName:            dnsName,
 						AssignedObjectID:   NBIface.ID,
 						AssignedObjectType: constants.ContentTypeDcimInterface,
+						VRF:                NBIface.VRF,
 					})
 					if err != nil {
 						return fmt.Errorf("add ip address")
 					}
+					fmcs.DeviceIPAddresses[deviceUUID] = append(
+						fmcs.DeviceIPAddresses[deviceUUID],
+						nbIPAddress,
+					)
 				}
 			}
 			// Add to internal map so we can connect subinterfaces
@@ -424,13 +526,21 @@ func (fmcs *FMCSource) syncSubInterfaces(
 		for _, subIface := range subIfaces {
 			// Add vlan
 			ifaceTaggedVlans := []*objects.Vlan{}
+			vlanFiltered := false
 			if subIface.VlanID > 1 {
+				var err error
+				vlanFiltered, err = fmcs.IsFiltered("vlan", utils.RelationAttributes{Name: subIface.Name})
+				if err != nil {
+					return fmt.Errorf("filter vlan: %s", err)
+				}
+			}
+			if subIface.VlanID > 1 && !vlanFiltered {
 				// Match vlan to site
 				vlanSite, err := common.MatchVlanToSite(
 					fmcs.Ctx,
 					nbi,
-					subIface.Name,
-					fmcs.SourceConfig.VlanSiteRelations,
+					utils.RelationAttributes{Name: subIface.Name},
+					fmcs.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match subiface vlan to site: %s", err)
@@ -439,10 +549,9 @@ func (fmcs *FMCSource) syncSubInterfaces(
 				vlanGroup, err := common.MatchVlanToGroup(
 					fmcs.Ctx,
 					nbi,
-					subIface.Name,
+					utils.RelationAttributes{Name: subIface.Name},
 					vlanSite,
-					fmcs.SourceConfig.VlanGroupRelations,
-					fmcs.SourceConfig.VlanGroupSiteRelations,
+					fmcs.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match subiface vlan to group: %s", err)
@@ -450,8 +559,8 @@ func (fmcs *FMCSource) syncSubInterfaces(
 				vlanTenant, err := common.MatchVlanToTenant(
 					fmcs.Ctx,
 					nbi,
-					subIface.Name,
-					fmcs.SourceConfig.VlanTenantRelations,
+					utils.RelationAttributes{Name: subIface.Name},
+					fmcs.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match subiface vlan to tenant: %s", err)
@@ -491,6 +600,7 @@ func (fmcs *FMCSource) syncSubInterfaces(
 				MTU:             subIface.MTU,
 				TaggedVlans:     ifaceTaggedVlans,
 				Type:            &objects.VirtualInterfaceType,
+				VRF:             fmcs.IfaceID2NBVRF[subIface.ID],
 			})
 			if err != nil {
 				return fmt.Errorf("add vlan interface: %s", err)
@@ -503,7 +613,7 @@ func (fmcs *FMCSource) syncSubInterfaces(
 					fmcs.SourceConfig.IgnoredSubnets,
 				) {
 					dnsName := utils.ReverseLookup(subIface.IPv4.Static.Address)
-					_, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
+					nbIPAddress, err := nbi.AddIPAddress(fmcs.Ctx, &objects.IPAddress{
 						NetboxObject: objects.NetboxObject{
 							Tags: fmcs.GetSourceTags(),
 							CustomFields: map[string]interface{}{
@@ -514,10 +624,15 @@ func (fmcs *FMCSource) syncSubInterfaces(
 						DNSName:            dnsName,
 						AssignedObjectID:   NBIface.ID,
 						AssignedObjectType: constants.ContentTypeDcimInterface,
+						VRF:                NBIface.VRF,
 					})
 					if err != nil {
 						return fmt.Errorf("add ip address")
 					}
+					fmcs.DeviceIPAddresses[deviceUUID] = append(
+						fmcs.DeviceIPAddresses[deviceUUID],
+						nbIPAddress,
+					)
 					// Also add prefix
 					prefix, mask, err := utils.GetPrefixAndMaskFromIPAddress(ipAddress)
 					if err != nil {
@@ -533,6 +648,7 @@ func (fmcs *FMCSource) syncSubInterfaces(
 							Prefix: prefix,
 							Tenant: prefixTenant,
 							Vlan:   prefixVlan,
+							VRF:    NBIface.VRF,
 						})
 						if err != nil {
 							return fmt.Errorf("add prefix: %s", err)
@@ -544,3 +660,110 @@ func (fmcs *FMCSource) syncSubInterfaces(
 	}
 	return nil
 }
+
+// syncS2SVPNs syncs site to site vpn topologies as tunnels. Each endpoint
+// on a managed device is terminated on the interface used by the topology,
+// while extranet peers are only recorded in the tunnel's comments.
+func (fmcs *FMCSource) syncS2SVPNs(nbi *inventory.NetboxInventory) error {
+	for _, topology := range fmcs.S2SVPNTopologies {
+		endpoints := []common.IPsecTunnelEndpoint{}
+		comments := []string{}
+		var tunnelTenant *objects.Tenant
+		for _, endpoint := range fmcs.S2SVPNs[topology.ID] {
+			if endpoint.Extranet {
+				if endpoint.ExtranetInfo != nil {
+					comments = append(comments, fmt.Sprintf(
+						"Extranet peer %s: %s",
+						endpoint.ExtranetInfo.Name,
+						endpoint.ExtranetInfo.IPAddress,
+					))
+				}
+				continue
+			}
+			if endpoint.Device == nil || endpoint.Interface == nil {
+				continue
+			}
+			nbDevice, ok := fmcs.NBDevices[endpoint.Device.ID]
+			if !ok {
+				fmcs.Logger.Debugf(fmcs.Ctx, "s2s vpn endpoint %s is not a synced device. Skipping...", endpoint.Name)
+				continue
+			}
+			ifaceName, ifaceIPv4, ok := fmcs.getInterfaceByID(endpoint.Device.ID, endpoint.Interface.ID)
+			if !ok {
+				fmcs.Logger.Debugf(
+					fmcs.Ctx,
+					"interface %s of s2s vpn endpoint %s not found. Skipping...",
+					endpoint.Interface.Name,
+					endpoint.Name,
+				)
+				continue
+			}
+			nbIface, ok := nbi.GetInterface(ifaceName, nbDevice.ID)
+			if !ok {
+				continue
+			}
+			role := &objects.TunnelTerminationRolePeer
+			switch endpoint.PeerType {
+			case "HUB":
+				role = &objects.TunnelTerminationRoleHub
+			case "SPOKE":
+				role = &objects.TunnelTerminationRoleSpoke
+			}
+			outsideAddress, _, _ := strings.Cut(getIPAddressForIface(ifaceIPv4), "/")
+			endpoints = append(endpoints, common.IPsecTunnelEndpoint{
+				Interface:      nbIface,
+				Role:           role,
+				OutsideAddress: outsideAddress,
+				OutsideVRF:     nbIface.VRF,
+			})
+			if tunnelTenant == nil {
+				tunnelTenant = nbDevice.Tenant
+			}
+		}
+		if len(endpoints) == 0 {
+			fmcs.Logger.Debugf(fmcs.Ctx, "s2s vpn topology %s has no synced endpoints. Skipping...", topology.Name)
+			continue
+		}
+		_, err := common.AddIPsecTunnel(fmcs.Ctx, nbi, common.IPsecTunnel{
+			Name:        topology.Name,
+			Group:       fmcs.SourceConfig.Name,
+			Description: fmt.Sprintf("FMC %s s2s vpn topology", strings.ToLower(topology.TopologyType)),
+			Comments:    strings.Join(comments, "\n"),
+			Tenant:      tunnelTenant,
+			Endpoints:   endpoints,
+		}, fmcs.GetSourceTags())
+		if err != nil {
+			fmcs.Logger.Warningf(fmcs.Ctx, "add s2s vpn topology %s: %s", topology.Name, err)
+		}
+	}
+	return nil
+}
+
+// getInterfaceByID returns netbox name and ipv4 configuration
+// of the device's interface with the given fmc id.
+func (fmcs *FMCSource) getInterfaceByID(
+	deviceUUID string,
+	ifaceID string,
+) (string, *client.InterfaceIPv4, bool) {
+	for _, iface := range fmcs.DevicePhysicalIfaces[deviceUUID] {
+		if iface.ID == ifaceID {
+			return iface.Name, iface.IPv4, true
+		}
+	}
+	for _, iface := range fmcs.DeviceVlanIfaces[deviceUUID] {
+		if iface.ID == ifaceID {
+			return iface.Name, iface.IPv4, true
+		}
+	}
+	for _, iface := range fmcs.DeviceEtherChannelIfaces[deviceUUID] {
+		if iface.ID == ifaceID {
+			return iface.Name, iface.IPv4, true
+		}
+	}
+	for _, iface := range fmcs.DeviceSubIfaces[deviceUUID] {
+		if iface.ID == ifaceID {
+			return iface.Name, iface.IPv4, true
+		}
+	}
+	return "", nil, false
+}
@@ -2,6 +2,7 @@ package fortigate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,12 +14,27 @@ import (
 	"github.com/bl4ko/netbox-ssot/internal/utils"
 )
 
+// rootVdomName is the name of the vdom, whose default vrf (0)
+// represents the global routing table.
+const rootVdomName = "root"
+
 //nolint:revive
 type FortigateSource struct {
 	common.Config
 	// Fortinet data. Initialized in init functions.
-	SystemInfo FortiSystemInfo              // Map storing system information
-	Ifaces     map[string]InterfaceResponse // iface name -> FortigateInterface
+	SystemInfo  FortiSystemInfo              // Map storing system information
+	Ifaces      map[string]InterfaceResponse // iface name -> FortigateInterface
+	DHCPServers []DHCPServerResponse         // Array of dhcp servers
+	VIPs        []VIPResponse                // Array of virtual ips
+	BGP         BGPResponse                  // BGP configuration
+
+	// IPsecPhase1 holds phase1 interfaces (ipsec tunnels) of the fortigate.
+	IPsecPhase1 []IPsecPhase1Response
+	// IPsecPhase2 maps phase1 name -> phase2 interfaces (selectors) of the tunnel.
+	IPsecPhase2 map[string][]IPsecPhase2Response
+
+	// LocalContextData holds dns, ntp and syslog servers of the fortigate.
+	LocalContextData map[string]interface{}
 
 	// NBFirewall representing fortinet firewall created in syncDevice func.
 	NBFirewall *objects.Device
@@ -79,6 +95,11 @@ func (fs *FortigateSource) Init() error {
 	initFunctions := []func(context.Context, *FortiClient) error{
 		fs.initSystemInfo,
 		fs.initInterfaces,
+		fs.initDHCPServers,
+		fs.initVIPs,
+		fs.initBGP,
+		fs.initIPsecTunnels,
+		fs.initLocalContextData,
 	}
 	for _, initFunc := range initFunctions {
 		startTime := time.Now()
@@ -100,11 +121,19 @@ func (fs *FortigateSource) Sync(nbi *inventory.NetboxInventory) error {
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		fs.syncDevice,
 		fs.syncInterfaces,
+		fs.syncDHCPServers,
+		fs.syncVIPs,
+		fs.syncBGP,
+		fs.syncIPsecTunnels,
 	}
 
 	for _, syncFunc := range syncFunctions {
 		startTime := time.Now()
 		err := syncFunc(nbi)
+		if errors.Is(err, common.ErrDeviceDropped) {
+			fs.Logger.Info(fs.Ctx, "Device is filtered out or dropped by a transform, skipping other objects")
+			return nil
+		}
 		if err != nil {
 			return err
 		}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/constants"
 )
 
 type APIResponse[T any] struct {
@@ -30,6 +33,7 @@ type InterfaceResponse struct {
 	MTU         int           `json:"mtu"`
 	MAC         string        `json:"macaddr"`
 	VlanID      int           `json:"vlanid"`
+	VRF         int           `json:"vrf"`
 	SecondaryIP []SecondaryIP `json:"secondaryip"`
 	VRRPIP      []VRRPIP      `json:"vrrp"`
 }
@@ -38,7 +42,96 @@ type SecondaryIP struct {
 	IP string `json:"ip"`
 }
 type VRRPIP struct {
-	VRIP string `json:"vrip"`
+	VRID     int    `json:"vrid"`
+	VRIP     string `json:"vrip"`
+	Priority int    `json:"priority"`
+	Version  string `json:"version"`
+}
+
+type DHCPServerResponse struct {
+	ID        int                 `json:"id"`
+	Status    string              `json:"status"`
+	Interface string              `json:"interface"`
+	Netmask   string              `json:"netmask"`
+	IPRanges  []DHCPServerIPRange `json:"ip-range"`
+}
+
+type DHCPServerIPRange struct {
+	ID      int    `json:"id"`
+	StartIP string `json:"start-ip"`
+	EndIP   string `json:"end-ip"`
+}
+
+type VIPResponse struct {
+	Name        string        `json:"name"`
+	Type        string        `json:"type"`
+	ExtIP       string        `json:"extip"`
+	MappedIPs   []VIPMappedIP `json:"mappedip"`
+	PortForward string        `json:"portforward"`
+	Protocol    string        `json:"protocol"`
+	ExtPort     string        `json:"extport"`
+	MappedPort  string        `json:"mappedport"`
+}
+
+type VIPMappedIP struct {
+	Range string `json:"range"`
+}
+
+type BGPResponse struct {
+	AS        ASNumber      `json:"as"`
+	RouterID  string        `json:"router-id"`
+	Neighbors []BGPNeighbor `json:"neighbor"`
+}
+
+type BGPNeighbor struct {
+	IP           string   `json:"ip"`
+	RemoteAS     ASNumber `json:"remote-as"`
+	Description  string   `json:"description"`
+	UpdateSource string   `json:"update-source"`
+}
+
+type DNSResponse struct {
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+}
+
+type NTPResponse struct {
+	Type       string      `json:"type"`
+	NTPServers []NTPServer `json:"ntpserver"`
+}
+
+type NTPServer struct {
+	Server string `json:"server"`
+}
+
+type SyslogSettingResponse struct {
+	Status string `json:"status"`
+	Server string `json:"server"`
+}
+
+type IPsecPhase1Response struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Interface string `json:"interface"`
+	RemoteGW  string `json:"remote-gw"`
+	LocalGW   string `json:"local-gw"`
+	Comments  string `json:"comments"`
+}
+
+type IPsecPhase2Response struct {
+	Name       string `json:"name"`
+	Phase1Name string `json:"phase1name"`
+	SrcSubnet  string `json:"src-subnet"`
+	DstSubnet  string `json:"dst-subnet"`
+}
+
+// ASNumber is an autonomous system number, which is returned either
+// as a number or as a string (asdot notation), depending on the fortiOS version.
+type ASNumber string
+
+func (a *ASNumber) UnmarshalJSON(data []byte) error {
+	*a = ASNumber(strings.Trim(string(data), `"`))
+	return nil
 }
 
 // Init system info collects system info from paloalto.
@@ -102,3 +195,165 @@ func (fs *FortigateSource) initInterfaces(ctx context.Context, c *FortiClient) e
 
 	return nil
 }
+
+// Fetches all dhcp servers with their ip ranges from fortigate api.
+func (fs *FortigateSource) initDHCPServers(ctx context.Context, c *FortiClient) error {
+	res, err := c.MakeRequest(ctx, http.MethodGet, "cmdb/system.dhcp/server/", nil)
+	if err != nil {
+		return fmt.Errorf("request error: %s", err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("body read error: %s", err)
+	}
+	var dhcpServerResponse APIResponse[[]DHCPServerResponse]
+	err = json.Unmarshal(body, &dhcpServerResponse)
+	if err != nil {
+		return fmt.Errorf("body unmarshal error: %s", err)
+	}
+
+	if dhcpServerResponse.HTTPStatus != http.StatusOK {
+		return fmt.Errorf("got http status: %d", dhcpServerResponse.HTTPStatus)
+	}
+
+	fs.DHCPServers = dhcpServerResponse.Results
+	return nil
+}
+
+// initVIPs collects virtual ips (destination NAT objects) from the fortigate.
+func (fs *FortigateSource) initVIPs(ctx context.Context, c *FortiClient) error {
+	res, err := c.MakeRequest(ctx, http.MethodGet, "cmdb/firewall/vip/", nil)
+	if err != nil {
+		return fmt.Errorf("request error: %s", err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("body read error: %s", err)
+	}
+	var vipResponse APIResponse[[]VIPResponse]
+	err = json.Unmarshal(body, &vipResponse)
+	if err != nil {
+		return fmt.Errorf("body unmarshal error: %s", err)
+	}
+
+	if vipResponse.HTTPStatus != http.StatusOK {
+		return fmt.Errorf("got http status: %d", vipResponse.HTTPStatus)
+	}
+
+	fs.VIPs = vipResponse.Results
+	return nil
+}
+
+// initBGP collects bgp configuration (local as and neighbors) from the fortigate.
+func (fs *FortigateSource) initBGP(ctx context.Context, c *FortiClient) error {
+	res, err := c.MakeRequest(ctx, http.MethodGet, "cmdb/router/bgp/", nil)
+	if err != nil {
+		return fmt.Errorf("request error: %s", err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("body read error: %s", err)
+	}
+	var bgpResponse APIResponse[BGPResponse]
+	err = json.Unmarshal(body, &bgpResponse)
+	if err != nil {
+		return fmt.Errorf("body unmarshal error: %s", err)
+	}
+
+	if bgpResponse.HTTPStatus != http.StatusOK {
+		return fmt.Errorf("got http status: %d", bgpResponse.HTTPStatus)
+	}
+
+	fs.BGP = bgpResponse.Results
+	return nil
+}
+
+// initLocalContextData collects dns, ntp and syslog servers from the fortigate,
+// which are written to local context data of the firewall.
+func (fs *FortigateSource) initLocalContextData(ctx context.Context, c *FortiClient) error {
+	if !fs.SourceConfig.CollectLocalContext {
+		return nil
+	}
+	dns, err := getResults[DNSResponse](ctx, c, "cmdb/system/dns/")
+	if err != nil {
+		return fmt.Errorf("dns settings: %s", err)
+	}
+	ntp, err := getResults[NTPResponse](ctx, c, "cmdb/system/ntp/")
+	if err != nil {
+		return fmt.Errorf("ntp settings: %s", err)
+	}
+	syslog, err := getResults[SyslogSettingResponse](ctx, c, "cmdb/log.syslogd/setting/")
+	if err != nil {
+		return fmt.Errorf("syslog settings: %s", err)
+	}
+
+	dnsServers := make([]string, 0)
+	for _, server := range []string{dns.Primary, dns.Secondary} {
+		if server != "" && server != "0.0.0.0" {
+			dnsServers = append(dnsServers, server)
+		}
+	}
+	ntpServers := make([]string, 0, len(ntp.NTPServers))
+	// When type is fortiguard, fortigate uses fortiguard ntp servers,
+	// that are not listed in the configuration.
+	if ntp.Type == "custom" {
+		for _, ntpServer := range ntp.NTPServers {
+			ntpServers = append(ntpServers, ntpServer.Server)
+		}
+	}
+	syslogServers := make([]string, 0)
+	if syslog.Status == "enable" && syslog.Server != "" {
+		syslogServers = append(syslogServers, syslog.Server)
+	}
+	fs.LocalContextData = map[string]interface{}{
+		constants.LocalContextKeyDNSServers:    dnsServers,
+		constants.LocalContextKeyNTPServers:    ntpServers,
+		constants.LocalContextKeySyslogServers: syslogServers,
+	}
+	return nil
+}
+
+// initIPsecTunnels collects route based ipsec vpns (phase1 and phase2 interfaces)
+// from the fortigate.
+func (fs *FortigateSource) initIPsecTunnels(ctx context.Context, c *FortiClient) error {
+	phase1Interfaces, err := getResults[[]IPsecPhase1Response](ctx, c, "cmdb/vpn.ipsec/phase1-interface/")
+	if err != nil {
+		return fmt.Errorf("ipsec phase1 interfaces: %s", err)
+	}
+	phase2Interfaces, err := getResults[[]IPsecPhase2Response](ctx, c, "cmdb/vpn.ipsec/phase2-interface/")
+	if err != nil {
+		return fmt.Errorf("ipsec phase2 interfaces: %s", err)
+	}
+	fs.IPsecPhase1 = phase1Interfaces
+	fs.IPsecPhase2 = make(map[string][]IPsecPhase2Response)
+	for _, phase2 := range phase2Interfaces {
+		fs.IPsecPhase2[phase2.Phase1Name] = append(fs.IPsecPhase2[phase2.Phase1Name], phase2)
+	}
+	return nil
+}
+
+// getResults makes get request to the given api path of the fortigate
+// and returns results of the response.
+func getResults[T any](ctx context.Context, c *FortiClient, path string) (T, error) {
+	var response APIResponse[T]
+	res, err := c.MakeRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return response.Results, fmt.Errorf("request error: %s", err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return response.Results, fmt.Errorf("body read error: %s", err)
+	}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return response.Results, fmt.Errorf("body unmarshal error: %s", err)
+	}
+	if response.HTTPStatus != http.StatusOK {
+		return response.Results, fmt.Errorf("got http status: %d", response.HTTPStatus)
+	}
+	return response.Results, nil
+}
@@ -17,6 +17,13 @@ func (fs *FortigateSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	if deviceName == "" {
 		return fmt.Errorf("can't extract hostname from system info")
 	}
+	filtered, err := fs.IsFiltered("device", utils.RelationAttributes{Name: deviceName})
+	if err != nil {
+		return fmt.Errorf("filter device: %s", err)
+	}
+	if filtered {
+		return common.ErrDeviceDropped
+	}
 	var deviceSerialNumber string
 	if !fs.SourceConfig.IgnoreSerialNumbers {
 		deviceSerialNumber = fs.SystemInfo.Serial
@@ -25,7 +32,7 @@ func (fs *FortigateSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	deviceModel := fs.SystemInfo.Hostname
 	if deviceModel == "" {
 		fs.Logger.Warningf(fs.Ctx, "model field in system info is empty. Using fallback mechanism.")
-		deviceModel = constants.DefaultModel
+		deviceModel = fs.SourceConfig.Defaults.DeviceType.Name
 	}
 	deviceManufacturer, err := nbi.AddManufacturer(fs.Ctx, &objects.Manufacturer{
 		Name: "Fortinet",
@@ -46,27 +53,27 @@ func (fs *FortigateSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	deviceTenant, err := common.MatchHostToTenant(
 		fs.Ctx,
 		nbi,
-		deviceName,
-		fs.SourceConfig.HostTenantRelations,
+		utils.RelationAttributes{Name: deviceName},
+		fs.SourceConfig,
 	)
 	if err != nil {
 		return fmt.Errorf("match host to tenant: %s", err)
 	}
 
 	var deviceRole *objects.DeviceRole
-	if len(fs.SourceConfig.HostRoleRelations) > 0 {
+	if len(fs.SourceConfig.HostRoleRelations) > 0 || len(fs.SourceConfig.RelationRules.HostRoleRelations) > 0 {
 		deviceRole, err = common.MatchHostToRole(
 			fs.Ctx,
 			nbi,
-			deviceName,
-			fs.SourceConfig.HostRoleRelations,
+			utils.RelationAttributes{Name: deviceName},
+			fs.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match host to role: %s", err)
 		}
 	}
 	if deviceRole == nil {
-		deviceRole, err = nbi.AddFirewallDeviceRole(fs.Ctx)
+		deviceRole, err = nbi.AddFirewallDeviceRole(fs.Ctx, fs.SourceConfig.Defaults.Roles)
 		if err != nil {
 			return fmt.Errorf("add DeviceRole firewall: %s", err)
 		}
@@ -74,8 +81,8 @@ func (fs *FortigateSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	deviceSite, err := common.MatchHostToSite(
 		fs.Ctx,
 		nbi,
-		deviceName,
-		fs.SourceConfig.HostSiteRelations,
+		utils.RelationAttributes{Name: deviceName},
+		fs.SourceConfig,
 	)
 	if err != nil {
 		return fmt.Errorf("match host to site: %s", err)
@@ -89,19 +96,31 @@ func (fs *FortigateSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	if err != nil {
 		return fmt.Errorf("add platform: %s", err)
 	}
-	NBDevice, err := nbi.AddDevice(fs.Ctx, &objects.Device{
+	deviceStruct := &objects.Device{
 		NetboxObject: objects.NetboxObject{
 			Tags: fs.GetSourceTags(),
 		},
-		Name:         deviceName,
-		Site:         deviceSite,
-		DeviceRole:   deviceRole,
-		Status:       &objects.DeviceStatusActive,
-		DeviceType:   deviceType,
-		Tenant:       deviceTenant,
-		Platform:     devicePlatform,
-		SerialNumber: deviceSerialNumber,
-	})
+		Name:             deviceName,
+		Site:             deviceSite,
+		DeviceRole:       deviceRole,
+		Status:           &objects.DeviceStatusActive,
+		DeviceType:       deviceType,
+		Tenant:           deviceTenant,
+		Platform:         devicePlatform,
+		SerialNumber:     deviceSerialNumber,
+		LocalContextData: common.FilterLocalContextData(fs.LocalContextData, fs.SourceConfig),
+	}
+	if err := common.MatchDeviceToRack(fs.Ctx, nbi, deviceStruct, fs.SourceConfig); err != nil {
+		fs.Logger.Warningf(fs.Ctx, "device %s rack placement: %s", deviceStruct.Name, err)
+	}
+	keep, err := common.TransformDevice(fs.Ctx, nbi, fs.SourceConfig, deviceStruct, fs.GetSourceTags())
+	if err != nil {
+		return err
+	}
+	if !keep {
+		return common.ErrDeviceDropped
+	}
+	NBDevice, err := nbi.AddDevice(fs.Ctx, deviceStruct)
 	if err != nil {
 		return fmt.Errorf("add device: %s", err)
 	}
@@ -112,6 +131,10 @@ func (fs *FortigateSource) syncDevice(nbi *inventory.NetboxInventory) error {
 
 // syncInterfaces syncs all interfaces for firewall.
 func (fs *FortigateSource) syncInterfaces(nbi *inventory.NetboxInventory) error {
+	// Address the firewall is reached on is preferred as its primary ip
+	sourceIP := utils.Lookup(fs.SourceConfig.Hostname)
+	ipAddresses := []*objects.IPAddress{}
+	preferredIPAddresses := []*objects.IPAddress{}
 	for _, iface := range fs.Ifaces {
 		switch iface.Type {
 		case "loopback":
@@ -160,6 +183,10 @@ func (fs *FortigateSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 			}
 			vdcs = append(vdcs, vdom)
 		}
+		ifaceVRF, err := fs.getVRF(nbi, iface)
+		if err != nil {
+			return fmt.Errorf("get vrf: %s", err)
+		}
 		NBIface, err := nbi.AddInterface(fs.Ctx, &objects.Interface{
 			NetboxObject: objects.NetboxObject{
 				Tags:        fs.GetSourceTags(),
@@ -171,6 +198,7 @@ func (fs *FortigateSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 			MTU:    interfaceMTU,
 			Status: interfaceStatus,
 			Vdcs:   vdcs,
+			VRF:    ifaceVRF,
 		})
 		if err != nil {
 			return fmt.Errorf("add interface: %s", err)
@@ -193,16 +221,29 @@ func (fs *FortigateSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 		if err != nil {
 			return fmt.Errorf("sync interface ips: %s", err)
 		}
+		if NBIPAddress != nil {
+			ipAddresses = append(ipAddresses, NBIPAddress)
+			if sourceIP != "" && strings.Split(NBIPAddress.Address, "/")[0] == sourceIP {
+				preferredIPAddresses = append(preferredIPAddresses, NBIPAddress)
+			}
+		}
 
 		if iface.Type == "vlan" {
 			// Add Vlan for interface
 			vlanID := iface.VlanID
 			vlanName := fmt.Sprintf("Vlan%d", vlanID)
+			vlanFiltered, err := fs.IsFiltered("vlan", utils.RelationAttributes{Name: vlanName})
+			if err != nil {
+				return fmt.Errorf("filter vlan: %s", err)
+			}
+			if vlanFiltered {
+				continue
+			}
 			vlanSite, err := common.MatchVlanToSite(
 				fs.Ctx,
 				nbi,
-				vlanName,
-				fs.SourceConfig.VlanSiteRelations,
+				utils.RelationAttributes{Name: vlanName},
+				fs.SourceConfig,
 			)
 			if err != nil {
 				return fmt.Errorf("match vlan to site: %s", err)
@@ -210,10 +251,9 @@ func (fs *FortigateSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 			vlanGroup, err := common.MatchVlanToGroup(
 				fs.Ctx,
 				nbi,
-				vlanName,
+				utils.RelationAttributes{Name: vlanName},
 				vlanSite,
-				fs.SourceConfig.VlanGroupRelations,
-				fs.SourceConfig.VlanGroupSiteRelations,
+				fs.SourceConfig,
 			)
 			if err != nil {
 				return fmt.Errorf("match vlan to group: %s", err)
@@ -221,8 +261,8 @@ func (fs *FortigateSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 			vlanTenant, err := common.MatchVlanToTenant(
 				fs.Ctx,
 				nbi,
-				vlanName,
-				fs.SourceConfig.VlanTenantRelations,
+				utils.RelationAttributes{Name: vlanName},
+				fs.SourceConfig,
 			)
 			if err != nil {
 				return fmt.Errorf("match vlan to tenant: %s", err)
@@ -260,6 +300,7 @@ func (fs *FortigateSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 						Vlan:      NBVlan,
 						ScopeID:   scopeID,
 						ScopeType: scopeType,
+						VRF:       NBIface.VRF,
 					})
 					if err != nil {
 						return fmt.Errorf("add prefix: %s", err)
@@ -268,9 +309,53 @@ func (fs *FortigateSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 			}
 		}
 	}
+	err := common.SetPrimaryIPAddresses(
+		fs.Ctx,
+		nbi,
+		fs.NBFirewall,
+		ipAddresses,
+		preferredIPAddresses,
+		fs.SourceConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("set primary ip addresses: %s", err)
+	}
 	return nil
 }
 
+// getVRF returns the VRF for the given interface, based on its vdom and vrf id.
+// Interfaces in the default vrf of the root vdom belong to the global table,
+// in that case nil is returned.
+func (fs *FortigateSource) getVRF(
+	nbi *inventory.NetboxInventory,
+	iface InterfaceResponse,
+) (*objects.VRF, error) {
+	vdom := iface.Vdom
+	if vdom == "" {
+		vdom = rootVdomName
+	}
+	if vdom == rootVdomName && iface.VRF == 0 {
+		return nil, nil
+	}
+	vrfName := vdom
+	if iface.VRF != 0 {
+		vrfName = fmt.Sprintf("%s-vrf-%d", vdom, iface.VRF)
+	}
+	vrfStruct := &objects.VRF{
+		NetboxObject: objects.NetboxObject{
+			Tags:        fs.GetSourceTags(),
+			Description: fmt.Sprintf("Vdom %s vrf %d", vdom, iface.VRF),
+		},
+		Name:   common.DeviceVRFName(fs.NBFirewall.Name, vrfName),
+		Tenant: fs.NBFirewall.Tenant,
+	}
+	nbVRF, err := nbi.AddVRF(fs.Ctx, vrfStruct)
+	if err != nil {
+		return nil, fmt.Errorf("add vrf %+v: %s", vrfStruct, err)
+	}
+	return nbVRF, nil
+}
+
 // syncInterfaceIPs is a helper function for syncInterfaces.
 // it synces IPs for an interface.
 func syncInterfaceIPs(
@@ -301,6 +386,7 @@ func syncInterfaceIPs(
 				Address:            fmt.Sprintf("%s/%d", ipAndMask[0], maskBits),
 				AssignedObjectType: constants.ContentTypeDcimInterface,
 				AssignedObjectID:   nbIface.ID,
+				VRF:                nbIface.VRF,
 			})
 			if err != nil {
 				return nil, fmt.Errorf("add ip address: %s", err)
@@ -330,6 +416,7 @@ func syncInterfaceIPs(
 						Address:            fmt.Sprintf("%s/%d", ipAndMask[0], maskBits),
 						AssignedObjectType: constants.ContentTypeDcimInterface,
 						AssignedObjectID:   nbIface.ID,
+						VRF:                nbIface.VRF,
 					})
 					if err != nil {
 						fs.Logger.Warningf(fs.Ctx, "add secondary ip address: %s", err)
@@ -339,37 +426,257 @@ func syncInterfaceIPs(
 		}
 	}
 
-	if len(iface.VRRPIP) > 0 {
-		for _, vrrp := range iface.VRRPIP {
-			ipAndMask := []string{vrrp.VRIP, "255.255.255.255"}
-			if len(ipAndMask) == 2 && ipAndMask[0] != constants.WildcardIP {
-				if utils.IsPermittedIPAddress(
-					ipAndMask[0],
-					fs.SourceConfig.PermittedSubnets,
-					fs.SourceConfig.IgnoredSubnets,
-				) {
-					maskBits, err := utils.MaskToBits(ipAndMask[1])
-					if err != nil {
-						return nil, fmt.Errorf("mask to bits: %s", err)
-					}
-					_, err = nbi.AddIPAddress(fs.Ctx, &objects.IPAddress{
-						NetboxObject: objects.NetboxObject{
-							Tags: fs.GetSourceTags(),
-							CustomFields: map[string]interface{}{
-								constants.CustomFieldArpEntryName: false,
-							},
-						},
-						Address:            fmt.Sprintf("%s/%d", ipAndMask[0], maskBits),
-						AssignedObjectType: constants.ContentTypeDcimInterface,
-						AssignedObjectID:   nbIface.ID,
-						Role:               &objects.IPAddressRoleVRRP,
-					})
-					if err != nil {
-						fs.Logger.Warningf(fs.Ctx, "add VRRP ip address: %s", err)
-					}
-				}
-			}
+	for _, vrrp := range iface.VRRPIP {
+		if vrrp.VRIP == "" || vrrp.VRIP == constants.WildcardIP {
+			continue
+		}
+		if !utils.IsPermittedIPAddress(
+			vrrp.VRIP,
+			fs.SourceConfig.PermittedSubnets,
+			fs.SourceConfig.IgnoredSubnets,
+		) {
+			continue
+		}
+		protocol := &objects.FHRPGroupProtocolVRRP2
+		if vrrp.Version == "3" {
+			protocol = &objects.FHRPGroupProtocolVRRP3
+		}
+		ifaceAddresses := []string{}
+		if NBIPAddress != nil {
+			ifaceAddresses = append(ifaceAddresses, NBIPAddress.Address)
+		}
+		maskBits := utils.GetMaskForIPAddress(vrrp.VRIP, ifaceAddresses)
+		err := common.AddFHRPVirtualIP(fs.Ctx, nbi, nbIface, common.FHRPVirtualIP{
+			Protocol: protocol,
+			GroupID:  vrrp.VRID,
+			Address:  fmt.Sprintf("%s/%d", vrrp.VRIP, maskBits),
+			Priority: vrrp.Priority,
+		}, fs.GetSourceTags())
+		if err != nil {
+			fs.Logger.Warningf(fs.Ctx, "add VRRP virtual ip %s: %s", vrrp.VRIP, err)
 		}
 	}
 	return NBIPAddress, nil
 }
+
+// syncDHCPServers syncs ip ranges of all enabled dhcp servers as ip ranges in netbox.
+// Each ip range is linked with the VRF of the interface the dhcp server is serving.
+func (fs *FortigateSource) syncDHCPServers(nbi *inventory.NetboxInventory) error {
+	for _, dhcpServer := range fs.DHCPServers {
+		if dhcpServer.Status == "disable" {
+			continue
+		}
+		maskBits, err := utils.MaskToBits(dhcpServer.Netmask)
+		if err != nil {
+			fs.Logger.Warningf(fs.Ctx, "dhcp server %d mask to bits: %s", dhcpServer.ID, err)
+			continue
+		}
+		var rangeVRF *objects.VRF
+		if nbIface, ok := nbi.GetInterface(dhcpServer.Interface, fs.NBFirewall.ID); ok {
+			rangeVRF = nbIface.VRF
+		}
+		for _, ipRange := range dhcpServer.IPRanges {
+			if !utils.IsPermittedIPAddress(
+				ipRange.StartIP,
+				fs.SourceConfig.PermittedSubnets,
+				fs.SourceConfig.IgnoredSubnets,
+			) {
+				continue
+			}
+			ipRangeStruct := &objects.IPRange{
+				NetboxObject: objects.NetboxObject{
+					Tags: fs.GetSourceTags(),
+					Description: fmt.Sprintf(
+						"DHCP pool on %s interface %s",
+						fs.NBFirewall.Name,
+						dhcpServer.Interface,
+					),
+				},
+				StartAddress: fmt.Sprintf("%s/%d", ipRange.StartIP, maskBits),
+				EndAddress:   fmt.Sprintf("%s/%d", ipRange.EndIP, maskBits),
+				Status:       &objects.IPRangeStatusActive,
+				VRF:          rangeVRF,
+				Tenant:       fs.NBFirewall.Tenant,
+			}
+			if _, err := nbi.AddIPRange(fs.Ctx, ipRangeStruct); err != nil {
+				return fmt.Errorf("add ip range %+v: %s", ipRangeStruct, err)
+			}
+		}
+	}
+	return nil
+}
+
+// syncVIPs syncs port forwarding virtual ips as services. Each virtual ip is added
+// as a service of the firewall with external ports, and as a service of the internal host
+// with mapped ports, if the mapped ip is assigned to a device or a vm in the inventory.
+func (fs *FortigateSource) syncVIPs(nbi *inventory.NetboxInventory) error {
+	for _, vip := range fs.VIPs {
+		if vip.PortForward != "enable" {
+			continue
+		}
+		protocol, ok := objects.ServiceProtocols[vip.Protocol]
+		if !ok {
+			fs.Logger.Debugf(fs.Ctx, "vip %s has unsupported protocol %s. Skipping...", vip.Name, vip.Protocol)
+			continue
+		}
+		extPorts, err := utils.ParsePorts(vip.ExtPort)
+		if err != nil {
+			fs.Logger.Warningf(fs.Ctx, "vip %s external ports: %s", vip.Name, err)
+			continue
+		}
+		mappedPorts := extPorts
+		if vip.MappedPort != "" {
+			mappedPorts, err = utils.ParsePorts(vip.MappedPort)
+			if err != nil {
+				fs.Logger.Warningf(fs.Ctx, "vip %s mapped ports: %s", vip.Name, err)
+				continue
+			}
+		}
+		_, err = nbi.AddService(fs.Ctx, &objects.Service{
+			NetboxObject: objects.NetboxObject{
+				Tags:        fs.GetSourceTags(),
+				Description: fmt.Sprintf("VIP %s:%s", vip.ExtIP, vip.ExtPort),
+			},
+			Device:   fs.NBFirewall,
+			Name:     vip.Name,
+			Protocol: protocol,
+			Ports:    extPorts,
+		})
+		if err != nil {
+			return fmt.Errorf("add service for vip %s: %s", vip.Name, err)
+		}
+		for _, mappedIP := range vip.MappedIPs {
+			// Mapped ip ranges can't be mapped to a single host
+			if strings.Contains(mappedIP.Range, "-") {
+				continue
+			}
+			// Firewall vrfs are scoped to the firewall, so published hosts are in the global table
+			_, err := common.AddServiceForIPAddress(fs.Ctx, nbi, nil, mappedIP.Range, &objects.Service{
+				NetboxObject: objects.NetboxObject{
+					Tags: fs.GetSourceTags(),
+					Description: fmt.Sprintf(
+						"Published by %s VIP %s:%s",
+						fs.NBFirewall.Name,
+						vip.ExtIP,
+						vip.ExtPort,
+					),
+				},
+				Name:     vip.Name,
+				Protocol: protocol,
+				Ports:    mappedPorts,
+			})
+			if err != nil {
+				fs.Logger.Warningf(fs.Ctx, "add service for vip %s mapped ip %s: %s", vip.Name, mappedIP.Range, err)
+			}
+		}
+	}
+	return nil
+}
+
+// syncBGP syncs ASNs and bgp neighbors of the fortigate.
+func (fs *FortigateSource) syncBGP(nbi *inventory.NetboxInventory) error {
+	if fs.BGP.AS == "" || fs.BGP.AS == "0" {
+		return nil
+	}
+	localASN, err := utils.ParseASN(string(fs.BGP.AS))
+	if err != nil {
+		fs.Logger.Warningf(fs.Ctx, "bgp local as: %s", err)
+		return nil
+	}
+	sessions := make([]common.BGPSession, 0, len(fs.BGP.Neighbors))
+	for _, neighbor := range fs.BGP.Neighbors {
+		remoteASN, err := utils.ParseASN(string(neighbor.RemoteAS))
+		if err != nil {
+			fs.Logger.Warningf(fs.Ctx, "bgp neighbor %s remote as: %s", neighbor.IP, err)
+			continue
+		}
+		var localAddress string
+		if iface, ok := fs.Ifaces[neighbor.UpdateSource]; ok {
+			localAddress, _, _ = strings.Cut(iface.IP, " ")
+			if localAddress == "0.0.0.0" {
+				localAddress = ""
+			}
+		}
+		sessions = append(sessions, common.BGPSession{
+			LocalASN:      localASN,
+			LocalAddress:  localAddress,
+			RemoteASN:     remoteASN,
+			RemoteAddress: neighbor.IP,
+			Name:          neighbor.Description,
+		})
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+	err = common.AddBGPSessions(fs.Ctx, nbi, fs.NBFirewall, sessions, fs.GetSourceTags())
+	if err != nil {
+		fs.Logger.Warningf(fs.Ctx, "add bgp sessions: %s", err)
+	}
+	return nil
+}
+
+// syncIPsecTunnels syncs route based ipsec vpns of the firewall as tunnels,
+// which are terminated on the tunnel interfaces created by phase1 interfaces.
+func (fs *FortigateSource) syncIPsecTunnels(nbi *inventory.NetboxInventory) error {
+	for _, phase1 := range fs.IPsecPhase1 {
+		nbIface, ok := nbi.GetInterface(phase1.Name, fs.NBFirewall.ID)
+		if !ok {
+			fs.Logger.Debugf(fs.Ctx, "tunnel interface %s is not synced. Skipping...", phase1.Name)
+			continue
+		}
+		var outsideVRF *objects.VRF
+		if outsideIface, ok := nbi.GetInterface(phase1.Interface, fs.NBFirewall.ID); ok {
+			outsideVRF = outsideIface.VRF
+		}
+		outsideAddress := phase1.LocalGW
+		if outsideAddress == "" || outsideAddress == constants.WildcardIP {
+			outsideAddress, _, _ = strings.Cut(fs.Ifaces[phase1.Interface].IP, " ")
+		}
+		if outsideAddress == constants.WildcardIP {
+			outsideAddress = ""
+		}
+		comments := []string{}
+		if phase1.Type == "static" && phase1.RemoteGW != constants.WildcardIP {
+			comments = append(comments, fmt.Sprintf("Remote gateway: %s", phase1.RemoteGW))
+		} else {
+			comments = append(comments, fmt.Sprintf("Remote gateway: %s", phase1.Type))
+		}
+		for _, phase2 := range fs.IPsecPhase2[phase1.Name] {
+			comments = append(comments, fmt.Sprintf(
+				"Phase2 %s: %s -> %s",
+				phase2.Name,
+				formatSubnet(phase2.SrcSubnet),
+				formatSubnet(phase2.DstSubnet),
+			))
+		}
+		_, err := common.AddIPsecTunnel(fs.Ctx, nbi, common.IPsecTunnel{
+			Name:        fmt.Sprintf("%s-%s", fs.NBFirewall.Name, phase1.Name),
+			Group:       fs.SourceConfig.Name,
+			Disabled:    fs.Ifaces[phase1.Name].Status == "down",
+			Description: phase1.Comments,
+			Comments:    strings.Join(comments, "\n"),
+			Tenant:      fs.NBFirewall.Tenant,
+			Endpoints: []common.IPsecTunnelEndpoint{
+				{Interface: nbIface, OutsideAddress: outsideAddress, OutsideVRF: outsideVRF},
+			},
+		}, fs.GetSourceTags())
+		if err != nil {
+			fs.Logger.Warningf(fs.Ctx, "add ipsec tunnel %s: %s", phase1.Name, err)
+		}
+	}
+	return nil
+}
+
+// formatSubnet converts fortigate subnet ("10.0.0.0 255.255.255.0")
+// to cidr notation (10.0.0.0/24). Subnets in other formats are returned unchanged.
+func formatSubnet(subnet string) string {
+	address, mask, ok := strings.Cut(subnet, " ")
+	if !ok {
+		return subnet
+	}
+	maskBits, err := utils.MaskToBits(mask)
+	if err != nil {
+		return subnet
+	}
+	return fmt.Sprintf("%s/%d", address, maskBits)
+}
@@ -1,6 +1,7 @@
 package iosxe
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,6 +13,19 @@ import (
 	"github.com/scrapli/scrapligo/driver/options"
 )
 
+// defaultFHRPPriority is the default HSRP and VRRP priority of an interface.
+const defaultFHRPPriority = 100
+
+// defaultBGPVrfName is the vrf name of bgp neighbors in the global routing table.
+const defaultBGPVrfName = "default"
+
+// managementVRFs are vrfs of dedicated management ports (e.g. GigabitEthernet0/0).
+// Interfaces in these vrfs are synced as out-of-band management interfaces.
+var managementVRFs = map[string]bool{
+	"Mgmt-vrf":  true, // Catalyst switches
+	"Mgmt-intf": true, // ASR and ISR routers
+}
+
 //nolint:revive
 type IOSXESource struct {
 	common.Config
@@ -21,10 +35,26 @@ type IOSXESource struct {
 	SystemInfo   systemReply
 	Interfaces   map[string]iface
 	ArpEntries   []arpEntry
+	VRFs         map[string]vrfDefinition // vrfName -> vrfDefinition
+	Iface2VRF    map[string]string        // interfaceName -> vrfName
+	DHCPPools    []dhcpPool
+	// FHRPInterfaces are interfaces with HSRP or VRRP groups (interfaceName -> nativeInterface).
+	FHRPInterfaces map[string]nativeInterface
+	// MgmtInterfaces are interfaces in the management vrf (interfaceName -> nativeInterface).
+	MgmtInterfaces map[string]nativeInterface
+	// ConsoleLines are console lines of the device (e.g. con 0).
+	ConsoleLines []consoleLine
+	// BGPNeighbors are bgp neighbors of all vrfs (vrfName + neighborID -> bgpNeighbor).
+	BGPNeighbors map[string]bgpNeighbor
+	// BGPLocalASNs are local ASNs of bgp instances (vrfName -> localASN).
+	BGPLocalASNs map[string]int64
+	// PowerSensors are environment sensors measuring power in watts.
+	PowerSensors []environmentSensor
 
 	// IOSXE synced data. Created in sync functions.
 	NBDevice     *objects.Device
 	NBInterfaces map[string]*objects.Interface // interfaceName -> netboxInterface
+	NBVRFs       map[string]*objects.VRF       // vrfName -> netboxVRF
 }
 
 func (is *IOSXESource) Init() error {
@@ -52,6 +82,12 @@ func (is *IOSXESource) Init() error {
 		is.initDeviceHardwareInfo,
 		is.initInterfaces,
 		is.initArpData,
+		is.initVRFs,
+		is.initDHCPPools,
+		is.initNativeInterfaces,
+		is.initBGPNeighbors,
+		is.initPowerSensors,
+		is.initConsoleLines,
 	}
 
 	for _, initFunc := range initFunctions {
@@ -73,13 +109,24 @@ func (is *IOSXESource) Init() error {
 func (is *IOSXESource) Sync(nbi *inventory.NetboxInventory) error {
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		is.syncDevice,
+		is.syncHardwareInventory,
+		is.syncPowerSupplies,
+		is.syncConsolePorts,
+		is.syncVRFs,
 		is.syncInterfaces,
+		is.syncDHCPPools,
+		is.syncFHRPGroups,
+		is.syncBGPNeighbors,
 		is.syncArpTable,
 	}
 
 	for _, syncFunc := range syncFunctions {
 		startTime := time.Now()
 		err := syncFunc(nbi)
+		if errors.Is(err, common.ErrDeviceDropped) {
+			is.Logger.Info(is.Ctx, "Device is filtered out or dropped by a transform, skipping other objects")
+			return nil
+		}
 		if err != nil {
 			return err
 		}
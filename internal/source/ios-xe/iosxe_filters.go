@@ -36,3 +36,67 @@ const interfaceFilter = `<interfaces xmlns="http://openconfig.net/yang/interface
   </interfaces>`
 
 const arpFilter = `<arp-data xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-arp-oper"/>`
+
+const vrfFilter = `<native xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-native">
+  <vrf>
+    <definition/>
+  </vrf>
+</native>`
+
+const networkInstanceFilter = `<network-instances xmlns="http://openconfig.net/yang/network-instance">
+  <network-instance>
+    <name/>
+    <interfaces/>
+  </network-instance>
+</network-instances>`
+
+const dhcpPoolFilter = `<native xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-native">
+  <ip>
+    <dhcp>
+      <pool/>
+    </dhcp>
+  </ip>
+</native>`
+
+const nativeInterfaceFilter = `<native xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-native">
+  <interface/>
+</native>`
+
+const bgpStateFilter = `<bgp-state-data xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-bgp-oper">
+  <neighbors>
+    <neighbor>
+      <afi-safi/>
+      <vrf-name/>
+      <neighbor-id/>
+      <description/>
+      <as/>
+      <transport>
+        <local-host/>
+      </transport>
+    </neighbor>
+  </neighbors>
+  <address-families>
+    <address-family>
+      <afi-safi/>
+      <vrf-name/>
+      <local-as/>
+    </address-family>
+  </address-families>
+</bgp-state-data>`
+
+const environmentFilter = `<environment-sensors xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-environment-oper">
+  <environment-sensor>
+    <name/>
+    <location/>
+    <current-reading/>
+    <sensor-units/>
+  </environment-sensor>
+</environment-sensors>`
+
+const consoleLineFilter = `<native xmlns="http://cisco.com/ns/yang/Cisco-IOS-XE-native">
+  <line>
+    <console>
+      <first/>
+    </console>
+  </line>
+</native>`
@@ -3,6 +3,7 @@ package iosxe
 import (
 	"encoding/xml"
 	"fmt"
+	"strings"
 
 	"github.com/scrapli/scrapligo/driver/netconf"
 )
@@ -60,7 +61,153 @@ func (is *IOSXESource) initArpData(d *netconf.Driver) error {
 	}
 	is.ArpEntries = make([]arpEntry, 0)
 	for _, arpVrf := range arpReply.ArpVrf {
-		is.ArpEntries = append(is.ArpEntries, arpVrf.ArpOper...)
+		for _, entry := range arpVrf.ArpOper {
+			entry.Vrf = arpVrf.Vrf
+			is.ArpEntries = append(is.ArpEntries, entry)
+		}
 	}
 	return nil
 }
+
+// initVRFs collects vrf definitions from native configuration and
+// interface membership from openconfig network instances.
+func (is *IOSXESource) initVRFs(d *netconf.Driver) error {
+	var vrfReply vrfReply
+	r, err := d.Get(vrfFilter)
+	if err != nil {
+		return fmt.Errorf("error with vrf filter: %s", err)
+	}
+	err = xml.Unmarshal(r.RawResult, &vrfReply)
+	if err != nil {
+		return fmt.Errorf("error with unmarshaling vrf reply: %s", err)
+	}
+	is.VRFs = make(map[string]vrfDefinition, len(vrfReply.VRFs))
+	for _, vrf := range vrfReply.VRFs {
+		is.VRFs[vrf.Name] = vrf
+	}
+
+	var networkInstanceReply networkInstanceReply
+	r, err = d.Get(networkInstanceFilter)
+	if err != nil {
+		return fmt.Errorf("error with network instance filter: %s", err)
+	}
+	err = xml.Unmarshal(r.RawResult, &networkInstanceReply)
+	if err != nil {
+		return fmt.Errorf("error with unmarshaling network instance reply: %s", err)
+	}
+	is.Iface2VRF = make(map[string]string)
+	for _, networkInstance := range networkInstanceReply.NetworkInstances {
+		// Interfaces in the default network instance belong to the global table.
+		if _, ok := is.VRFs[networkInstance.Name]; !ok {
+			continue
+		}
+		for _, ifaceName := range networkInstance.Interfaces {
+			is.Iface2VRF[ifaceName] = networkInstance.Name
+		}
+	}
+	return nil
+}
+
+func (is *IOSXESource) initDHCPPools(d *netconf.Driver) error {
+	var dhcpPoolReply dhcpPoolReply
+	r, err := d.Get(dhcpPoolFilter)
+	if err != nil {
+		return fmt.Errorf("error with dhcp pool filter: %s", err)
+	}
+	err = xml.Unmarshal(r.RawResult, &dhcpPoolReply)
+	if err != nil {
+		return fmt.Errorf("error with unmarshaling dhcp pool reply: %s", err)
+	}
+	is.DHCPPools = dhcpPoolReply.Pools
+	return nil
+}
+
+// initNativeInterfaces collects HSRP and VRRP groups configured on interfaces
+// and ip addresses of management interfaces from native configuration.
+func (is *IOSXESource) initNativeInterfaces(d *netconf.Driver) error {
+	var nativeIfaceReply nativeInterfaceReply
+	r, err := d.Get(nativeInterfaceFilter)
+	if err != nil {
+		return fmt.Errorf("error with native interface filter: %s", err)
+	}
+	err = xml.Unmarshal(r.RawResult, &nativeIfaceReply)
+	if err != nil {
+		return fmt.Errorf("error with unmarshaling native interface reply: %s", err)
+	}
+	is.FHRPInterfaces = make(map[string]nativeInterface)
+	is.MgmtInterfaces = make(map[string]nativeInterface)
+	for _, nativeIface := range nativeIfaceReply.Interfaces.Entries {
+		ifaceName := nativeIface.XMLName.Local + nativeIface.Name
+		if managementVRFs[is.Iface2VRF[ifaceName]] {
+			is.MgmtInterfaces[ifaceName] = nativeIface
+		}
+		if len(nativeIface.HSRPGroups) == 0 && len(nativeIface.VRRPGroups) == 0 {
+			continue
+		}
+		is.FHRPInterfaces[ifaceName] = nativeIface
+	}
+	return nil
+}
+
+// initBGPNeighbors collects bgp neighbors and local ASNs of all vrfs
+// from the bgp operational data.
+func (is *IOSXESource) initBGPNeighbors(d *netconf.Driver) error {
+	var bgpReply bgpStateReply
+	r, err := d.Get(bgpStateFilter)
+	if err != nil {
+		return fmt.Errorf("error with bgp state filter: %s", err)
+	}
+	err = xml.Unmarshal(r.RawResult, &bgpReply)
+	if err != nil {
+		return fmt.Errorf("error with unmarshaling bgp state reply: %s", err)
+	}
+	is.BGPLocalASNs = make(map[string]int64)
+	for _, addressFamily := range bgpReply.AddressFamilies {
+		if addressFamily.LocalAS != 0 {
+			is.BGPLocalASNs[addressFamily.VrfName] = addressFamily.LocalAS
+		}
+	}
+	// Neighbors are listed once per address family, so we deduplicate them
+	is.BGPNeighbors = make(map[string]bgpNeighbor)
+	for _, neighbor := range bgpReply.Neighbors {
+		is.BGPNeighbors[neighbor.VrfName+neighbor.NeighborID] = neighbor
+	}
+	return nil
+}
+
+// initPowerSensors collects power sensors (in watts) from the environment operational data.
+// Environment data is not supported on all platforms, so failures are only logged.
+func (is *IOSXESource) initPowerSensors(d *netconf.Driver) error {
+	var envReply environmentReply
+	r, err := d.Get(environmentFilter)
+	if err != nil {
+		is.Logger.Warningf(is.Ctx, "error with environment filter: %s", err)
+		return nil
+	}
+	err = xml.Unmarshal(r.RawResult, &envReply)
+	if err != nil {
+		is.Logger.Warningf(is.Ctx, "error with unmarshaling environment reply: %s", err)
+		return nil
+	}
+	for _, sensor := range envReply.Sensors {
+		if strings.EqualFold(sensor.SensorUnits, "watts") {
+			is.PowerSensors = append(is.PowerSensors, sensor)
+		}
+	}
+	return nil
+}
+
+// initConsoleLines collects console lines from native configuration.
+func (is *IOSXESource) initConsoleLines(d *netconf.Driver) error {
+	var lineReply consoleLineReply
+	r, err := d.Get(consoleLineFilter)
+	if err != nil {
+		return fmt.Errorf("error with console line filter: %s", err)
+	}
+	err = xml.Unmarshal(r.RawResult, &lineReply)
+	if err != nil {
+		return fmt.Errorf("error with unmarshaling console line reply: %s", err)
+	}
+	is.ConsoleLines = lineReply.Lines
+	return nil
+}
@@ -68,6 +68,9 @@ type arpVrf struct {
 }
 
 type arpEntry struct {
+	// Vrf is not part of the arp-oper entry, it is set from
+	// the parent arp-vrf when arp data is initialized.
+	Vrf       string `xml:"-"`
 	Address   string `xml:"address"`
 	Interface string `xml:"interface"`
 	Type      string `xml:"type"`
@@ -75,3 +78,138 @@ type arpEntry struct {
 	HWType    string `xml:"hwtype"`
 	MAC       string `xml:"hardware"`
 }
+
+// vrfReply holds all vrf definitions from the native configuration.
+type vrfReply struct {
+	XMLName   xml.Name        `xml:"urn:ietf:params:xml:ns:netconf:base:1.0 rpc-reply"`
+	MessageID string          `xml:"message-id,attr"`
+	VRFs      []vrfDefinition `xml:"data>native>vrf>definition"`
+}
+
+type vrfDefinition struct {
+	Name        string          `xml:"name"`
+	RD          string          `xml:"rd"`
+	Description string          `xml:"description"`
+	IPv4RTs     vrfRouteTargets `xml:"address-family>ipv4>route-target"`
+	IPv6RTs     vrfRouteTargets `xml:"address-family>ipv6>route-target"`
+}
+
+// vrfRouteTargets holds route targets of a vrf address family. Newer IOS-XE
+// releases nest them under without-stitching container, older do not.
+type vrfRouteTargets struct {
+	Export       []string `xml:"export-route-target>without-stitching>asn-ip"`
+	Import       []string `xml:"import-route-target>without-stitching>asn-ip"`
+	LegacyExport []string `xml:"export>asn-ip"`
+	LegacyImport []string `xml:"import>asn-ip"`
+}
+
+// networkInstanceReply holds network instances with their member interfaces.
+type networkInstanceReply struct {
+	XMLName          xml.Name          `xml:"urn:ietf:params:xml:ns:netconf:base:1.0 rpc-reply"`
+	MessageID        string            `xml:"message-id,attr"`
+	NetworkInstances []networkInstance `xml:"data>network-instances>network-instance"`
+}
+
+type networkInstance struct {
+	Name       string   `xml:"name"`
+	Interfaces []string `xml:"interfaces>interface>id"`
+}
+
+// dhcpPoolReply holds all dhcp pools from the native configuration.
+type dhcpPoolReply struct {
+	XMLName   xml.Name   `xml:"urn:ietf:params:xml:ns:netconf:base:1.0 rpc-reply"`
+	MessageID string     `xml:"message-id,attr"`
+	Pools     []dhcpPool `xml:"data>native>ip>dhcp>pool"`
+}
+
+type dhcpPool struct {
+	ID      string `xml:"id"`
+	Vrf     string `xml:"vrf"`
+	Network string `xml:"network>primary-network>number"`
+	Mask    string `xml:"network>primary-network>mask"`
+}
+
+// nativeInterfaceReply holds interface configuration from the native configuration.
+type nativeInterfaceReply struct {
+	XMLName    xml.Name         `xml:"urn:ietf:params:xml:ns:netconf:base:1.0 rpc-reply"`
+	MessageID  string           `xml:"message-id,attr"`
+	Interfaces nativeInterfaces `xml:"data>native>interface"`
+}
+
+// nativeInterfaces holds interfaces of all types. Each interface type
+// (e.g. GigabitEthernet, Vlan) is its own element in the native configuration.
+type nativeInterfaces struct {
+	Entries []nativeInterface `xml:",any"`
+}
+
+type nativeInterface struct {
+	// XMLName.Local is the interface type, e.g. GigabitEthernet.
+	XMLName     xml.Name
+	Name        string      `xml:"name"`
+	PrimaryIP   string      `xml:"ip>address>primary>address"`
+	PrimaryMask string      `xml:"ip>address>primary>mask"`
+	HSRPGroups  []hsrpGroup `xml:"standby>standby-list"`
+	VRRPGroups  []vrrpGroup `xml:"vrrp"`
+}
+
+type hsrpGroup struct {
+	GroupNumber int      `xml:"group-number"`
+	Addresses   []string `xml:"ip>address"`
+	Priority    int      `xml:"priority"`
+}
+
+// vrrpGroup holds legacy vrrp (VRRPv2) group configuration.
+type vrrpGroup struct {
+	ID        int      `xml:"id"`
+	Addresses []string `xml:"ip>address"`
+	Priority  int      `xml:"priority"`
+}
+
+// bgpStateReply holds bgp neighbors and local ASNs from the bgp operational data.
+type bgpStateReply struct {
+	XMLName         xml.Name           `xml:"urn:ietf:params:xml:ns:netconf:base:1.0 rpc-reply"`
+	MessageID       string             `xml:"message-id,attr"`
+	Neighbors       []bgpNeighbor      `xml:"data>bgp-state-data>neighbors>neighbor"`
+	AddressFamilies []bgpAddressFamily `xml:"data>bgp-state-data>address-families>address-family"`
+}
+
+type bgpNeighbor struct {
+	AfiSafi     string `xml:"afi-safi"`
+	VrfName     string `xml:"vrf-name"`
+	NeighborID  string `xml:"neighbor-id"`
+	Description string `xml:"description"`
+	AS          int64  `xml:"as"`
+	LocalHost   string `xml:"transport>local-host"`
+}
+
+type bgpAddressFamily struct {
+	AfiSafi string `xml:"afi-safi"`
+	VrfName string `xml:"vrf-name"`
+	LocalAS int64  `xml:"local-as"`
+}
+
+// environmentReply holds sensors from the environment operational data.
+type environmentReply struct {
+	XMLName   xml.Name            `xml:"urn:ietf:params:xml:ns:netconf:base:1.0 rpc-reply"`
+	MessageID string              `xml:"message-id,attr"`
+	Sensors   []environmentSensor `xml:"data>environment-sensors>environment-sensor"`
+}
+
+type environmentSensor struct {
+	Name           string `xml:"name"`
+	Location       string `xml:"location"`
+	CurrentReading int    `xml:"current-reading"`
+	SensorUnits    string `xml:"sensor-units"`
+}
+
+// consoleLineReply holds console lines from native configuration.
+type consoleLineReply struct {
+	XMLName   xml.Name      `xml:"urn:ietf:params:xml:ns:netconf:base:1.0 rpc-reply"`
+	MessageID string        `xml:"message-id,attr"`
+	Lines     []consoleLine `xml:"data>native>line>console"`
+}
+
+type consoleLine struct {
+	// First is the number of the console line (e.g. 0 for con 0).
+	First string `xml:"first"`
+}
@@ -2,6 +2,10 @@ package iosxe
 
 import (
 	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	devices "github.com/bl4ko/go-devicetype-library/pkg"
@@ -12,6 +16,9 @@ import (
 	"github.com/bl4ko/netbox-ssot/internal/utils"
 )
 
+// wattageRegex matches wattage of a power supply (e.g. 715W in PWR-C1-715WAC).
+var wattageRegex = regexp.MustCompile(`(\d+)\s?W`)
+
 // Syncs dnac sites to netbox inventory.
 func (is *IOSXESource) syncDevice(nbi *inventory.NetboxInventory) error {
 	var err error
@@ -19,6 +26,13 @@ func (is *IOSXESource) syncDevice(nbi *inventory.NetboxInventory) error {
 	if deviceName == "" {
 		return fmt.Errorf("hostname for device is empty")
 	}
+	filtered, err := is.IsFiltered("device", utils.RelationAttributes{Name: deviceName})
+	if err != nil {
+		return fmt.Errorf("filter device: %s", err)
+	}
+	if filtered {
+		return common.ErrDeviceDropped
+	}
 
 	var deviceModel, serialNumber, description string
 	if len(is.HardwareInfo.Inventory) > 0 {
@@ -31,7 +45,7 @@ func (is *IOSXESource) syncDevice(nbi *inventory.NetboxInventory) error {
 		}
 	}
 	if deviceModel == "" {
-		deviceModel = constants.DefaultModel
+		deviceModel = is.SourceConfig.Defaults.DeviceType.Name
 	}
 	deviceManufacturer, err := nbi.AddManufacturer(is.Ctx, &objects.Manufacturer{
 		Name: "Cisco",
@@ -64,8 +78,8 @@ func (is *IOSXESource) syncDevice(nbi *inventory.NetboxInventory) error {
 	deviceTenant, err := common.MatchHostToTenant(
 		is.Ctx,
 		nbi,
-		deviceName,
-		is.SourceConfig.HostTenantRelations,
+		utils.RelationAttributes{Name: deviceName},
+		is.SourceConfig,
 	)
 	if err != nil {
 		return fmt.Errorf("match host to tenant: %s", err)
@@ -74,19 +88,19 @@ func (is *IOSXESource) syncDevice(nbi *inventory.NetboxInventory) error {
 	// Match host to a role. First test if user provided relations, if
 	// not use default switch role.
 	var deviceRole *objects.DeviceRole
-	if len(is.SourceConfig.HostRoleRelations) > 0 {
+	if len(is.SourceConfig.HostRoleRelations) > 0 || len(is.SourceConfig.RelationRules.HostRoleRelations) > 0 {
 		deviceRole, err = common.MatchHostToRole(
 			is.Ctx,
 			nbi,
-			deviceName,
-			is.SourceConfig.HostRoleRelations,
+			utils.RelationAttributes{Name: deviceName},
+			is.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match host to role: %s", err)
 		}
 	}
 	if deviceRole == nil {
-		deviceRole, err = nbi.AddSwitchDeviceRole(is.Ctx)
+		deviceRole, err = nbi.AddSwitchDeviceRole(is.Ctx, is.SourceConfig.Defaults.Roles)
 		if err != nil {
 			return fmt.Errorf("add device role: %s", err)
 		}
@@ -95,8 +109,8 @@ func (is *IOSXESource) syncDevice(nbi *inventory.NetboxInventory) error {
 	deviceSite, err := common.MatchHostToSite(
 		is.Ctx,
 		nbi,
-		deviceName,
-		is.SourceConfig.HostSiteRelations,
+		utils.RelationAttributes{Name: deviceName},
+		is.SourceConfig,
 	)
 	if err != nil {
 		return fmt.Errorf("match host to site: %s", err)
@@ -111,7 +125,7 @@ func (is *IOSXESource) syncDevice(nbi *inventory.NetboxInventory) error {
 	if err != nil {
 		return fmt.Errorf("add platform: %s", err)
 	}
-	NBDevice, err := nbi.AddDevice(is.Ctx, &objects.Device{
+	deviceStruct := &objects.Device{
 		NetboxObject: objects.NetboxObject{
 			Tags:        is.GetSourceTags(),
 			Description: description,
@@ -124,7 +138,18 @@ func (is *IOSXESource) syncDevice(nbi *inventory.NetboxInventory) error {
 		DeviceType:   deviceType,
 		Tenant:       deviceTenant,
 		Platform:     devicePlatform,
-	})
+	}
+	if err := common.MatchDeviceToRack(is.Ctx, nbi, deviceStruct, is.SourceConfig); err != nil {
+		is.Logger.Warningf(is.Ctx, "device %s rack placement: %s", deviceStruct.Name, err)
+	}
+	keep, err := common.TransformDevice(is.Ctx, nbi, is.SourceConfig, deviceStruct, is.GetSourceTags())
+	if err != nil {
+		return err
+	}
+	if !keep {
+		return common.ErrDeviceDropped
+	}
+	NBDevice, err := nbi.AddDevice(is.Ctx, deviceStruct)
 	if err != nil {
 		return fmt.Errorf("add device: %s", err)
 	}
@@ -133,8 +158,215 @@ func (is *IOSXESource) syncDevice(nbi *inventory.NetboxInventory) error {
 	return nil
 }
 
+// syncHardwareInventory syncs line cards, transceivers, fans and power supplies
+// of the device. Line cards are synced as modules, everything else as inventory items.
+func (is *IOSXESource) syncHardwareInventory(nbi *inventory.NetboxInventory) error {
+	manufacturer, err := nbi.AddManufacturer(is.Ctx, &objects.Manufacturer{
+		Name: "Cisco",
+		Slug: utils.Slugify("Cisco"),
+	})
+	if err != nil {
+		return fmt.Errorf("add manufacturer: %s", err)
+	}
+	for _, inv := range is.HardwareInfo.Inventory {
+		if inv.Type == "hw-type-chassis" {
+			continue
+		}
+		componentName := inv.DevName
+		if componentName == "" {
+			componentName = strings.TrimSpace(fmt.Sprintf("%s %s", inv.Description, inv.DevIndex))
+		}
+		if componentName == "" {
+			is.Logger.Debugf(is.Ctx, "skipping hardware inventory entry without name: %+v", inv)
+			continue
+		}
+		var serialNumber string
+		if !is.SourceConfig.IgnoreSerialNumbers {
+			serialNumber = inv.SerialNumber
+		}
+		err = common.AddHardwareComponent(is.Ctx, nbi, is.NBDevice, common.HardwareComponent{
+			Name:         componentName,
+			Manufacturer: manufacturer,
+			PartNumber:   inv.PartNumber,
+			SerialNumber: serialNumber,
+			Description:  inv.Description,
+			IsModule:     inv.Type == "hw-type-module",
+		}, is.GetSourceTags())
+		if err != nil {
+			return fmt.Errorf("add hardware component %s: %s", componentName, err)
+		}
+	}
+	return nil
+}
+
+// syncPowerSupplies syncs power supplies of the device as power ports and
+// adds power feeds matched by host power feed relations.
+//
+// Maximum draw is parsed from the wattage in psu's part number or description
+// (e.g. PWR-C1-715WAC). Allocated draw is the highest power sensor reading,
+// whose name or location contains the name of the power supply.
+func (is *IOSXESource) syncPowerSupplies(nbi *inventory.NetboxInventory) error {
+	for _, inv := range is.HardwareInfo.Inventory {
+		if inv.Type != "hw-type-pem" || inv.DevName == "" {
+			continue
+		}
+		powerSupply := common.PowerSupply{
+			Name:        inv.DevName,
+			Description: inv.Description,
+			MaximumDraw: parseWattage(inv.PartNumber),
+		}
+		if powerSupply.MaximumDraw == 0 {
+			powerSupply.MaximumDraw = parseWattage(inv.Description)
+		}
+		for _, sensor := range is.PowerSensors {
+			if !strings.Contains(sensor.Name, inv.DevName) && !strings.Contains(sensor.Location, inv.DevName) {
+				continue
+			}
+			if sensor.CurrentReading > powerSupply.AllocatedDraw {
+				powerSupply.AllocatedDraw = sensor.CurrentReading
+			}
+		}
+		_, err := common.AddPowerSupply(is.Ctx, nbi, is.NBDevice, powerSupply, is.GetSourceTags())
+		if err != nil {
+			return fmt.Errorf("add power supply %s: %s", inv.DevName, err)
+		}
+	}
+	if _, err := common.MatchDeviceToPowerFeeds(is.Ctx, nbi, is.NBDevice, is.SourceConfig); err != nil {
+		is.Logger.Warningf(is.Ctx, "device %s power feeds: %s", is.NBDevice.Name, err)
+	}
+	return nil
+}
+
+// syncConsolePorts syncs console lines of the device as console ports.
+func (is *IOSXESource) syncConsolePorts(nbi *inventory.NetboxInventory) error {
+	for _, line := range is.ConsoleLines {
+		if line.First == "" {
+			continue
+		}
+		portName := fmt.Sprintf("con %s", line.First)
+		_, err := nbi.AddConsolePort(is.Ctx, &objects.ConsolePort{
+			NetboxObject: objects.NetboxObject{
+				Tags: is.GetSourceTags(),
+			},
+			Device: is.NBDevice,
+			Name:   portName,
+		})
+		if err != nil {
+			return fmt.Errorf("add console port %s: %s", portName, err)
+		}
+	}
+	return nil
+}
+
+// parseWattage returns wattage found in the string (e.g. 715 for "PWR-C1-715WAC").
+// If no wattage is found, 0 is returned.
+func parseWattage(s string) int {
+	match := wattageRegex.FindStringSubmatch(s)
+	if match == nil {
+		return 0
+	}
+	wattage, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return wattage
+}
+
+// syncVRFs syncs vrf definitions with their route targets to netbox inventory.
+func (is *IOSXESource) syncVRFs(nbi *inventory.NetboxInventory) error {
+	is.NBVRFs = make(map[string]*objects.VRF, len(is.VRFs))
+	for vrfName, vrf := range is.VRFs {
+		importTargets, err := is.syncRouteTargets(
+			nbi,
+			vrf.IPv4RTs.Import,
+			vrf.IPv4RTs.LegacyImport,
+			vrf.IPv6RTs.Import,
+			vrf.IPv6RTs.LegacyImport,
+		)
+		if err != nil {
+			return fmt.Errorf("sync import route targets for vrf %s: %s", vrfName, err)
+		}
+		exportTargets, err := is.syncRouteTargets(
+			nbi,
+			vrf.IPv4RTs.Export,
+			vrf.IPv4RTs.LegacyExport,
+			vrf.IPv6RTs.Export,
+			vrf.IPv6RTs.LegacyExport,
+		)
+		if err != nil {
+			return fmt.Errorf("sync export route targets for vrf %s: %s", vrfName, err)
+		}
+		_, vrfExists := nbi.GetVRF(vrfName)
+		vrfStruct := is.newVRF(vrfName, vrf, vrfExists, importTargets, exportTargets)
+		nbVRF, err := nbi.AddVRF(is.Ctx, vrfStruct)
+		if err != nil {
+			return fmt.Errorf("add vrf %+v: %s", vrfStruct, err)
+		}
+		is.NBVRFs[vrfName] = nbVRF
+	}
+	return nil
+}
+
+// newVRF returns netbox vrf of the vrf definition. VRFs are shared by name between
+// routers, so RD and tenant, which are specific to the router, are only set when
+// the vrf doesn't exist yet. Otherwise routers with different RDs of the same vrf
+// would patch it on every sync.
+func (is *IOSXESource) newVRF(
+	vrfName string,
+	vrf vrfDefinition,
+	vrfExists bool,
+	importTargets []*objects.RouteTarget,
+	exportTargets []*objects.RouteTarget,
+) *objects.VRF {
+	vrfStruct := &objects.VRF{
+		NetboxObject: objects.NetboxObject{
+			Tags:        is.GetSourceTags(),
+			Description: vrf.Description,
+		},
+		Name:          vrfName,
+		ImportTargets: importTargets,
+		ExportTargets: exportTargets,
+	}
+	if !vrfExists {
+		vrfStruct.RD = vrf.RD
+		vrfStruct.Tenant = is.NBDevice.Tenant
+	}
+	return vrfStruct
+}
+
+// syncRouteTargets adds all unique route targets from the given lists to
+// netbox inventory and returns them.
+func (is *IOSXESource) syncRouteTargets(
+	nbi *inventory.NetboxInventory,
+	routeTargetLists ...[]string,
+) ([]*objects.RouteTarget, error) {
+	seen := make(map[string]bool)
+	nbRouteTargets := []*objects.RouteTarget{}
+	for _, routeTargets := range routeTargetLists {
+		for _, routeTarget := range routeTargets {
+			if routeTarget == "" || seen[routeTarget] {
+				continue
+			}
+			seen[routeTarget] = true
+			nbRouteTarget, err := nbi.AddRouteTarget(is.Ctx, &objects.RouteTarget{
+				NetboxObject: objects.NetboxObject{
+					Tags: is.GetSourceTags(),
+				},
+				Name:   routeTarget,
+				Tenant: is.NBDevice.Tenant,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("add route target %s: %s", routeTarget, err)
+			}
+			nbRouteTargets = append(nbRouteTargets, nbRouteTarget)
+		}
+	}
+	return nbRouteTargets, nil
+}
+
 func (is *IOSXESource) syncInterfaces(nbi *inventory.NetboxInventory) error {
 	is.NBInterfaces = make(map[string]*objects.Interface)
+	ipAddresses := []*objects.IPAddress{}
 	for ifaceName, iface := range is.Interfaces {
 		ifaceEnabled := iface.State.Enabled
 		ifaceMAC := iface.Ethernet.MACAddress
@@ -188,10 +420,22 @@ func (is *IOSXESource) syncInterfaces(nbi *inventory.NetboxInventory) error {
 			Device: is.NBDevice,
 			Speed:  ifaceLinkSpeed,
 			Status: ifaceEnabled,
+			VRF:    is.NBVRFs[is.Iface2VRF[ifaceName]],
+			// Interfaces in the management vrf are used only for out-of-band management
+			MgmtOnly: managementVRFs[is.Iface2VRF[ifaceName]],
 		})
 		if err != nil {
 			return fmt.Errorf("add interface: %s", err)
 		}
+		if mgmtIface, ok := is.MgmtInterfaces[ifaceName]; ok {
+			nbIPAddress, err := is.syncMgmtIPAddress(nbi, nbIface, mgmtIface)
+			if err != nil {
+				return fmt.Errorf("sync management ip address: %s", err)
+			}
+			if nbIPAddress != nil {
+				ipAddresses = append(ipAddresses, nbIPAddress)
+			}
+		}
 		if ifaceMAC != "" {
 			nbMACAddress, err := common.CreateMACAddressForObjectType(
 				is.Ctx,
@@ -208,6 +452,196 @@ func (is *IOSXESource) syncInterfaces(nbi *inventory.NetboxInventory) error {
 		}
 		is.NBInterfaces[ifaceName] = nbIface
 	}
+	err := common.SetPrimaryIPAddresses(is.Ctx, nbi, is.NBDevice, ipAddresses, nil, is.SourceConfig)
+	if err != nil {
+		return fmt.Errorf("set primary ip addresses: %s", err)
+	}
+	return nil
+}
+
+// syncMgmtIPAddress adds primary ip address of the management interface to netbox inventory.
+// Because the interface is mgmt_only, the address is used as device's oob ip.
+func (is *IOSXESource) syncMgmtIPAddress(
+	nbi *inventory.NetboxInventory,
+	nbIface *objects.Interface,
+	mgmtIface nativeInterface,
+) (*objects.IPAddress, error) {
+	if mgmtIface.PrimaryIP == "" || mgmtIface.PrimaryMask == "" {
+		return nil, nil
+	}
+	if !utils.IsPermittedIPAddress(
+		mgmtIface.PrimaryIP,
+		is.SourceConfig.PermittedSubnets,
+		is.SourceConfig.IgnoredSubnets,
+	) {
+		return nil, nil
+	}
+	maskBits, err := utils.MaskToBits(mgmtIface.PrimaryMask)
+	if err != nil {
+		return nil, fmt.Errorf("mask to bits: %s", err)
+	}
+	nbIPAddress, err := nbi.AddIPAddress(is.Ctx, &objects.IPAddress{
+		NetboxObject: objects.NetboxObject{
+			Tags: is.GetSourceTags(),
+			CustomFields: map[string]interface{}{
+				constants.CustomFieldArpEntryName: false,
+			},
+		},
+		Address:            fmt.Sprintf("%s/%d", mgmtIface.PrimaryIP, maskBits),
+		DNSName:            utils.ReverseLookup(mgmtIface.PrimaryIP),
+		Status:             &objects.IPAddressStatusActive,
+		Tenant:             is.NBDevice.Tenant,
+		AssignedObjectType: constants.ContentTypeDcimInterface,
+		AssignedObjectID:   nbIface.ID,
+		VRF:                nbIface.VRF,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add ip address: %s", err)
+	}
+	return nbIPAddress, nil
+}
+
+// syncDHCPPools syncs usable host ranges of all dhcp pools as ip ranges in netbox.
+func (is *IOSXESource) syncDHCPPools(nbi *inventory.NetboxInventory) error {
+	for _, pool := range is.DHCPPools {
+		if pool.Network == "" || pool.Mask == "" {
+			is.Logger.Debugf(is.Ctx, "dhcp pool %s has no network. Skipping...", pool.ID)
+			continue
+		}
+		maskBits, err := utils.MaskToBits(pool.Mask)
+		if err != nil {
+			is.Logger.Warningf(is.Ctx, "dhcp pool %s mask to bits: %s", pool.ID, err)
+			continue
+		}
+		startAddress, endAddress, err := utils.GetUsableIPv4Range(
+			fmt.Sprintf("%s/%d", pool.Network, maskBits),
+		)
+		if err != nil {
+			is.Logger.Warningf(is.Ctx, "dhcp pool %s range: %s", pool.ID, err)
+			continue
+		}
+		if !utils.IsPermittedIPAddress(
+			startAddress,
+			is.SourceConfig.PermittedSubnets,
+			is.SourceConfig.IgnoredSubnets,
+		) {
+			continue
+		}
+		ipRangeStruct := &objects.IPRange{
+			NetboxObject: objects.NetboxObject{
+				Tags:        is.GetSourceTags(),
+				Description: fmt.Sprintf("DHCP pool %s on %s", pool.ID, is.NBDevice.Name),
+			},
+			StartAddress: startAddress,
+			EndAddress:   endAddress,
+			Status:       &objects.IPRangeStatusActive,
+			VRF:          is.NBVRFs[pool.Vrf],
+			Tenant:       is.NBDevice.Tenant,
+		}
+		if _, err := nbi.AddIPRange(is.Ctx, ipRangeStruct); err != nil {
+			return fmt.Errorf("add ip range %+v: %s", ipRangeStruct, err)
+		}
+	}
+	return nil
+}
+
+// syncFHRPGroups syncs HSRP and VRRP groups as FHRP groups, assigned to the
+// interfaces they are configured on. Virtual ips inherit mask of the interface's primary ip.
+func (is *IOSXESource) syncFHRPGroups(nbi *inventory.NetboxInventory) error {
+	for ifaceName, nativeIface := range is.FHRPInterfaces {
+		nbIface, ok := is.NBInterfaces[ifaceName]
+		if !ok {
+			is.Logger.Debugf(is.Ctx, "interface %s with fhrp groups is not synced. Skipping...", ifaceName)
+			continue
+		}
+		ifaceAddresses := []string{}
+		if nativeIface.PrimaryIP != "" && nativeIface.PrimaryMask != "" {
+			maskBits, err := utils.MaskToBits(nativeIface.PrimaryMask)
+			if err == nil {
+				ifaceAddresses = append(ifaceAddresses, fmt.Sprintf("%s/%d", nativeIface.PrimaryIP, maskBits))
+			}
+		}
+		virtualIPs := []common.FHRPVirtualIP{}
+		for _, hsrp := range nativeIface.HSRPGroups {
+			for _, address := range hsrp.Addresses {
+				virtualIPs = append(virtualIPs, common.FHRPVirtualIP{
+					Protocol: &objects.FHRPGroupProtocolHSRP,
+					GroupID:  hsrp.GroupNumber,
+					Address:  fmt.Sprintf("%s/%d", address, utils.GetMaskForIPAddress(address, ifaceAddresses)),
+					Priority: getFHRPPriority(hsrp.Priority),
+				})
+			}
+		}
+		for _, vrrp := range nativeIface.VRRPGroups {
+			for _, address := range vrrp.Addresses {
+				virtualIPs = append(virtualIPs, common.FHRPVirtualIP{
+					Protocol: &objects.FHRPGroupProtocolVRRP2,
+					GroupID:  vrrp.ID,
+					Address:  fmt.Sprintf("%s/%d", address, utils.GetMaskForIPAddress(address, ifaceAddresses)),
+					Priority: getFHRPPriority(vrrp.Priority),
+				})
+			}
+		}
+		for _, virtualIP := range virtualIPs {
+			ipAddress, _, _ := strings.Cut(virtualIP.Address, "/")
+			if !utils.IsPermittedIPAddress(
+				ipAddress,
+				is.SourceConfig.PermittedSubnets,
+				is.SourceConfig.IgnoredSubnets,
+			) {
+				continue
+			}
+			err := common.AddFHRPVirtualIP(is.Ctx, nbi, nbIface, virtualIP, is.GetSourceTags())
+			if err != nil {
+				is.Logger.Warningf(is.Ctx, "add fhrp virtual ip %s: %s", virtualIP.Address, err)
+			}
+		}
+	}
+	return nil
+}
+
+// getFHRPPriority returns the given priority or the HSRP/VRRP default
+// priority, if priority is not configured.
+func getFHRPPriority(priority int) int {
+	if priority == 0 {
+		return defaultFHRPPriority
+	}
+	return priority
+}
+
+// syncBGPNeighbors syncs ASNs and bgp neighbors of the device.
+func (is *IOSXESource) syncBGPNeighbors(nbi *inventory.NetboxInventory) error {
+	sessions := make([]common.BGPSession, 0, len(is.BGPNeighbors))
+	for _, neighbor := range is.BGPNeighbors {
+		localASN, ok := is.BGPLocalASNs[neighbor.VrfName]
+		if !ok || neighbor.AS == 0 {
+			is.Logger.Debugf(is.Ctx, "bgp neighbor %s has unknown as. Skipping...", neighbor.NeighborID)
+			continue
+		}
+		localAddress := neighbor.LocalHost
+		if net.ParseIP(localAddress) == nil || net.ParseIP(localAddress).IsUnspecified() {
+			localAddress = ""
+		}
+		vrfName := neighbor.VrfName
+		if vrfName == defaultBGPVrfName {
+			vrfName = ""
+		}
+		sessions = append(sessions, common.BGPSession{
+			LocalASN:      localASN,
+			LocalAddress:  localAddress,
+			RemoteASN:     neighbor.AS,
+			RemoteAddress: neighbor.NeighborID,
+			VRF:           vrfName,
+			Name:          neighbor.Description,
+		})
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+	err := common.AddBGPSessions(is.Ctx, nbi, is.NBDevice, sessions, is.GetSourceTags())
+	if err != nil {
+		is.Logger.Warningf(is.Ctx, "add bgp sessions: %s", err)
+	}
 	return nil
 }
 
@@ -257,6 +691,7 @@ func (is *IOSXESource) syncArpTable(nbi *inventory.NetboxInventory) error {
 				Address: addressWithMask,
 				DNSName: dnsName,
 				Status:  &objects.IPAddressStatusActive,
+				VRF:     is.NBVRFs[arpEntry.Vrf],
 			})
 			if err != nil {
 				is.Logger.Warningf(is.Ctx, "error creating ip address: %s", err)
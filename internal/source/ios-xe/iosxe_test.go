@@ -1 +1,51 @@
 package iosxe
+
+import (
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+)
+
+// newTestRouter returns ios-xe source of the router with the tenant and vrf definitions.
+func newTestRouter(tenant *objects.Tenant, vrfs map[string]vrfDefinition) *IOSXESource {
+	return &IOSXESource{
+		Config: common.Config{
+			SourceNameTag: &objects.Tag{ID: 1, Name: "Source: routers"},
+			SourceTypeTag: &objects.Tag{ID: 2, Name: "Type: ios-xe"},
+		},
+		VRFs:     vrfs,
+		NBDevice: &objects.Device{Tenant: tenant},
+	}
+}
+
+func TestNewVRFSharedBetweenRouters(t *testing.T) {
+	router1 := newTestRouter(
+		&objects.Tenant{NetboxObject: objects.NetboxObject{ID: 1}, Name: "Tenant1"},
+		map[string]vrfDefinition{"CUST": {Name: "CUST", RD: "65000:1", Description: "Customer vrf"}},
+	)
+	router2 := newTestRouter(
+		&objects.Tenant{NetboxObject: objects.NetboxObject{ID: 2}, Name: "Tenant2"},
+		map[string]vrfDefinition{"CUST": {Name: "CUST", RD: "65000:2", Description: "Customer vrf"}},
+	)
+
+	// First router creates the vrf with its RD and tenant
+	createdVRF := router1.newVRF("CUST", router1.VRFs["CUST"], false, nil, nil)
+	if createdVRF.RD != "65000:1" || createdVRF.Tenant == nil || createdVRF.Tenant.Name != "Tenant1" {
+		t.Fatalf("newVRF() = %+v, want RD 65000:1 and tenant Tenant1", createdVRF)
+	}
+	createdVRF.ID = 1
+
+	// Neither router patches RD or tenant of the existing vrf
+	for _, router := range []*IOSXESource{router1, router2} {
+		vrf := router.newVRF("CUST", router.VRFs["CUST"], true, nil, nil)
+		diffMap, err := utils.JSONDiffMapExceptID(vrf, createdVRF, false, nil)
+		if err != nil {
+			t.Fatalf("JSONDiffMapExceptID() error = %v", err)
+		}
+		if len(diffMap) > 0 {
+			t.Errorf("vrf of router with RD %s patches existing vrf with %v", router.VRFs["CUST"].RD, diffMap)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
 	"github.com/bl4ko/netbox-ssot/internal/source/common"
 	"github.com/bl4ko/netbox-ssot/internal/utils"
 	ovirtsdk4 "github.com/ovirt/go-ovirt"
@@ -23,6 +24,13 @@ type OVirtSource struct {
 	Hosts       map[string]*ovirtsdk4.Host
 	Vms         map[string]*ovirtsdk4.Vm
 	Networks    *NetworkData
+
+	// NBHosts is a map of host ids to synced netbox devices. Hosts, that are
+	// filtered out or dropped by transforms, are not in the map.
+	NBHosts map[string]*objects.Device
+	// FilteredClusterIDs are ids of clusters, that are filtered out. Their hosts
+	// and vms are not synced.
+	FilteredClusterIDs map[string]bool
 }
 
 type NetworkData struct {
@@ -24,12 +24,19 @@ func (o *OVirtSource) syncNetworks(nbi *inventory.NetboxInventory) error {
 		description, _ := network.Description()
 		// TODO: handle other networks
 		if networkVlan, exists := network.Vlan(); exists {
+			filtered, err := o.IsFiltered("vlan", utils.RelationAttributes{Name: name})
+			if err != nil {
+				return fmt.Errorf("filter vlan: %s", err)
+			}
+			if filtered {
+				continue
+			}
 			// Get vlanSite from relation
 			vlanSite, err := common.MatchVlanToSite(
 				o.Ctx,
 				nbi,
-				name,
-				o.SourceConfig.VlanSiteRelations,
+				utils.RelationAttributes{Name: name},
+				o.SourceConfig,
 			)
 			if err != nil {
 				return fmt.Errorf("match vlan to site: %s", err)
@@ -38,10 +45,9 @@ func (o *OVirtSource) syncNetworks(nbi *inventory.NetboxInventory) error {
 			vlanGroup, err := common.MatchVlanToGroup(
 				o.Ctx,
 				nbi,
-				name,
+				utils.RelationAttributes{Name: name},
 				vlanSite,
-				o.SourceConfig.VlanGroupRelations,
-				o.SourceConfig.VlanGroupSiteRelations,
+				o.SourceConfig,
 			)
 			if err != nil {
 				return err
@@ -50,8 +56,8 @@ func (o *OVirtSource) syncNetworks(nbi *inventory.NetboxInventory) error {
 			vlanTenant, err := common.MatchVlanToTenant(
 				o.Ctx,
 				nbi,
-				name,
-				o.SourceConfig.VlanTenantRelations,
+				utils.RelationAttributes{Name: name},
+				o.SourceConfig,
 			)
 			if err != nil {
 				return err
@@ -130,11 +136,23 @@ func (o *OVirtSource) syncClusters(nbi *inventory.NetboxInventory) error {
 		return fmt.Errorf("failed to add oVirt cluster type: %v", err)
 	}
 	// Then sync oVirt Clusters as NetBoxClusters
-	for _, cluster := range o.Clusters {
+	o.FilteredClusterIDs = make(map[string]bool)
+	for clusterID, cluster := range o.Clusters {
 		clusterName, exists := cluster.Name()
 		if !exists {
 			return fmt.Errorf("failed to get name for oVirt cluster %s", clusterName)
 		}
+		_, datacenterName := o.clusterNames(clusterID)
+		filtered, err := o.IsFiltered(
+			"cluster", utils.RelationAttributes{Name: clusterName, Datacenter: datacenterName},
+		)
+		if err != nil {
+			return fmt.Errorf("filter cluster: %s", err)
+		}
+		if filtered {
+			o.FilteredClusterIDs[clusterID] = true
+			continue
+		}
 		description, exists := cluster.Description()
 		if !exists {
 			o.Logger.Warning(o.Ctx, "description for oVirt cluster ", clusterName, " is empty.")
@@ -158,8 +176,8 @@ func (o *OVirtSource) syncClusters(nbi *inventory.NetboxInventory) error {
 		clusterSite, err := common.MatchClusterToSite(
 			o.Ctx,
 			nbi,
-			clusterName,
-			o.SourceConfig.ClusterSiteRelations,
+			utils.RelationAttributes{Name: clusterName},
+			o.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match cluster to site: %s", err)
@@ -172,8 +190,8 @@ func (o *OVirtSource) syncClusters(nbi *inventory.NetboxInventory) error {
 		clusterTenant, err := common.MatchClusterToTenant(
 			o.Ctx,
 			nbi,
-			clusterName,
-			o.SourceConfig.ClusterTenantRelations,
+			utils.RelationAttributes{Name: clusterName},
+			o.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match cluster to tenant: %s", err)
@@ -207,16 +225,46 @@ func (o *OVirtSource) syncClusters(nbi *inventory.NetboxInventory) error {
 // syncHosts synces collected hosts from ovirt api to netbox inventory
 // as devices.
 func (o *OVirtSource) syncHosts(nbi *inventory.NetboxInventory) error {
+	o.NBHosts = make(map[string]*objects.Device, len(o.Hosts))
 	for hostID, host := range o.Hosts {
+		hostName, _ := host.Name()
+		clusterID := host.MustCluster().MustId()
+		if o.FilteredClusterIDs[clusterID] {
+			o.Logger.Debugf(o.Ctx, "skipping host %s of filtered out cluster", hostName)
+			continue
+		}
+		clusterName, datacenterName := o.clusterNames(clusterID)
+		filtered, err := o.IsFiltered("device", utils.RelationAttributes{
+			Name:       hostName,
+			Cluster:    clusterName,
+			Datacenter: datacenterName,
+		})
+		if err != nil {
+			return fmt.Errorf("filter host: %s", err)
+		}
+		if filtered {
+			continue
+		}
 		hostStruct, err := extractHostData(o, nbi, host, hostID)
 		if err != nil {
 			return fmt.Errorf("extract host data: %s", err)
 		}
 
+		if err := common.MatchDeviceToRack(o.Ctx, nbi, hostStruct, o.SourceConfig); err != nil {
+			o.Logger.Warningf(o.Ctx, "device %s rack placement: %s", hostStruct.Name, err)
+		}
+		keep, err := common.TransformDevice(o.Ctx, nbi, o.SourceConfig, hostStruct, o.GetSourceTags())
+		if err != nil {
+			return err
+		}
+		if !keep {
+			continue
+		}
 		nbHost, err := nbi.AddDevice(o.Ctx, hostStruct)
 		if err != nil {
 			return fmt.Errorf("failed to add oVirt host %+v with error: %v", hostStruct, err)
 		}
+		o.NBHosts[hostID] = nbHost
 
 		// We also need to sync nics separately, because nic is a separate object in netbox
 		err = o.syncHostNics(nbi, host, nbHost)
@@ -241,15 +289,15 @@ func extractHostData(
 	}
 	hostCluster, _ := nbi.GetCluster(o.Clusters[host.MustCluster().MustId()].MustName())
 
-	hostSite, err := common.MatchHostToSite(o.Ctx, nbi, hostName, o.SourceConfig.HostSiteRelations)
+	hostSite, err := common.MatchHostToSite(o.Ctx, nbi, utils.RelationAttributes{Name: hostName}, o.SourceConfig)
 	if err != nil {
 		return nil, fmt.Errorf("hostSite: %s", err)
 	}
 	hostTenant, err := common.MatchHostToTenant(
 		o.Ctx,
 		nbi,
-		hostName,
-		o.SourceConfig.HostTenantRelations,
+		utils.RelationAttributes{Name: hostName},
+		o.SourceConfig,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("hostTenant: %s", err)
@@ -257,8 +305,8 @@ func extractHostData(
 
 	// Extract host hardware information if possible, if not use generic values
 	var hostSerialNumber, hostUUID string
-	hostManufacturerName := constants.DefaultManufacturer
-	hostModel := constants.DefaultModel
+	hostManufacturerName := o.SourceConfig.Defaults.Manufacturer.Name
+	hostModel := o.SourceConfig.Defaults.DeviceType.Name
 	if hwInfo, exists := host.HardwareInformation(); exists {
 		hostUUID, _ = hwInfo.Uuid()
 		if !o.SourceConfig.IgnoreSerialNumbers {
@@ -376,19 +424,19 @@ func extractHostData(
 	// Match host to a role. First test if user provided relations, if not
 	// use default server role.
 	var hostRole *objects.DeviceRole
-	if len(o.SourceConfig.HostRoleRelations) > 0 {
+	if len(o.SourceConfig.HostRoleRelations) > 0 || len(o.SourceConfig.RelationRules.HostRoleRelations) > 0 {
 		hostRole, err = common.MatchHostToRole(
 			o.Ctx,
 			nbi,
-			hostName,
-			o.SourceConfig.HostRoleRelations,
+			utils.RelationAttributes{Name: hostName},
+			o.SourceConfig,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("match host to role: %s", err)
 		}
 	}
 	if hostRole == nil {
-		hostRole, err = nbi.AddServerDeviceRole(o.Ctx)
+		hostRole, err = nbi.AddServerDeviceRole(o.Ctx, o.SourceConfig.Defaults.Roles)
 		if err != nil {
 			return nil, fmt.Errorf("add server device role %s", err)
 		}
@@ -522,6 +570,8 @@ func (o *OVirtSource) syncHostNics(
 		}
 
 		// Fifth loop we add ip addresses to interfaces
+		hostIPAddresses := []*objects.IPAddress{}
+		preferredIPAddresses := []*objects.IPAddress{}
 		for nicID, ipv4 := range nicID2IPv4 {
 			nbNic := nicID2nbNic[nicID]
 			address := strings.Split(ipv4, "/")[0]
@@ -548,18 +598,9 @@ func (o *OVirtSource) syncHostNics(
 					o.Logger.Warningf(o.Ctx, "add ipv4 address %+v: %s", ipAddressStruct, err)
 					continue
 				}
+				hostIPAddresses = append(hostIPAddresses, nbIPAddress)
 				if address == hostIP {
-					hostCopy := *nbHost
-					hostCopy.PrimaryIPv4 = nbIPAddress
-					_, err := nbi.AddDevice(o.Ctx, &hostCopy)
-					if err != nil {
-						o.Logger.Warningf(
-							o.Ctx,
-							"adding primary ipv4 address %+v: %s",
-							nbIPAddress,
-							err,
-						)
-					}
+					preferredIPAddresses = append(preferredIPAddresses, nbIPAddress)
 				}
 
 				// Also create prefix if it doesn't exist yet
@@ -601,6 +642,7 @@ func (o *OVirtSource) syncHostNics(
 				if err != nil {
 					return fmt.Errorf("add ipv6 address %+v: %s", ipAddressStruct, err)
 				}
+				hostIPAddresses = append(hostIPAddresses, nbIPAddress)
 
 				// Also create prefix if it doesn't exist yet
 				prefix, mask, err := utils.GetPrefixAndMaskFromIPAddress(nbIPAddress.Address)
@@ -617,6 +659,17 @@ func (o *OVirtSource) syncHostNics(
 				}
 			}
 		}
+		err = common.SetPrimaryIPAddresses(
+			o.Ctx,
+			nbi,
+			nbHost,
+			hostIPAddresses,
+			preferredIPAddresses,
+			o.SourceConfig,
+		)
+		if err != nil {
+			o.Logger.Warningf(o.Ctx, "set primary ip addresses for %s: %s", nbHost, err)
+		}
 	}
 	return nil
 }
@@ -875,8 +928,8 @@ func (o *OVirtSource) collectHostNicsData(
 				vlanSite, err := common.MatchVlanToSite(
 					o.Ctx,
 					nbi,
-					vlanName,
-					o.SourceConfig.VlanSiteRelations,
+					utils.RelationAttributes{Name: vlanName},
+					o.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match vlan to site: %s", err)
@@ -885,10 +938,9 @@ func (o *OVirtSource) collectHostNicsData(
 				vlanGroup, err := common.MatchVlanToGroup(
 					o.Ctx,
 					nbi,
-					vlanName,
+					utils.RelationAttributes{Name: vlanName},
 					vlanSite,
-					o.SourceConfig.VlanGroupRelations,
-					o.SourceConfig.VlanGroupSiteRelations,
+					o.SourceConfig,
 				)
 				if err != nil {
 					return err
@@ -1003,11 +1055,25 @@ func (o *OVirtSource) syncVM(
 	vmID string,
 	ovirtVM *ovirtsdk4.Vm,
 ) error {
+	filtered, err := o.filterVM(ovirtVM)
+	if err != nil {
+		return fmt.Errorf("filter vm: %s", err)
+	}
+	if filtered {
+		return nil
+	}
 	collectedVM, err := o.extractVMData(nbi, vmID, ovirtVM)
 	if err != nil {
 		return err
 	}
 
+	keep, err := common.TransformVM(o.Ctx, nbi, o.SourceConfig, collectedVM, o.GetSourceTags())
+	if err != nil {
+		return err
+	}
+	if !keep {
+		return nil
+	}
 	nbVM, err := nbi.AddVM(o.Ctx, collectedVM)
 	if err != nil {
 		return fmt.Errorf("failed to sync oVirt vm %s: %v", collectedVM.Name, err)
@@ -1018,9 +1084,54 @@ func (o *OVirtSource) syncVM(
 		return fmt.Errorf("failed to sync oVirt vm %s's interfaces: %v", collectedVM.Name, err)
 	}
 
+	err = common.AddVMRoleServices(o.Ctx, nbi, nbVM, o.SourceConfig.VMRoleServices, o.GetSourceTags())
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// filterVM returns true, if the vm is filtered out, or belongs to a filtered out cluster.
+func (o *OVirtSource) filterVM(vm *ovirtsdk4.Vm) (bool, error) {
+	vmName, _ := vm.Name()
+	vmAttributes := utils.RelationAttributes{Name: vmName}
+	if cluster, exists := vm.Cluster(); exists {
+		if o.FilteredClusterIDs[cluster.MustId()] {
+			o.Logger.Debugf(o.Ctx, "skipping vm %s of filtered out cluster", vmAttributes.Name)
+			return true, nil
+		}
+		vmAttributes.Cluster, vmAttributes.Datacenter = o.clusterNames(cluster.MustId())
+	}
+	if status, exists := vm.Status(); exists {
+		switch status {
+		case ovirtsdk4.VMSTATUS_UP:
+			vmAttributes.PowerState = "on"
+		case ovirtsdk4.VMSTATUS_DOWN:
+			vmAttributes.PowerState = "off"
+		default:
+			vmAttributes.PowerState = string(status)
+		}
+	}
+	return o.IsFiltered("vm", vmAttributes)
+}
+
+// clusterNames returns names of the cluster with the given id and of its datacenter.
+func (o *OVirtSource) clusterNames(clusterID string) (string, string) {
+	cluster, ok := o.Clusters[clusterID]
+	if !ok {
+		return "", ""
+	}
+	clusterName, _ := cluster.Name()
+	var datacenterName string
+	if datacenter, exists := cluster.DataCenter(); exists {
+		if ovirtDatacenter, ok := o.DataCenters[datacenter.MustId()]; ok {
+			datacenterName, _ = ovirtDatacenter.Name()
+		}
+	}
+	return clusterName, datacenterName
+}
+
 //
 //nolint:gocyclo
 func (o *OVirtSource) extractVMData(
@@ -1076,11 +1187,7 @@ func (o *OVirtSource) extractVMData(
 	// VM's Host Device (server)
 	var vmHostDevice *objects.Device
 	if host, exists := vm.Host(); exists {
-		if oHost, ok := o.Hosts[host.MustId()]; ok {
-			if oHostName, ok := oHost.Name(); ok {
-				vmHostDevice, _ = nbi.GetDevice(oHostName, vmSite.ID)
-			}
-		}
+		vmHostDevice = o.NBHosts[host.MustId()]
 	}
 
 	// vmVCPUs
@@ -1159,14 +1266,14 @@ func (o *OVirtSource) extractVMData(
 		}
 	}
 	var vmRole *objects.DeviceRole
-	if len(o.SourceConfig.VMRoleRelations) > 0 {
-		vmRole, err = common.MatchVMToRole(o.Ctx, nbi, vmName, o.SourceConfig.VMRoleRelations)
+	if len(o.SourceConfig.VMRoleRelations) > 0 || len(o.SourceConfig.RelationRules.VMRoleRelations) > 0 {
+		vmRole, err = common.MatchVMToRole(o.Ctx, nbi, utils.RelationAttributes{Name: vmName}, o.SourceConfig)
 		if err != nil {
 			return nil, fmt.Errorf("match vm to role: %s", err)
 		}
 	}
 	if vmRole == nil {
-		vmRole, err = nbi.AddVMDeviceRole(o.Ctx)
+		vmRole, err = nbi.AddVMDeviceRole(o.Ctx, o.SourceConfig.Defaults.Roles)
 		if err != nil {
 			return nil, fmt.Errorf("add vm device role: %s", err)
 		}
@@ -1220,6 +1327,10 @@ func (o *OVirtSource) syncVMInterfaces(
 		return fmt.Errorf("sync VMNics %s", err)
 	}
 	if reportedDevices, exist := ovirtVM.ReportedDevices(); exist {
+		// IP address which VM's name resolves to is preferred as primary
+		vmIP := utils.Lookup(netboxVM.Name)
+		vmIPAddresses := []*objects.IPAddress{}
+		preferredIPAddresses := []*objects.IPAddress{}
 		for _, reportedDevice := range reportedDevices.Slice() {
 			if reportedDeviceType, exist := reportedDevice.Type(); exist {
 				if reportedDeviceType == "network" {
@@ -1281,22 +1392,43 @@ func (o *OVirtSource) syncVMInterfaces(
 						o.Logger.Warning(o.Ctx, "name for oVirt vm's reported device is empty. Skipping...")
 						continue
 					}
-					o.processVMInterfaceIPs(nbi, reportedDevice, netboxVM, vmInterface)
+					ipAddresses, preferred := o.processVMInterfaceIPs(
+						nbi,
+						reportedDevice,
+						netboxVM,
+						vmInterface,
+						vmIP,
+					)
+					vmIPAddresses = append(vmIPAddresses, ipAddresses...)
+					preferredIPAddresses = append(preferredIPAddresses, preferred...)
 				}
 			}
 		}
+		err := common.SetPrimaryIPAddresses(
+			o.Ctx,
+			nbi,
+			netboxVM,
+			vmIPAddresses,
+			preferredIPAddresses,
+			o.SourceConfig,
+		)
+		if err != nil {
+			return fmt.Errorf("set primary ip addresses for %s: %s", netboxVM, err)
+		}
 	}
 	return nil
 }
 
 // processVMInterfaceIPs is a helper function for syncVMInterfaces,
-// that processes IPs of VM interfaces.
+// that processes IPs of VM interfaces. It returns all synced ip addresses
+// and those matching vmIP, which are preferred as VM's primary ip address.
 func (o *OVirtSource) processVMInterfaceIPs(
 	nbi *inventory.NetboxInventory,
 	reportedDevice *ovirtsdk4.ReportedDevice,
 	netboxVM *objects.VM,
 	vmInterface *objects.VMInterface,
-) {
+	vmIP string,
+) (ipAddresses []*objects.IPAddress, preferredIPAddresses []*objects.IPAddress) {
 	if reportedDeviceIps, exist := reportedDevice.Ips(); exist {
 		for _, ip := range reportedDeviceIps.Slice() {
 			if ipAddress, exists := ip.Address(); exists {
@@ -1352,24 +1484,9 @@ func (o *OVirtSource) processVMInterfaceIPs(
 							continue
 						}
 
-						// Check if ip is primary
-						if ipVersion == "v4" {
-							vmIP := utils.Lookup(netboxVM.Name)
-							if vmIP != "" && vmIP == ipAddress ||
-								netboxVM.PrimaryIPv4 == nil {
-								vmCopy := *netboxVM
-								vmCopy.PrimaryIPv4 = newIPAddress
-								_, err := nbi.AddVM(o.Ctx, &vmCopy)
-								if err != nil {
-									o.Logger.Warningf(
-										o.Ctx,
-										"adding vm's primary ipv4 address %+v: %s",
-										newIPAddress,
-										err,
-									)
-									continue
-								}
-							}
+						ipAddresses = append(ipAddresses, newIPAddress)
+						if vmIP != "" && vmIP == ipAddress {
+							preferredIPAddresses = append(preferredIPAddresses, newIPAddress)
 						}
 						prefix, mask, err := utils.GetPrefixAndMaskFromIPAddress(
 							newIPAddress.Address,
@@ -1389,6 +1506,7 @@ func (o *OVirtSource) processVMInterfaceIPs(
 			}
 		}
 	}
+	return ipAddresses, preferredIPAddresses
 }
 
 func (o *OVirtSource) syncVMNics(
@@ -1446,8 +1564,8 @@ func (o *OVirtSource) syncVMNics(
 							vlanSite, err := common.MatchVlanToSite(
 								o.Ctx,
 								nbi,
-								vlanName,
-								o.SourceConfig.VlanSiteRelations,
+								utils.RelationAttributes{Name: vlanName},
+								o.SourceConfig,
 							)
 							if err != nil {
 								return fmt.Errorf("match vlan to site: %s", err)
@@ -1455,10 +1573,9 @@ func (o *OVirtSource) syncVMNics(
 							vlanGroup, err := common.MatchVlanToGroup(
 								o.Ctx,
 								nbi,
-								vlanName,
+								utils.RelationAttributes{Name: vlanName},
 								vlanSite,
-								o.SourceConfig.VlanGroupRelations,
-								o.SourceConfig.VlanGroupSiteRelations,
+								o.SourceConfig,
 							)
 							if err != nil {
 								o.Logger.Warningf(o.Ctx, "match vlan to group: %s", err)
@@ -1,15 +1,22 @@
 package paloalto
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/PaloAltoNetworks/pango"
+	"github.com/PaloAltoNetworks/pango/netw/ikegw"
 	"github.com/PaloAltoNetworks/pango/netw/interface/eth"
 	"github.com/PaloAltoNetworks/pango/netw/interface/subinterface/layer3"
+	"github.com/PaloAltoNetworks/pango/netw/ipsectunnel"
+	tpiv4 "github.com/PaloAltoNetworks/pango/netw/ipsectunnel/proxyid/ipv4"
 	"github.com/PaloAltoNetworks/pango/netw/routing/router"
 	"github.com/PaloAltoNetworks/pango/netw/zone"
+	"github.com/PaloAltoNetworks/pango/objs/addr"
+	"github.com/PaloAltoNetworks/pango/objs/srvc"
+	"github.com/PaloAltoNetworks/pango/poli/nat"
 	"github.com/PaloAltoNetworks/pango/vsys"
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
@@ -18,6 +25,10 @@ import (
 	"github.com/bl4ko/netbox-ssot/internal/utils"
 )
 
+// defaultVirtualRouterName is the name of the virtual router, that
+// represents the global routing table on palo alto firewalls.
+const defaultVirtualRouterName = "default"
+
 //nolint:revive
 type PaloAltoSource struct {
 	common.Config
@@ -31,9 +42,21 @@ type PaloAltoSource struct {
 	Iface2SubIfaces     map[string][]layer3.Entry // Iface name -> SubIfaces
 	VirtualRouters      map[string]router.Entry   // VirtualRouter name -> VirutalRouter
 	ArpData             []ArpEntry                // Array of arp entreies
+	DHCPServers         []DHCPServerInterface     // Array of interfaces with dhcp server
+	HAGroup             HAGroupData               // High availability group with virtual addresses
+	NATRules            []nat.Entry               // Array of nat rules of all virtual systems
+	ServiceObjects      map[string]srvc.Entry     // Service name -> Service object
+	AddressObjects      map[string]addr.Entry     // Address name -> Address object
+	BGPSessions         []common.BGPSession       // Array of bgp peers of all virtual routers
+	IPsecTunnels        []ipsectunnel.Entry       // Array of auto key ipsec tunnels
+	IKEGateways         map[string]ikegw.Entry    // IKE gateway name -> IKE gateway
+	IPsecProxyIDs       map[string][]tpiv4.Entry  // IPsec tunnel name -> IPv4 proxy ids
+	LocalContextData    map[string]interface{}    // Dns, ntp and syslog servers of the firewall
 
 	// NBFirewall representing paloalto firewall created in syncDevice func.
 	NBFirewall *objects.Device
+	// NBVirtualRouters maps VirtualRouter name -> VRF created in syncVirtualRouters func.
+	NBVirtualRouters map[string]*objects.VRF
 }
 
 func (pas *PaloAltoSource) Init() error {
@@ -67,6 +90,12 @@ func (pas *PaloAltoSource) Init() error {
 		pas.initVirtualSystems,
 		pas.initInterfaces,
 		pas.initVirtualRouters,
+		pas.initDHCPServers,
+		pas.initHAVirtualAddresses,
+		pas.initNATRules,
+		pas.initBGPSessions,
+		pas.initIPsecTunnels,
+		pas.initLocalContextData,
 	}
 	for _, initFunc := range initFunctions {
 		startTime := time.Now()
@@ -87,14 +116,24 @@ func (pas *PaloAltoSource) Init() error {
 func (pas *PaloAltoSource) Sync(nbi *inventory.NetboxInventory) error {
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		pas.syncDevice,
+		pas.syncVirtualRouters,
 		pas.syncSecurityZones,
 		pas.syncInterfaces,
+		pas.syncDHCPServers,
+		pas.syncHAVirtualAddresses,
+		pas.syncNATRules,
+		pas.syncBGPSessions,
+		pas.syncIPsecTunnels,
 		pas.syncArpTable,
 	}
 
 	for _, syncFunc := range syncFunctions {
 		startTime := time.Now()
 		err := syncFunc(nbi)
+		if errors.Is(err, common.ErrDeviceDropped) {
+			pas.Logger.Info(pas.Ctx, "Device is filtered out or dropped by a transform, skipping other objects")
+			return nil
+		}
 		if err != nil {
 			return err
 		}
@@ -2,14 +2,27 @@ package paloalto
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/PaloAltoNetworks/pango"
+	pangoerrors "github.com/PaloAltoNetworks/pango/errors"
+	"github.com/PaloAltoNetworks/pango/netw/ikegw"
 	"github.com/PaloAltoNetworks/pango/netw/interface/eth"
 	"github.com/PaloAltoNetworks/pango/netw/interface/subinterface/layer3"
+	"github.com/PaloAltoNetworks/pango/netw/ipsectunnel"
+	tpiv4 "github.com/PaloAltoNetworks/pango/netw/ipsectunnel/proxyid/ipv4"
 	"github.com/PaloAltoNetworks/pango/netw/routing/router"
 	"github.com/PaloAltoNetworks/pango/netw/zone"
+	"github.com/PaloAltoNetworks/pango/objs/addr"
+	"github.com/PaloAltoNetworks/pango/objs/srvc"
+	"github.com/PaloAltoNetworks/pango/poli/nat"
 	"github.com/PaloAltoNetworks/pango/vsys"
+	"github.com/bl4ko/netbox-ssot/internal/constants"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
 )
 
 // Init system info collects system info from paloalto.
@@ -126,3 +139,286 @@ func (pas *PaloAltoSource) initArpData(c *pango.Firewall) error {
 	}
 	return nil
 }
+
+// dhcpServerXpath is the configuration xpath of dhcp servers per interface.
+const dhcpServerXpath = "/config/devices/entry[@name='localhost.localdomain']/network/dhcp/interface"
+
+type DHCPServerData struct {
+	XMLName    xml.Name              `xml:"response"`
+	Status     string                `xml:"status,attr"`
+	Interfaces []DHCPServerInterface `xml:"result>interface>entry"`
+}
+
+type DHCPServerInterface struct {
+	Name    string   `xml:"name,attr"`
+	Mode    string   `xml:"server>mode"`
+	IPPools []string `xml:"server>ip-pool>member"`
+}
+
+// initDHCPServers collects dhcp server configuration for all interfaces.
+// It stores them as attribute of the paloalto source.
+func (pas *PaloAltoSource) initDHCPServers(c *pango.Firewall) error {
+	var dhcpServerData DHCPServerData
+	_, err := c.Show(dhcpServerXpath, nil, &dhcpServerData)
+	if err != nil {
+		var panosErr pangoerrors.Panos
+		if errors.As(err, &panosErr) && panosErr.ObjectNotFound() {
+			pas.Logger.Debug(pas.Ctx, "no dhcp servers configured")
+			return nil
+		}
+		return fmt.Errorf("init dhcp servers: %s", err)
+	}
+	pas.DHCPServers = dhcpServerData.Interfaces
+	return nil
+}
+
+// haGroupXpath is the configuration xpath of high availability group.
+const haGroupXpath = "/config/devices/entry[@name='localhost.localdomain']/deviceconfig/high-availability/group"
+
+type HAGroupData struct {
+	XMLName          xml.Name                    `xml:"response"`
+	Status           string                      `xml:"status,attr"`
+	GroupID          int                         `xml:"result>group>group-id"`
+	DevicePriority   int                         `xml:"result>group>election-option>device-priority"`
+	VirtualAddresses []HAVirtualAddressInterface `xml:"result>group>mode>active-active>virtual-address>entry"`
+}
+
+type HAVirtualAddressInterface struct {
+	Name  string   `xml:"name,attr"`
+	IPv4s []HAAddr `xml:"ip>entry"`
+	IPv6s []HAAddr `xml:"ipv6>entry"`
+}
+
+type HAAddr struct {
+	Address string `xml:"name,attr"`
+}
+
+// initHAVirtualAddresses collects virtual addresses of active/active high availability group.
+// It stores them as attribute of the paloalto source.
+func (pas *PaloAltoSource) initHAVirtualAddresses(c *pango.Firewall) error {
+	var haGroupData HAGroupData
+	_, err := c.Show(haGroupXpath, nil, &haGroupData)
+	if err != nil {
+		var panosErr pangoerrors.Panos
+		if errors.As(err, &panosErr) && panosErr.ObjectNotFound() {
+			pas.Logger.Debug(pas.Ctx, "no high availability group configured")
+			return nil
+		}
+		return fmt.Errorf("init ha virtual addresses: %s", err)
+	}
+	pas.HAGroup = haGroupData
+	return nil
+}
+
+// initNATRules collects nat rules of all virtual systems, together with
+// service and address objects, that are used to resolve destination translation.
+// It stores them as attribute of the paloalto source.
+func (pas *PaloAltoSource) initNATRules(c *pango.Firewall) error {
+	pas.NATRules = make([]nat.Entry, 0)
+	pas.ServiceObjects = make(map[string]srvc.Entry)
+	pas.AddressObjects = make(map[string]addr.Entry)
+
+	predefinedServices, err := c.Predefined.Services.ShowAll()
+	if err != nil {
+		pas.Logger.Warningf(pas.Ctx, "get predefined services: %s", err)
+	}
+	for _, serviceObject := range predefinedServices {
+		pas.ServiceObjects[serviceObject.Name] = serviceObject
+	}
+
+	for vsysName := range pas.VirtualSystems {
+		natRules, err := c.Policies.Nat.GetAll(vsysName)
+		if err != nil {
+			return fmt.Errorf("get nat rules for virtual system %s: %s", vsysName, err)
+		}
+		pas.NATRules = append(pas.NATRules, natRules...)
+
+		serviceObjects, err := c.Objects.Services.GetAll(vsysName)
+		if err != nil {
+			return fmt.Errorf("get services for virtual system %s: %s", vsysName, err)
+		}
+		for _, serviceObject := range serviceObjects {
+			pas.ServiceObjects[serviceObject.Name] = serviceObject
+		}
+
+		addressObjects, err := c.Objects.Address.GetAll(vsysName)
+		if err != nil {
+			return fmt.Errorf("get addresses for virtual system %s: %s", vsysName, err)
+		}
+		for _, addressObject := range addressObjects {
+			pas.AddressObjects[addressObject.Name] = addressObject
+		}
+	}
+	return nil
+}
+
+// initBGPSessions collects bgp peers of all virtual routers with enabled bgp.
+// It stores them as attribute of the paloalto source.
+//
+// Must be run after initVirtualRouters and initNATRules, because peer
+// addresses can be address objects.
+func (pas *PaloAltoSource) initBGPSessions(c *pango.Firewall) error {
+	pas.BGPSessions = make([]common.BGPSession, 0)
+	for vrName := range pas.VirtualRouters {
+		bgpConfig, err := c.Network.BgpConfig.Get(vrName)
+		if err != nil {
+			var panosErr pangoerrors.Panos
+			if errors.As(err, &panosErr) && panosErr.ObjectNotFound() {
+				pas.Logger.Debugf(pas.Ctx, "no bgp configured on virtual router %s", vrName)
+				continue
+			}
+			return fmt.Errorf("get bgp config for virtual router %s: %s", vrName, err)
+		}
+		if !bgpConfig.Enable {
+			continue
+		}
+		localASN, err := utils.ParseASN(bgpConfig.AsNumber)
+		if err != nil {
+			pas.Logger.Warningf(pas.Ctx, "virtual router %s local as: %s", vrName, err)
+			continue
+		}
+		vrfName := vrName
+		if vrName == defaultVirtualRouterName {
+			vrfName = ""
+		}
+
+		peerGroups, err := c.Network.BgpPeerGroup.GetAll(vrName)
+		if err != nil {
+			return fmt.Errorf("get bgp peer groups for virtual router %s: %s", vrName, err)
+		}
+		for _, peerGroup := range peerGroups {
+			if !peerGroup.Enable {
+				continue
+			}
+			peers, err := c.Network.BgpPeer.GetAll(vrName, peerGroup.Name)
+			if err != nil {
+				return fmt.Errorf("get bgp peers for peer group %s: %s", peerGroup.Name, err)
+			}
+			for _, peer := range peers {
+				if !peer.Enable {
+					continue
+				}
+				remoteASN := localASN
+				if peer.PeerAs != "" {
+					remoteASN, err = utils.ParseASN(peer.PeerAs)
+					if err != nil {
+						pas.Logger.Warningf(pas.Ctx, "bgp peer %s remote as: %s", peer.Name, err)
+						continue
+					}
+				}
+				remoteAddress := pas.resolveAddress(peer.PeerAddressIp)
+				if remoteAddress == "" {
+					pas.Logger.Debugf(pas.Ctx, "bgp peer %s has no host peer address. Skipping...", peer.Name)
+					continue
+				}
+				localAddress, _, _ := strings.Cut(peer.LocalAddressIp, "/")
+				pas.BGPSessions = append(pas.BGPSessions, common.BGPSession{
+					LocalASN:      localASN,
+					LocalAddress:  pas.resolveAddress(localAddress),
+					RemoteASN:     remoteASN,
+					RemoteAddress: remoteAddress,
+					VRF:           vrfName,
+					Name:          peer.Name,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// initIPsecTunnels collects auto key ipsec tunnels with their ike gateways
+// and proxy ids. It stores them as attributes of the paloalto source.
+func (pas *PaloAltoSource) initIPsecTunnels(c *pango.Firewall) error {
+	pas.IKEGateways = make(map[string]ikegw.Entry)
+	pas.IPsecProxyIDs = make(map[string][]tpiv4.Entry)
+	tunnels, err := c.Network.IpsecTunnel.GetAll()
+	if err != nil {
+		var panosErr pangoerrors.Panos
+		if errors.As(err, &panosErr) && panosErr.ObjectNotFound() {
+			pas.Logger.Debug(pas.Ctx, "no ipsec tunnels configured")
+			return nil
+		}
+		return fmt.Errorf("get ipsec tunnels: %s", err)
+	}
+	gateways, err := c.Network.IkeGateway.GetAll()
+	if err != nil {
+		return fmt.Errorf("get ike gateways: %s", err)
+	}
+	for _, gateway := range gateways {
+		pas.IKEGateways[gateway.Name] = gateway
+	}
+	for _, tunnel := range tunnels {
+		if tunnel.Type != ipsectunnel.TypeAutoKey {
+			pas.Logger.Debugf(pas.Ctx, "ipsec tunnel %s of type %s. Skipping...", tunnel.Name, tunnel.Type)
+			continue
+		}
+		pas.IPsecTunnels = append(pas.IPsecTunnels, tunnel)
+		proxyIDs, err := c.Network.IpsecTunnelProxyId.GetAll(tunnel.Name)
+		if err != nil {
+			var panosErr pangoerrors.Panos
+			if errors.As(err, &panosErr) && panosErr.ObjectNotFound() {
+				continue
+			}
+			return fmt.Errorf("get proxy ids of ipsec tunnel %s: %s", tunnel.Name, err)
+		}
+		pas.IPsecProxyIDs[tunnel.Name] = proxyIDs
+	}
+	return nil
+}
+
+// initLocalContextData collects dns, ntp and syslog servers of the firewall,
+// which are written to local context data of the firewall.
+//
+// Must be run after initVirtualSystems, because syslog server profiles
+// can be defined per virtual system.
+func (pas *PaloAltoSource) initLocalContextData(c *pango.Firewall) error {
+	if !pas.SourceConfig.CollectLocalContext {
+		return nil
+	}
+	generalSettings, err := c.Device.GeneralSettings.Get()
+	if err != nil {
+		return fmt.Errorf("get general settings: %s", err)
+	}
+	dnsServers := make([]string, 0)
+	for _, server := range []string{generalSettings.DnsPrimary, generalSettings.DnsSecondary} {
+		if server != "" {
+			dnsServers = append(dnsServers, server)
+		}
+	}
+	ntpServers := make([]string, 0)
+	for _, server := range []string{generalSettings.NtpPrimaryAddress, generalSettings.NtpSecondaryAddress} {
+		if server != "" {
+			ntpServers = append(ntpServers, server)
+		}
+	}
+
+	// Syslog server profiles can be shared ("") or defined in each virtual system
+	syslogServers := make([]string, 0)
+	locations := []string{""}
+	for vsysName := range pas.VirtualSystems {
+		locations = append(locations, vsysName)
+	}
+	for _, location := range locations {
+		syslogProfiles, err := c.Device.SyslogServerProfile.GetAll(location)
+		if err != nil {
+			var panosErr pangoerrors.Panos
+			if errors.As(err, &panosErr) && panosErr.ObjectNotFound() {
+				continue
+			}
+			return fmt.Errorf("get syslog server profiles: %s", err)
+		}
+		for _, syslogProfile := range syslogProfiles {
+			for _, server := range syslogProfile.Servers {
+				if server.Server != "" && !slices.Contains(syslogServers, server.Server) {
+					syslogServers = append(syslogServers, server.Server)
+				}
+			}
+		}
+	}
+	pas.LocalContextData = map[string]interface{}{
+		constants.LocalContextKeyDNSServers:    dnsServers,
+		constants.LocalContextKeyNTPServers:    ntpServers,
+		constants.LocalContextKeySyslogServers: syslogServers,
+	}
+	return nil
+}
@@ -3,9 +3,11 @@ package paloalto
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/PaloAltoNetworks/pango/objs/addr"
 	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/inventory"
 	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
@@ -19,6 +21,13 @@ func (pas *PaloAltoSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	if deviceName == "" {
 		return fmt.Errorf("can't extract device name from system info")
 	}
+	filtered, err := pas.IsFiltered("device", utils.RelationAttributes{Name: deviceName})
+	if err != nil {
+		return fmt.Errorf("filter device: %s", err)
+	}
+	if filtered {
+		return common.ErrDeviceDropped
+	}
 	var deviceSerialNumber string
 	if !pas.SourceConfig.IgnoreSerialNumbers {
 		deviceSerialNumber = pas.SystemInfo["serial"]
@@ -29,7 +38,7 @@ func (pas *PaloAltoSource) syncDevice(nbi *inventory.NetboxInventory) error {
 			pas.Ctx,
 			"model field in system info is empty. Using fallback mechanism.",
 		)
-		deviceModel = constants.DefaultModel
+		deviceModel = pas.SourceConfig.Defaults.DeviceType.Name
 	}
 	deviceManufacturer, err := nbi.AddManufacturer(pas.Ctx, &objects.Manufacturer{
 		Name: "Palo Alto",
@@ -51,27 +60,27 @@ func (pas *PaloAltoSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	deviceTenant, err := common.MatchHostToTenant(
 		pas.Ctx,
 		nbi,
-		deviceName,
-		pas.SourceConfig.HostTenantRelations,
+		utils.RelationAttributes{Name: deviceName},
+		pas.SourceConfig,
 	)
 	if err != nil {
 		return fmt.Errorf("match host %s to tenant: %s", deviceName, err)
 	}
 
 	var deviceRole *objects.DeviceRole
-	if len(pas.SourceConfig.HostRoleRelations) > 0 {
+	if len(pas.SourceConfig.HostRoleRelations) > 0 || len(pas.SourceConfig.RelationRules.HostRoleRelations) > 0 {
 		deviceRole, err = common.MatchHostToRole(
 			pas.Ctx,
 			nbi,
-			deviceName,
-			pas.SourceConfig.HostRoleRelations,
+			utils.RelationAttributes{Name: deviceName},
+			pas.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match host to role: %s", err)
 		}
 	}
 	if deviceRole == nil {
-		deviceRole, err = nbi.AddFirewallDeviceRole(pas.Ctx)
+		deviceRole, err = nbi.AddFirewallDeviceRole(pas.Ctx, pas.SourceConfig.Defaults.Roles)
 		if err != nil {
 			return fmt.Errorf("add DeviceRole firewall: %s", err)
 		}
@@ -79,8 +88,8 @@ func (pas *PaloAltoSource) syncDevice(nbi *inventory.NetboxInventory) error {
 	deviceSite, err := common.MatchHostToSite(
 		pas.Ctx,
 		nbi,
-		deviceName,
-		pas.SourceConfig.HostSiteRelations,
+		utils.RelationAttributes{Name: deviceName},
+		pas.SourceConfig,
 	)
 	if err != nil {
 		return fmt.Errorf("match host to site: %s", err)
@@ -99,14 +108,25 @@ func (pas *PaloAltoSource) syncDevice(nbi *inventory.NetboxInventory) error {
 		NetboxObject: objects.NetboxObject{
 			Tags: pas.GetSourceTags(),
 		},
-		Name:         deviceName,
-		Site:         deviceSite,
-		DeviceRole:   deviceRole,
-		Status:       &objects.DeviceStatusActive,
-		DeviceType:   deviceType,
-		Tenant:       deviceTenant,
-		Platform:     devicePlatform,
-		SerialNumber: deviceSerialNumber,
+		Name:             deviceName,
+		Site:             deviceSite,
+		DeviceRole:       deviceRole,
+		Status:           &objects.DeviceStatusActive,
+		DeviceType:       deviceType,
+		Tenant:           deviceTenant,
+		Platform:         devicePlatform,
+		SerialNumber:     deviceSerialNumber,
+		LocalContextData: common.FilterLocalContextData(pas.LocalContextData, pas.SourceConfig),
+	}
+	if err := common.MatchDeviceToRack(pas.Ctx, nbi, deviceStruct, pas.SourceConfig); err != nil {
+		pas.Logger.Warningf(pas.Ctx, "device %s rack placement: %s", deviceStruct.Name, err)
+	}
+	keep, err := common.TransformDevice(pas.Ctx, nbi, pas.SourceConfig, deviceStruct, pas.GetSourceTags())
+	if err != nil {
+		return err
+	}
+	if !keep {
+		return common.ErrDeviceDropped
 	}
 	NBDevice, err := nbi.AddDevice(pas.Ctx, deviceStruct)
 	if err != nil {
@@ -118,6 +138,7 @@ func (pas *PaloAltoSource) syncDevice(nbi *inventory.NetboxInventory) error {
 }
 
 func (pas *PaloAltoSource) syncInterfaces(nbi *inventory.NetboxInventory) error {
+	ipAddresses := []*objects.IPAddress{}
 	for _, iface := range pas.Ifaces {
 		if iface.Name == "" {
 			pas.Logger.Debugf(pas.Ctx, "empty interface name. Skipping...")
@@ -172,13 +193,14 @@ func (pas *PaloAltoSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 			MTU:    iface.Mtu,
 			Speed:  ifaceLinkSpeed,
 			Vdcs:   ifaceVdcs,
+			VRF:    pas.getVRF(iface.Name),
 		})
 		if err != nil {
 			return fmt.Errorf("add interface %s", err)
 		}
 
 		if len(iface.StaticIps) > 0 {
-			pas.syncIPs(nbi, nbIface, iface.StaticIps, nil)
+			ipAddresses = append(ipAddresses, pas.syncIPs(nbi, nbIface, iface.StaticIps, nil)...)
 		}
 
 		for _, subIface := range pas.Iface2SubIfaces[iface.Name] {
@@ -189,14 +211,23 @@ func (pas *PaloAltoSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 			var subIfaceVlan *objects.Vlan
 			subIfaceVlans := []*objects.Vlan{}
 			var subifaceMode *objects.InterfaceMode
+			vlanFiltered := false
 			if subIface.Tag != 0 {
+				vlanFiltered, err = pas.IsFiltered(
+					"vlan", utils.RelationAttributes{Name: fmt.Sprintf("Vlan%d", subIface.Tag)},
+				)
+				if err != nil {
+					return fmt.Errorf("filter vlan: %s", err)
+				}
+			}
+			if subIface.Tag != 0 && !vlanFiltered {
 				// Extract Vlan
 				vlanName := fmt.Sprintf("Vlan%d", subIface.Tag)
 				vlanSite, err := common.MatchVlanToSite(
 					pas.Ctx,
 					nbi,
-					vlanName,
-					pas.SourceConfig.VlanSiteRelations,
+					utils.RelationAttributes{Name: vlanName},
+					pas.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match vlan to site: %s", err)
@@ -204,10 +235,9 @@ func (pas *PaloAltoSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 				vlanGroup, err := common.MatchVlanToGroup(
 					pas.Ctx,
 					nbi,
-					vlanName,
+					utils.RelationAttributes{Name: vlanName},
 					vlanSite,
-					pas.SourceConfig.VlanGroupRelations,
-					pas.SourceConfig.VlanGroupSiteRelations,
+					pas.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match vlan to group: %s", err)
@@ -215,8 +245,8 @@ func (pas *PaloAltoSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 				vlanTenant, err := common.MatchVlanToTenant(
 					pas.Ctx,
 					nbi,
-					vlanName,
-					pas.SourceConfig.VlanTenantRelations,
+					utils.RelationAttributes{Name: vlanName},
+					pas.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match vlan to tenant: %s", err)
@@ -257,27 +287,53 @@ func (pas *PaloAltoSource) syncInterfaces(nbi *inventory.NetboxInventory) error
 				ParentInterface: nbIface,
 				MTU:             subIface.Mtu,
 				Vdcs:            vdcs,
+				VRF:             pas.getVRF(subIfaceName),
 			}
 			nbSubIface, err := nbi.AddInterface(pas.Ctx, interfaceStruct)
 			if err != nil {
 				return fmt.Errorf("add subinterface +%v: %s", interfaceStruct, err)
 			}
 			if len(subIface.StaticIps) > 0 {
-				pas.syncIPs(nbi, nbSubIface, subIface.StaticIps, subIfaceVlan)
+				ipAddresses = append(
+					ipAddresses,
+					pas.syncIPs(nbi, nbSubIface, subIface.StaticIps, subIfaceVlan)...,
+				)
+			}
+		}
+	}
+	// Address the firewall is reached on is preferred as its primary ip
+	preferredIPAddresses := []*objects.IPAddress{}
+	if sourceIP := utils.Lookup(pas.SourceConfig.Hostname); sourceIP != "" {
+		for _, ipAddress := range ipAddresses {
+			if strings.Split(ipAddress.Address, "/")[0] == sourceIP {
+				preferredIPAddresses = append(preferredIPAddresses, ipAddress)
 			}
 		}
 	}
+	err := common.SetPrimaryIPAddresses(
+		pas.Ctx,
+		nbi,
+		pas.NBFirewall,
+		ipAddresses,
+		preferredIPAddresses,
+		pas.SourceConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("set primary ip addresses: %s", err)
+	}
 	return nil
 }
 
 // syncIPs adds all of the given ips to the given nbIface. It also
 // Extracts prefixes from ips and connect them with prefix vlan.
+// It returns ip addresses that were successfully added.
 func (pas *PaloAltoSource) syncIPs(
 	nbi *inventory.NetboxInventory,
 	nbIface *objects.Interface,
 	ips []string,
 	prefixVlan *objects.Vlan,
-) {
+) []*objects.IPAddress {
+	nbIPAddresses := []*objects.IPAddress{}
 	for _, ipAddress := range ips {
 		if utils.IsPermittedIPAddress(
 			ipAddress,
@@ -285,7 +341,7 @@ func (pas *PaloAltoSource) syncIPs(
 			pas.SourceConfig.IgnoredSubnets,
 		) {
 			dnsName := utils.ReverseLookup(ipAddress)
-			_, err := nbi.AddIPAddress(pas.Ctx, &objects.IPAddress{
+			nbIPAddress, err := nbi.AddIPAddress(pas.Ctx, &objects.IPAddress{
 				NetboxObject: objects.NetboxObject{
 					Tags: pas.GetSourceTags(),
 					CustomFields: map[string]interface{}{
@@ -296,6 +352,7 @@ func (pas *PaloAltoSource) syncIPs(
 				AssignedObjectID:   nbIface.ID,
 				DNSName:            dnsName,
 				AssignedObjectType: constants.ContentTypeDcimInterface,
+				VRF:                nbIface.VRF,
 			})
 			if err != nil {
 				pas.Logger.Errorf(
@@ -306,6 +363,7 @@ func (pas *PaloAltoSource) syncIPs(
 				)
 				continue
 			}
+			nbIPAddresses = append(nbIPAddresses, nbIPAddress)
 			prefix, mask, err := utils.GetPrefixAndMaskFromIPAddress(ipAddress)
 			if err != nil {
 				pas.Logger.Warningf(pas.Ctx, "extract prefix from address: %s", err)
@@ -318,6 +376,7 @@ func (pas *PaloAltoSource) syncIPs(
 					Prefix: prefix,
 					Tenant: prefixTenant,
 					Vlan:   prefixVlan,
+					VRF:    nbIface.VRF,
 				}
 				_, err = nbi.AddPrefix(pas.Ctx, prefixStruct)
 				if err != nil {
@@ -326,6 +385,39 @@ func (pas *PaloAltoSource) syncIPs(
 			}
 		}
 	}
+	return nbIPAddresses
+}
+
+// syncVirtualRouters syncs all virtual routers from palo alto as VRFs in netbox.
+// The default virtual router represents the global routing table, so no VRF is
+// created for it.
+func (pas *PaloAltoSource) syncVirtualRouters(nbi *inventory.NetboxInventory) error {
+	pas.NBVirtualRouters = make(map[string]*objects.VRF, len(pas.VirtualRouters))
+	for routerName := range pas.VirtualRouters {
+		if routerName == "" || routerName == defaultVirtualRouterName {
+			continue
+		}
+		vrfStruct := &objects.VRF{
+			NetboxObject: objects.NetboxObject{
+				Tags:        pas.GetSourceTags(),
+				Description: fmt.Sprintf("Virtual router %s", routerName),
+			},
+			Name:   common.DeviceVRFName(pas.NBFirewall.Name, routerName),
+			Tenant: pas.NBFirewall.Tenant,
+		}
+		nbVRF, err := nbi.AddVRF(pas.Ctx, vrfStruct)
+		if err != nil {
+			return fmt.Errorf("add vrf %+v: %s", vrfStruct, err)
+		}
+		pas.NBVirtualRouters[routerName] = nbVRF
+	}
+	return nil
+}
+
+// getVRF retrieves the VRF of the virtual router the given interface belongs to.
+// It returns nil for interfaces in the default virtual router (global table).
+func (pas *PaloAltoSource) getVRF(ifaceName string) *objects.VRF {
+	return pas.NBVirtualRouters[pas.Iface2VirtualRouter[ifaceName]]
 }
 
 // syncSecurityZones syncs all security zones from palo alto as virtual device context in netbox.
@@ -361,6 +453,303 @@ func (pas *PaloAltoSource) getVirtualDeviceContext(
 	return virtualDeviceContext
 }
 
+// syncDHCPServers syncs ip pools of all dhcp servers as ip ranges in netbox.
+// Each ip range is linked with the VRF of the interface the dhcp server is serving.
+func (pas *PaloAltoSource) syncDHCPServers(nbi *inventory.NetboxInventory) error {
+	for _, dhcpServer := range pas.DHCPServers {
+		if dhcpServer.Mode == "disabled" {
+			continue
+		}
+		var rangeVRF *objects.VRF
+		if nbIface, ok := nbi.GetInterface(dhcpServer.Name, pas.NBFirewall.ID); ok {
+			rangeVRF = nbIface.VRF
+		}
+		ifaceIPs := pas.getInterfaceIPs(dhcpServer.Name)
+		for _, ipPool := range dhcpServer.IPPools {
+			startAddress, endAddress, err := getIPPoolBoundaries(ipPool, ifaceIPs)
+			if err != nil {
+				pas.Logger.Warningf(pas.Ctx, "parse dhcp ip pool %s: %s", ipPool, err)
+				continue
+			}
+			if !utils.IsPermittedIPAddress(
+				startAddress,
+				pas.SourceConfig.PermittedSubnets,
+				pas.SourceConfig.IgnoredSubnets,
+			) {
+				continue
+			}
+			ipRangeStruct := &objects.IPRange{
+				NetboxObject: objects.NetboxObject{
+					Tags: pas.GetSourceTags(),
+					Description: fmt.Sprintf(
+						"DHCP pool on %s interface %s",
+						pas.NBFirewall.Name,
+						dhcpServer.Name,
+					),
+				},
+				StartAddress: startAddress,
+				EndAddress:   endAddress,
+				Status:       &objects.IPRangeStatusActive,
+				VRF:          rangeVRF,
+				Tenant:       pas.NBFirewall.Tenant,
+			}
+			if _, err := nbi.AddIPRange(pas.Ctx, ipRangeStruct); err != nil {
+				return fmt.Errorf("add ip range %+v: %s", ipRangeStruct, err)
+			}
+		}
+	}
+	return nil
+}
+
+// getInterfaceIPs returns static ips of the interface or subinterface with the given name.
+func (pas *PaloAltoSource) getInterfaceIPs(ifaceName string) []string {
+	if iface, ok := pas.Ifaces[ifaceName]; ok {
+		return iface.StaticIps
+	}
+	for _, subIfaces := range pas.Iface2SubIfaces {
+		for _, subIface := range subIfaces {
+			if subIface.Name == ifaceName {
+				return subIface.StaticIps
+			}
+		}
+	}
+	return nil
+}
+
+// getIPPoolBoundaries returns start and end address (with mask) of the given dhcp ip pool.
+// Ip pool can be a range (192.168.1.10-192.168.1.100), a subnet (192.168.1.0/24)
+// or a single ip address. Mask of a range is taken from the matching interface ip.
+func getIPPoolBoundaries(ipPool string, ifaceIPs []string) (string, string, error) {
+	if strings.Contains(ipPool, "/") {
+		return utils.GetUsableIPv4Range(ipPool)
+	}
+	startIP, endIP, isRange := strings.Cut(ipPool, "-")
+	if !isRange {
+		endIP = startIP
+	}
+	startIP = strings.TrimSpace(startIP)
+	endIP = strings.TrimSpace(endIP)
+	if utils.GetIPVersion(startIP) == 0 || utils.GetIPVersion(endIP) == 0 {
+		return "", "", fmt.Errorf("invalid ip pool %s", ipPool)
+	}
+	maskBits := utils.GetMaskForIPAddress(startIP, ifaceIPs)
+	return fmt.Sprintf("%s/%d", startIP, maskBits), fmt.Sprintf("%s/%d", endIP, maskBits), nil
+}
+
+// syncHAVirtualAddresses syncs virtual addresses of active/active high availability group
+// as FHRP groups, assigned to the interfaces they are configured on.
+func (pas *PaloAltoSource) syncHAVirtualAddresses(nbi *inventory.NetboxInventory) error {
+	for _, haIface := range pas.HAGroup.VirtualAddresses {
+		nbIface, ok := nbi.GetInterface(haIface.Name, pas.NBFirewall.ID)
+		if !ok {
+			pas.Logger.Warningf(pas.Ctx, "interface %s for ha virtual address not found", haIface.Name)
+			continue
+		}
+		ifaceIPs := pas.getInterfaceIPs(haIface.Name)
+		for _, haAddr := range append(haIface.IPv4s, haIface.IPv6s...) {
+			address := haAddr.Address
+			if !strings.Contains(address, "/") {
+				address = fmt.Sprintf("%s/%d", address, utils.GetMaskForIPAddress(address, ifaceIPs))
+			}
+			ipAddress, _, _ := strings.Cut(address, "/")
+			if !utils.IsPermittedIPAddress(
+				ipAddress,
+				pas.SourceConfig.PermittedSubnets,
+				pas.SourceConfig.IgnoredSubnets,
+			) {
+				continue
+			}
+			err := common.AddFHRPVirtualIP(pas.Ctx, nbi, nbIface, common.FHRPVirtualIP{
+				Protocol: &objects.FHRPGroupProtocolOther,
+				GroupID:  pas.HAGroup.GroupID,
+				Address:  address,
+				Priority: pas.HAGroup.DevicePriority,
+			}, pas.GetSourceTags())
+			if err != nil {
+				pas.Logger.Warningf(pas.Ctx, "add ha virtual address %s: %s", address, err)
+			}
+		}
+	}
+	return nil
+}
+
+// syncNATRules syncs destination nat rules as services. Each rule is added as a service
+// of the firewall with original ports, and as a service of the internal host with translated
+// ports, if the translated address is assigned to a device or a vm in the inventory.
+func (pas *PaloAltoSource) syncNATRules(nbi *inventory.NetboxInventory) error {
+	for _, natRule := range pas.NATRules {
+		if natRule.Disabled || natRule.DatAddress == "" {
+			continue
+		}
+		serviceObject, ok := pas.ServiceObjects[natRule.Service]
+		if !ok {
+			pas.Logger.Debugf(pas.Ctx, "nat rule %s has no service with ports. Skipping...", natRule.Name)
+			continue
+		}
+		protocol, ok := objects.ServiceProtocols[serviceObject.Protocol]
+		if !ok {
+			continue
+		}
+		ports, err := utils.ParsePorts(serviceObject.DestinationPort)
+		if err != nil {
+			pas.Logger.Warningf(pas.Ctx, "nat rule %s service ports: %s", natRule.Name, err)
+			continue
+		}
+		translatedPorts := ports
+		if natRule.DatPort != 0 {
+			translatedPorts = []int{natRule.DatPort}
+		}
+		_, err = nbi.AddService(pas.Ctx, &objects.Service{
+			NetboxObject: objects.NetboxObject{
+				Tags: pas.GetSourceTags(),
+				Description: fmt.Sprintf(
+					"NAT %s -> %s",
+					strings.Join(natRule.DestinationAddresses, ","),
+					natRule.DatAddress,
+				),
+			},
+			Device:   pas.NBFirewall,
+			Name:     natRule.Name,
+			Protocol: protocol,
+			Ports:    ports,
+		})
+		if err != nil {
+			return fmt.Errorf("add service for nat rule %s: %s", natRule.Name, err)
+		}
+		translatedIP := pas.resolveAddress(natRule.DatAddress)
+		if translatedIP == "" {
+			continue
+		}
+		// Firewall vrfs are scoped to the firewall, so published hosts are in the global table
+		_, err = common.AddServiceForIPAddress(pas.Ctx, nbi, nil, translatedIP, &objects.Service{
+			NetboxObject: objects.NetboxObject{
+				Tags:        pas.GetSourceTags(),
+				Description: fmt.Sprintf("Published by %s NAT rule %s", pas.NBFirewall.Name, natRule.Name),
+			},
+			Name:     natRule.Name,
+			Protocol: protocol,
+			Ports:    translatedPorts,
+		})
+		if err != nil {
+			pas.Logger.Warningf(pas.Ctx, "add service for nat rule %s host %s: %s", natRule.Name, translatedIP, err)
+		}
+	}
+	return nil
+}
+
+// resolveAddress returns single ip address (without mask) of the given address,
+// which is either an address object name or an ip address.
+// It returns empty string if address doesn't represent a single host.
+func (pas *PaloAltoSource) resolveAddress(address string) string {
+	if addressObject, ok := pas.AddressObjects[address]; ok {
+		if addressObject.Type != addr.IpNetmask {
+			return ""
+		}
+		address = addressObject.Value
+	}
+	ip, _, hasMask := strings.Cut(address, "/")
+	if hasMask {
+		_, maskBits, err := utils.GetPrefixAndMaskFromIPAddress(address)
+		hostMaskBits := constants.MaxIPv4MaskBits
+		if utils.GetIPVersion(ip) == constants.IPv6 {
+			hostMaskBits = constants.MaxIPv6MaskBits
+		}
+		if err != nil || maskBits != hostMaskBits {
+			return ""
+		}
+	}
+	if utils.GetIPVersion(ip) == 0 {
+		return ""
+	}
+	return ip
+}
+
+// syncBGPSessions syncs ASNs and bgp peers of the firewall.
+func (pas *PaloAltoSource) syncBGPSessions(nbi *inventory.NetboxInventory) error {
+	if len(pas.BGPSessions) == 0 {
+		return nil
+	}
+	err := common.AddBGPSessions(pas.Ctx, nbi, pas.NBFirewall, pas.BGPSessions, pas.GetSourceTags())
+	if err != nil {
+		pas.Logger.Warningf(pas.Ctx, "add bgp sessions: %s", err)
+	}
+	return nil
+}
+
+// syncIPsecTunnels syncs auto key ipsec tunnels of the firewall as tunnels,
+// which are terminated on their tunnel interfaces.
+func (pas *PaloAltoSource) syncIPsecTunnels(nbi *inventory.NetboxInventory) error {
+	for _, tunnel := range pas.IPsecTunnels {
+		if tunnel.TunnelInterface == "" {
+			continue
+		}
+		if utils.FilterInterfaceName(tunnel.TunnelInterface, pas.SourceConfig.InterfaceFilter) {
+			pas.Logger.Debugf(
+				pas.Ctx,
+				"tunnel interface %s is filtered out with interface filter %s",
+				tunnel.TunnelInterface,
+				pas.SourceConfig.InterfaceFilter,
+			)
+			continue
+		}
+		var ifaceVdcs []*objects.VirtualDeviceContext
+		if vdc := pas.getVirtualDeviceContext(nbi, tunnel.TunnelInterface); vdc != nil {
+			ifaceVdcs = []*objects.VirtualDeviceContext{vdc}
+		}
+		nbIface, err := nbi.AddInterface(pas.Ctx, &objects.Interface{
+			NetboxObject: objects.NetboxObject{
+				Tags: pas.GetSourceTags(),
+			},
+			Name:   tunnel.TunnelInterface,
+			Type:   &objects.VirtualInterfaceType,
+			Device: pas.NBFirewall,
+			Vdcs:   ifaceVdcs,
+			VRF:    pas.getVRF(tunnel.TunnelInterface),
+		})
+		if err != nil {
+			return fmt.Errorf("add tunnel interface %s: %s", tunnel.TunnelInterface, err)
+		}
+
+		gateway := pas.IKEGateways[tunnel.AkIkeGateway]
+		localAddress, _, _ := strings.Cut(gateway.LocalIpAddressValue, "/")
+		outsideAddress := pas.resolveAddress(localAddress)
+		if outsideAddress == "" {
+			if ifaceIPs := pas.getInterfaceIPs(gateway.Interface); len(ifaceIPs) > 0 {
+				outsideAddress, _, _ = strings.Cut(ifaceIPs[0], "/")
+			}
+		}
+		comments := []string{}
+		if tunnel.AkIkeGateway != "" {
+			// Peer is either an ip address (object), fqdn or dynamic
+			remoteGateway := gateway.PeerIpType
+			if gateway.PeerIpValue != "" {
+				remoteGateway = gateway.PeerIpValue
+				if peerAddress := pas.resolveAddress(gateway.PeerIpValue); peerAddress != "" {
+					remoteGateway = peerAddress
+				}
+			}
+			comments = append(comments, fmt.Sprintf("IKE gateway %s: %s", tunnel.AkIkeGateway, remoteGateway))
+		}
+		for _, proxyID := range pas.IPsecProxyIDs[tunnel.Name] {
+			comments = append(comments, fmt.Sprintf("Proxy ID %s: %s -> %s", proxyID.Name, proxyID.Local, proxyID.Remote))
+		}
+		_, err = common.AddIPsecTunnel(pas.Ctx, nbi, common.IPsecTunnel{
+			Name:     fmt.Sprintf("%s-%s", pas.NBFirewall.Name, tunnel.Name),
+			Group:    pas.SourceConfig.Name,
+			Disabled: tunnel.Disabled || gateway.Disabled,
+			Comments: strings.Join(comments, "\n"),
+			Tenant:   pas.NBFirewall.Tenant,
+			Endpoints: []common.IPsecTunnelEndpoint{
+				{Interface: nbIface, OutsideAddress: outsideAddress, OutsideVRF: pas.getVRF(gateway.Interface)},
+			},
+		}, pas.GetSourceTags())
+		if err != nil {
+			pas.Logger.Warningf(pas.Ctx, "add ipsec tunnel %s: %s", tunnel.Name, err)
+		}
+	}
+	return nil
+}
+
 func (pas *PaloAltoSource) syncArpTable(nbi *inventory.NetboxInventory) error {
 	if !pas.SourceConfig.CollectArpData {
 		pas.Logger.Info(pas.Ctx, "skipping collecting of arp data")
@@ -444,6 +833,7 @@ func (pas *PaloAltoSource) syncArpEntry(
 			Address: addressWithMask,
 			DNSName: dnsName,
 			Status:  &objects.IPAddressStatusActive,
+			VRF:     pas.getVRF(entry.Interface),
 		}
 		_, err := nbi.AddIPAddress(pas.Ctx, ipAddressStruct)
 		if err != nil {
@@ -1 +1,87 @@
 package paloalto
+
+import (
+	"testing"
+
+	"github.com/PaloAltoNetworks/pango/objs/addr"
+)
+
+func TestGetIPPoolBoundaries(t *testing.T) {
+	tests := []struct {
+		name      string
+		ipPool    string
+		ifaceIPs  []string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{
+			name:      "IP pool range with matching interface ip",
+			ipPool:    "192.168.1.10-192.168.1.100",
+			ifaceIPs:  []string{"192.168.1.1/24"},
+			wantStart: "192.168.1.10/24",
+			wantEnd:   "192.168.1.100/24",
+		},
+		{
+			name:      "IP pool subnet",
+			ipPool:    "10.0.0.0/29",
+			wantStart: "10.0.0.1/29",
+			wantEnd:   "10.0.0.6/29",
+		},
+		{
+			name:      "Single ip without matching interface ip",
+			ipPool:    "172.16.0.5",
+			wantStart: "172.16.0.5/32",
+			wantEnd:   "172.16.0.5/32",
+		},
+		{
+			name:    "Invalid ip pool",
+			ipPool:  "address-object-name",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotEnd, err := getIPPoolBoundaries(tt.ipPool, tt.ifaceIPs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getIPPoolBoundaries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotStart != tt.wantStart {
+				t.Errorf("getIPPoolBoundaries() gotStart = %v, want %v", gotStart, tt.wantStart)
+			}
+			if gotEnd != tt.wantEnd {
+				t.Errorf("getIPPoolBoundaries() gotEnd = %v, want %v", gotEnd, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestResolveAddress(t *testing.T) {
+	pas := &PaloAltoSource{
+		AddressObjects: map[string]addr.Entry{
+			"web-server": {Name: "web-server", Type: addr.IpNetmask, Value: "10.0.0.5/32"},
+			"web-subnet": {Name: "web-subnet", Type: addr.IpNetmask, Value: "10.0.0.0/24"},
+			"web-fqdn":   {Name: "web-fqdn", Type: addr.Fqdn, Value: "web.example.com"},
+		},
+	}
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{name: "Host address object", address: "web-server", want: "10.0.0.5"},
+		{name: "Subnet address object", address: "web-subnet", want: ""},
+		{name: "FQDN address object", address: "web-fqdn", want: ""},
+		{name: "Plain ip address", address: "10.0.0.6", want: "10.0.0.6"},
+		{name: "Plain ip address with host mask", address: "10.0.0.7/32", want: "10.0.0.7"},
+		{name: "Unknown address", address: "unknown", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pas.resolveAddress(tt.address); got != tt.want {
+				t.Errorf("resolveAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
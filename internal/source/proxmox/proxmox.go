@@ -24,10 +24,16 @@ type ProxmoxSource struct {
 	VMIfaces        map[string][]*proxmox.AgentNetworkIface  // VMName -> NetworkDevices
 	Containers      map[string][]*proxmox.Container          // NodeName -> Contatiners
 	ContainerIfaces map[string][]*proxmox.ContainerInterface // ContainerName -> ContainerInterfaces
+	NodeDNS         map[string]NodeDNS                       // NodeName -> NodeDNS
+	SDNZones        map[string]SDNZone                       // ZoneName -> SDNZone
+	SDNVnets        map[string]SDNVnet                       // VnetName -> SDNVnet
+	VMNetBridges    map[string]map[string]string             // VMName -> MAC -> Bridge (or VnetName)
 
 	// Netbox related data for easier access. Initialized in sync functions.
-	NetboxCluster *objects.Cluster
-	NetboxNodes   map[string]*objects.Device // NodeName -> netbox device
+	NetboxCluster   *objects.Cluster
+	NetboxNodes     map[string]*objects.Device // NodeName -> netbox device
+	NetboxVnets     map[string]*objects.L2VPN  // VnetName -> L2VPN (vxlan and evpn zones)
+	NetboxVnetVLANs map[string]*objects.Vlan   // VnetName -> Vlan (vlan and qinq zones)
 }
 
 // Function that collects all data from Proxmox API and stores it in ProxmoxSource struct.
@@ -56,6 +62,7 @@ func (ps *ProxmoxSource) Init() error {
 
 	initFuncs := []func(context.Context, *proxmox.Client) error{
 		ps.initCluster,
+		ps.initSDN,
 		ps.initNodes,
 	}
 
@@ -81,6 +88,7 @@ func (ps *ProxmoxSource) Sync(nbi *inventory.NetboxInventory) error {
 	syncFunctions := []func(*inventory.NetboxInventory) error{
 		ps.syncCluster,
 		ps.syncNodes,
+		ps.syncSDN,
 		ps.syncVMs,
 		ps.syncContainers,
 	}
@@ -3,10 +3,50 @@ package proxmox
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/bl4ko/netbox-ssot/internal/constants"
 	"github.com/luthermonson/go-proxmox"
 )
 
+// NodeDNS represents dns settings of the proxmox node.
+type NodeDNS struct {
+	DNS1   string `json:"dns1"`
+	DNS2   string `json:"dns2"`
+	DNS3   string `json:"dns3"`
+	Search string `json:"search"`
+}
+
+// localContextData returns dns servers and search domains of the node as local context data.
+func (nd NodeDNS) localContextData() map[string]interface{} {
+	dnsServers := make([]string, 0)
+	for _, server := range []string{nd.DNS1, nd.DNS2, nd.DNS3} {
+		if server != "" {
+			dnsServers = append(dnsServers, server)
+		}
+	}
+	return map[string]interface{}{
+		constants.LocalContextKeyDNSServers:       dnsServers,
+		constants.LocalContextKeyDNSSearchDomains: strings.Fields(nd.Search),
+	}
+}
+
+// SDNZone represents a proxmox sdn zone.
+type SDNZone struct {
+	Zone string `json:"zone"`
+	// Type of the zone (simple, vlan, qinq, vxlan or evpn).
+	Type string `json:"type"`
+}
+
+// SDNVnet represents a proxmox sdn vnet.
+type SDNVnet struct {
+	Vnet string `json:"vnet"`
+	Zone string `json:"zone"`
+	// Tag is vlan id for vlan and qinq zones and vni for vxlan and evpn zones.
+	Tag   int    `json:"tag"`
+	Alias string `json:"alias"`
+}
+
 func (ps *ProxmoxSource) initCluster(ctx context.Context, c *proxmox.Client) error {
 	cluster, err := c.Cluster(ctx)
 	if err != nil {
@@ -17,6 +57,29 @@ func (ps *ProxmoxSource) initCluster(ctx context.Context, c *proxmox.Client) err
 	return nil
 }
 
+// initSDN collects sdn zones and vnets of the proxmox cluster.
+// SDN is optional, so in case it is not available, no zones and vnets are collected.
+func (ps *ProxmoxSource) initSDN(ctx context.Context, c *proxmox.Client) error {
+	ps.SDNZones = make(map[string]SDNZone)
+	ps.SDNVnets = make(map[string]SDNVnet)
+	var zones []SDNZone
+	if err := c.Get(ctx, "/cluster/sdn/zones", &zones); err != nil {
+		ps.Logger.Warningf(ps.Ctx, "sdn zones are not available: %s", err)
+		return nil
+	}
+	var vnets []SDNVnet
+	if err := c.Get(ctx, "/cluster/sdn/vnets", &vnets); err != nil {
+		return fmt.Errorf("init sdn vnets: %s", err)
+	}
+	for _, zone := range zones {
+		ps.SDNZones[zone.Zone] = zone
+	}
+	for _, vnet := range vnets {
+		ps.SDNVnets[vnet.Vnet] = vnet
+	}
+	return nil
+}
+
 func (ps *ProxmoxSource) initNodes(ctx context.Context, c *proxmox.Client) error {
 	nodes, err := c.Nodes(ctx)
 	if err != nil {
@@ -29,6 +92,8 @@ func (ps *ProxmoxSource) initNodes(ctx context.Context, c *proxmox.Client) error
 	ps.VMIfaces = make(map[string][]*proxmox.AgentNetworkIface, 0)
 	ps.Containers = make(map[string][]*proxmox.Container, len(nodes))
 	ps.ContainerIfaces = make(map[string][]*proxmox.ContainerInterface, 0)
+	ps.NodeDNS = make(map[string]NodeDNS, len(nodes))
+	ps.VMNetBridges = make(map[string]map[string]string)
 
 	for _, node := range nodes {
 		node, err := c.Node(ctx, node.Node)
@@ -51,6 +116,15 @@ func (ps *ProxmoxSource) initNodes(ctx context.Context, c *proxmox.Client) error
 		if err != nil {
 			return fmt.Errorf("init node containers: %s", err)
 		}
+
+		if ps.SourceConfig.CollectLocalContext {
+			var nodeDNS NodeDNS
+			err = c.Get(ctx, fmt.Sprintf("/nodes/%s/dns", node.Name), &nodeDNS)
+			if err != nil {
+				return fmt.Errorf("init node dns: %s", err)
+			}
+			ps.NodeDNS[node.Name] = nodeDNS
+		}
 	}
 	return nil
 }
@@ -84,6 +158,21 @@ func (ps *ProxmoxSource) initNodeVMs(ctx context.Context, node *proxmox.Node) er
 		ifaces, _ := vm.AgentGetNetworkIFaces(ctx)
 		ps.VMIfaces[vm.Name] = make([]*proxmox.AgentNetworkIface, 0, len(ifaces))
 		ps.VMIfaces[vm.Name] = append(ps.VMIfaces[vm.Name], ifaces...)
+
+		// Vm's network config is needed only to connect vm's interfaces to sdn vnets
+		if len(ps.SDNVnets) > 0 {
+			vmWithConfig, err := node.VirtualMachine(ctx, int(vm.VMID)) //nolint:gosec
+			if err != nil {
+				return fmt.Errorf("init vm %s config: %s", vm.Name, err)
+			}
+			ps.VMNetBridges[vm.Name] = make(map[string]string)
+			if vmWithConfig.VirtualMachineConfig != nil {
+				for _, net := range vmWithConfig.VirtualMachineConfig.MergeNets() {
+					mac, bridge := parseVMNet(net)
+					ps.VMNetBridges[vm.Name][mac] = bridge
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -103,3 +192,23 @@ func (ps *ProxmoxSource) initContainers(ctx context.Context, node *proxmox.Node)
 	}
 	return nil
 }
+
+// parseVMNet parses proxmox vm network device config
+// (e.g. "virtio=BC:24:11:2E:C5:E6,bridge=vnet1,firewall=1") and returns its mac address
+// (in upper case) and bridge.
+func parseVMNet(net string) (string, string) {
+	var mac, bridge string
+	for _, option := range strings.Split(net, ",") {
+		key, value, ok := strings.Cut(option, "=")
+		if !ok {
+			continue
+		}
+		switch {
+		case key == "bridge":
+			bridge = value
+		case key == "macaddr" || (mac == "" && strings.Count(value, ":") == 5): //nolint:mnd
+			mac = strings.ToUpper(value)
+		}
+	}
+	return mac, bridge
+}
@@ -19,8 +19,8 @@ func (ps *ProxmoxSource) syncCluster(nbi *inventory.NetboxInventory) error {
 	clusterSite, err := common.MatchClusterToSite(
 		ps.Ctx,
 		nbi,
-		ps.Cluster.Name,
-		ps.SourceConfig.ClusterSiteRelations,
+		utils.RelationAttributes{Name: ps.Cluster.Name},
+		ps.SourceConfig,
 	)
 	if err != nil {
 		return err
@@ -32,8 +32,8 @@ func (ps *ProxmoxSource) syncCluster(nbi *inventory.NetboxInventory) error {
 	clusterTenant, err := common.MatchClusterToTenant(
 		ps.Ctx,
 		nbi,
-		ps.Cluster.Name,
-		ps.SourceConfig.ClusterTenantRelations,
+		utils.RelationAttributes{Name: ps.Cluster.Name},
+		ps.SourceConfig,
 	)
 	if err != nil {
 		return err
@@ -55,6 +55,15 @@ func (ps *ProxmoxSource) syncCluster(nbi *inventory.NetboxInventory) error {
 	if ps.Cluster.Name == "" {
 		ps.Cluster.Name = ps.SourceConfig.Name
 	}
+	filtered, err := ps.IsFiltered("cluster", utils.RelationAttributes{Name: ps.Cluster.Name})
+	if err != nil {
+		return fmt.Errorf("filter cluster: %s", err)
+	}
+	if filtered {
+		// Nodes and their vms belong to the cluster, so they are not synced
+		ps.NetboxCluster = nil
+		return nil
+	}
 
 	clusterStruct := &objects.Cluster{
 		NetboxObject: objects.NetboxObject{
@@ -76,18 +85,30 @@ func (ps *ProxmoxSource) syncCluster(nbi *inventory.NetboxInventory) error {
 
 func (ps *ProxmoxSource) syncNodes(nbi *inventory.NetboxInventory) error {
 	ps.NetboxNodes = make(map[string]*objects.Device, len(ps.Nodes))
+	if ps.NetboxCluster == nil {
+		ps.Logger.Debugf(ps.Ctx, "skipping nodes of filtered out cluster %s", ps.Cluster.Name)
+		return nil
+	}
 	for _, node := range ps.Nodes {
+		filtered, err := ps.IsFiltered(
+			"device", utils.RelationAttributes{Name: node.Name, Cluster: ps.NetboxCluster.Name},
+		)
+		if err != nil {
+			return fmt.Errorf("filter node: %s", err)
+		}
+		if filtered {
+			continue
+		}
 		var hostSite *objects.Site
 		if ps.NetboxCluster.ScopeType == constants.ContentTypeDcimSite {
 			hostSite = nbi.GetSiteByID(ps.NetboxCluster.ScopeID)
 		}
-		var err error
 		if hostSite == nil {
 			hostSite, err = common.MatchHostToSite(
 				ps.Ctx,
 				nbi,
-				node.Name,
-				ps.SourceConfig.HostSiteRelations,
+				utils.RelationAttributes{Name: node.Name},
+				ps.SourceConfig,
 			)
 			if err != nil {
 				return fmt.Errorf("match host to site: %s", err)
@@ -96,31 +117,33 @@ func (ps *ProxmoxSource) syncNodes(nbi *inventory.NetboxInventory) error {
 		hostTenant, err := common.MatchHostToTenant(
 			ps.Ctx,
 			nbi,
-			node.Name,
-			ps.SourceConfig.HostTenantRelations,
+			utils.RelationAttributes{Name: node.Name},
+			ps.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match host to tenant: %s", err)
 		}
 		// TODO: find a way to get device type info from proxmox
+		defaultManufacturer := ps.SourceConfig.Defaults.Manufacturer
 		manufacturerStruct := &objects.Manufacturer{
 			NetboxObject: objects.NetboxObject{
-				Description: constants.DefaultManufacturerDescription,
+				Description: defaultManufacturer.Description,
 			},
-			Name: constants.DefaultManufacturer,
-			Slug: utils.Slugify(constants.DefaultManufacturer),
+			Name: defaultManufacturer.Name,
+			Slug: defaultManufacturer.Slug,
 		}
 		hostManufacturer, err := nbi.AddManufacturer(ps.Ctx, manufacturerStruct)
 		if err != nil {
 			return fmt.Errorf("adding host manufacturer %+v: %s", manufacturerStruct, err)
 		}
+		defaultDeviceType := ps.SourceConfig.Defaults.DeviceType
 		deviceTypeStruct := &objects.DeviceType{
 			NetboxObject: objects.NetboxObject{
-				Description: constants.DefaultDeviceTypeDescription,
+				Description: defaultDeviceType.Description,
 			},
 			Manufacturer: hostManufacturer,
-			Model:        constants.DefaultModel,
-			Slug:         utils.Slugify(hostManufacturer.Name + constants.DefaultModel),
+			Model:        defaultDeviceType.Name,
+			Slug:         utils.Slugify(hostManufacturer.Name + defaultDeviceType.Name),
 		}
 		hostDeviceType, err := nbi.AddDeviceType(ps.Ctx, deviceTypeStruct)
 		if err != nil {
@@ -130,25 +153,25 @@ func (ps *ProxmoxSource) syncNodes(nbi *inventory.NetboxInventory) error {
 		// Match host to a role. First test if user provided relations, if not
 		// use default server role.
 		var hostRole *objects.DeviceRole
-		if len(ps.SourceConfig.HostRoleRelations) > 0 {
+		if len(ps.SourceConfig.HostRoleRelations) > 0 || len(ps.SourceConfig.RelationRules.HostRoleRelations) > 0 {
 			hostRole, err = common.MatchHostToRole(
 				ps.Ctx,
 				nbi,
-				node.Name,
-				ps.SourceConfig.HostRoleRelations,
+				utils.RelationAttributes{Name: node.Name},
+				ps.SourceConfig,
 			)
 			if err != nil {
 				return fmt.Errorf("match host to role: %s", err)
 			}
 		}
 		if hostRole == nil {
-			hostRole, err = nbi.AddServerDeviceRole(ps.Ctx)
+			hostRole, err = nbi.AddServerDeviceRole(ps.Ctx, ps.SourceConfig.Defaults.Roles)
 			if err != nil {
 				return fmt.Errorf("add server device role %s", err)
 			}
 		}
 
-		nbHost, err := nbi.AddDevice(ps.Ctx, &objects.Device{
+		hostStruct := &objects.Device{
 			NetboxObject: objects.NetboxObject{
 				Tags: ps.GetSourceTags(),
 				CustomFields: map[string]interface{}{
@@ -165,7 +188,22 @@ func (ps *ProxmoxSource) syncNodes(nbi *inventory.NetboxInventory) error {
 			Tenant:     hostTenant,
 			Cluster:    ps.NetboxCluster,
 			DeviceType: hostDeviceType,
-		})
+			LocalContextData: common.FilterLocalContextData(
+				ps.NodeDNS[node.Name].localContextData(),
+				ps.SourceConfig,
+			),
+		}
+		if err := common.MatchDeviceToRack(ps.Ctx, nbi, hostStruct, ps.SourceConfig); err != nil {
+			ps.Logger.Warningf(ps.Ctx, "device %s rack placement: %s", hostStruct.Name, err)
+		}
+		keep, err := common.TransformDevice(ps.Ctx, nbi, ps.SourceConfig, hostStruct, ps.GetSourceTags())
+		if err != nil {
+			return err
+		}
+		if !keep {
+			continue
+		}
+		nbHost, err := nbi.AddDevice(ps.Ctx, hostStruct)
 		if err != nil {
 			return fmt.Errorf("add device: %s", err)
 		}
@@ -219,6 +257,96 @@ func (ps *ProxmoxSource) syncNodeNetworks(
 	return nil
 }
 
+// syncSDN syncs proxmox sdn vnets to the netbox inventory. Vnets of vlan and qinq zones
+// are synced as vlans (tag is vlan id), while vnets of vxlan and evpn zones are synced
+// as L2VPNs (tag is vni).
+func (ps *ProxmoxSource) syncSDN(nbi *inventory.NetboxInventory) error {
+	ps.NetboxVnets = make(map[string]*objects.L2VPN)
+	ps.NetboxVnetVLANs = make(map[string]*objects.Vlan)
+	for vnetName, vnet := range ps.SDNVnets {
+		zone, ok := ps.SDNZones[vnet.Zone]
+		if !ok || vnet.Tag == 0 {
+			ps.Logger.Debugf(ps.Ctx, "sdn vnet %s has no zone or tag. Skipping...", vnetName)
+			continue
+		}
+		switch zone.Type {
+		case "vlan", "qinq":
+			filtered, err := ps.IsFiltered("vlan", utils.RelationAttributes{Name: vnet.Vnet})
+			if err != nil {
+				return fmt.Errorf("filter vlan: %s", err)
+			}
+			if filtered {
+				continue
+			}
+			nbVlan, err := ps.addVnetVlan(nbi, vnet)
+			if err != nil {
+				return fmt.Errorf("add vlan for vnet %s: %s", vnetName, err)
+			}
+			ps.NetboxVnetVLANs[vnetName] = nbVlan
+		case "vxlan", "evpn":
+			l2vpnType := &objects.L2VPNTypeVXLAN
+			if zone.Type == "evpn" {
+				l2vpnType = &objects.L2VPNTypeVXLANEVPN
+			}
+			nbL2VPN, err := nbi.AddL2VPN(ps.Ctx, &objects.L2VPN{
+				NetboxObject: objects.NetboxObject{
+					Tags:        ps.GetSourceTags(),
+					Description: vnet.Alias,
+				},
+				Name:       vnetName,
+				Slug:       utils.Slugify(vnetName),
+				Type:       l2vpnType,
+				Identifier: int64(vnet.Tag),
+				Comments:   fmt.Sprintf("Proxmox SDN %s zone %s", zone.Type, zone.Zone),
+			})
+			if err != nil {
+				return fmt.Errorf("add l2vpn for vnet %s: %s", vnetName, err)
+			}
+			ps.NetboxVnets[vnetName] = nbL2VPN
+		default:
+			ps.Logger.Debugf(ps.Ctx, "sdn zone type %s of vnet %s is not supported. Skipping...", zone.Type, vnetName)
+		}
+	}
+	return nil
+}
+
+// addVnetVlan adds vlan, which represents vnet of vlan or qinq sdn zone.
+func (ps *ProxmoxSource) addVnetVlan(
+	nbi *inventory.NetboxInventory,
+	vnet SDNVnet,
+) (*objects.Vlan, error) {
+	vlanSite, err := common.MatchVlanToSite(ps.Ctx, nbi, utils.RelationAttributes{Name: vnet.Vnet}, ps.SourceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("match vlan to site: %s", err)
+	}
+	vlanGroup, err := common.MatchVlanToGroup(
+		ps.Ctx,
+		nbi,
+		utils.RelationAttributes{Name: vnet.Vnet},
+		vlanSite,
+		ps.SourceConfig,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("match vlan to group: %s", err)
+	}
+	vlanTenant, err := common.MatchVlanToTenant(ps.Ctx, nbi, utils.RelationAttributes{Name: vnet.Vnet}, ps.SourceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("match vlan to tenant: %s", err)
+	}
+	return nbi.AddVlan(ps.Ctx, &objects.Vlan{
+		NetboxObject: objects.NetboxObject{
+			Tags:        ps.GetSourceTags(),
+			Description: vnet.Alias,
+		},
+		Status: &objects.VlanStatusActive,
+		Name:   vnet.Vnet,
+		Vid:    vnet.Tag,
+		Site:   vlanSite,
+		Tenant: vlanTenant,
+		Group:  vlanGroup,
+	})
+}
+
 // Function that synces proxmox vms to the netbox inventory.
 func (ps *ProxmoxSource) syncVMs(nbi *inventory.NetboxInventory) error {
 	const maxGoroutines = 50
@@ -227,7 +355,11 @@ func (ps *ProxmoxSource) syncVMs(nbi *inventory.NetboxInventory) error {
 	var wg sync.WaitGroup
 
 	for nodeName, vms := range ps.Vms {
-		nbHost := ps.NetboxNodes[nodeName]
+		nbHost, ok := ps.NetboxNodes[nodeName]
+		if !ok {
+			ps.Logger.Debugf(ps.Ctx, "skipping vms of node %s, because the node is filtered out or dropped", nodeName)
+			continue
+		}
 		for _, vm := range vms {
 			guard <- struct{}{} // Block if maxGoroutines are running
 			wg.Add(1)
@@ -257,11 +389,38 @@ func (ps *ProxmoxSource) syncVMs(nbi *inventory.NetboxInventory) error {
 	return nil
 }
 
+// filterGuest returns true, if the vm or container is filtered out.
+func (ps *ProxmoxSource) filterGuest(name string, status string, tags string) (bool, error) {
+	guestAttributes := utils.RelationAttributes{
+		Name:       name,
+		Cluster:    ps.NetboxCluster.Name,
+		PowerState: status,
+	}
+	switch status {
+	case "running":
+		guestAttributes.PowerState = "on"
+	case "stopped":
+		guestAttributes.PowerState = "off"
+	}
+	if tags != "" {
+		guestAttributes.Tags = strings.Split(tags, ";")
+	}
+	return ps.IsFiltered("vm", guestAttributes)
+}
+
 func (ps *ProxmoxSource) syncVM(
 	nbi *inventory.NetboxInventory,
 	vm *proxmox.VirtualMachine,
 	nbHost *objects.Device,
 ) error {
+	filtered, err := ps.filterGuest(vm.Name, vm.Status, vm.Tags)
+	if err != nil {
+		return fmt.Errorf("filter vm: %s", err)
+	}
+	if filtered {
+		return nil
+	}
+
 	// Determine VM status
 	vmStatus := &objects.VMStatusActive
 	if vm.Status == "stopped" {
@@ -269,20 +428,20 @@ func (ps *ProxmoxSource) syncVM(
 	}
 
 	// Determine VM tenant
-	vmTenant, err := common.MatchVMToTenant(ps.Ctx, nbi, vm.Name, ps.SourceConfig.VMTenantRelations)
+	vmTenant, err := common.MatchVMToTenant(ps.Ctx, nbi, utils.RelationAttributes{Name: vm.Name}, ps.SourceConfig)
 	if err != nil {
 		return fmt.Errorf("match vm to tenant: %s", err)
 	}
 
 	var vmRole *objects.DeviceRole
-	if len(ps.SourceConfig.VMRoleRelations) > 0 {
-		vmRole, err = common.MatchVMToRole(ps.Ctx, nbi, vm.Name, ps.SourceConfig.VMRoleRelations)
+	if len(ps.SourceConfig.VMRoleRelations) > 0 || len(ps.SourceConfig.RelationRules.VMRoleRelations) > 0 {
+		vmRole, err = common.MatchVMToRole(ps.Ctx, nbi, utils.RelationAttributes{Name: vm.Name}, ps.SourceConfig)
 		if err != nil {
 			return fmt.Errorf("match vm to role: %s", err)
 		}
 	}
 	if vmRole == nil {
-		vmRole, err = nbi.AddVMDeviceRole(ps.Ctx)
+		vmRole, err = nbi.AddVMDeviceRole(ps.Ctx, ps.SourceConfig.Defaults.Roles)
 		if err != nil {
 			return fmt.Errorf("add vm device role: %s", err)
 		}
@@ -308,24 +467,39 @@ func (ps *ProxmoxSource) syncVM(
 		Name:    vm.Name,
 		Status:  vmStatus,
 	}
+	keep, err := common.TransformVM(ps.Ctx, nbi, ps.SourceConfig, vmStruct, ps.GetSourceTags())
+	if err != nil {
+		return err
+	}
+	if !keep {
+		return nil
+	}
 	nbVM, err := nbi.AddVM(ps.Ctx, vmStruct)
 	if err != nil {
 		return fmt.Errorf("add vm: %s", err)
 	}
 
 	// Sync VM networks
-	err = ps.syncVMNetworks(nbi, nbVM)
+	err = ps.syncVMNetworks(nbi, vm.Name, nbVM)
 	if err != nil {
 		return fmt.Errorf("sync vm networks: %s", err)
 	}
 
+	// Sync services of the vm's role
+	err = common.AddVMRoleServices(ps.Ctx, nbi, nbVM, ps.SourceConfig.VMRoleServices, ps.GetSourceTags())
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func (ps *ProxmoxSource) syncVMNetworks(nbi *inventory.NetboxInventory, nbVM *objects.VM) error {
+// syncVMNetworks syncs networks of the vm. Name of the vm in proxmox can differ from
+// the name of nbVM, if it's renamed by a transform.
+func (ps *ProxmoxSource) syncVMNetworks(nbi *inventory.NetboxInventory, vmName string, nbVM *objects.VM) error {
 	vmIPv4Addresses := make([]*objects.IPAddress, 0)
 	vmIPv6Addresses := make([]*objects.IPAddress, 0)
-	for _, vmNetwork := range ps.VMIfaces[nbVM.Name] {
+	for _, vmNetwork := range ps.VMIfaces[vmName] {
 		if utils.FilterInterfaceName(vmNetwork.Name, ps.SourceConfig.InterfaceFilter) {
 			ps.Logger.Debugf(
 				ps.Ctx,
@@ -335,6 +509,8 @@ func (ps *ProxmoxSource) syncVMNetworks(nbi *inventory.NetboxInventory, nbVM *ob
 			)
 			continue
 		}
+		vmIfaceMAC := strings.ToUpper(vmNetwork.HardwareAddress)
+		vmIfaceBridge := ps.VMNetBridges[vmName][vmIfaceMAC]
 		vmInterfaceStruct := &objects.VMInterface{
 			NetboxObject: objects.NetboxObject{
 				Tags: ps.GetSourceTags(),
@@ -342,11 +518,27 @@ func (ps *ProxmoxSource) syncVMNetworks(nbi *inventory.NetboxInventory, nbVM *ob
 			Name: vmNetwork.Name,
 			VM:   nbVM,
 		}
+		if vnetVlan, ok := ps.NetboxVnetVLANs[vmIfaceBridge]; ok {
+			vmInterfaceStruct.Mode = &objects.VMInterfaceModeAccess
+			vmInterfaceStruct.UntaggedVlan = vnetVlan
+		}
 		nbVMIface, err := nbi.AddVMInterface(ps.Ctx, vmInterfaceStruct)
 		if err != nil {
 			return fmt.Errorf("add vm interface %+v: %s", vmInterfaceStruct, err)
 		}
-		vmIfaceMAC := strings.ToUpper(vmNetwork.HardwareAddress)
+		if vnetL2VPN, ok := ps.NetboxVnets[vmIfaceBridge]; ok {
+			_, err = nbi.AddL2VPNTermination(ps.Ctx, &objects.L2VPNTermination{
+				NetboxObject: objects.NetboxObject{
+					Tags: ps.GetSourceTags(),
+				},
+				L2VPN:              vnetL2VPN,
+				AssignedObjectType: constants.ContentTypeVirtualizationVMInterface,
+				AssignedObjectID:   nbVMIface.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("add l2vpn termination for vm interface %s: %s", nbVMIface.Name, err)
+			}
+		}
 		if vmIfaceMAC == "" {
 			nbMACAddress, err := common.CreateMACAddressForObjectType(
 				ps.Ctx,
@@ -427,20 +619,16 @@ func (ps *ProxmoxSource) syncVMNetworks(nbi *inventory.NetboxInventory, nbVM *ob
 		}
 	}
 	// From all IPv4 addresses and IPv6 addresses determine primary ips
-	if len(vmIPv4Addresses) > 0 || len(vmIPv6Addresses) > 0 {
-		nbVMCopy := *nbVM
-		if len(vmIPv4Addresses) > 0 {
-			// TODO: add criteria for primary IPv4
-			nbVMCopy.PrimaryIPv4 = vmIPv4Addresses[0]
-		}
-		if len(vmIPv6Addresses) > 0 {
-			// TODO add criteria for primary IPv6
-			nbVMCopy.PrimaryIPv6 = vmIPv6Addresses[0]
-		}
-		_, err := nbi.AddVM(ps.Ctx, &nbVMCopy)
-		if err != nil {
-			return fmt.Errorf("updating vm primary ip: %s", err)
-		}
+	err := common.SetPrimaryIPAddresses(
+		ps.Ctx,
+		nbi,
+		nbVM,
+		append(vmIPv4Addresses, vmIPv6Addresses...),
+		nil,
+		ps.SourceConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("updating vm primary ip: %s", err)
 	}
 	return nil
 }
@@ -449,13 +637,26 @@ func (ps *ProxmoxSource) syncVMNetworks(nbi *inventory.NetboxInventory, nbVM *ob
 func (ps *ProxmoxSource) syncContainers(nbi *inventory.NetboxInventory) error {
 	if len(ps.Containers) > 0 {
 		// Create container role
-		containerRole, err := nbi.AddContainerDeviceRole(ps.Ctx)
+		containerRole, err := nbi.AddContainerDeviceRole(ps.Ctx, ps.SourceConfig.Defaults.Roles)
 		if err != nil {
 			return fmt.Errorf("create container role: %s", err)
 		}
 		for nodeName, containers := range ps.Containers {
-			nbHost := ps.NetboxNodes[nodeName]
+			nbHost, ok := ps.NetboxNodes[nodeName]
+			if !ok {
+				ps.Logger.Debugf(
+					ps.Ctx, "skipping containers of node %s, because the node is filtered out or dropped", nodeName,
+				)
+				continue
+			}
 			for _, container := range containers {
+				filtered, err := ps.filterGuest(container.Name, container.Status, container.Tags)
+				if err != nil {
+					return fmt.Errorf("filter container: %s", err)
+				}
+				if filtered {
+					continue
+				}
 				// Determine Container status
 				containerStatus := &objects.VMStatusActive
 				if container.Status == "stopped" {
@@ -465,13 +666,13 @@ func (ps *ProxmoxSource) syncContainers(nbi *inventory.NetboxInventory) error {
 				vmTenant, err := common.MatchVMToTenant(
 					ps.Ctx,
 					nbi,
-					container.Name,
-					ps.SourceConfig.VMTenantRelations,
+					utils.RelationAttributes{Name: container.Name},
+					ps.SourceConfig,
 				)
 				if err != nil {
 					return fmt.Errorf("match vm to tenant: %s", err)
 				}
-				nbContainer, err := nbi.AddVM(ps.Ctx, &objects.VM{
+				containerStruct := &objects.VM{
 					NetboxObject: objects.NetboxObject{
 						Tags: ps.GetSourceTags(),
 						CustomFields: map[string]interface{}{
@@ -488,15 +689,34 @@ func (ps *ProxmoxSource) syncContainers(nbi *inventory.NetboxInventory) error {
 					Site:    nbHost.Site,
 					Name:    container.Name,
 					Status:  containerStatus,
-				})
+				}
+				keep, err := common.TransformVM(ps.Ctx, nbi, ps.SourceConfig, containerStruct, ps.GetSourceTags())
+				if err != nil {
+					return err
+				}
+				if !keep {
+					continue
+				}
+				nbContainer, err := nbi.AddVM(ps.Ctx, containerStruct)
 				if err != nil {
 					return fmt.Errorf("new vm: %s", err)
 				}
 
-				err = ps.syncContainerNetworks(nbi, nbContainer)
+				err = ps.syncContainerNetworks(nbi, container.Name, nbContainer)
 				if err != nil {
 					return fmt.Errorf("sync container networks: %s", err)
 				}
+
+				err = common.AddVMRoleServices(
+					ps.Ctx,
+					nbi,
+					nbContainer,
+					ps.SourceConfig.VMRoleServices,
+					ps.GetSourceTags(),
+				)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -505,11 +725,12 @@ func (ps *ProxmoxSource) syncContainers(nbi *inventory.NetboxInventory) error {
 
 func (ps *ProxmoxSource) syncContainerNetworks(
 	nbi *inventory.NetboxInventory,
+	containerName string,
 	nbContainer *objects.VM,
 ) error {
 	vmIPv4Addresses := make([]*objects.IPAddress, 0)
 	vmIPv6Addresses := make([]*objects.IPAddress, 0)
-	for _, containerIface := range ps.ContainerIfaces[nbContainer.Name] {
+	for _, containerIface := range ps.ContainerIfaces[containerName] {
 		if utils.FilterInterfaceName(containerIface.Name, ps.SourceConfig.InterfaceFilter) {
 			ps.Logger.Debugf(
 				ps.Ctx,
@@ -617,20 +838,16 @@ func (ps *ProxmoxSource) syncContainerNetworks(
 		}
 	}
 	// From all IPv4 addresses and IPv6 addresses determine primary ips
-	if len(vmIPv4Addresses) > 0 || len(vmIPv6Addresses) > 0 {
-		nbContainerCopy := *nbContainer
-		if len(vmIPv4Addresses) > 0 {
-			// TODO: add criteria for primary IPv4
-			nbContainerCopy.PrimaryIPv4 = vmIPv4Addresses[0]
-		}
-		if len(vmIPv6Addresses) > 0 {
-			// TODO add criteria for primary IPv6
-			nbContainerCopy.PrimaryIPv6 = vmIPv6Addresses[0]
-		}
-		_, err := nbi.AddVM(ps.Ctx, &nbContainerCopy)
-		if err != nil {
-			return fmt.Errorf("updating vm primary ip: %s", err)
-		}
+	err := common.SetPrimaryIPAddresses(
+		ps.Ctx,
+		nbi,
+		nbContainer,
+		append(vmIPv4Addresses, vmIPv6Addresses...),
+		nil,
+		ps.SourceConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("updating vm primary ip: %s", err)
 	}
 	return nil
 }
@@ -61,6 +61,8 @@ func NewSource(
 		SourceTypeTag: sourceTypeTag,
 		Ctx:           ctx,
 		CAFile:        config.CAFile,
+
+		FilteredObjects: &common.FilteredObjects{},
 	}
 
 	switch config.Type {
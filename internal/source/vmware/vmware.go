@@ -32,6 +32,7 @@ type VmwareSource struct {
 	Clusters    map[string]mo.ClusterComputeResource
 	Hosts       map[string]mo.HostSystem
 	Vms         map[string]mo.VirtualMachine
+	Folders     map[string]mo.Folder
 	Networks    NetworkData
 
 	// Relations between objects "object_id": "object_id"
@@ -44,6 +45,12 @@ type VmwareSource struct {
 	// Object2Tags is a map of object ids to their tags
 	Object2Tags   map[string][]*tags.Tag
 	Object2NBTags map[string][]*objects.Tag // Created in sync function
+	// NBHosts is a map of host ids to synced netbox devices. Hosts, that are
+	// filtered out or dropped by transforms, are not in the map.
+	NBHosts map[string]*objects.Device // Created in sync function
+	// FilteredClusterIDs are ids of clusters, that are filtered out. Their hosts
+	// and vms are not synced.
+	FilteredClusterIDs map[string]bool // Created in sync function
 }
 
 type NetworkData struct {
@@ -51,6 +58,9 @@ type NetworkData struct {
 	DistributedVirtualPortgroups map[string]*DistributedPortgroupData
 	// Vid2Name is a Helper map, for quickly obtaining name of the vid
 	Vid2Name map[int]string
+	// Vid2PortgroupKey is a helper map, for quickly obtaining key of the distributed
+	// portgroup of the vid (the same portgroup, whose name is in Vid2Name)
+	Vid2PortgroupKey map[int]string
 	// HostVirtualSwitches: hostName -> VSwitchName-> VSwitchData
 	HostVirtualSwitches map[string]map[string]*HostVirtualSwitchData
 	// HostProxySwitches: hostName -> PSwitchName -> HostProxySwitchData
@@ -60,7 +70,9 @@ type NetworkData struct {
 }
 
 type DistributedPortgroupData struct {
-	Name         string
+	Name string
+	// Switch is name of the distributed virtual switch of the portgroup
+	Switch       string
 	VlanIDs      []int
 	VlanIDRanges []string
 	Private      bool
@@ -137,6 +149,7 @@ func (vc *VmwareSource) Init() error {
 	// Each string in this slice represents a different vSphere Managed Object type.
 	viewType := []string{
 		"Datastore", "Datacenter", "ClusterComputeResource", "HostSystem", "VirtualMachine", "Network",
+		"DistributedVirtualSwitch", "Folder",
 	}
 
 	// A container view is a subset of the vSphere inventory, focusing on the specified
@@ -186,6 +199,7 @@ func (vc *VmwareSource) Init() error {
 		vc.initClusters,
 		vc.initHosts,
 		vc.initVms,
+		vc.initFolders,
 	}
 
 	for _, initFunc := range initFunctions {
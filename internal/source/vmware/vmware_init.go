@@ -22,9 +22,19 @@ func (vc *VmwareSource) initNetworks(ctx context.Context, containerView *view.Co
 	if err != nil {
 		return fmt.Errorf("failed retrieving DistributedVirtualPortgroups: %s", err)
 	}
+	var dvSwitches []mo.DistributedVirtualSwitch
+	err = containerView.Retrieve(ctx, []string{"DistributedVirtualSwitch"}, []string{"name"}, &dvSwitches)
+	if err != nil {
+		return fmt.Errorf("failed retrieving DistributedVirtualSwitches: %s", err)
+	}
+	dvSwitchNames := make(map[string]string, len(dvSwitches))
+	for _, dvSwitch := range dvSwitches {
+		dvSwitchNames[dvSwitch.Self.Value] = dvSwitch.Name
+	}
 	vc.Networks = NetworkData{
 		DistributedVirtualPortgroups: make(map[string]*DistributedPortgroupData),
 		Vid2Name:                     make(map[int]string),
+		Vid2PortgroupKey:             make(map[int]string),
 		HostVirtualSwitches:          make(map[string]map[string]*HostVirtualSwitchData),
 		HostProxySwitches:            make(map[string]map[string]*HostProxySwitchData),
 		HostPortgroups:               make(map[string]map[string]*HostPortgroupData),
@@ -69,10 +79,16 @@ func (vc *VmwareSource) initNetworks(ctx context.Context, containerView *view.Co
 					continue
 				}
 				vc.Networks.Vid2Name[vid] = dvpg.Config.Name
+				vc.Networks.Vid2PortgroupKey[vid] = dvpg.Config.Key
 			}
 
+			var dvSwitchName string
+			if dvpg.Config.DistributedVirtualSwitch != nil {
+				dvSwitchName = dvSwitchNames[dvpg.Config.DistributedVirtualSwitch.Value]
+			}
 			vc.Networks.DistributedVirtualPortgroups[dvpg.Config.Key] = &DistributedPortgroupData{
 				Name:         dvpg.Config.Name,
+				Switch:       dvSwitchName,
 				VlanIDs:      vlanIDs,
 				VlanIDRanges: vlanIDRanges,
 				Private:      private,
@@ -140,18 +156,29 @@ func (vc *VmwareSource) initClusters(ctx context.Context, containerView *view.Co
 
 func (vc *VmwareSource) initHosts(ctx context.Context, containerView *view.ContainerView) error {
 	var hosts []mo.HostSystem
+	hostProperties := []string{
+		"name",
+		"summary.host",
+		"summary.hardware",
+		"summary.runtime",
+		"summary.config",
+		"summary.customValue",
+		"vm",
+		"config.network",
+		"config.virtualNicManagerInfo",
+		"config.storageDevice",
+		"hardware.pciDevice",
+		// Hardware sensors, used for power draw of power supplies
+		"runtime.healthSystemRuntime.systemHealthInfo",
+	}
+	if vc.SourceConfig.CollectLocalContext {
+		// Ntp servers and advanced settings (e.g. syslog servers)
+		hostProperties = append(hostProperties, "config.dateTimeInfo", "config.option")
+	}
 	err := containerView.Retrieve(
 		ctx,
 		[]string{"HostSystem"},
-		[]string{
-			"name",
-			"summary.host",
-			"summary.hardware",
-			"summary.runtime",
-			"summary.config",
-			"vm",
-			"config.network",
-		},
+		hostProperties,
 		&hosts,
 	)
 	if err != nil {
@@ -228,6 +255,7 @@ func (vc *VmwareSource) initVms(ctx context.Context, containerView *view.Contain
 		[]string{
 			"summary",
 			"name",
+			"parent",
 			"runtime",
 			"guest",
 			"config.hardware",
@@ -245,3 +273,17 @@ func (vc *VmwareSource) initVms(ctx context.Context, containerView *view.Contain
 	}
 	return nil
 }
+
+// initFolders initializes folders, which are used for folder paths of vms.
+func (vc *VmwareSource) initFolders(ctx context.Context, containerView *view.ContainerView) error {
+	var folders []mo.Folder
+	err := containerView.Retrieve(ctx, []string{"Folder"}, []string{"name", "parent"}, &folders)
+	if err != nil {
+		return fmt.Errorf("failed retrieving folders: %s", err)
+	}
+	vc.Folders = make(map[string]mo.Folder, len(folders))
+	for _, folder := range folders {
+		vc.Folders[folder.Self.Value] = folder
+	}
+	return nil
+}
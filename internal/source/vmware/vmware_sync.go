@@ -2,6 +2,8 @@ package vmware
 
 import (
 	"fmt"
+	"math"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -46,12 +48,13 @@ func (vc *VmwareSource) syncTags(nbi *inventory.NetboxInventory) error {
 func (vc *VmwareSource) syncNetworks(nbi *inventory.NetboxInventory) error {
 	for dvpgID, dvpg := range vc.Networks.DistributedVirtualPortgroups {
 		// TODO: currently we are syncing only vlans
+		dvpgAttributes := vc.dvpgRelationAttributes(dvpgID)
 		// Get vlanGroup from relations
 		vlanSite, err := common.MatchVlanToSite(
 			vc.Ctx,
 			nbi,
-			dvpg.Name,
-			vc.SourceConfig.VlanSiteRelations,
+			dvpgAttributes,
+			vc.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match vlan to site: %s", err)
@@ -59,10 +62,9 @@ func (vc *VmwareSource) syncNetworks(nbi *inventory.NetboxInventory) error {
 		vlanGroup, err := common.MatchVlanToGroup(
 			vc.Ctx,
 			nbi,
-			dvpg.Name,
+			dvpgAttributes,
 			vlanSite,
-			vc.SourceConfig.VlanGroupRelations,
-			vc.SourceConfig.VlanGroupSiteRelations,
+			vc.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match vlan to group: %s", err)
@@ -71,13 +73,20 @@ func (vc *VmwareSource) syncNetworks(nbi *inventory.NetboxInventory) error {
 		vlanTenant, err := common.MatchVlanToTenant(
 			vc.Ctx,
 			nbi,
-			dvpg.Name,
-			vc.SourceConfig.VlanTenantRelations,
+			dvpgAttributes,
+			vc.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("vlanTenant: %s", err)
 		}
 		if len(dvpg.VlanIDs) == 1 && len(dvpg.VlanIDRanges) == 0 && dvpg.VlanIDs[0] != 0 {
+			filtered, err := vc.IsFiltered("vlan", dvpgAttributes)
+			if err != nil {
+				return fmt.Errorf("filter vlan: %s", err)
+			}
+			if filtered {
+				continue
+			}
 			networkTags := vc.Object2NBTags[dvpgID]
 			vlanStruct := &objects.Vlan{
 				NetboxObject: objects.NetboxObject{
@@ -93,7 +102,7 @@ func (vc *VmwareSource) syncNetworks(nbi *inventory.NetboxInventory) error {
 				Status: &objects.VlanStatusActive,
 				Tenant: vlanTenant,
 			}
-			_, err := nbi.AddVlan(vc.Ctx, vlanStruct)
+			_, err = nbi.AddVlan(vc.Ctx, vlanStruct)
 			if err != nil {
 				return fmt.Errorf("add vlan %+v: %s", vlanStruct, err)
 			}
@@ -143,9 +152,22 @@ func (vc *VmwareSource) syncClusters(nbi *inventory.NetboxInventory) error {
 		return fmt.Errorf("failed to add vmware ClusterType: %v", err)
 	}
 	// Then sync vmware Clusters as NetBoxClusters
+	vc.FilteredClusterIDs = make(map[string]bool)
 	for clusterID, cluster := range vc.Clusters {
 		clusterName := cluster.Name
 		clusterTags := vc.Object2NBTags[clusterID]
+		filtered, err := vc.IsFiltered("cluster", utils.RelationAttributes{
+			Name:       clusterName,
+			Tags:       vc.objectTagNames(clusterID),
+			Datacenter: vc.DataCenters[vc.Cluster2Datacenter[clusterID]].Name,
+		})
+		if err != nil {
+			return fmt.Errorf("filter cluster: %s", err)
+		}
+		if filtered {
+			vc.FilteredClusterIDs[clusterID] = true
+			continue
+		}
 
 		var clusterGroup *objects.ClusterGroup
 		datacenterID := vc.Cluster2Datacenter[clusterID]
@@ -160,8 +182,8 @@ func (vc *VmwareSource) syncClusters(nbi *inventory.NetboxInventory) error {
 		clusterSite, err := common.MatchClusterToSite(
 			vc.Ctx,
 			nbi,
-			clusterName,
-			vc.SourceConfig.ClusterSiteRelations,
+			utils.RelationAttributes{Name: clusterName},
+			vc.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match cluster to site: %s", err)
@@ -174,8 +196,8 @@ func (vc *VmwareSource) syncClusters(nbi *inventory.NetboxInventory) error {
 		clusterTenant, err := common.MatchClusterToTenant(
 			vc.Ctx,
 			nbi,
-			clusterName,
-			vc.SourceConfig.ClusterTenantRelations,
+			utils.RelationAttributes{Name: clusterName},
+			vc.SourceConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("match cluster to tenant: %s", err)
@@ -211,26 +233,28 @@ func (vc *VmwareSource) syncClusters(nbi *inventory.NetboxInventory) error {
 // Host in vmware is a represented as device in netbox with a
 // custom role Server.
 func (vc *VmwareSource) syncHosts(nbi *inventory.NetboxInventory) error {
+	vc.NBHosts = make(map[string]*objects.Device, len(vc.Hosts))
 	for hostID, host := range vc.Hosts {
-		var err error
 		hostName := host.Name
+		if vc.FilteredClusterIDs[vc.Host2Cluster[hostID]] {
+			vc.Logger.Debugf(vc.Ctx, "skipping host %s of filtered out cluster", hostName)
+			continue
+		}
+		hostAttributes := vc.hostRelationAttributes(hostID, host)
+		filtered, err := vc.IsFiltered("device", hostAttributes)
+		if err != nil {
+			return fmt.Errorf("filter host: %s", err)
+		}
+		if filtered {
+			continue
+		}
 
-		hostSite, err := common.MatchHostToSite(
-			vc.Ctx,
-			nbi,
-			hostName,
-			vc.SourceConfig.HostSiteRelations,
-		)
+		hostSite, err := common.MatchHostToSite(vc.Ctx, nbi, hostAttributes, vc.SourceConfig)
 		if err != nil {
 			return fmt.Errorf("hostSite: %s", err)
 		}
 
-		hostTenant, err := common.MatchHostToTenant(
-			vc.Ctx,
-			nbi,
-			hostName,
-			vc.SourceConfig.HostTenantRelations,
-		)
+		hostTenant, err := common.MatchHostToTenant(vc.Ctx, nbi, hostAttributes, vc.SourceConfig)
 		if err != nil {
 			return fmt.Errorf("hostTenant: %s", err)
 		}
@@ -259,10 +283,10 @@ func (vc *VmwareSource) syncHosts(nbi *inventory.NetboxInventory) error {
 		}
 
 		if hostModel == "" {
-			hostModel = constants.DefaultModel
+			hostModel = vc.SourceConfig.Defaults.DeviceType.Name
 		}
 		if hostManufacturerName == "" {
-			hostManufacturerName = constants.DefaultManufacturer
+			hostManufacturerName = vc.SourceConfig.Defaults.Manufacturer.Name
 		}
 
 		// Enrich data from device type library if possible
@@ -358,19 +382,14 @@ func (vc *VmwareSource) syncHosts(nbi *inventory.NetboxInventory) error {
 		// Match host to a role. First test if user provided relations, if not
 		// use default server role.
 		var hostRole *objects.DeviceRole
-		if len(vc.SourceConfig.HostRoleRelations) > 0 {
-			hostRole, err = common.MatchHostToRole(
-				vc.Ctx,
-				nbi,
-				hostName,
-				vc.SourceConfig.HostRoleRelations,
-			)
+		if len(vc.SourceConfig.HostRoleRelations) > 0 || len(vc.SourceConfig.RelationRules.HostRoleRelations) > 0 {
+			hostRole, err = common.MatchHostToRole(vc.Ctx, nbi, hostAttributes, vc.SourceConfig)
 			if err != nil {
 				return fmt.Errorf("match host to role: %s", err)
 			}
 		}
 		if hostRole == nil {
-			hostRole, err = nbi.AddServerDeviceRole(vc.Ctx)
+			hostRole, err = nbi.AddServerDeviceRole(vc.Ctx, vc.SourceConfig.Defaults.Roles)
 			if err != nil {
 				return fmt.Errorf("add server device role %s", err)
 			}
@@ -398,17 +417,381 @@ func (vc *VmwareSource) syncHosts(nbi *inventory.NetboxInventory) error {
 			SerialNumber: hostSerialNumber,
 			AssetTag:     assetTag,
 			DeviceType:   hostDeviceType,
+			LocalContextData: common.FilterLocalContextData(
+				getHostLocalContextData(host),
+				vc.SourceConfig,
+			),
+		}
+		rackPlacement, err := vc.getHostRackPlacement(host, hostName)
+		if err != nil {
+			vc.Logger.Warningf(vc.Ctx, "host %s rack placement: %s", hostName, err)
+		} else {
+			err = common.SetDeviceRackPlacement(vc.Ctx, nbi, hostStruct, rackPlacement, vc.SourceConfig)
+			if err != nil {
+				vc.Logger.Warningf(vc.Ctx, "set host %s rack placement: %s", hostName, err)
+			}
+		}
+		keep, err := common.TransformDevice(vc.Ctx, nbi, vc.SourceConfig, hostStruct, vc.GetSourceTags())
+		if err != nil {
+			return err
+		}
+		if !keep {
+			continue
 		}
 		nbHost, err := nbi.AddDevice(vc.Ctx, hostStruct)
 		if err != nil {
 			return fmt.Errorf("failed to add vmware host %+v with error: %v", hostStruct, err)
 		}
+		vc.NBHosts[hostID] = nbHost
 
 		// We also need to sync nics separately, because nic is a separate object in netbox
 		err = vc.syncHostNics(nbi, host, nbHost, deviceData)
 		if err != nil {
 			return fmt.Errorf("failed to sync vmware host %s nics with error: %v", host.Name, err)
 		}
+
+		// Sync host's hardware components (nics, hbas, accelerators) as inventory items
+		err = vc.syncHostHardware(nbi, host, nbHost)
+		if err != nil {
+			return fmt.Errorf("failed to sync vmware host %s hardware with error: %v", host.Name, err)
+		}
+
+		// Sync host's power supplies as power ports and its power feeds
+		for _, powerSupply := range getHostPowerSupplies(host) {
+			_, err = common.AddPowerSupply(vc.Ctx, nbi, nbHost, powerSupply, vc.GetSourceTags())
+			if err != nil {
+				return fmt.Errorf("failed to sync vmware host %s power supply with error: %v", host.Name, err)
+			}
+		}
+		if _, err := common.MatchDeviceToPowerFeeds(vc.Ctx, nbi, nbHost, vc.SourceConfig); err != nil {
+			vc.Logger.Warningf(vc.Ctx, "host %s power feeds: %s", hostName, err)
+		}
+	}
+	return nil
+}
+
+// hostPowerSupplyRegex matches hardware sensors of host's power supplies (e.g. "Power Supply 1 Input Power").
+var hostPowerSupplyRegex = regexp.MustCompile(`(?i)^power supply (\d+)`)
+
+// getHostPowerSupplies returns power supplies of the host, found in its hardware sensors.
+//
+// Allocated draw of the power supply is its own power reading in watts. If power supplies
+// don't report their power, host's total power consumption (the highest power reading
+// of other sensors) is split evenly among them.
+func getHostPowerSupplies(host mo.HostSystem) []common.PowerSupply {
+	if host.Runtime.HealthSystemRuntime == nil || host.Runtime.HealthSystemRuntime.SystemHealthInfo == nil {
+		return nil
+	}
+	powerSupplies := make(map[string]*common.PowerSupply)
+	names := make([]string, 0)
+	totalDraw := 0
+	for _, sensor := range host.Runtime.HealthSystemRuntime.SystemHealthInfo.NumericSensorInfo {
+		var watts int
+		if strings.EqualFold(sensor.BaseUnits, "watts") {
+			watts = int(float64(sensor.CurrentReading) * math.Pow10(int(sensor.UnitModifier)))
+		}
+		match := hostPowerSupplyRegex.FindStringSubmatch(sensor.Name)
+		if match == nil {
+			if watts > totalDraw {
+				totalDraw = watts
+			}
+			continue
+		}
+		name := fmt.Sprintf("Power Supply %s", match[1])
+		if _, ok := powerSupplies[name]; !ok {
+			powerSupplies[name] = &common.PowerSupply{Name: name}
+			names = append(names, name)
+		}
+		if watts > powerSupplies[name].AllocatedDraw {
+			powerSupplies[name].AllocatedDraw = watts
+		}
+	}
+	output := make([]common.PowerSupply, 0, len(names))
+	for _, name := range names {
+		powerSupply := powerSupplies[name]
+		if powerSupply.AllocatedDraw == 0 {
+			powerSupply.AllocatedDraw = totalDraw / len(names)
+		}
+		output = append(output, *powerSupply)
+	}
+	return output
+}
+
+// vmwareSyslogHostSetting is the advanced setting, that holds syslog servers of the esxi host.
+const vmwareSyslogHostSetting = "Syslog.global.logHost"
+
+// getHostLocalContextData returns dns, ntp and syslog servers and advanced settings of the host.
+func getHostLocalContextData(host mo.HostSystem) map[string]interface{} {
+	if host.Config == nil {
+		return nil
+	}
+	localContextData := make(map[string]interface{})
+	if host.Config.Network != nil && host.Config.Network.DnsConfig != nil {
+		dnsConfig := host.Config.Network.DnsConfig.GetHostDnsConfig()
+		localContextData[constants.LocalContextKeyDNSServers] = dnsConfig.Address
+		localContextData[constants.LocalContextKeyDNSSearchDomains] = dnsConfig.SearchDomain
+	}
+	if host.Config.DateTimeInfo != nil && host.Config.DateTimeInfo.NtpConfig != nil {
+		localContextData[constants.LocalContextKeyNTPServers] = host.Config.DateTimeInfo.NtpConfig.Server
+	}
+	advancedSettings := make(map[string]string, len(host.Config.Option))
+	for _, option := range host.Config.Option {
+		optionValue := option.GetOptionValue()
+		advancedSettings[optionValue.Key] = fmt.Sprintf("%v", optionValue.Value)
+	}
+	localContextData[constants.LocalContextKeyAdvancedSettings] = advancedSettings
+	if syslogHosts := advancedSettings[vmwareSyslogHostSetting]; syslogHosts != "" {
+		syslogServers := make([]string, 0)
+		for _, syslogHost := range strings.Split(syslogHosts, ",") {
+			if syslogHost = strings.TrimSpace(syslogHost); syslogHost != "" {
+				syslogServers = append(syslogServers, syslogHost)
+			}
+		}
+		localContextData[constants.LocalContextKeySyslogServers] = syslogServers
+	}
+	return localContextData
+}
+
+// getHostRackPlacement returns rack placement of the host, matched using host rack relations.
+// Host's custom attributes mapped to rack, rackPosition or rackFace in customFieldMappings
+// take precedence over the relations.
+func (vc *VmwareSource) getHostRackPlacement(
+	host mo.HostSystem,
+	hostName string,
+) (common.RackPlacement, error) {
+	rackPlacement, err := common.MatchHostToRackPlacement(hostName, vc.SourceConfig)
+	if err != nil {
+		return rackPlacement, err
+	}
+	for _, field := range host.Summary.CustomValue {
+		field, ok := field.(*types.CustomFieldStringValue)
+		if !ok || strings.TrimSpace(field.Value) == "" {
+			continue
+		}
+		fieldName := vc.CustomFieldID2Name[field.Key]
+		switch vc.SourceConfig.CustomFieldMappings[fieldName] {
+		case "rack":
+			rackPlacement.RackName = strings.TrimSpace(field.Value)
+		case "rackPosition":
+			rackPlacement.Position = strings.TrimSpace(field.Value)
+		case "rackFace":
+			rackPlacement.Face = strings.TrimSpace(field.Value)
+		}
+	}
+	return rackPlacement, nil
+}
+
+// objectTagNames returns names of vsphere tags of the object.
+func (vc *VmwareSource) objectTagNames(objectID string) []string {
+	tagNames := make([]string, 0, len(vc.Object2Tags[objectID]))
+	for _, tag := range vc.Object2Tags[objectID] {
+		tagNames = append(tagNames, tag.Name)
+	}
+	return tagNames
+}
+
+// customAttributeValues returns values of custom attributes mapped to their names.
+func (vc *VmwareSource) customAttributeValues(customValues []types.BaseCustomFieldValue) map[string]string {
+	customAttributes := make(map[string]string, len(customValues))
+	for _, field := range customValues {
+		if field, ok := field.(*types.CustomFieldStringValue); ok {
+			customAttributes[vc.CustomFieldID2Name[field.Key]] = field.Value
+		}
+	}
+	return customAttributes
+}
+
+// folderPath returns path of the folder (e.g. "prod/web"), relative to the
+// datacenter's hidden vm folder.
+func (vc *VmwareSource) folderPath(folderRef *types.ManagedObjectReference) string {
+	path := []string{}
+	for folderRef != nil && folderRef.Type == "Folder" {
+		folder, ok := vc.Folders[folderRef.Value]
+		// The datacenter's vm folder is not shown in vsphere client
+		if !ok || folder.Parent == nil || folder.Parent.Type != "Folder" {
+			break
+		}
+		path = append([]string{folder.Name}, path...)
+		folderRef = folder.Parent
+	}
+	return strings.Join(path, "/")
+}
+
+// dvpgRelationAttributes returns attributes of the distributed portgroup, that relation
+// rules and filters of its vlans can match on.
+func (vc *VmwareSource) dvpgRelationAttributes(dvpgKey string) utils.RelationAttributes {
+	dvpg := vc.Networks.DistributedVirtualPortgroups[dvpgKey]
+	if dvpg == nil {
+		return utils.RelationAttributes{}
+	}
+	return utils.RelationAttributes{
+		Name:   dvpg.Name,
+		Tags:   vc.objectTagNames(dvpgKey),
+		Switch: dvpg.Switch,
+	}
+}
+
+// vlanRelationAttributes returns attributes of the vlan with the given vid, that relation
+// rules and filters can match on. These are the attributes of the distributed portgroup,
+// the vlan was synced from, so vlans are matched to the same site, group and tenant
+// when they are synced and when they are looked up for interfaces.
+func (vc *VmwareSource) vlanRelationAttributes(vid int) utils.RelationAttributes {
+	if dvpgKey, ok := vc.Networks.Vid2PortgroupKey[vid]; ok {
+		return vc.dvpgRelationAttributes(dvpgKey)
+	}
+	return utils.RelationAttributes{Name: vc.Networks.Vid2Name[vid]}
+}
+
+// hostPortgroupVlanAttributes returns attributes of the vlan of the host portgroup, and true
+// if the vlan belongs to a distributed portgroup. Vlans of distributed portgroups are matched
+// with attributes of their portgroup, the same way as in syncNetworks, so they are filtered
+// and grouped consistently. Other vlans are matched by the name of the host portgroup.
+func (vc *VmwareSource) hostPortgroupVlanAttributes(portgroupName string, vid int) (utils.RelationAttributes, bool) {
+	if _, ok := vc.Networks.Vid2Name[vid]; ok {
+		return vc.vlanRelationAttributes(vid), true
+	}
+	return utils.RelationAttributes{Name: portgroupName}, false
+}
+
+// hostRelationAttributes returns attributes of the host, that relation rules can match on.
+func (vc *VmwareSource) hostRelationAttributes(hostID string, host mo.HostSystem) utils.RelationAttributes {
+	hostAttributes := utils.RelationAttributes{
+		Name:             host.Name,
+		Tags:             vc.objectTagNames(hostID),
+		Cluster:          vc.Clusters[vc.Host2Cluster[hostID]].Name,
+		Datacenter:       vc.DataCenters[vc.Cluster2Datacenter[vc.Host2Cluster[hostID]]].Name,
+		PowerState:       powerState(string(host.Runtime.PowerState)),
+		CustomAttributes: vc.customAttributeValues(host.Summary.CustomValue),
+	}
+	if host.Config != nil && host.Config.Network != nil {
+		for _, vnic := range host.Config.Network.Vnic {
+			if vnic.Spec.Ip != nil && vnic.Spec.Ip.IpAddress != "" {
+				hostAttributes.IPs = append(hostAttributes.IPs, vnic.Spec.Ip.IpAddress)
+			}
+		}
+	}
+	return hostAttributes
+}
+
+// vmRelationAttributes returns attributes of the vm, that relation rules can match on.
+func (vc *VmwareSource) vmRelationAttributes(vmKey string, vm mo.VirtualMachine) utils.RelationAttributes {
+	vmAttributes := utils.RelationAttributes{
+		Name:             vm.Name,
+		Tags:             vc.objectTagNames(vmKey),
+		Cluster:          vc.Clusters[vc.Host2Cluster[vc.VM2Host[vmKey]]].Name,
+		Datacenter:       vc.DataCenters[vc.Cluster2Datacenter[vc.Host2Cluster[vc.VM2Host[vmKey]]]].Name,
+		Folder:           vc.folderPath(vm.Parent),
+		PowerState:       powerState(string(vm.Runtime.PowerState)),
+		Platform:         vmPlatformName(vm),
+		CustomAttributes: vc.customAttributeValues(vm.Summary.CustomValue),
+	}
+	if vm.Guest != nil {
+		for _, nic := range vm.Guest.Net {
+			vmAttributes.IPs = append(vmAttributes.IPs, nic.IpAddress...)
+		}
+	}
+	return vmAttributes
+}
+
+// powerState returns on or off for powered on and powered off objects, otherwise
+// it returns power state reported by vsphere (e.g. suspended).
+func powerState(vmwarePowerState string) string {
+	switch vmwarePowerState {
+	case "poweredOn":
+		return "on"
+	case "poweredOff":
+		return "off"
+	}
+	return vmwarePowerState
+}
+
+// vmPlatformName returns guest OS of the vm, using fallback mechanisms.
+func vmPlatformName(vm mo.VirtualMachine) string {
+	switch {
+	case vm.Summary.Guest != nil && vm.Summary.Guest.GuestFullName != "":
+		return vm.Summary.Guest.GuestFullName
+	case vm.Config != nil && vm.Config.GuestFullName != "":
+		return vm.Config.GuestFullName
+	case vm.Guest != nil && vm.Guest.GuestFullName != "":
+		return vm.Guest.GuestFullName
+	}
+	return ""
+}
+
+// PCI base class codes of devices, which are synced as inventory items
+// on top of nics and hbas.
+var pciAcceleratorClasses = map[uint16]bool{
+	0x03: true, // Display controller (e.g. GPU)
+	0x12: true, // Processing accelerator
+}
+
+// syncHostHardware syncs host's physical nics, host bus adapters and
+// accelerator pci devices as inventory items.
+func (vc *VmwareSource) syncHostHardware(
+	nbi *inventory.NetboxInventory,
+	vcHost mo.HostSystem,
+	nbHost *objects.Device,
+) error {
+	pciDevices := make(map[string]types.HostPciDevice)
+	if vcHost.Hardware != nil {
+		for _, pciDevice := range vcHost.Hardware.PciDevice {
+			pciDevices[pciDevice.Id] = pciDevice
+		}
+	}
+
+	// Component name -> pci id of the component
+	componentPciIDs := make(map[string]string)
+	if vcHost.Config != nil && vcHost.Config.Network != nil {
+		for _, pnic := range vcHost.Config.Network.Pnic {
+			componentPciIDs[pnic.Device] = pnic.Pci
+		}
+	}
+	if vcHost.Config != nil && vcHost.Config.StorageDevice != nil {
+		for _, hba := range vcHost.Config.StorageDevice.HostBusAdapter {
+			componentPciIDs[hba.GetHostHostBusAdapter().Device] = hba.GetHostHostBusAdapter().Pci
+		}
+	}
+	syncedPciIDs := make(map[string]bool, len(componentPciIDs))
+	for _, pciID := range componentPciIDs {
+		syncedPciIDs[pciID] = true
+	}
+	for pciID, pciDevice := range pciDevices {
+		if !syncedPciIDs[pciID] && pciAcceleratorClasses[uint16(pciDevice.ClassId)>>8] { //nolint:gosec,mnd
+			componentPciIDs[pciID] = pciID
+		}
+	}
+
+	for componentName, pciID := range componentPciIDs {
+		component := common.HardwareComponent{Name: componentName}
+		if pciDevice, ok := pciDevices[pciID]; ok {
+			if pciDevice.VendorName != "" {
+				manufacturerName := utils.SerializeManufacturerName(pciDevice.VendorName)
+				manufacturer, err := nbi.AddManufacturer(vc.Ctx, &objects.Manufacturer{
+					Name: manufacturerName,
+					Slug: utils.Slugify(manufacturerName),
+				})
+				if err != nil {
+					return fmt.Errorf("add manufacturer %s: %s", manufacturerName, err)
+				}
+				component.Manufacturer = manufacturer
+			}
+			component.PartNumber = fmt.Sprintf(
+				"%04x:%04x",
+				uint16(pciDevice.VendorId), //nolint:gosec
+				uint16(pciDevice.DeviceId), //nolint:gosec
+			)
+			component.Description = pciDevice.DeviceName
+		}
+		err := common.AddHardwareComponent(
+			vc.Ctx,
+			nbi,
+			nbHost,
+			component,
+			vc.GetSourceTags(),
+		)
+		if err != nil {
+			return fmt.Errorf("add hardware component %s: %s", componentName, err)
+		}
 	}
 	return nil
 }
@@ -419,25 +802,32 @@ func (vc *VmwareSource) syncHostNics(
 	nbHost *objects.Device,
 	deviceData *devices.DeviceData,
 ) error {
-	// Variable for storeing all ipAddresses from all host interfaces,
-	// we use them to determine the primary ip of the host.
-	hostIPv4Addresses := []*objects.IPAddress{}
-	hostIPv6Addresses := []*objects.IPAddress{}
-
 	// Sync host's physical interfaces
 	err := vc.syncHostPhysicalNics(nbi, vcHost, nbHost, deviceData)
 	if err != nil {
 		return fmt.Errorf("physical interfaces sync: %s", err)
 	}
 
-	// Sync host's virtual interfaces
-	err = vc.syncHostVirtualNics(nbi, vcHost, nbHost, hostIPv4Addresses, hostIPv6Addresses)
+	// Sync host's virtual interfaces. We use their ip addresses
+	// to determine primary and oob ips of the host.
+	hostIPAddresses, err := vc.syncHostVirtualNics(nbi, vcHost, nbHost)
 	if err != nil {
 		return fmt.Errorf("virtual interfaces sync: %s", err)
 	}
 
-	// Set host's private ip address from collected ips
-	err = vc.setHostPrimaryIPAddress(nbi, nbHost, hostIPv4Addresses, hostIPv6Addresses)
+	// Address, that the host name resolves to, is preferred as host's primary ip
+	preferredIPAddresses := []*objects.IPAddress{}
+	if resolvedIP := utils.Lookup(nbHost.Name); resolvedIP != "" {
+		for _, ipAddress := range hostIPAddresses {
+			if address, _, _ := strings.Cut(ipAddress.Address, "/"); address == resolvedIP {
+				preferredIPAddresses = append(preferredIPAddresses, ipAddress)
+			}
+		}
+	}
+
+	err = common.SetPrimaryIPAddresses(
+		vc.Ctx, nbi, nbHost, hostIPAddresses, preferredIPAddresses, vc.SourceConfig,
+	)
 	if err != nil {
 		return fmt.Errorf("adding host primary ip addresses: %s", err)
 	}
@@ -455,6 +845,7 @@ func (vc *VmwareSource) syncHostPhysicalNics(
 		for _, pnic := range vcHost.Config.Network.Pnic {
 			hostPnic, macAddress, err := vc.collectHostPhysicalNicData(
 				nbi,
+				vcHost,
 				nbHost,
 				pnic,
 				deviceData,
@@ -499,6 +890,7 @@ func (vc *VmwareSource) syncHostPhysicalNics(
 //nolint:gocyclo
 func (vc *VmwareSource) collectHostPhysicalNicData(
 	nbi *inventory.NetboxInventory,
+	vcHost mo.HostSystem,
 	nbHost *objects.Device,
 	pnic types.PhysicalNic,
 	_ *devices.DeviceData,
@@ -528,7 +920,7 @@ func (vc *VmwareSource) collectHostPhysicalNicData(
 	var pnicMtu int
 	var pnicMode *objects.InterfaceMode
 	// Check virtual switches for data
-	for vswitch, vswitchData := range vc.Networks.HostVirtualSwitches[nbHost.Name] {
+	for vswitch, vswitchData := range vc.Networks.HostVirtualSwitches[vcHost.Name] {
 		if slices.Contains(vswitchData.pnics, pnic.Key) {
 			pnicDescription = fmt.Sprintf("%s (%s)", pnicDescription, vswitch)
 			pnicMtu = vswitchData.mtu
@@ -536,7 +928,7 @@ func (vc *VmwareSource) collectHostPhysicalNicData(
 	}
 
 	// Check proxy switches for data
-	for _, pswitchData := range vc.Networks.HostProxySwitches[nbHost.Name] {
+	for _, pswitchData := range vc.Networks.HostProxySwitches[vcHost.Name] {
 		if slices.Contains(pswitchData.pnics, pnic.Key) {
 			pnicDescription = fmt.Sprintf("%s (%s)", pnicDescription, pswitchData.name)
 			pnicMtu = pswitchData.mtu
@@ -546,19 +938,27 @@ func (vc *VmwareSource) collectHostPhysicalNicData(
 
 	// Check vlans on this pnic
 	vlanIDMap := map[int]*objects.Vlan{} // set of vlans
-	for portgroupName, portgroupData := range vc.Networks.HostPortgroups[nbHost.Name] {
+	for portgroupName, portgroupData := range vc.Networks.HostPortgroups[vcHost.Name] {
 		if slices.Contains(portgroupData.nics, pnicName) {
 			if portgroupData.vlanID == 0 || portgroupData.vlanID > 4094 {
 				vlanIDMap[portgroupData.vlanID] = &objects.Vlan{Vid: portgroupData.vlanID}
 				continue
 			}
+			vlanAttributes, isDvpgVlan := vc.hostPortgroupVlanAttributes(portgroupName, portgroupData.vlanID)
+			vlanFiltered, err := vc.IsFiltered("vlan", vlanAttributes)
+			if err != nil {
+				return nil, "", fmt.Errorf("filter vlan: %s", err)
+			}
+			if vlanFiltered {
+				continue
+			}
 			// Check if vlan with this vid already exists, else create it
-			if vlanName, ok := vc.Networks.Vid2Name[portgroupData.vlanID]; ok {
+			if isDvpgVlan {
 				vlanSite, err := common.MatchVlanToSite(
 					vc.Ctx,
 					nbi,
-					vlanName,
-					vc.SourceConfig.VlanSiteRelations,
+					vlanAttributes,
+					vc.SourceConfig,
 				)
 				if err != nil {
 					return nil, "", fmt.Errorf("match vlan to site: %s", err)
@@ -566,10 +966,9 @@ func (vc *VmwareSource) collectHostPhysicalNicData(
 				vlanGroup, err := common.MatchVlanToGroup(
 					vc.Ctx,
 					nbi,
-					vlanName,
+					vlanAttributes,
 					vlanSite,
-					vc.SourceConfig.VlanGroupRelations,
-					vc.SourceConfig.VlanGroupSiteRelations,
+					vc.SourceConfig,
 				)
 				if err != nil {
 					return nil, "", fmt.Errorf("match vlan to group: %s", err)
@@ -579,23 +978,21 @@ func (vc *VmwareSource) collectHostPhysicalNicData(
 					vlanIDMap[portgroupData.vlanID] = vlan
 				}
 			} else {
-				vlanName := portgroupName
-				vlanSite, err := common.MatchVlanToSite(vc.Ctx, nbi, vlanName, vc.SourceConfig.VlanSiteRelations)
+				vlanSite, err := common.MatchVlanToSite(vc.Ctx, nbi, vlanAttributes, vc.SourceConfig)
 				if err != nil {
 					return nil, "", fmt.Errorf("match vlan to site: %s", err)
 				}
 				vlanGroup, err := common.MatchVlanToGroup(
 					vc.Ctx,
 					nbi,
-					vlanName,
+					vlanAttributes,
 					vlanSite,
-					vc.SourceConfig.VlanGroupRelations,
-					vc.SourceConfig.VlanGroupSiteRelations,
+					vc.SourceConfig,
 				)
 				if err != nil {
 					return nil, "", fmt.Errorf("match vlan to group: %s", err)
 				}
-				vlanTenant, err := common.MatchVlanToTenant(vc.Ctx, nbi, vlanName, vc.SourceConfig.VlanTenantRelations)
+				vlanTenant, err := common.MatchVlanToTenant(vc.Ctx, nbi, vlanAttributes, vc.SourceConfig)
 				if err != nil {
 					return nil, "", fmt.Errorf("match vlan to tenant: %s", err)
 				}
@@ -606,7 +1003,7 @@ func (vc *VmwareSource) collectHostPhysicalNicData(
 							Tags: vc.Config.GetSourceTags(),
 						},
 						Status: &objects.VlanStatusActive,
-						Name:   vlanName,
+						Name:   portgroupName,
 						Site:   vlanSite,
 						Vid:    portgroupData.vlanID,
 						Tenant: vlanTenant,
@@ -667,20 +1064,25 @@ func (vc *VmwareSource) collectHostPhysicalNicData(
 	}, strings.ToUpper(pnic.Mac), nil
 }
 
+// syncHostVirtualNics syncs host's vmkernel nics with their ip addresses
+// and returns all synced ip addresses.
 func (vc *VmwareSource) syncHostVirtualNics(
 	nbi *inventory.NetboxInventory,
 	vcHost mo.HostSystem,
 	nbHost *objects.Device,
-	hostIPv4Addresses []*objects.IPAddress,
-	hostIPv6Addresses []*objects.IPAddress,
-) error {
+) ([]*objects.IPAddress, error) {
+	hostIPAddresses := []*objects.IPAddress{}
+	// Vmkernel nics tagged for management are synced as oob interfaces
+	managementVnics := getHostManagementVnics(vcHost)
+
 	// Collect data over all virtual interfaces
 	if vcHost.Config != nil && vcHost.Config.Network != nil && vcHost.Config.Network.Vnic != nil {
 		for _, vnic := range vcHost.Config.Network.Vnic {
 			hostVnic, err := vc.collectHostVirtualNicData(nbi, nbHost, vcHost, vnic)
 			if err != nil {
-				return err
+				return nil, err
 			}
+			hostVnic.MgmtOnly = managementVnics[vnic.Device]
 
 			if utils.FilterInterfaceName(hostVnic.Name, vc.SourceConfig.InterfaceFilter) {
 				vc.Logger.Debugf(
@@ -694,7 +1096,7 @@ func (vc *VmwareSource) syncHostVirtualNics(
 
 			nbVnic, err := nbi.AddInterface(vc.Ctx, hostVnic)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// Get IPv4 address for this vnic
@@ -706,7 +1108,7 @@ func (vc *VmwareSource) syncHostVirtualNics(
 			) {
 				ipv4MaskBits, err := utils.MaskToBits(vnic.Spec.Ip.SubnetMask)
 				if err != nil {
-					return fmt.Errorf("mask to bits: %s", err)
+					return nil, fmt.Errorf("mask to bits: %s", err)
 				}
 				ipv4DNS := utils.ReverseLookup(ipv4Address)
 				nbIPv4Address, err := nbi.AddIPAddress(vc.Ctx, &objects.IPAddress{
@@ -727,7 +1129,7 @@ func (vc *VmwareSource) syncHostVirtualNics(
 					vc.Logger.Errorf(vc.Ctx, "add ipv4 address: %s", err)
 					continue
 				}
-				hostIPv4Addresses = append(hostIPv4Addresses, nbIPv4Address)
+				hostIPAddresses = append(hostIPAddresses, nbIPv4Address)
 
 				prefix, mask, err := utils.GetPrefixAndMaskFromIPAddress(nbIPv4Address.Address)
 				if err != nil {
@@ -768,44 +1170,32 @@ func (vc *VmwareSource) syncHostVirtualNics(
 							vc.Logger.Errorf(vc.Ctx, "add ipv6 address: %s", err)
 							continue
 						}
-						hostIPv6Addresses = append(hostIPv6Addresses, nbIPv6Address)
+						hostIPAddresses = append(hostIPAddresses, nbIPv6Address)
 					}
 				}
 			}
 		}
 	}
-	return nil
+	return hostIPAddresses, nil
 }
 
-func (vc *VmwareSource) setHostPrimaryIPAddress(
-	nbi *inventory.NetboxInventory,
-	nbHost *objects.Device,
-	hostIPv4Addresses []*objects.IPAddress,
-	hostIPv6Addresses []*objects.IPAddress,
-) error {
-	if len(hostIPv4Addresses) > 0 || len(hostIPv6Addresses) > 0 {
-		var hostPrimaryIPv4 *objects.IPAddress
-		for _, addr := range hostIPv4Addresses {
-			if hostPrimaryIPv4 == nil || utils.Lookup(nbHost.Name) == addr.Address {
-				hostPrimaryIPv4 = addr
-			}
+// getHostManagementVnics returns names of host's vmkernel nics, which are tagged for management.
+func getHostManagementVnics(vcHost mo.HostSystem) map[string]bool {
+	managementVnics := make(map[string]bool)
+	if vcHost.Config == nil || vcHost.Config.VirtualNicManagerInfo == nil {
+		return managementVnics
+	}
+	for _, netConfig := range vcHost.Config.VirtualNicManagerInfo.NetConfig {
+		if netConfig.NicType != string(types.HostVirtualNicManagerNicTypeManagement) {
+			continue
 		}
-		var hostPrimaryIPv6 *objects.IPAddress
-		for _, addr := range hostIPv6Addresses {
-			if hostPrimaryIPv6 == nil || utils.Lookup(nbHost.Name) == addr.Address {
-				hostPrimaryIPv6 = addr
+		for _, candidateVnic := range netConfig.CandidateVnic {
+			if slices.Contains(netConfig.SelectedVnic, candidateVnic.Key) {
+				managementVnics[candidateVnic.Device] = true
 			}
 		}
-		newHost := *nbHost
-		newHost.PrimaryIPv4 = hostPrimaryIPv4
-		newHost.PrimaryIPv6 = hostPrimaryIPv6
-		_, err := nbi.AddDevice(vc.Ctx, &newHost)
-		if err != nil {
-			return fmt.Errorf("updating host's primary ip: %s", err)
-		}
 	}
-
-	return nil
+	return managementVnics
 }
 
 func (vc *VmwareSource) collectHostVirtualNicData(
@@ -864,11 +1254,12 @@ func (vc *VmwareSource) collectHostVirtualNicData(
 	var vnicUntaggedVlan *objects.Vlan
 	var vnicTaggedVlans []*objects.Vlan
 	if vnicPortgroupData != nil && vnicPortgroupVlanID != 0 {
+		vnicUntaggedVlanAttributes := vc.vlanRelationAttributes(vnicPortgroupVlanID)
 		vnicUntaggedVlanSite, err := common.MatchVlanToSite(
 			vc.Ctx,
 			nbi,
-			vc.Networks.Vid2Name[vnicPortgroupVlanID],
-			vc.SourceConfig.VlanSiteRelations,
+			vnicUntaggedVlanAttributes,
+			vc.SourceConfig,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("vlan site: %s", err)
@@ -876,10 +1267,9 @@ func (vc *VmwareSource) collectHostVirtualNicData(
 		vnicUntaggedVlanGroup, err := common.MatchVlanToGroup(
 			vc.Ctx,
 			nbi,
-			vc.Networks.Vid2Name[vnicPortgroupVlanID],
+			vnicUntaggedVlanAttributes,
 			vnicUntaggedVlanSite,
-			vc.SourceConfig.VlanGroupRelations,
-			vc.SourceConfig.VlanGroupSiteRelations,
+			vc.SourceConfig,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("vlan group: %s", err)
@@ -899,11 +1289,12 @@ func (vc *VmwareSource) collectHostVirtualNicData(
 			if vnicDvPortgroupDataVlanID == 0 {
 				continue
 			}
+			vnicTaggedVlanAttributes := vc.vlanRelationAttributes(vnicDvPortgroupDataVlanID)
 			vnicTaggedVlanSite, err := common.MatchVlanToSite(
 				vc.Ctx,
 				nbi,
-				vc.Networks.Vid2Name[vnicDvPortgroupDataVlanID],
-				vc.SourceConfig.VlanSiteRelations,
+				vnicTaggedVlanAttributes,
+				vc.SourceConfig,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("match vlan to site: %s", err)
@@ -911,10 +1302,9 @@ func (vc *VmwareSource) collectHostVirtualNicData(
 			vnicTaggedVlanGroup, err := common.MatchVlanToGroup(
 				vc.Ctx,
 				nbi,
-				vc.Networks.Vid2Name[vnicDvPortgroupDataVlanID],
+				vnicTaggedVlanAttributes,
 				vnicTaggedVlanSite,
-				vc.SourceConfig.VlanGroupRelations,
-				vc.SourceConfig.VlanGroupSiteRelations,
+				vc.SourceConfig,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("match vlan to vlan group: %s", err)
@@ -1005,24 +1395,40 @@ func (vc *VmwareSource) syncVM(
 
 	vmName := vm.Name
 	vmHostName := vc.Hosts[vc.VM2Host[vmKey]].Name
+	vmAttributes := vc.vmRelationAttributes(vmKey, vm)
+
+	vmHost, ok := vc.NBHosts[vc.VM2Host[vmKey]]
+	if !ok {
+		vc.Logger.Debugf(vc.Ctx, "skipping vm %s, because its host %s is filtered out or dropped", vmName, vmHostName)
+		return nil
+	}
+	filtered, err := vc.IsFiltered("vm", vmAttributes)
+	if err != nil {
+		return fmt.Errorf("filter vm: %s", err)
+	}
+	if filtered {
+		return nil
+	}
 
 	// Map to a vm role
 	var vmRole *objects.DeviceRole
-	var err error
-	if len(vc.SourceConfig.VMRoleRelations) > 0 {
-		vmRole, err = common.MatchVMToRole(vc.Ctx, nbi, vmHostName, vc.SourceConfig.VMRoleRelations)
+	if len(vc.SourceConfig.VMRoleRelations) > 0 || len(vc.SourceConfig.RelationRules.VMRoleRelations) > 0 {
+		// Regex role relations are matched with the name of the vm's host
+		vmRoleAttributes := vmAttributes
+		vmRoleAttributes.Name = vmHostName
+		vmRole, err = common.MatchVMToRole(vc.Ctx, nbi, vmRoleAttributes, vc.SourceConfig)
 		if err != nil {
 			return fmt.Errorf("match vm to role: %s", err)
 		}
 	}
 	if vmRole == nil {
 		if isTemplate {
-			vmRole, err = nbi.AddVMTemplateDeviceRole(vc.Ctx)
+			vmRole, err = nbi.AddVMTemplateDeviceRole(vc.Ctx, vc.SourceConfig.Defaults.Roles)
 			if err != nil {
 				return fmt.Errorf("add template device role: %s", err)
 			}
 		} else {
-			vmRole, err = nbi.AddVMDeviceRole(vc.Ctx)
+			vmRole, err = nbi.AddVMDeviceRole(vc.Ctx, vc.SourceConfig.Defaults.Roles)
 			if err != nil {
 				return fmt.Errorf("get vm device role: %s", err)
 			}
@@ -1030,22 +1436,13 @@ func (vc *VmwareSource) syncVM(
 	}
 
 	// Tenant is received from VmTenantRelations
-	vmTenant, err := common.MatchVMToTenant(vc.Ctx, nbi, vmName, vc.SourceConfig.VMTenantRelations)
+	vmTenant, err := common.MatchVMToTenant(vc.Ctx, nbi, vmAttributes, vc.SourceConfig)
 	if err != nil {
 		return fmt.Errorf("vm's Tenant: %s", err)
 	}
 
 	// Site is the same as the Host
-	vmSite, err := common.MatchHostToSite(
-		vc.Ctx,
-		nbi,
-		vmHostName,
-		vc.SourceConfig.HostSiteRelations,
-	)
-	if err != nil {
-		return fmt.Errorf("vm's Site: %s", err)
-	}
-	vmHost, _ := nbi.GetDevice(vmHostName, vmSite.ID)
+	vmSite := vmHost.Site
 
 	// Cluster of the vm is same as the host
 	vmCluster := vmHost.Cluster
@@ -1069,17 +1466,7 @@ func (vc *VmwareSource) syncVM(
 	}
 	vmDiskSizeMiB := vmDiskSizeB / constants.MiB
 
-	// Determine guest OS using fallback mechanisms
-	var platformName string
-	switch {
-	case vm.Summary.Guest != nil && vm.Summary.Guest.GuestFullName != "":
-		platformName = vm.Summary.Guest.GuestFullName
-	case vm.Config.GuestFullName != "":
-		platformName = vm.Config.GuestFullName
-	case vm.Guest.GuestFullName != "":
-		platformName = vm.Guest.GuestFullName
-	}
-
+	platformName := vmPlatformName(vm)
 	platformStruct := &objects.Platform{
 		Name: platformName,
 		Slug: utils.Slugify(platformName),
@@ -1161,6 +1548,13 @@ func (vc *VmwareSource) syncVM(
 		Comments: vmComments,
 		Role:     vmRole,
 	}
+	keep, err := common.TransformVM(vc.Ctx, nbi, vc.SourceConfig, vmStruct, vc.GetSourceTags())
+	if err != nil {
+		return err
+	}
+	if !keep {
+		return nil
+	}
 	newVM, err := nbi.AddVM(vc.Ctx, vmStruct)
 	if err != nil {
 		return fmt.Errorf("failed to sync vmware VM %s: %v", vmName, err)
@@ -1178,6 +1572,18 @@ func (vc *VmwareSource) syncVM(
 		if err != nil {
 			return fmt.Errorf("failed to sync vmware %s's interfaces: %v", newVM, err)
 		}
+
+		// Sync services of the vm's role
+		err = common.AddVMRoleServices(
+			vc.Ctx,
+			nbi,
+			newVM,
+			vc.SourceConfig.VMRoleServices,
+			vc.GetSourceTags(),
+		)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1418,11 +1824,12 @@ func (vc *VmwareSource) collectVMInterfaceData(
 	if len(intNetworkVlanIDs) > 0 && intMode != &objects.VMInterfaceModeTaggedAll {
 		if len(intNetworkVlanIDs) == 1 && intNetworkVlanIDs[0] != 0 {
 			vidID := intNetworkVlanIDs[0]
+			nicUntaggedVlanAttributes := vc.vlanRelationAttributes(vidID)
 			nicUntaggedVlanSite, err := common.MatchVlanToSite(
 				vc.Ctx,
 				nbi,
-				vc.Networks.Vid2Name[vidID],
-				vc.SourceConfig.VlanSiteRelations,
+				nicUntaggedVlanAttributes,
+				vc.SourceConfig,
 			)
 			if err != nil {
 				return nicIPv4Addresses, nicIPv6Addresses, nil, "", fmt.Errorf(
@@ -1433,10 +1840,9 @@ func (vc *VmwareSource) collectVMInterfaceData(
 			nicUntaggedVlanGroup, err := common.MatchVlanToGroup(
 				vc.Ctx,
 				nbi,
-				vc.Networks.Vid2Name[vidID],
+				nicUntaggedVlanAttributes,
 				nicUntaggedVlanSite,
-				vc.SourceConfig.VlanGroupRelations,
-				vc.SourceConfig.VlanGroupSiteRelations,
+				vc.SourceConfig,
 			)
 			if err != nil {
 				return nicIPv4Addresses, nicIPv6Addresses, nil, "", fmt.Errorf(
@@ -1562,10 +1968,9 @@ func (vc *VmwareSource) addVMInterfaceIPs(
 	return vmIPv4Addresses, vmIPv6Addresses
 }
 
-// setVMPrimaryIPAddress updates the vm's primary IP in the following way:
-// we loop through all of the collected IPv4 and IPv6 addresses for the vm.
-// If any of the ips is in the same subnet as the default gateway, we choose it.
-// If there is no ip in the subnet of the default gateway, we choose the first one.
+// setVMPrimaryIPAddress sets vm's primary ips using the primary ip policy.
+// Ip addresses in the same subnet as the default gateway are preferred by the policy
+// when all policy rules are equal.
 func (vc *VmwareSource) setVMPrimaryIPAddress(
 	nbi *inventory.NetboxInventory,
 	netboxVM *objects.VM,
@@ -1574,29 +1979,31 @@ func (vc *VmwareSource) setVMPrimaryIPAddress(
 	vmIPv4Addresses []*objects.IPAddress,
 	vmIPv6Addresses []*objects.IPAddress,
 ) {
-	if len(vmIPv4Addresses) > 0 || len(vmIPv6Addresses) > 0 {
-		var vmIPv4PrimaryAddress *objects.IPAddress
-		for _, addr := range vmIPv4Addresses {
-			if vmIPv4PrimaryAddress == nil ||
-				utils.SubnetContainsIPAddress(vmDefaultGatewayIpv4, addr.Address) {
-				vmIPv4PrimaryAddress = addr
-			}
+	vmIPAddresses := make([]*objects.IPAddress, 0, len(vmIPv4Addresses)+len(vmIPv6Addresses))
+	preferredIPAddresses := []*objects.IPAddress{}
+	for _, addr := range vmIPv4Addresses {
+		vmIPAddresses = append(vmIPAddresses, addr)
+		if utils.SubnetContainsIPAddress(vmDefaultGatewayIpv4, addr.Address) {
+			preferredIPAddresses = append(preferredIPAddresses, addr)
 		}
-		var vmIPv6PrimaryAddress *objects.IPAddress
-		for _, addr := range vmIPv6Addresses {
-			if vmIPv6PrimaryAddress == nil ||
-				utils.SubnetContainsIPAddress(vmDefaultGatewayIpv6, addr.Address) {
-				vmIPv6PrimaryAddress = addr
-			}
-		}
-		newNetboxVM := *netboxVM
-		newNetboxVM.PrimaryIPv4 = vmIPv4PrimaryAddress
-		newNetboxVM.PrimaryIPv6 = vmIPv6PrimaryAddress
-		_, err := nbi.AddVM(vc.Ctx, &newNetboxVM)
-		if err != nil {
-			vc.Logger.Warningf(vc.Ctx, "updating vm's primary ip: %s", err)
+	}
+	for _, addr := range vmIPv6Addresses {
+		vmIPAddresses = append(vmIPAddresses, addr)
+		if utils.SubnetContainsIPAddress(vmDefaultGatewayIpv6, addr.Address) {
+			preferredIPAddresses = append(preferredIPAddresses, addr)
 		}
 	}
+	err := common.SetPrimaryIPAddresses(
+		vc.Ctx,
+		nbi,
+		netboxVM,
+		vmIPAddresses,
+		preferredIPAddresses,
+		vc.SourceConfig,
+	)
+	if err != nil {
+		vc.Logger.Warningf(vc.Ctx, "updating vm's primary ip: %s", err)
+	}
 }
 
 func (vc *VmwareSource) addVMContact(
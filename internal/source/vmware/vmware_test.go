@@ -1 +1,118 @@
 package vmware
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	"github.com/bl4ko/netbox-ssot/internal/logger"
+	"github.com/bl4ko/netbox-ssot/internal/parser"
+	"github.com/bl4ko/netbox-ssot/internal/source/common"
+	"github.com/bl4ko/netbox-ssot/internal/utils"
+	"github.com/vmware/govmomi/vapi/tags"
+)
+
+func newTestVmwareSource() *VmwareSource {
+	return &VmwareSource{
+		Networks: NetworkData{
+			DistributedVirtualPortgroups: map[string]*DistributedPortgroupData{
+				"dvportgroup-1": {Name: "servers", Switch: "dvs-dc1", VlanIDs: []int{100}},
+				"dvportgroup-2": {Name: "trunk", Switch: "dvs-dc2", VlanIDs: []int{200, 300}},
+			},
+			Vid2Name:         map[int]string{100: "servers", 200: "trunk", 300: "trunk"},
+			Vid2PortgroupKey: map[int]string{100: "dvportgroup-1", 200: "dvportgroup-2", 300: "dvportgroup-2"},
+		},
+		Object2Tags: map[string][]*tags.Tag{
+			"dvportgroup-1": {{Name: "production"}},
+		},
+	}
+}
+
+func TestVlanRelationAttributesMatchPortgroup(t *testing.T) {
+	vc := newTestVmwareSource()
+	vlanGroupRules := []utils.RelationRule{
+		{RelationCondition: utils.RelationCondition{Switch: "^dvs-dc1$"}, Value: "DC1 vlans"},
+		{RelationCondition: utils.RelationCondition{Switch: "^dvs-dc2$"}, Value: "DC2 vlans"},
+	}
+	tests := []struct {
+		name      string
+		dvpgKey   string
+		vid       int
+		wantGroup string
+	}{
+		{"Access portgroup", "dvportgroup-1", 100, "DC1 vlans"},
+		{"Trunk portgroup", "dvportgroup-2", 300, "DC2 vlans"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Group of the vlan, when it is synced from the portgroup
+			syncedGroup, err := utils.MatchRelations(vc.dvpgRelationAttributes(tt.dvpgKey), nil, vlanGroupRules)
+			if err != nil {
+				t.Fatalf("MatchRelations() error = %v", err)
+			}
+			// Group of the vlan, when it is looked up for host and vm interfaces
+			lookedUpGroup, err := utils.MatchRelations(vc.vlanRelationAttributes(tt.vid), nil, vlanGroupRules)
+			if err != nil {
+				t.Fatalf("MatchRelations() error = %v", err)
+			}
+			if syncedGroup != tt.wantGroup || lookedUpGroup != tt.wantGroup {
+				t.Errorf("vlan group = %s (synced), %s (looked up), want %s", syncedGroup, lookedUpGroup, tt.wantGroup)
+			}
+		})
+	}
+
+	attributes := vc.vlanRelationAttributes(100)
+	if attributes.Switch != "dvs-dc1" || len(attributes.Tags) != 1 || attributes.Tags[0] != "production" {
+		t.Errorf("vlanRelationAttributes() = %+v, want attributes of portgroup servers", attributes)
+	}
+	if attributes := vc.vlanRelationAttributes(400); attributes.Name != "" || attributes.Switch != "" {
+		t.Errorf("vlanRelationAttributes() = %+v, want empty attributes for unknown vid", attributes)
+	}
+}
+
+func TestHostPortgroupVlanFilteredLikePortgroup(t *testing.T) {
+	vc := newTestVmwareSource()
+	vc.Config = common.Config{
+		Logger: &logger.Logger{Logger: log.New(io.Discard, "", 0)},
+		Ctx:    context.Background(),
+		SourceConfig: &parser.SourceConfig{
+			Filters: map[string]utils.Filter{
+				"vlan": {Exclude: []utils.RelationCondition{{Switch: "^dvs-dc1$"}}},
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		portgroupName string
+		vid           int
+		wantDvpg      bool
+		wantFiltered  bool
+	}{
+		{"Vlan of excluded distributed switch", "host-servers", 100, true, true},
+		{"Vlan of other distributed switch", "host-trunk", 200, true, false},
+		{"Vlan of standard switch", "vm-network", 500, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes, isDvpg := vc.hostPortgroupVlanAttributes(tt.portgroupName, tt.vid)
+			if isDvpg != tt.wantDvpg {
+				t.Errorf("hostPortgroupVlanAttributes() isDvpg = %v, want %v", isDvpg, tt.wantDvpg)
+			}
+			filtered, err := vc.IsFiltered("vlan", attributes)
+			if err != nil {
+				t.Fatalf("IsFiltered() error = %v", err)
+			}
+			if filtered != tt.wantFiltered {
+				t.Errorf("IsFiltered() = %v, want %v", filtered, tt.wantFiltered)
+			}
+			if isDvpg {
+				// Vlan is filtered the same way, as when it is synced from its portgroup
+				syncFiltered, _ := vc.IsFiltered("vlan", vc.dvpgRelationAttributes(vc.Networks.Vid2PortgroupKey[tt.vid]))
+				if syncFiltered != filtered {
+					t.Errorf("IsFiltered() = %v, but portgroup filtered = %v", filtered, syncFiltered)
+				}
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/bl4ko/netbox-ssot/internal/constants"
@@ -371,6 +373,12 @@ func addStructDiff(
 	return nil
 }
 
+// replacedMapAttributes are map attributes, that netbox replaces as a whole when patched
+// (unlike custom fields, where only the sent keys are updated).
+var replacedMapAttributes = map[string]bool{
+	"local_context_data": true,
+}
+
 func addMapDiff(
 	newMap reflect.Value,
 	existingMap reflect.Value,
@@ -391,7 +399,7 @@ func addMapDiff(
 		if keyValue, ok := key.Interface().(string); ok {
 			if !existingMap.MapIndex(key).IsValid() {
 				mapsDiff[keyValue] = newMap.MapIndex(key).Interface()
-			} else if newMap.MapIndex(key).Interface() != existingMap.MapIndex(key).Interface() {
+			} else if !mapValuesEqual(newMap.MapIndex(key).Interface(), existingMap.MapIndex(key).Interface()) {
 				if hasPriority {
 					mapsDiff[keyValue] = newMap.MapIndex(key).Interface()
 				}
@@ -401,6 +409,19 @@ func addMapDiff(
 		}
 	}
 
+	if len(mapsDiff) > 0 && replacedMapAttributes[jsonTag] {
+		// Netbox replaces the whole map, so unchanged keys of both maps are also sent
+		for _, key := range existingMap.MapKeys() {
+			if keyValue, ok := key.Interface().(string); ok {
+				if _, changed := mapsDiff[keyValue]; !changed {
+					mapsDiff[keyValue] = existingMap.MapIndex(key).Interface()
+				}
+			}
+		}
+		diffMap[jsonTag] = mapsDiff
+		return nil
+	}
+
 	if len(mapsDiff) > 0 {
 		for _, key := range existingMap.MapKeys() {
 			if keyValue, ok := key.Interface().(string); ok {
@@ -416,6 +437,26 @@ func addMapDiff(
 	return nil
 }
 
+// mapValuesEqual compares two map values. Values that are not comparable (e.g. slices
+// and maps, as in local context data) are compared by their json representation,
+// because values returned from netbox api are always decoded as []interface{}
+// and map[string]interface{}.
+func mapValuesEqual(newValue, existingValue interface{}) bool {
+	newType, existingType := reflect.TypeOf(newValue), reflect.TypeOf(existingValue)
+	if newType == nil || existingType == nil || (newType.Comparable() && existingType.Comparable()) {
+		return newValue == existingValue
+	}
+	newJSON, err := json.Marshal(newValue)
+	if err != nil {
+		return false
+	}
+	existingJSON, err := json.Marshal(existingValue)
+	if err != nil {
+		return false
+	}
+	return string(newJSON) == string(existingJSON)
+}
+
 func addPrimaryDiff(
 	newField reflect.Value,
 	existingField reflect.Value,
@@ -452,3 +493,108 @@ func ExtractFieldsFromDiffMap(
 	}
 	return extractedFields
 }
+
+// RawObjectDiffMap compares two raw objects and returns a map of attributes,
+// that are different, with their values from newObj. Attributes common to all
+// netbox objects are compared with JSONDiffMapExceptID, all others with RawValuesEqual.
+func RawObjectDiffMap(
+	newObj, existingObj *objects.RawObject,
+	source2priority map[string]int,
+) (map[string]interface{}, error) {
+	diff, err := JSONDiffMapExceptID(
+		&newObj.NetboxObject,
+		&existingObj.NetboxObject,
+		false,
+		source2priority,
+	)
+	if err != nil {
+		return nil, err
+	}
+	hasPriority := hasPriorityOver(
+		reflect.ValueOf(newObj.NetboxObject),
+		reflect.ValueOf(existingObj.NetboxObject),
+		source2priority,
+	)
+	for key, newValue := range newObj.Fields {
+		existingValue := existingObj.Fields[key]
+		if RawValuesEqual(newValue, existingValue) {
+			continue
+		}
+		if existingValue == nil || hasPriority {
+			diff[key] = newValue
+		}
+	}
+	return diff, nil
+}
+
+// RawValuesEqual compares value of a raw object's attribute as sent to netbox api,
+// with the value returned from netbox api. Netbox returns related objects and choices
+// as nested objects, so they are equal, when new value is their id (or choice value),
+// or when all attributes of the new nested object match.
+func RawValuesEqual(newValue, existingValue interface{}) bool {
+	switch newValue := newValue.(type) {
+	case map[string]interface{}:
+		existingMap, ok := existingValue.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range newValue {
+			if !RawValuesEqual(value, existingMap[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		existingSlice, ok := existingValue.([]interface{})
+		if !ok || len(newValue) != len(existingSlice) {
+			return false
+		}
+		// Order of related objects (e.g. tags) is not significant
+		for _, value := range newValue {
+			if !slices.ContainsFunc(existingSlice, func(existing interface{}) bool {
+				return RawValuesEqual(value, existing)
+			}) {
+				return false
+			}
+		}
+		return true
+	default:
+		if existingMap, ok := existingValue.(map[string]interface{}); ok {
+			if id, ok := existingMap["id"]; ok {
+				return reflect.DeepEqual(newValue, id)
+			}
+			if choiceValue, ok := existingMap["value"]; ok {
+				return reflect.DeepEqual(newValue, choiceValue)
+			}
+			return false
+		}
+		return reflect.DeepEqual(newValue, existingValue)
+	}
+}
+
+// RawObjectKey returns natural key of the raw object, built from the given
+// attributes of its fields. Related objects and choices are represented with their
+// ids and choice values, so the key of an object returned from netbox api equals
+// the key of the same object, where related objects are referenced by their ids.
+func RawObjectKey(fields map[string]interface{}, naturalKey []string) string {
+	keyValues := make([]string, 0, len(naturalKey))
+	for _, attribute := range naturalKey {
+		keyValues = append(keyValues, rawKeyValue(fields[attribute]))
+	}
+	key, _ := json.Marshal(keyValues)
+	return string(key)
+}
+
+// rawKeyValue returns string representation of a natural key attribute.
+func rawKeyValue(value interface{}) string {
+	if nestedObject, ok := value.(map[string]interface{}); ok {
+		if id, ok := nestedObject["id"]; ok {
+			return rawKeyValue(id)
+		}
+		if choiceValue, ok := nestedObject["value"]; ok {
+			return rawKeyValue(choiceValue)
+		}
+	}
+	keyValue, _ := json.Marshal(value)
+	return string(keyValue)
+}
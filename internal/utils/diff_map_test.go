@@ -439,6 +439,42 @@ func TestMapAttributeDiff(t *testing.T) {
 					"existing_tag2":                       "existing_tag2",
 				},
 			},
+		}, {
+			name:        "Map with slice values no diff",
+			resetFields: false,
+			newStruct: &objects.Device{
+				LocalContextData: map[string]interface{}{
+					constants.LocalContextKeyDNSServers: []string{"10.0.0.1", "10.0.0.2"},
+				},
+			},
+			existingStruct: &objects.Device{
+				LocalContextData: map[string]interface{}{
+					constants.LocalContextKeyDNSServers: []interface{}{"10.0.0.1", "10.0.0.2"},
+					"user_key":                          "user_value",
+				},
+			},
+			expectedDiff: map[string]interface{}{},
+		},
+		{
+			name:        "Map with slice values diff",
+			resetFields: false,
+			newStruct: &objects.Device{
+				LocalContextData: map[string]interface{}{
+					constants.LocalContextKeyDNSServers: []string{"10.0.0.3"},
+				},
+			},
+			existingStruct: &objects.Device{
+				LocalContextData: map[string]interface{}{
+					constants.LocalContextKeyDNSServers: []interface{}{"10.0.0.1", "10.0.0.2"},
+					"user_key":                          "user_value",
+				},
+			},
+			expectedDiff: map[string]interface{}{
+				"local_context_data": map[string]interface{}{
+					constants.LocalContextKeyDNSServers: []string{"10.0.0.3"},
+					"user_key":                          "user_value",
+				},
+			},
 		},
 	}
 
@@ -1222,6 +1258,46 @@ func Test_addMapDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "local context data is sent as a whole",
+			args: args{
+				newMap: reflect.ValueOf(map[string]interface{}{
+					"dns_servers": []interface{}{"1.1.1.1"},
+					"ntp_servers": []interface{}{"pool"},
+				}),
+				existingMap: reflect.ValueOf(map[string]interface{}{
+					"dns_servers": []interface{}{"8.8.8.8"},
+					"ntp_servers": []interface{}{"pool"},
+					"user_key":    "x",
+				}),
+				hasPriority: true,
+				jsonTag:     "local_context_data",
+				diffMap:     map[string]interface{}{},
+			},
+			wantDiffMap: map[string]interface{}{
+				"local_context_data": map[string]interface{}{
+					"dns_servers": []interface{}{"1.1.1.1"},
+					"ntp_servers": []interface{}{"pool"},
+					"user_key":    "x",
+				},
+			},
+		},
+		{
+			name: "unchanged local context data is not sent",
+			args: args{
+				newMap: reflect.ValueOf(map[string]interface{}{
+					"ntp_servers": []interface{}{"pool"},
+				}),
+				existingMap: reflect.ValueOf(map[string]interface{}{
+					"ntp_servers": []interface{}{"pool"},
+					"user_key":    "x",
+				}),
+				hasPriority: true,
+				jsonTag:     "local_context_data",
+				diffMap:     map[string]interface{}{},
+			},
+			wantDiffMap: map[string]interface{}{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1376,3 +1452,171 @@ func TestExtractFieldsFromDiffMap(t *testing.T) {
 		})
 	}
 }
+
+func TestRawValuesEqual(t *testing.T) {
+	tests := []struct {
+		name          string
+		newValue      interface{}
+		existingValue interface{}
+		want          bool
+	}{
+		{
+			name:          "Equal scalars",
+			newValue:      "peer1",
+			existingValue: "peer1",
+			want:          true,
+		},
+		{
+			name:          "Different scalars",
+			newValue:      float64(65001),
+			existingValue: float64(65002),
+			want:          false,
+		},
+		{
+			name:          "Related object referenced by id",
+			newValue:      float64(1),
+			existingValue: map[string]interface{}{"id": float64(1), "name": "router1"},
+			want:          true,
+		},
+		{
+			name:          "Choice referenced by value",
+			newValue:      "active",
+			existingValue: map[string]interface{}{"value": "active", "label": "Active"},
+			want:          true,
+		},
+		{
+			name:          "Subset of nested object",
+			newValue:      map[string]interface{}{"name": "router1"},
+			existingValue: map[string]interface{}{"id": float64(1), "name": "router1"},
+			want:          true,
+		},
+		{
+			name:          "Slices in different order",
+			newValue:      []interface{}{float64(2), float64(1)},
+			existingValue: []interface{}{map[string]interface{}{"id": float64(1)}, map[string]interface{}{"id": float64(2)}},
+			want:          true,
+		},
+		{
+			name:          "Slices of different length",
+			newValue:      []interface{}{float64(1)},
+			existingValue: []interface{}{float64(1), float64(2)},
+			want:          false,
+		},
+		{
+			name:          "Missing existing value",
+			newValue:      "peer1",
+			existingValue: nil,
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RawValuesEqual(tt.newValue, tt.existingValue); got != tt.want {
+				t.Errorf("RawValuesEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawObjectKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		fields     map[string]interface{}
+		naturalKey []string
+		want       string
+	}{
+		{
+			name:       "Scalar attributes",
+			fields:     map[string]interface{}{"name": "peer1", "remote_as": float64(65001)},
+			naturalKey: []string{"name", "remote_as"},
+			want:       `["\"peer1\"","65001"]`,
+		},
+		{
+			name:       "Related object is represented with its id",
+			fields:     map[string]interface{}{"device": map[string]interface{}{"id": float64(1), "name": "router1"}},
+			naturalKey: []string{"device"},
+			want:       `["1"]`,
+		},
+		{
+			name:       "Missing attribute",
+			fields:     map[string]interface{}{},
+			naturalKey: []string{"name"},
+			want:       `["null"]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RawObjectKey(tt.fields, tt.naturalKey); got != tt.want {
+				t.Errorf("RawObjectKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRawObjectDiffMap(t *testing.T) {
+	tests := []struct {
+		name            string
+		newObj          *objects.RawObject
+		existingObj     *objects.RawObject
+		source2priority map[string]int
+		want            map[string]interface{}
+	}{
+		{
+			name: "Equal objects",
+			newObj: &objects.RawObject{
+				Fields: map[string]interface{}{"name": "peer1", "device": float64(1)},
+			},
+			existingObj: &objects.RawObject{
+				NetboxObject: objects.NetboxObject{ID: 1},
+				Fields: map[string]interface{}{
+					"name":   "peer1",
+					"device": map[string]interface{}{"id": float64(1)},
+					"status": map[string]interface{}{"value": "active"},
+				},
+			},
+			want: map[string]interface{}{},
+		},
+		{
+			name: "Changed field and description",
+			newObj: &objects.RawObject{
+				NetboxObject: objects.NetboxObject{Description: "new"},
+				Fields:       map[string]interface{}{"name": "peer1", "remote_as": float64(65002)},
+			},
+			existingObj: &objects.RawObject{
+				NetboxObject: objects.NetboxObject{ID: 1, Description: "old"},
+				Fields:       map[string]interface{}{"name": "peer1", "remote_as": float64(65001)},
+			},
+			want: map[string]interface{}{"description": "new", "remote_as": float64(65002)},
+		},
+		{
+			name: "Existing object from source with higher priority",
+			newObj: &objects.RawObject{
+				NetboxObject: objects.NetboxObject{
+					CustomFields: map[string]interface{}{constants.CustomFieldSourceName: "source2"},
+				},
+				Fields: map[string]interface{}{"remote_as": float64(65002), "comment": "peer"},
+			},
+			existingObj: &objects.RawObject{
+				NetboxObject: objects.NetboxObject{
+					ID:           1,
+					CustomFields: map[string]interface{}{constants.CustomFieldSourceName: "source1"},
+				},
+				Fields: map[string]interface{}{"remote_as": float64(65001), "comment": nil},
+			},
+			source2priority: map[string]int{"source1": 0, "source2": 1},
+			want:            map[string]interface{}{"comment": "peer"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RawObjectDiffMap(tt.newObj, tt.existingObj, tt.source2priority)
+			if err != nil {
+				t.Errorf("RawObjectDiffMap() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RawObjectDiffMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
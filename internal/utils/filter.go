@@ -0,0 +1,54 @@
+package utils
+
+import "fmt"
+
+// FilterObjectTypes are object types, that filters can be defined for.
+var FilterObjectTypes = []string{"cluster", "device", "vm", "vlan"}
+
+// Filter includes or excludes objects of one type, using the same conditions as relation rules.
+// Object passes the filter, if it satisfies any of Include conditions (or there are none),
+// and none of Exclude conditions.
+type Filter struct {
+	Include []RelationCondition `yaml:"include"`
+	Exclude []RelationCondition `yaml:"exclude"`
+}
+
+// ValidateFilter validates regexes and subnets of all conditions of the filter.
+func ValidateFilter(filter Filter) error {
+	for i, condition := range filter.Include {
+		if err := validateRelationCondition(condition); err != nil {
+			return fmt.Errorf("include[%d]: %s", i, err)
+		}
+	}
+	for i, condition := range filter.Exclude {
+		if err := validateRelationCondition(condition); err != nil {
+			return fmt.Errorf("exclude[%d]: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// Excludes returns true, if the object doesn't pass the filter.
+func (f Filter) Excludes(object RelationAttributes) (bool, error) {
+	included := len(f.Include) == 0
+	for _, condition := range f.Include {
+		matched, err := condition.matches(object)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return true, nil
+	}
+	for _, condition := range f.Exclude {
+		matched, err := condition.matches(object)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,83 @@
+package utils
+
+import "testing"
+
+func TestFilterExcludes(t *testing.T) {
+	object := RelationAttributes{
+		Name:       "lju-web01",
+		Tags:       []string{"prod"},
+		Cluster:    "prod-cluster",
+		Datacenter: "Ljubljana",
+		PowerState: "off",
+	}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{
+			name:   "Empty filter",
+			filter: Filter{},
+			want:   false,
+		},
+		{
+			name:   "Included object",
+			filter: Filter{Include: []RelationCondition{{Cluster: `^test-`}, {Datacenter: `^Ljubljana$`}}},
+			want:   false,
+		},
+		{
+			name:   "Not included object",
+			filter: Filter{Include: []RelationCondition{{Cluster: `^test-`}}},
+			want:   true,
+		},
+		{
+			name:   "Excluded object",
+			filter: Filter{Exclude: []RelationCondition{{Name: `^lju-`, PowerState: `^off$`}}},
+			want:   true,
+		},
+		{
+			name: "Exclude takes precedence over include",
+			filter: Filter{
+				Include: []RelationCondition{{Tag: `^prod$`}},
+				Exclude: []RelationCondition{{PowerState: `^off$`}},
+			},
+			want: true,
+		},
+		{
+			name:   "Unmatched exclude",
+			filter: Filter{Exclude: []RelationCondition{{Family: `Switches`}}},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Excludes(object)
+			if err != nil {
+				t.Fatalf("Excludes() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Excludes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantErr bool
+	}{
+		{name: "Valid filter", filter: Filter{Include: []RelationCondition{{Name: ".*"}}}},
+		{name: "Empty condition", filter: Filter{Exclude: []RelationCondition{{}}}, wantErr: true},
+		{name: "Invalid regex", filter: Filter{Include: []RelationCondition{{PowerState: "(on"}}}, wantErr: true},
+		{name: "Invalid subnet", filter: Filter{Exclude: []RelationCondition{{IP: "10.0.0.0"}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateFilter(tt.filter); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -62,13 +62,18 @@ func TestExtractJSONTagsFromStruct(t *testing.T) {
 				"asset_tag",
 				"site",
 				"location",
+				"rack",
+				"position",
+				"face",
 				"status",
 				"platform",
 				"primary_ip4",
 				"primary_ip6",
+				"oob_ip",
 				"cluster",
 				"tenant",
 				"comments",
+				"local_context_data",
 			},
 		},
 		{
@@ -112,12 +117,14 @@ func TestExtractJSONTagsFromStruct(t *testing.T) {
 				"bridge",
 				"lag",
 				"mtu",
+				"mgmt_only",
 				"primary_mac_address",
 				"duplex",
 				"mode",
 				"tagged_vlans",
 				"untagged_vlan",
 				"vdcs",
+				"vrf",
 			},
 		},
 	}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"reflect"
 	"strings"
+
+	"github.com/bl4ko/netbox-ssot/internal/netbox/objects"
 )
 
 // NetboxJSONMarshal takes an object pointer, and returns a json body,
@@ -111,3 +113,13 @@ func StructToNetboxJSONMap(obj interface{}) map[string]interface{} {
 	}
 	return netboxJSONMap
 }
+
+// RawObjectToNetboxJSONMap converts a raw object to a map[string]interface{},
+// which can be used to create the object with netbox API.
+func RawObjectToNetboxJSONMap(rawObject *objects.RawObject) map[string]interface{} {
+	netboxJSONMap := StructToNetboxJSONMap(&rawObject.NetboxObject)
+	for key, value := range rawObject.Fields {
+		netboxJSONMap[key] = value
+	}
+	return netboxJSONMap
+}
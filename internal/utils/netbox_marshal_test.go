@@ -289,3 +289,23 @@ func TestStructToNetboxJSONMap(t *testing.T) {
 		})
 	}
 }
+
+func TestRawObjectToNetboxJSONMap(t *testing.T) {
+	rawObject := &objects.RawObject{
+		NetboxObject: objects.NetboxObject{
+			Tags:        []*objects.Tag{{ID: 1, Name: "ssot"}},
+			Description: "Test session",
+		},
+		Path:   "/api/plugins/bgp/session/",
+		Fields: map[string]interface{}{"name": "peer1", "device": float64(1)},
+	}
+	want := map[string]interface{}{
+		"tags":        []interface{}{int64(1)},
+		"description": "Test session",
+		"name":        "peer1",
+		"device":      float64(1),
+	}
+	if got := RawObjectToNetboxJSONMap(rawObject); !reflect.DeepEqual(got, want) {
+		t.Errorf("RawObjectToNetboxJSONMap() = %v, want %v", got, want)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -152,3 +154,151 @@ func GetPrefixAndMaskFromIPAddress(ipAddress string) (string, int, error) {
 	maskBits, _ := ipNet.Mask.Size()
 	return ipNet.String(), maskBits, err
 }
+
+// GetMaskForIPAddress returns the mask bits of the first of the given
+// interface addresses (of format ip/mask), whose subnet contains the given ip address.
+// If no subnet contains it, host mask (32 for IPv4, 128 for IPv6) is returned.
+// e.g. ("192.168.1.50", ["10.0.0.1/8", "192.168.1.1/24"]) --> 24.
+func GetMaskForIPAddress(ipAddress string, interfaceAddresses []string) int {
+	for _, ifaceAddress := range interfaceAddresses {
+		if SubnetContainsIPAddress(ipAddress, ifaceAddress) {
+			_, maskBits, err := GetPrefixAndMaskFromIPAddress(ifaceAddress)
+			if err == nil {
+				return maskBits
+			}
+		}
+	}
+	if GetIPVersion(ipAddress) == constants.IPv6 {
+		return constants.MaxIPv6MaskBits
+	}
+	return constants.MaxIPv4MaskBits
+}
+
+// GetUsableIPv4Range returns first and last usable host address (with mask)
+// of the given IPv4 subnet.
+// e.g. 192.168.1.0/24 --> (192.168.1.1/24, 192.168.1.254/24).
+func GetUsableIPv4Range(subnet string) (string, string, error) {
+	_, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return "", "", err
+	}
+	network := ipNet.IP.To4()
+	if network == nil {
+		return "", "", fmt.Errorf("%s is not an IPv4 subnet", subnet)
+	}
+	maskBits, _ := ipNet.Mask.Size()
+	if maskBits > constants.MaxIPv4MaskBits-2 {
+		return "", "", fmt.Errorf("subnet %s has no usable host range", subnet)
+	}
+	first := make(net.IP, len(network))
+	last := make(net.IP, len(network))
+	for i := range network {
+		first[i] = network[i]
+		last[i] = network[i] | ^ipNet.Mask[i]
+	}
+	first[len(first)-1]++
+	last[len(last)-1]--
+	return fmt.Sprintf("%s/%d", first, maskBits), fmt.Sprintf("%s/%d", last, maskBits), nil
+}
+
+// ParsePorts parses comma separated list of ports and port ranges
+// into a sorted list of unique ports.
+// e.g. "443, 8000-8002" --> [443, 8000, 8001, 8002].
+func ParsePorts(ports string) ([]int, error) {
+	portSet := make(map[int]bool)
+	for _, portStr := range strings.Split(ports, ",") {
+		portStr = strings.TrimSpace(portStr)
+		if portStr == "" {
+			continue
+		}
+		startStr, endStr, isRange := strings.Cut(portStr, "-")
+		if !isRange {
+			endStr = startStr
+		}
+		start, err := parsePort(startStr)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parsePort(endStr)
+		if err != nil {
+			return nil, err
+		}
+		if start > end {
+			return nil, fmt.Errorf("invalid port range %s", portStr)
+		}
+		for port := start; port <= end; port++ {
+			portSet[port] = true
+		}
+	}
+	if len(portSet) == 0 {
+		return nil, fmt.Errorf("no ports in %q", ports)
+	}
+	parsedPorts := make([]int, 0, len(portSet))
+	for port := range portSet {
+		parsedPorts = append(parsedPorts, port)
+	}
+	sort.Ints(parsedPorts)
+	return parsedPorts, nil
+}
+
+func parsePort(portStr string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(portStr))
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %s", portStr)
+	}
+	if port < 1 || port > constants.MaxPort {
+		return 0, fmt.Errorf("port %d out of range", port)
+	}
+	return port, nil
+}
+
+// ParseASN parses autonomous system number in asplain (e.g. 65536)
+// or asdot (e.g. 1.0) notation.
+func ParseASN(asn string) (int64, error) {
+	asn = strings.TrimSpace(asn)
+	if high, low, ok := strings.Cut(asn, "."); ok {
+		highValue, err := strconv.ParseInt(high, 10, 64)
+		if err != nil || highValue < 0 || highValue > constants.MaxASN16 {
+			return 0, fmt.Errorf("invalid asn %s", asn)
+		}
+		lowValue, err := strconv.ParseInt(low, 10, 64)
+		if err != nil || lowValue < 0 || lowValue > constants.MaxASN16 {
+			return 0, fmt.Errorf("invalid asn %s", asn)
+		}
+		asn = strconv.FormatInt(highValue*(constants.MaxASN16+1)+lowValue, 10)
+	}
+	value, err := strconv.ParseInt(asn, 10, 64)
+	if err != nil || value < 1 || value > constants.MaxASN32 {
+		return 0, fmt.Errorf("invalid asn %s", asn)
+	}
+	return value, nil
+}
+
+// IsPrivateASN returns true if the asn is reserved for private use (RFC 6996).
+func IsPrivateASN(asn int64) bool {
+	return (asn >= constants.MinPrivateASN16 && asn <= constants.MaxPrivateASN16) ||
+		(asn >= constants.MinPrivateASN32 && asn <= constants.MaxPrivateASN32)
+}
+
+// GetParentPrefixes returns parent prefixes of the given address (of format ip/mask)
+// for each of the given prefix lengths, that is shorter than the mask of the address.
+// e.g. ("10.20.3.4/24", [8, 16, 28]) --> ["10.0.0.0/8", "10.20.0.0/16"].
+func GetParentPrefixes(address string, prefixLengths []int) ([]string, error) {
+	ip, ipNet, err := net.ParseCIDR(address)
+	if err != nil {
+		return nil, err
+	}
+	maskBits, totalBits := ipNet.Mask.Size()
+	parentPrefixes := make([]string, 0, len(prefixLengths))
+	for _, prefixLength := range prefixLengths {
+		if prefixLength <= 0 || prefixLength >= maskBits {
+			continue
+		}
+		parentNet := net.IPNet{
+			IP:   ip.Mask(net.CIDRMask(prefixLength, totalBits)),
+			Mask: net.CIDRMask(prefixLength, totalBits),
+		}
+		parentPrefixes = append(parentPrefixes, parentNet.String())
+	}
+	return parentPrefixes, nil
+}
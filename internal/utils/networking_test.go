@@ -513,3 +513,258 @@ func TestSerializeMask(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMaskForIPAddress(t *testing.T) {
+	tests := []struct {
+		name               string
+		ipAddress          string
+		interfaceAddresses []string
+		want               int
+	}{
+		{
+			name:               "IP address in one of interface subnets",
+			ipAddress:          "192.168.1.50",
+			interfaceAddresses: []string{"10.0.0.1/8", "192.168.1.1/24"},
+			want:               24,
+		},
+		{
+			name:               "IPv4 address not in any interface subnet",
+			ipAddress:          "172.16.0.10",
+			interfaceAddresses: []string{"10.0.0.1/8"},
+			want:               32,
+		},
+		{
+			name:               "IPv6 address not in any interface subnet",
+			ipAddress:          "2001:db8::10",
+			interfaceAddresses: []string{},
+			want:               128,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetMaskForIPAddress(tt.ipAddress, tt.interfaceAddresses); got != tt.want {
+				t.Errorf("GetMaskForIPAddress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetUsableIPv4Range(t *testing.T) {
+	tests := []struct {
+		name      string
+		subnet    string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{
+			name:      "Usable range of /24 subnet",
+			subnet:    "192.168.1.0/24",
+			wantStart: "192.168.1.1/24",
+			wantEnd:   "192.168.1.254/24",
+		},
+		{
+			name:      "Usable range of /22 subnet",
+			subnet:    "10.0.4.0/22",
+			wantStart: "10.0.4.1/22",
+			wantEnd:   "10.0.7.254/22",
+		},
+		{
+			name:    "Subnet without usable range",
+			subnet:  "10.0.0.0/31",
+			wantErr: true,
+		},
+		{
+			name:    "IPv6 subnet",
+			subnet:  "2001:db8::/64",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotEnd, err := GetUsableIPv4Range(tt.subnet)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetUsableIPv4Range() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotStart != tt.wantStart {
+				t.Errorf("GetUsableIPv4Range() gotStart = %v, want %v", gotStart, tt.wantStart)
+			}
+			if gotEnd != tt.wantEnd {
+				t.Errorf("GetUsableIPv4Range() gotEnd = %v, want %v", gotEnd, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParsePorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		ports   string
+		want    []int
+		wantErr bool
+	}{
+		{
+			name:  "Single port",
+			ports: "443",
+			want:  []int{443},
+		},
+		{
+			name:  "List of ports and ranges",
+			ports: "8443, 80, 8000-8002, 80",
+			want:  []int{80, 8000, 8001, 8002, 8443},
+		},
+		{
+			name:    "Port out of range",
+			ports:   "70000",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid range",
+			ports:   "90-80",
+			wantErr: true,
+		},
+		{
+			name:    "Empty ports",
+			ports:   "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePorts(tt.ports)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePorts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParsePorts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseASN(t *testing.T) {
+	tests := []struct {
+		name    string
+		asn     string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "Asplain notation",
+			asn:  "65001",
+			want: 65001,
+		},
+		{
+			name: "Asdot notation",
+			asn:  "1.10",
+			want: 65546,
+		},
+		{
+			name: "Four byte asplain notation",
+			asn:  "4200000001",
+			want: 4200000001,
+		},
+		{
+			name:    "Zero asn",
+			asn:     "0",
+			wantErr: true,
+		},
+		{
+			name:    "Asn out of range",
+			asn:     "4294967296",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid asdot notation",
+			asn:     "1.65536",
+			wantErr: true,
+		},
+		{
+			name:    "Empty asn",
+			asn:     "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseASN(tt.asn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseASN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseASN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPrivateASN(t *testing.T) {
+	tests := []struct {
+		name string
+		asn  int64
+		want bool
+	}{
+		{name: "Public two byte asn", asn: 15169, want: false},
+		{name: "Private two byte asn", asn: 64512, want: true},
+		{name: "Reserved two byte asn", asn: 65535, want: false},
+		{name: "Private four byte asn", asn: 4200000000, want: true},
+		{name: "Public four byte asn", asn: 396982, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPrivateASN(tt.asn); got != tt.want {
+				t.Errorf("IsPrivateASN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetParentPrefixes(t *testing.T) {
+	tests := []struct {
+		name          string
+		address       string
+		prefixLengths []int
+		want          []string
+		wantErr       bool
+	}{
+		{
+			name:          "IPv4 address with shorter and longer lengths",
+			address:       "10.20.3.4/24",
+			prefixLengths: []int{8, 16, 28},
+			want:          []string{"10.0.0.0/8", "10.20.0.0/16"},
+		},
+		{
+			name:          "IPv6 address",
+			address:       "2001:db8:1:2::10/64",
+			prefixLengths: []int{48},
+			want:          []string{"2001:db8:1::/48"},
+		},
+		{
+			name:          "Prefix with the same length",
+			address:       "10.20.0.0/16",
+			prefixLengths: []int{16},
+			want:          []string{},
+		},
+		{
+			name:          "Invalid address",
+			address:       "10.20.3.4",
+			prefixLengths: []int{16},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetParentPrefixes(tt.address, tt.prefixLengths)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetParentPrefixes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("GetParentPrefixes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// relationReferenceRegex matches references to capture groups in relation values:
+// $1, $name, ${1}, ${name} and ${name|filter|...}. $$ is an escaped $.
+var relationReferenceRegex = regexp.MustCompile(`\$(\$|\w+|\{[^{}]*\})`)
+
+// relationLookups are lookup tables, that relation values can reference with lookup filter,
+// e.g. ${1|lookup:sites}. They are shared by all sources.
+var (
+	relationLookups      map[string]map[string]string
+	relationLookupsMutex sync.RWMutex
+)
+
+// SetRelationLookups sets lookup tables, that relation values can reference with lookup filter.
+func SetRelationLookups(lookups map[string]map[string]string) {
+	relationLookupsMutex.Lock()
+	defer relationLookupsMutex.Unlock()
+	relationLookups = lookups
+}
+
+// getRelationLookup returns value of the key in the lookup table.
+func getRelationLookup(table string, key string) (string, bool) {
+	relationLookupsMutex.RLock()
+	defer relationLookupsMutex.RUnlock()
+	value, ok := relationLookups[table][key]
+	return value, ok
+}
+
+// relationReference is a parsed reference to a capture group in relation value.
+type relationReference struct {
+	group   string
+	filters []string
+}
+
+// parseRelationReference parses reference matched by relationReferenceRegex.
+// For escaped $ (i.e. $$), empty group is returned.
+func parseRelationReference(match string) relationReference {
+	reference := strings.TrimPrefix(match, "$")
+	if reference == "$" {
+		return relationReference{}
+	}
+	reference = strings.TrimSuffix(strings.TrimPrefix(reference, "{"), "}")
+	parts := strings.Split(reference, "|")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return relationReference{group: parts[0], filters: parts[1:]}
+}
+
+// IsRelationTemplate returns true, if relation value references capture groups of the regex.
+func IsRelationTemplate(value string) bool {
+	for _, match := range relationReferenceRegex.FindAllString(value, -1) {
+		if parseRelationReference(match).group != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRelationTemplate validates, that all capture groups and filters, referenced
+// in the relation value, exist. Lookup tables of lookup filters are returned, so their
+// existence can be validated by the caller.
+func ValidateRelationTemplate(regex *regexp.Regexp, value string) ([]string, error) {
+	lookupTables := []string{}
+	for _, match := range relationReferenceRegex.FindAllString(value, -1) {
+		reference := parseRelationReference(match)
+		if reference.group == "" {
+			continue
+		}
+		if groupIndex(regex, reference.group) < 0 {
+			return nil, fmt.Errorf(
+				"capture group %s referenced in %s doesn't exist in regex %s",
+				reference.group, value, regex,
+			)
+		}
+		for _, filter := range reference.filters {
+			filterName, table, _ := strings.Cut(filter, ":")
+			switch filterName {
+			case "upper", "lower", "title", "slug":
+			case "lookup":
+				if table == "" {
+					return nil, fmt.Errorf("lookup filter in %s must be in format lookup:table", value)
+				}
+				lookupTables = append(lookupTables, table)
+			default:
+				return nil, fmt.Errorf(
+					"unknown filter %s in %s. Must be one of upper, lower, title, slug or lookup:table",
+					filter, value,
+				)
+			}
+		}
+	}
+	return lookupTables, nil
+}
+
+// groupIndex returns index of the numbered or named capture group of the regex,
+// or -1 if the group doesn't exist.
+func groupIndex(regex *regexp.Regexp, group string) int {
+	if index, err := strconv.Atoi(group); err == nil {
+		if index > regex.NumSubexp() {
+			return -1
+		}
+		return index
+	}
+	return regex.SubexpIndex(group)
+}
+
+// expandRelationValue replaces references in relation value with capture groups of the
+// regex match, with applied filters. If a lookup table doesn't contain the group,
+// false is returned.
+func expandRelationValue(regex *regexp.Regexp, input string, match []int, value string) (string, bool) {
+	found := true
+	expanded := relationReferenceRegex.ReplaceAllStringFunc(value, func(referenceMatch string) string {
+		reference := parseRelationReference(referenceMatch)
+		if reference.group == "" {
+			return "$"
+		}
+		index := groupIndex(regex, reference.group)
+		// Capture groups, that didn't participate in the match, are empty
+		if index < 0 || match[2*index] < 0 {
+			return ""
+		}
+		groupValue := input[match[2*index]:match[2*index+1]]
+		for _, filter := range reference.filters {
+			filterName, table, _ := strings.Cut(filter, ":")
+			switch filterName {
+			case "upper":
+				groupValue = strings.ToUpper(groupValue)
+			case "lower":
+				groupValue = strings.ToLower(groupValue)
+			case "title":
+				groupValue = title(groupValue)
+			case "slug":
+				groupValue = Slugify(groupValue)
+			case "lookup":
+				lookupValue, ok := getRelationLookup(table, groupValue)
+				if !ok {
+					found = false
+				}
+				groupValue = lookupValue
+			}
+		}
+		return groupValue
+	})
+	return expanded, found
+}
+
+// title returns string with upper cased first letter, and lower cased other letters.
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	first, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(first)) + strings.ToLower(s[size:])
+}
+
+// RelationAttributes are attributes of an object (e.g. host or vm), that relation rules
+// can match on. Sources set the attributes they know about.
+type RelationAttributes struct {
+	Name string
+	// IPs are ip addresses of the object, with or without mask.
+	IPs        []string
+	Tags       []string
+	Cluster    string
+	Datacenter string
+	Folder     string
+	Platform   string
+	// PowerState is on or off, or power state reported by the source (e.g. suspended).
+	PowerState string
+	// Family is family of the device (e.g. Switches and Hubs).
+	Family string
+	// Switch is name of the (distributed) switch, e.g. of a vlan.
+	Switch           string
+	CustomAttributes map[string]string
+}
+
+// RelationCondition is a condition of a relation rule. Condition is satisfied, when all of its
+// attributes match, all conditions of All are satisfied and at least one condition of Any is satisfied.
+// Attributes are matched with regexes, except IP, which is a subnet containing any ip of the object.
+type RelationCondition struct {
+	Name             string              `yaml:"name"`
+	IP               string              `yaml:"ip"`
+	Tag              string              `yaml:"tag"`
+	Cluster          string              `yaml:"cluster"`
+	Datacenter       string              `yaml:"datacenter"`
+	Folder           string              `yaml:"folder"`
+	Platform         string              `yaml:"platform"`
+	PowerState       string              `yaml:"powerState"`
+	Family           string              `yaml:"family"`
+	Switch           string              `yaml:"switch"`
+	CustomAttributes map[string]string   `yaml:"customAttributes"`
+	All              []RelationCondition `yaml:"all"`
+	Any              []RelationCondition `yaml:"any"`
+}
+
+// RelationRule maps objects, that satisfy the condition, to the value. Value can reference
+// capture groups of the name regex.
+type RelationRule struct {
+	RelationCondition `yaml:",inline"`
+	Value             string `yaml:"value"`
+}
+
+// ValidateRelationRule validates regexes and subnets of the rule, and capture groups referenced
+// in its value. Lookup tables of lookup filters are returned, so their existence can be validated
+// by the caller.
+func ValidateRelationRule(rule RelationRule) ([]string, error) {
+	if strings.TrimSpace(rule.Value) == "" {
+		return nil, fmt.Errorf("value of relation rule cannot be empty")
+	}
+	if err := validateRelationCondition(rule.RelationCondition); err != nil {
+		return nil, err
+	}
+	if !IsRelationTemplate(rule.Value) {
+		return []string{}, nil
+	}
+	if rule.Name == "" {
+		return nil, fmt.Errorf("value %s references capture groups, but rule has no name regex", rule.Value)
+	}
+	return ValidateRelationTemplate(regexp.MustCompile(rule.Name), rule.Value)
+}
+
+// validateRelationCondition validates regexes and subnets of the condition and its subconditions.
+func validateRelationCondition(condition RelationCondition) error {
+	if condition.isEmpty() {
+		return fmt.Errorf("relation condition must match at least one attribute")
+	}
+	regexes := []string{
+		condition.Name, condition.Tag, condition.Cluster, condition.Datacenter, condition.Folder,
+		condition.Platform, condition.PowerState, condition.Family, condition.Switch,
+	}
+	for _, regexStr := range regexes {
+		if _, err := regexp.Compile(regexStr); err != nil {
+			return fmt.Errorf("invalid regex: %s", regexStr)
+		}
+	}
+	for attribute, regexStr := range condition.CustomAttributes {
+		if _, err := regexp.Compile(regexStr); err != nil {
+			return fmt.Errorf("invalid regex: %s, of custom attribute %s", regexStr, attribute)
+		}
+	}
+	if condition.IP != "" {
+		if _, _, err := net.ParseCIDR(condition.IP); err != nil {
+			return fmt.Errorf("invalid subnet: %s", condition.IP)
+		}
+	}
+	for _, subcondition := range slices.Concat(condition.All, condition.Any) {
+		if err := validateRelationCondition(subcondition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isEmpty returns true, if condition doesn't match on any attribute.
+func (rc RelationCondition) isEmpty() bool {
+	return rc.Name == "" && rc.IP == "" && rc.Tag == "" && rc.Cluster == "" && rc.Datacenter == "" &&
+		rc.Folder == "" && rc.Platform == "" && rc.PowerState == "" && rc.Family == "" && rc.Switch == "" &&
+		len(rc.CustomAttributes) == 0 && len(rc.All) == 0 && len(rc.Any) == 0
+}
+
+// matches returns true, if the object satisfies the condition.
+func (rc RelationCondition) matches(object RelationAttributes) (bool, error) {
+	regexAttributes := []struct{ regex, value string }{
+		{rc.Name, object.Name},
+		{rc.Cluster, object.Cluster},
+		{rc.Datacenter, object.Datacenter},
+		{rc.Folder, object.Folder},
+		{rc.Platform, object.Platform},
+		{rc.PowerState, object.PowerState},
+		{rc.Family, object.Family},
+		{rc.Switch, object.Switch},
+	}
+	for attributeName, regexStr := range rc.CustomAttributes {
+		value, ok := object.CustomAttributes[attributeName]
+		if !ok {
+			return false, nil
+		}
+		regexAttributes = append(regexAttributes, struct{ regex, value string }{regexStr, value})
+	}
+	for _, attribute := range regexAttributes {
+		if attribute.regex == "" {
+			continue
+		}
+		matched, err := regexp.MatchString(attribute.regex, attribute.value)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	if rc.Tag != "" {
+		matched, err := matchAny(rc.Tag, object.Tags)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	if rc.IP != "" {
+		_, subnet, err := net.ParseCIDR(rc.IP)
+		if err != nil {
+			return false, err
+		}
+		if !slices.ContainsFunc(object.IPs, func(ipAddress string) bool {
+			ip := net.ParseIP(strings.Split(ipAddress, "/")[0])
+			return ip != nil && subnet.Contains(ip)
+		}) {
+			return false, nil
+		}
+	}
+	for _, subcondition := range rc.All {
+		matched, err := subcondition.matches(object)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	if len(rc.Any) == 0 {
+		return true, nil
+	}
+	for _, subcondition := range rc.Any {
+		matched, err := subcondition.matches(object)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchAny returns true, if any of the values matches the regex.
+func matchAny(regexStr string, values []string) (bool, error) {
+	for _, value := range values {
+		matched, err := regexp.MatchString(regexStr, value)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
+
+// MatchRelations matches the object to a value using relation rules and regex relations.
+// Rules are checked in order before regex relations, which only match the object's name.
+// If there is no match, it returns an empty string.
+func MatchRelations(object RelationAttributes, relations map[string]string, rules []RelationRule) (string, error) {
+	for _, rule := range rules {
+		matched, err := rule.matches(object)
+		if err != nil {
+			return "", err
+		}
+		if !matched {
+			continue
+		}
+		if rule.Name == "" {
+			return rule.Value, nil
+		}
+		// Value can reference capture groups of the name regex
+		regex, err := regexp.Compile(rule.Name)
+		if err != nil {
+			return "", err
+		}
+		value, ok := expandRelationValue(regex, object.Name, regex.FindStringSubmatchIndex(object.Name), rule.Value)
+		if ok {
+			return value, nil
+		}
+	}
+	return MatchStringToValue(object.Name, relations)
+}
@@ -0,0 +1,236 @@
+package utils
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestMatchStringToValueWithCaptureGroups(t *testing.T) {
+	SetRelationLookups(map[string]map[string]string{
+		"sites": {"lju": "Ljubljana", "mb": "Maribor"},
+	})
+	defer SetRelationLookups(nil)
+
+	tests := []struct {
+		name     string
+		input    string
+		patterns map[string]string
+		want     string
+	}{
+		{
+			name:     "Numbered group",
+			input:    "lju-esxi01",
+			patterns: map[string]string{`^([a-z]{3})-.*`: "Site-$1"},
+			want:     "Site-lju",
+		},
+		{
+			name:     "Named group with filter",
+			input:    "lju-esxi01",
+			patterns: map[string]string{`^(?P<site>[a-z]{3})-.*`: "Site-${site|upper}"},
+			want:     "Site-LJU",
+		},
+		{
+			name:     "Multiple filters",
+			input:    "LJU-esxi01",
+			patterns: map[string]string{`^([A-Z]{3})-.*`: "${1|lower|title}"},
+			want:     "Lju",
+		},
+		{
+			name:     "Slug filter",
+			input:    "Tenant A: esxi01",
+			patterns: map[string]string{`^(.*):.*`: "${1|slug}"},
+			want:     "tenant-a",
+		},
+		{
+			name:     "Lookup table",
+			input:    "mb-esxi01",
+			patterns: map[string]string{`^([a-z]+)-.*`: "${1|lookup:sites}"},
+			want:     "Maribor",
+		},
+		{
+			name:     "Missing key in lookup table",
+			input:    "ce-esxi01",
+			patterns: map[string]string{`^([a-z]+)-.*`: "${1|lookup:sites}"},
+			want:     "",
+		},
+		{
+			name:     "Unmatched optional group",
+			input:    "esxi01",
+			patterns: map[string]string{`^(lju-)?esxi(\d+)`: "${1}host$2"},
+			want:     "host01",
+		},
+		{
+			name:     "Escaped dollar",
+			input:    "esxi01",
+			patterns: map[string]string{`^esxi(\d+)`: "$$1-$1"},
+			want:     "$1-01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchStringToValue(tt.input, tt.patterns)
+			if err != nil {
+				t.Fatalf("MatchStringToValue() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MatchStringToValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRelationTemplate(t *testing.T) {
+	tests := []struct {
+		name             string
+		regex            string
+		value            string
+		wantLookupTables []string
+		wantErr          bool
+	}{
+		{name: "Fixed value", regex: `^lju-.*`, value: "Ljubljana", wantLookupTables: []string{}},
+		{name: "Existing groups", regex: `^(?P<site>[a-z]+)-(\d+)`, value: "${site|title}-$2", wantLookupTables: []string{}},
+		{name: "Lookup table", regex: `^([a-z]+)-.*`, value: "${1|lookup:sites}", wantLookupTables: []string{"sites"}},
+		{name: "Missing numbered group", regex: `^([a-z]+)-.*`, value: "$2", wantErr: true},
+		{name: "Missing named group", regex: `^([a-z]+)-.*`, value: "${site}", wantErr: true},
+		{name: "Unknown filter", regex: `^([a-z]+)-.*`, value: "${1|reverse}", wantErr: true},
+		{name: "Lookup without table", regex: `^([a-z]+)-.*`, value: "${1|lookup}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookupTables, err := ValidateRelationTemplate(regexp.MustCompile(tt.regex), tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRelationTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(lookupTables, tt.wantLookupTables) {
+				t.Errorf("ValidateRelationTemplate() = %v, want %v", lookupTables, tt.wantLookupTables)
+			}
+		})
+	}
+}
+
+func TestIsRelationTemplate(t *testing.T) {
+	values := map[string]bool{"Ljubljana": false, "Site-$1": true, "${site|upper}": true, "Price $$": false}
+	for value, want := range values {
+		if got := IsRelationTemplate(value); got != want {
+			t.Errorf("IsRelationTemplate(%s) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestMatchRelations(t *testing.T) {
+	SetRelationLookups(map[string]map[string]string{"sites": {"lju": "Ljubljana"}})
+	defer SetRelationLookups(nil)
+
+	object := RelationAttributes{
+		Name:             "lju-web01",
+		IPs:              []string{"10.1.2.3/24", "fe80::1"},
+		Tags:             []string{"prod", "tenant-b"},
+		Cluster:          "prod-cluster",
+		Folder:           "tenant-a/web",
+		Platform:         "Ubuntu Linux (64-bit)",
+		CustomAttributes: map[string]string{"owner": "team-c"},
+	}
+	tests := []struct {
+		name      string
+		relations map[string]string
+		rules     []RelationRule
+		want      string
+	}{
+		{
+			name:      "Regex relations",
+			relations: map[string]string{`^lju-.*`: "Ljubljana"},
+			want:      "Ljubljana",
+		},
+		{
+			name: "Rule with multiple attributes",
+			rules: []RelationRule{{
+				RelationCondition: RelationCondition{Name: `^lju-`, Cluster: `^prod-`, Folder: `^tenant-a/`},
+				Value:             "Tenant A",
+			}},
+			want: "Tenant A",
+		},
+		{
+			name: "Rule with capture groups",
+			rules: []RelationRule{{
+				RelationCondition: RelationCondition{Name: `^([a-z]{3})-`, Platform: `(?i)linux`},
+				Value:             "${1|lookup:sites}",
+			}},
+			want: "Ljubljana",
+		},
+		{
+			name:      "Rules take precedence over regex relations",
+			relations: map[string]string{`^lju-.*`: "Ljubljana"},
+			rules: []RelationRule{
+				{RelationCondition: RelationCondition{IP: "192.168.0.0/16"}, Value: "Wrong"},
+				{RelationCondition: RelationCondition{IP: "10.0.0.0/8"}, Value: "Internal"},
+			},
+			want: "Internal",
+		},
+		{
+			name:      "Unmatched rule falls back to regex relations",
+			relations: map[string]string{`^lju-.*`: "Ljubljana"},
+			rules:     []RelationRule{{RelationCondition: RelationCondition{Tag: `^dev$`}, Value: "Dev"}},
+			want:      "Ljubljana",
+		},
+		{
+			name: "All and any conditions",
+			rules: []RelationRule{{
+				RelationCondition: RelationCondition{
+					All: []RelationCondition{{Tag: `^prod$`}, {CustomAttributes: map[string]string{"owner": `^team-`}}},
+					Any: []RelationCondition{{Switch: `.+`}, {Tag: `^tenant-b$`}},
+				},
+				Value: "Tenant B",
+			}},
+			want: "Tenant B",
+		},
+		{
+			name: "Missing custom attribute",
+			rules: []RelationRule{{
+				RelationCondition: RelationCondition{CustomAttributes: map[string]string{"department": `.*`}},
+				Value:             "Department",
+			}},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchRelations(object, tt.relations, tt.rules)
+			if err != nil {
+				t.Fatalf("MatchRelations() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MatchRelations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRelationRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    RelationRule
+		wantErr bool
+	}{
+		{name: "Valid rule", rule: RelationRule{RelationCondition: RelationCondition{IP: "10.0.0.0/8"}, Value: "A"}},
+		{name: "Empty value", rule: RelationRule{RelationCondition: RelationCondition{Name: ".*"}}, wantErr: true},
+		{name: "Empty condition", rule: RelationRule{Value: "A"}, wantErr: true},
+		{
+			name:    "Invalid nested regex",
+			rule:    RelationRule{RelationCondition: RelationCondition{Any: []RelationCondition{{Tag: "(a"}}}, Value: "A"},
+			wantErr: true,
+		},
+		{
+			name:    "Template without name regex",
+			rule:    RelationRule{RelationCondition: RelationCondition{Folder: "(a)"}, Value: "$1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateRelationRule(tt.rule); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRelationRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
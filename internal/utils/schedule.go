@@ -0,0 +1,265 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleMacros are shorthands for common cron expressions.
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var weekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// Schedule is a parsed cron expression with fields minute, hour, day of month,
+// month and day of week (e.g. "*/15 * * * *" or "0 22 * * mon-fri").
+type Schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	// When both day fields are restricted, day matches if any of them matches,
+	// otherwise only the restricted field is matched (same as in cron).
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// ParseSchedule parses a cron expression with 5 fields, or one of macros
+// @yearly, @monthly, @weekly, @daily and @hourly.
+func ParseSchedule(expression string) (*Schedule, error) {
+	if macro, ok := scheduleMacros[strings.TrimSpace(expression)]; ok {
+		expression = macro
+	}
+	fields := strings.Fields(expression)
+	if len(fields) != 5 { //nolint:mnd
+		return nil, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+	var schedule Schedule
+	var err error
+	if schedule.minutes, err = parseScheduleField(fields[0], 0, 59, nil); err != nil { //nolint:mnd
+		return nil, fmt.Errorf("minute: %s", err)
+	}
+	if schedule.hours, err = parseScheduleField(fields[1], 0, 23, nil); err != nil { //nolint:mnd
+		return nil, fmt.Errorf("hour: %s", err)
+	}
+	if schedule.daysOfMonth, err = parseScheduleField(fields[2], 1, 31, nil); err != nil { //nolint:mnd
+		return nil, fmt.Errorf("day of month: %s", err)
+	}
+	if schedule.months, err = parseScheduleField(fields[3], 1, 12, monthNames); err != nil { //nolint:mnd
+		return nil, fmt.Errorf("month: %s", err)
+	}
+	// Both 0 and 7 are sunday
+	if schedule.daysOfWeek, err = parseScheduleField(fields[4], 0, 7, weekdayNames); err != nil { //nolint:mnd
+		return nil, fmt.Errorf("day of week: %s", err)
+	}
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek |= 1
+	}
+	schedule.anyDayOfMonth = strings.HasPrefix(fields[2], "*")
+	schedule.anyDayOfWeek = strings.HasPrefix(fields[4], "*")
+	return &schedule, nil
+}
+
+// parseScheduleField parses comma separated list of values, ranges (a-b) and
+// steps (*/n, a-b/n, a/n) into a bitset of values between minValue and maxValue.
+func parseScheduleField(field string, minValue int, maxValue int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		valueRange, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %s", stepStr)
+			}
+		}
+		start, end := minValue, maxValue
+		if valueRange != "*" {
+			startStr, endStr, isRange := strings.Cut(valueRange, "-")
+			var err error
+			if start, err = parseScheduleValue(startStr, minValue, maxValue, names); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseScheduleValue(endStr, minValue, maxValue, names); err != nil {
+					return 0, err
+				}
+				if end < start {
+					return 0, fmt.Errorf("invalid range %s", valueRange)
+				}
+			} else if hasStep {
+				// a/n means a-max/n
+				end = maxValue
+			}
+		}
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// parseScheduleValue parses a number or a name of the value of the schedule field.
+func parseScheduleValue(valueStr string, minValue int, maxValue int, names map[string]int) (int, error) {
+	if value, ok := names[strings.ToLower(valueStr)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %s", valueStr)
+	}
+	if value < minValue || value > maxValue {
+		return 0, fmt.Errorf("value %d is not between %d and %d", value, minValue, maxValue)
+	}
+	return value, nil
+}
+
+// Next returns the first time after t, that matches the schedule. Zero time is returned,
+// if the schedule never matches (e.g. 30th of february).
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, t.Location())
+	limit := t.AddDate(5, 0, 0) //nolint:mnd
+	for t.Before(limit) {
+		switch {
+		case s.months&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minutes&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay returns true, if day of month and day of week of t match the schedule.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.daysOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.daysOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// MaintenanceWindow is a weekly recurring time window. Window starts on each of Days
+// (all days, if empty) at Start and ends at End (e.g. "22:00"). If End is before Start,
+// window ends on the next day. Window without Start and End lasts the whole day.
+type MaintenanceWindow struct {
+	Days  []string `yaml:"days"`
+	Start string   `yaml:"start"`
+	End   string   `yaml:"end"`
+}
+
+// ValidateMaintenanceWindow validates days and times of the maintenance window.
+func ValidateMaintenanceWindow(window MaintenanceWindow) error {
+	for _, day := range window.Days {
+		if _, ok := weekdayNames[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("days: invalid day %s. Valid days: mon, tue, wed, thu, fri, sat, sun", day)
+		}
+	}
+	if window.Start == "" && window.End == "" {
+		return nil
+	}
+	start, err := parseTimeOfDay(window.Start)
+	if err != nil {
+		return fmt.Errorf("start: %s", err)
+	}
+	end, err := parseTimeOfDay(window.End)
+	if err != nil {
+		return fmt.Errorf("end: %s", err)
+	}
+	if start == end {
+		return fmt.Errorf("start and end cannot be equal")
+	}
+	return nil
+}
+
+// parseTimeOfDay parses time in format HH:MM into minutes after midnight.
+func parseTimeOfDay(timeStr string) (int, error) {
+	parsed, err := time.Parse("15:04", timeStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected format HH:MM", timeStr)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil //nolint:mnd
+}
+
+// Contains returns true, if t is inside the maintenance window.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	if w.Start == "" && w.End == "" {
+		return w.startsOn(t.Weekday())
+	}
+	start, _ := parseTimeOfDay(w.Start)
+	end, _ := parseTimeOfDay(w.End)
+	minute := t.Hour()*60 + t.Minute() //nolint:mnd
+	if start < end {
+		return w.startsOn(t.Weekday()) && minute >= start && minute < end
+	}
+	// Window over midnight either started today, or the day before
+	previousDay := (t.Weekday() + 6) % 7 //nolint:mnd
+	return (w.startsOn(t.Weekday()) && minute >= start) || (w.startsOn(previousDay) && minute < end)
+}
+
+// startsOn returns true, if the window starts on the weekday.
+func (w MaintenanceWindow) startsOn(weekday time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if weekdayNames[strings.ToLower(day)] == int(weekday) {
+			return true
+		}
+	}
+	return false
+}
+
+// InMaintenanceWindows returns true, if t is inside any of the windows, or there are no windows.
+func InMaintenanceWindows(windows []MaintenanceWindow, t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, window := range windows {
+		if window.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextMaintenanceWindow returns the first minute from t on, that is inside any of the windows.
+// Zero time is returned, if no window starts in the next week.
+func NextMaintenanceWindow(windows []MaintenanceWindow, t time.Time) time.Time {
+	if InMaintenanceWindows(windows, t) {
+		return t
+	}
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, t.Location())
+	for limit := t.AddDate(0, 0, 8); t.Before(limit); t = t.Add(time.Minute) { //nolint:mnd
+		if InMaintenanceWindows(windows, t) {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseScheduleInvalid(t *testing.T) {
+	expressions := []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * foo *",
+	}
+	for _, expression := range expressions {
+		if _, err := ParseSchedule(expression); err == nil {
+			t.Errorf("ParseSchedule(%q) expected error", expression)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Monday
+	from := time.Date(2024, time.June, 3, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		expression string
+		want       time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, time.June, 3, 10, 15, 0, 0, time.UTC)},
+		{"* * * * *", time.Date(2024, time.June, 3, 10, 8, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.June, 3, 11, 0, 0, 0, time.UTC)},
+		{"0 22 * * mon-fri", time.Date(2024, time.June, 3, 22, 0, 0, 0, time.UTC)},
+		{"30 2 * * sat,sun", time.Date(2024, time.June, 8, 2, 30, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, time.June, 9, 0, 0, 0, 0, time.UTC)},
+		// Restricted day of month and day of week match any of them
+		{"0 0 15 * 5", time.Date(2024, time.June, 7, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 feb *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseSchedule() error = %v", err)
+			}
+			if got := schedule.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  MaintenanceWindow
+		wantErr bool
+	}{
+		{"Whole day", MaintenanceWindow{Days: []string{"sat", "Sun"}}, false},
+		{"Over midnight", MaintenanceWindow{Start: "22:00", End: "06:00"}, false},
+		{"Invalid day", MaintenanceWindow{Days: []string{"monday"}}, true},
+		{"Missing end", MaintenanceWindow{Start: "22:00"}, true},
+		{"Invalid time", MaintenanceWindow{Start: "25:00", End: "06:00"}, true},
+		{"Equal start and end", MaintenanceWindow{Start: "06:00", End: "06:00"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateMaintenanceWindow(tt.window); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMaintenanceWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindows(t *testing.T) {
+	windows := []MaintenanceWindow{
+		{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "18:00", End: "07:00"},
+		{Days: []string{"sat", "sun"}},
+	}
+	tests := []struct {
+		name string
+		time time.Time
+		want bool
+	}{
+		{"Monday business hours", time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC), false},
+		{"Monday evening", time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC), true},
+		{"Tuesday early morning", time.Date(2024, time.June, 4, 6, 59, 0, 0, time.UTC), true},
+		{"Tuesday morning", time.Date(2024, time.June, 4, 7, 0, 0, 0, time.UTC), false},
+		{"Monday early morning after weekend", time.Date(2024, time.June, 3, 3, 0, 0, 0, time.UTC), false},
+		{"Saturday noon", time.Date(2024, time.June, 8, 12, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InMaintenanceWindows(windows, tt.time); got != tt.want {
+				t.Errorf("InMaintenanceWindows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	from := time.Date(2024, time.June, 3, 12, 30, 0, 0, time.UTC)
+	want := time.Date(2024, time.June, 3, 18, 0, 0, 0, time.UTC)
+	if got := NextMaintenanceWindow(windows, from); !got.Equal(want) {
+		t.Errorf("NextMaintenanceWindow() = %v, want %v", got, want)
+	}
+	if got := NextMaintenanceWindow(nil, from); !got.Equal(from) {
+		t.Errorf("NextMaintenanceWindow() without windows = %v, want %v", got, from)
+	}
+}
@@ -0,0 +1,249 @@
+package utils
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// TransformObjectTypes are object types, that transforms can be defined for. Other objects
+// (e.g. interfaces, ip addresses and vlans) can't be transformed.
+var TransformObjectTypes = []string{"device", "vm"}
+
+// transformFields are fields of objects, that transforms can set. Custom fields
+// are set with customFields.<name>.
+var transformFields = []string{"name", "description", "comments", "role", "platform"}
+
+// Transform rewrites fields of the object with expressions, drops the object, or emits
+// raw objects for it. Transform is applied only to objects, for which When expression is true.
+type Transform struct {
+	When string            `yaml:"when"`
+	Set  map[string]string `yaml:"set"`
+	Drop bool              `yaml:"drop"`
+	Emit []TransformEmit   `yaml:"emit"`
+}
+
+// TransformEmit is a raw object (of one of netbox.rawObjectTypes), that the transform
+// emits for the object. Attributes of the raw object are expressions, which are evaluated
+// after fields of the transform are set.
+type TransformEmit struct {
+	Path   string            `yaml:"path"`
+	Object map[string]string `yaml:"object"`
+}
+
+// EmittedObject is a document of the raw object on the path, emitted by a transform.
+type EmittedObject struct {
+	Path   string         `yaml:"path"`
+	Object map[string]any `yaml:"object"`
+}
+
+// transformPrograms is a cache of compiled transform expressions.
+var transformPrograms sync.Map
+
+// NewTransformObject returns object, that can be transformed, with all fields that
+// expressions can use. Missing fields of values are set to their empty values.
+func NewTransformObject(values map[string]any) map[string]any {
+	object := map[string]any{
+		"name":         "",
+		"description":  "",
+		"comments":     "",
+		"role":         "",
+		"platform":     "",
+		"status":       "",
+		"serial":       "",
+		"site":         "",
+		"cluster":      "",
+		"tenant":       "",
+		"source":       "",
+		"tags":         []string{},
+		"customFields": map[string]any{},
+	}
+	maps.Copy(object, values)
+	return object
+}
+
+// transformOptions returns options used for compiling transform expressions.
+func transformOptions() []expr.Option {
+	return []expr.Option{
+		expr.Env(NewTransformObject(nil)),
+		expr.Function("slug", func(params ...any) (any, error) {
+			return Slugify(params[0].(string)), nil
+		}, new(func(string) string)),
+		expr.Function("title", func(params ...any) (any, error) {
+			return title(params[0].(string)), nil
+		}, new(func(string) string)),
+		expr.Function("regexReplace", func(params ...any) (any, error) {
+			regex, err := regexp.Compile(params[1].(string))
+			if err != nil {
+				return nil, err
+			}
+			return regex.ReplaceAllString(params[0].(string), params[2].(string)), nil
+		}, new(func(string, string, string) string)),
+		// lookup returns nil, if the table doesn't contain the key, so it can be
+		// combined with ?? operator, e.g. lookup("sites", name) ?? "Unknown"
+		expr.Function("lookup", func(params ...any) (any, error) {
+			if value, ok := getRelationLookup(params[0].(string), params[1].(string)); ok {
+				return value, nil
+			}
+			return nil, nil
+		}, new(func(string, string) any)),
+	}
+}
+
+// compileTransformExpression compiles the expression, or returns already compiled program.
+func compileTransformExpression(expression string, asBool bool) (*vm.Program, error) {
+	key := fmt.Sprintf("%t:%s", asBool, expression)
+	if program, ok := transformPrograms.Load(key); ok {
+		return program.(*vm.Program), nil
+	}
+	options := transformOptions()
+	if asBool {
+		options = append(options, expr.AsBool())
+	}
+	program, err := expr.Compile(expression, options...)
+	if err != nil {
+		return nil, err
+	}
+	transformPrograms.Store(key, program)
+	return program, nil
+}
+
+// ValidateTransform validates fields and expressions of the transform.
+func ValidateTransform(transform Transform) error {
+	if !transform.Drop && len(transform.Set) == 0 && len(transform.Emit) == 0 {
+		return fmt.Errorf("transform must either set fields, emit raw objects or drop objects")
+	}
+	if transform.Drop && (len(transform.Set) > 0 || len(transform.Emit) > 0) {
+		return fmt.Errorf("transform that drops objects can't set fields or emit raw objects")
+	}
+	if transform.When != "" {
+		if _, err := compileTransformExpression(transform.When, true); err != nil {
+			return fmt.Errorf("invalid when expression %q: %s", transform.When, expressionError(err))
+		}
+	}
+	for _, field := range slices.Sorted(maps.Keys(transform.Set)) {
+		customField, isCustomField := strings.CutPrefix(field, "customFields.")
+		if !slices.Contains(transformFields, field) && (!isCustomField || customField == "") {
+			return fmt.Errorf(
+				"field %s can't be set. Must be one of %s or customFields.<name>",
+				field, strings.Join(transformFields, ", "),
+			)
+		}
+		if _, err := compileTransformExpression(transform.Set[field], false); err != nil {
+			return fmt.Errorf(
+				"invalid expression %q of field %s: %s", transform.Set[field], field, expressionError(err),
+			)
+		}
+	}
+	for i, emit := range transform.Emit {
+		if emit.Path == "" {
+			return fmt.Errorf("emit[%d]: path of raw object can't be empty", i)
+		}
+		if len(emit.Object) == 0 {
+			return fmt.Errorf("emit[%d]: object can't be empty", i)
+		}
+		for _, attribute := range slices.Sorted(maps.Keys(emit.Object)) {
+			if _, err := compileTransformExpression(emit.Object[attribute], false); err != nil {
+				return fmt.Errorf(
+					"emit[%d]: invalid expression %q of attribute %s: %s",
+					i, emit.Object[attribute], attribute, expressionError(err),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// expressionError returns the first line of the expression error, without
+// the snippet of the expression.
+func expressionError(err error) string {
+	message, _, _ := strings.Cut(err.Error(), "\n")
+	return message
+}
+
+// ApplyTransforms applies transforms in order to the object, created with NewTransformObject.
+// Each transform sees fields set by previous transforms. It returns true, if the object is dropped,
+// and raw objects emitted by the transforms. Objects, that are dropped, don't emit any raw objects.
+//
+//nolint:gocyclo
+func ApplyTransforms(transforms []Transform, object map[string]any) (bool, []EmittedObject, error) {
+	emitted := []EmittedObject{}
+	for _, transform := range transforms {
+		if transform.When != "" {
+			program, err := compileTransformExpression(transform.When, true)
+			if err != nil {
+				return false, nil, err
+			}
+			matched, err := expr.Run(program, object)
+			if err != nil {
+				return false, nil, fmt.Errorf("when expression %q: %s", transform.When, expressionError(err))
+			}
+			if !matched.(bool) {
+				continue
+			}
+		}
+		if transform.Drop {
+			return true, nil, nil
+		}
+		// All expressions of the transform are evaluated before any field is set
+		values := make(map[string]any, len(transform.Set))
+		for field, expression := range transform.Set {
+			program, err := compileTransformExpression(expression, false)
+			if err != nil {
+				return false, nil, err
+			}
+			value, err := expr.Run(program, object)
+			if err != nil {
+				return false, nil, fmt.Errorf("expression %q of field %s: %s", expression, field, expressionError(err))
+			}
+			if _, isCustomField := strings.CutPrefix(field, "customFields."); !isCustomField {
+				if value == nil {
+					value = ""
+				}
+				if _, ok := value.(string); !ok {
+					return false, nil, fmt.Errorf(
+						"expression %q of field %s must return string, not %T", expression, field, value,
+					)
+				}
+			}
+			values[field] = value
+		}
+		for field, value := range values {
+			if customField, isCustomField := strings.CutPrefix(field, "customFields."); isCustomField {
+				customFields, _ := object["customFields"].(map[string]any)
+				customFields = maps.Clone(customFields)
+				if customFields == nil {
+					customFields = make(map[string]any)
+				}
+				customFields[customField] = value
+				object["customFields"] = customFields
+				continue
+			}
+			object[field] = value
+		}
+		for _, emit := range transform.Emit {
+			emittedObject := EmittedObject{Path: emit.Path, Object: make(map[string]any, len(emit.Object))}
+			for attribute, expression := range emit.Object {
+				program, err := compileTransformExpression(expression, false)
+				if err != nil {
+					return false, nil, err
+				}
+				value, err := expr.Run(program, object)
+				if err != nil {
+					return false, nil, fmt.Errorf(
+						"expression %q of emitted %s attribute %s: %s", expression, emit.Path, attribute, expressionError(err),
+					)
+				}
+				emittedObject.Object[attribute] = value
+			}
+			emitted = append(emitted, emittedObject)
+		}
+	}
+	return false, emitted, nil
+}
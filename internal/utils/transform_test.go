@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyTransforms(t *testing.T) {
+	SetRelationLookups(map[string]map[string]string{"sites": {"lju": "Ljubljana"}})
+	defer SetRelationLookups(nil)
+
+	tests := []struct {
+		name        string
+		transforms  []Transform
+		object      map[string]any
+		want        map[string]any
+		wantEmitted []EmittedObject
+		wantDropped bool
+	}{
+		{
+			name:       "Set fields",
+			transforms: []Transform{{Set: map[string]string{"name": "upper(name)", "description": `"VM " + name`}}},
+			object:     map[string]any{"name": "web01"},
+			want:       map[string]any{"name": "WEB01", "description": "VM web01"},
+		},
+		{
+			name: "When expression",
+			transforms: []Transform{
+				{When: `"prod" in tags`, Set: map[string]string{"role": `"Production"`}},
+				{When: `platform matches "(?i)windows"`, Set: map[string]string{"role": `"Windows"`}},
+			},
+			object: map[string]any{"tags": []string{"prod"}, "platform": "Ubuntu"},
+			want:   map[string]any{"role": "Production"},
+		},
+		{
+			name: "Transforms see fields set by previous transforms",
+			transforms: []Transform{
+				{Set: map[string]string{"name": "lower(name)"}},
+				{Set: map[string]string{"customFields.site": `lookup("sites", split(name, "-")[0]) ?? "Unknown"`}},
+			},
+			object: map[string]any{"name": "LJU-web01", "customFields": map[string]any{"owner": "alice"}},
+			want: map[string]any{
+				"name":         "lju-web01",
+				"customFields": map[string]any{"owner": "alice", "site": "Ljubljana"},
+			},
+		},
+		{
+			name:       "Custom functions",
+			transforms: []Transform{{Set: map[string]string{"platform": `regexReplace(title(platform), "\\s+", "-")`}}},
+			object:     map[string]any{"platform": "CENTOS linux"},
+			want:       map[string]any{"platform": "Centos-linux"},
+		},
+		{
+			name: "Drop",
+			transforms: []Transform{
+				{When: `name startsWith "tmp-"`, Drop: true},
+				{Set: map[string]string{"name": `"never"`}},
+			},
+			object:      map[string]any{"name": "tmp-vm"},
+			wantDropped: true,
+		},
+		{
+			name: "Emit raw objects after fields are set",
+			transforms: []Transform{
+				{
+					Set: map[string]string{"name": "lower(name)"},
+					Emit: []TransformEmit{{
+						Path:   "/api/plugins/netbox-dns/records/",
+						Object: map[string]string{"name": "name", "zone": "1", "type": `"A"`},
+					}},
+				},
+				{
+					When: `name == "other"`,
+					Emit: []TransformEmit{{Path: "/api/plugins/other/", Object: map[string]string{"name": "name"}}},
+				},
+			},
+			object: map[string]any{"name": "WEB01"},
+			want:   map[string]any{"name": "web01"},
+			wantEmitted: []EmittedObject{{
+				Path:   "/api/plugins/netbox-dns/records/",
+				Object: map[string]any{"name": "web01", "zone": 1, "type": "A"},
+			}},
+		},
+		{
+			name: "Dropped objects don't emit raw objects",
+			transforms: []Transform{
+				{Emit: []TransformEmit{{Path: "/api/plugins/netbox-dns/records/", Object: map[string]string{"name": "name"}}}},
+				{Drop: true},
+			},
+			object:      map[string]any{"name": "web01"},
+			wantDropped: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, transform := range tt.transforms {
+				if err := ValidateTransform(transform); err != nil {
+					t.Fatalf("ValidateTransform() error = %v", err)
+				}
+			}
+			object := NewTransformObject(tt.object)
+			dropped, emitted, err := ApplyTransforms(tt.transforms, object)
+			if err != nil {
+				t.Fatalf("ApplyTransforms() error = %v", err)
+			}
+			if dropped != tt.wantDropped {
+				t.Fatalf("ApplyTransforms() dropped = %v, want %v", dropped, tt.wantDropped)
+			}
+			if (len(emitted) > 0 || len(tt.wantEmitted) > 0) && !reflect.DeepEqual(emitted, tt.wantEmitted) {
+				t.Errorf("ApplyTransforms() emitted = %v, want %v", emitted, tt.wantEmitted)
+			}
+			for field, want := range tt.want {
+				if !reflect.DeepEqual(object[field], want) {
+					t.Errorf("ApplyTransforms() %s = %v, want %v", field, object[field], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTransform(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform Transform
+		wantErr   bool
+	}{
+		{name: "Valid transform", transform: Transform{When: `site == "Ljubljana"`, Set: map[string]string{"name": "name"}}},
+		{name: "Empty transform", transform: Transform{When: "true"}, wantErr: true},
+		{name: "Drop and set", transform: Transform{Drop: true, Set: map[string]string{"name": "name"}}, wantErr: true},
+		{name: "Unknown field", transform: Transform{Set: map[string]string{"serial": `"123"`}}, wantErr: true},
+		{name: "Empty custom field name", transform: Transform{Set: map[string]string{"customFields.": "1"}}, wantErr: true},
+		{name: "Unknown variable", transform: Transform{Set: map[string]string{"name": "hostname"}}, wantErr: true},
+		{name: "When is not bool", transform: Transform{When: "name", Drop: true}, wantErr: true},
+		{name: "Syntax error", transform: Transform{Set: map[string]string{"name": "upper(name"}}, wantErr: true},
+		{
+			name:      "Emit only",
+			transform: Transform{Emit: []TransformEmit{{Path: "/api/plugins/dns/", Object: map[string]string{"name": "name"}}}},
+		},
+		{
+			name: "Drop and emit",
+			transform: Transform{
+				Drop: true,
+				Emit: []TransformEmit{{Path: "/api/plugins/dns/", Object: map[string]string{"name": "name"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Emit without path",
+			transform: Transform{Emit: []TransformEmit{{Object: map[string]string{"name": "name"}}}},
+			wantErr:   true,
+		},
+		{
+			name:      "Emit without object",
+			transform: Transform{Emit: []TransformEmit{{Path: "/api/plugins/dns/"}}},
+			wantErr:   true,
+		},
+		{
+			name: "Emit syntax error",
+			transform: Transform{
+				Emit: []TransformEmit{{Path: "/api/plugins/dns/", Object: map[string]string{"name": "upper("}}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTransform(tt.transform); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransform() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
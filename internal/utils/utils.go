@@ -33,10 +33,13 @@ func ValidateRegexRelations(regexRelations []string) error {
 			)
 		}
 		regexStr := strings.TrimSpace(relation[0])
-		_, err := regexp.Compile(regexStr)
+		regex, err := regexp.Compile(regexStr)
 		if err != nil {
 			return fmt.Errorf("invalid regex: %s, in relation: %s", regexStr, regexRelation)
 		}
+		if _, err := ValidateRelationTemplate(regex, strings.TrimSpace(relation[1])); err != nil {
+			return fmt.Errorf("%s, in relation: %s", err, regexRelation)
+		}
 	}
 	return nil
 }
@@ -54,14 +57,21 @@ func ConvertStringsToRegexPairs(input []string) map[string]string {
 
 // Matches input string to a regex from input map patterns,
 // and returns the value. If there is no match, it returns an empty string.
+// References to capture groups in the value (e.g. $1 or ${site|upper}) are
+// replaced with the matched groups. Patterns whose lookup tables don't contain
+// the matched group are skipped.
 func MatchStringToValue(input string, patterns map[string]string) (string, error) {
-	for regex, value := range patterns {
-		matched, err := regexp.MatchString(regex, input)
+	for regexStr, value := range patterns {
+		regex, err := regexp.Compile(regexStr)
 		if err != nil {
 			return "", err
 		}
-		if matched {
-			return value, nil
+		match := regex.FindStringSubmatchIndex(input)
+		if match == nil {
+			continue
+		}
+		if expanded, ok := expandRelationValue(regex, input, match, value); ok {
+			return expanded, nil
 		}
 	}
 	return "", nil